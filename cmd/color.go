@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/spf13/viper"
+
+	"github.com/quantmind-br/shotgun-cli/internal/config"
+	"github.com/quantmind-br/shotgun-cli/internal/utils"
+)
+
+// colorDisabled reports whether output should be plain: --no-color was given or
+// NO_COLOR is set to a non-empty value (https://no-color.org).
+func colorDisabled() bool {
+	if noColor, _ := rootCmd.PersistentFlags().GetBool("no-color"); noColor {
+		return true
+	}
+
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// applyColorMode turns off lipgloss styling, in the TUI and in styled CLI output,
+// when color is disabled.
+func applyColorMode() {
+	if colorDisabled() {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
+
+// summaryOutput returns the writer for the headless summary: stdout, stdout with
+// emoji and ANSI sequences removed when color is disabled, or nowhere with --quiet.
+func summaryOutput() io.Writer {
+	if viper.GetBool(config.KeyQuiet) {
+		return io.Discard
+	}
+	if colorDisabled() {
+		return plainWriter{w: os.Stdout}
+	}
+
+	return os.Stdout
+}
+
+// plainWriter strips emoji and ANSI escape sequences from everything written to w.
+type plainWriter struct {
+	w io.Writer
+}
+
+func (p plainWriter) Write(b []byte) (int, error) {
+	if _, err := io.WriteString(p.w, utils.StripEmoji(utils.StripANSI(string(b)))); err != nil {
+		return 0, err
+	}
+
+	return len(b), nil
+}
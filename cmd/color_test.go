@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quantmind-br/shotgun-cli/internal/config"
+)
+
+func TestColorDisabled_NoColorEnv(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	assert.False(t, colorDisabled())
+
+	t.Setenv("NO_COLOR", "1")
+	assert.True(t, colorDisabled())
+}
+
+func TestSummaryOutput_Quiet(t *testing.T) {
+	viper.Set(config.KeyQuiet, true)
+	t.Cleanup(func() { viper.Set(config.KeyQuiet, false) })
+
+	assert.Equal(t, io.Discard, summaryOutput())
+}
+
+func TestPlainWriter(t *testing.T) {
+	var buf bytes.Buffer
+	input := "✅ Context generated successfully!\n\x1b[1m📄 Output file:\x1b[0m out.md\n"
+
+	n, err := plainWriter{w: &buf}.Write([]byte(input))
+	require.NoError(t, err)
+	assert.Equal(t, len(input), n, "the full input length should be reported as written")
+	assert.Equal(t, "Context generated successfully!\nOutput file: out.md\n", buf.String())
+}
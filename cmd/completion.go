@@ -9,6 +9,7 @@ import (
 
 const (
 	configKeyTemplateCustomPath = "template.custom-path"
+	configKeyOutputDir          = "output.dir"
 )
 
 var completionCmd = &cobra.Command{
@@ -86,19 +87,32 @@ func configKeyCompletion(_ *cobra.Command, args []string, _ string) ([]string, c
 		"scanner.respect-gitignore\tRespect .gitignore files (true/false)",
 		"scanner.skip-binary\tSkip binary files (true/false)",
 		"scanner.workers\tNumber of parallel workers (1-32)",
-		"scanner.include-hidden\tInclude hidden files (true/false)",
+		"scanner.include-hidden\tInclude hidden files and directories (true/false)",
+		"scanner.include-hidden-dirs\tTraverse hidden directories (true/false)",
+		"scanner.include-hidden-files\tInclude hidden files (true/false)",
 		"scanner.include-ignored\tInclude ignored files (true/false)",
 		"scanner.respect-shotgunignore\tRespect .shotgunignore files (true/false)",
+		"scanner.allowlist-mode\tOnly include files matched by .shotgunkeep (true/false)",
+		"scanner.respect-gitattributes\tSkip export-ignore and linguist-generated files (true/false)",
 		"scanner.max-memory\tMax memory usage (e.g., 500MB)",
 		// Context keys
 		"context.max-size\tMaximum context size (e.g., 10MB)",
 		"context.include-tree\tInclude directory tree (true/false)",
 		"context.include-summary\tInclude file summaries (true/false)",
+		"context.strip-comments\tStrip code comments from files (true/false)",
+		"context.fold-siblings\tFold similarly named sibling files (true/false)",
+		"context.file-header\tLine before each file, with {PATH} {SIZE} {LANG} {LINES}",
+		"context.file-footer\tLine after each file, with {PATH} {SIZE} {LANG} {LINES}",
+		"context.redact\tRedact secrets before embedding (true/false)",
+		"context.redact-patterns\tExtra regular expression to redact",
 		// Template keys
 		configKeyTemplateCustomPath + "\tPath to custom templates",
 		// Output keys
 		"output.format\tOutput format (markdown/text)",
 		"output.clipboard\tCopy to clipboard (true/false)",
+		"output.clipboard-mode\tWhat to copy (content/path/none)",
+		"output.clipboard-verify\tWarn when the clipboard copy is truncated (true/false)",
+		configKeyOutputDir + "\tDirectory for generated files",
 	}
 
 	return configKeys, cobra.ShellCompDirectiveNoFileComp
@@ -115,11 +129,19 @@ func boolValueCompletion(_ *cobra.Command, args []string, _ string) ([]string, c
 		"scanner.respect-gitignore",
 		"scanner.skip-binary",
 		"scanner.include-hidden",
+		"scanner.include-hidden-dirs",
+		"scanner.include-hidden-files",
 		"scanner.include-ignored",
 		"scanner.respect-shotgunignore",
+		"scanner.allowlist-mode",
+		"scanner.respect-gitattributes",
 		"context.include-tree",
 		"context.include-summary",
+		"context.strip-comments",
+		"context.fold-siblings",
+		"context.redact",
 		"output.clipboard",
+		"output.clipboard-verify",
 	}
 
 	for _, boolKey := range boolKeys {
@@ -133,8 +155,12 @@ func boolValueCompletion(_ *cobra.Command, args []string, _ string) ([]string, c
 		return []string{"markdown", "text"}, cobra.ShellCompDirectiveNoFileComp
 	}
 
+	if key == "output.clipboard-mode" {
+		return []string{"content", "path", "none"}, cobra.ShellCompDirectiveNoFileComp
+	}
+
 	// For path-based configs, enable file completion
-	if key == configKeyTemplateCustomPath {
+	if key == configKeyTemplateCustomPath || key == configKeyOutputDir {
 		return nil, cobra.ShellCompDirectiveDefault
 	}
 
@@ -28,7 +28,11 @@ all configuration settings organized by category.
 
 Subcommands:
   show    Display current configuration values
+  get     Print the value of one configuration key
   set     Set a specific configuration value
+  list    List every key with its value and source
+  profile Manage named configuration profiles
+  preset  Save and list named bundles of context generate flags
 
 Examples:
   # Launch interactive configuration TUI
@@ -37,6 +41,10 @@ Examples:
   # Show current configuration
   shotgun-cli config show
 
+  # Print one value, or every key with its source
+  shotgun-cli config get llm.provider
+  shotgun-cli config list
+
   # Set a configuration value
   shotgun-cli config set llm.provider openai`,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -77,6 +85,11 @@ Supported configuration keys:
     llm.base-url              - Custom API endpoint URL (for OpenRouter, Azure, etc.)
     llm.model                 - Model to use (e.g., gpt-4o, claude-sonnet-4-20250514, gemini-2.5-flash)
     llm.timeout               - Request timeout in seconds (default: 300)
+    llm.send-preview          - Preview and confirm the prompt before sending (default: false)
+    llm.overwrite-response    - Save responses under a stable name, overwriting it (default: false)
+    llm.proxy                 - Proxy URL for API requests (http, https or socks5)
+    llm.ca-cert               - PEM bundle of extra CA certificates to trust
+    llm.insecure-skip-verify  - DANGEROUS: skip TLS certificate verification (default: false)
 
   Scanner:
     scanner.max-files         - Maximum number of files to scan (default: 10000)
@@ -85,13 +98,24 @@ Supported configuration keys:
     scanner.respect-gitignore - Respect .gitignore files (default: true)
     scanner.skip-binary       - Skip binary files (default: true)
     scanner.workers           - Number of parallel workers (default: 1)
-    scanner.include-hidden    - Include hidden files (default: false)
+    scanner.include-hidden    - Include hidden files and directories (default: false)
+    scanner.include-hidden-dirs  - Traverse hidden directories only (default: false)
+    scanner.include-hidden-files - Include hidden files only (default: false)
     scanner.respect-shotgunignore - Respect .shotgunignore files (default: true)
+    scanner.allowlist-mode        - Only include files matched by .shotgunkeep (default: false)
+    scanner.respect-gitattributes - Skip export-ignore and linguist-generated files (default: false)
 
   Context:
     context.max-size          - Maximum context size (default: "10MB")
     context.include-tree      - Include directory tree (default: true)
     context.include-summary   - Include file summaries (default: true)
+    context.strip-comments    - Strip code comments from files (default: false)
+    context.fold-siblings     - Fold similarly named sibling files (default: false)
+    context.file-header       - Line before each file (default: "<file path=\"{PATH}\">")
+    context.file-footer       - Line after each file (default: "</file>")
+                                Placeholders: {PATH}, {SIZE}, {LANG}, {LINES}; empty omits the line
+    context.redact            - Replace secrets with [REDACTED] before embedding (default: false)
+    context.redact-patterns   - Extra regular expressions to redact (a list in the config file)
 
   Template:
     template.custom-path      - Path to custom templates (default: "")
@@ -99,6 +123,18 @@ Supported configuration keys:
   Output:
     output.format             - Output format: markdown, text (default: "markdown")
     output.clipboard          - Copy to clipboard (default: true)
+    output.clipboard-mode     - What to copy: content, path, none (default: "content")
+    output.clipboard-verify   - Read the clipboard back and warn if it was truncated (default: false)
+    output.dir                - Directory for generated prompt/response files (default: "")
+
+  Theme (TUI colors, hex like "#88C0D0" or ANSI numbers like "33"; defaults are the Nord dark palette):
+    theme.colors.primary      - Titles, focused borders and cursor (default: "#88C0D0")
+    theme.colors.accent       - Progress bars and statistics (default: "#A3BE8C")
+    theme.colors.error        - Errors (default: "#BF616A")
+    theme.colors.warning      - Warnings, partially selected directories (default: "#EBCB8B")
+    theme.colors.success      - Success messages, selected files (default: "#A3BE8C")
+    theme.colors.muted        - Help text, separators, unselected files (default: "#7B88A1")
+    theme.colors.text         - Main text (default: "#D8DEE9")
 
   Examples:
     # Configure OpenAI
@@ -130,9 +166,8 @@ Supported configuration keys:
 		key := args[0]
 		value := args[1]
 
-		if !config.IsValidKey(key) {
-			return fmt.Errorf(
-				"invalid configuration key '%s'. Use 'shotgun-cli config show' to see available keys", key)
+		if err := checkConfigKey(key); err != nil {
+			return err
 		}
 
 		if err := config.ValidateValue(key, value); err != nil {
@@ -180,6 +215,9 @@ func showCurrentConfig() error {
 	} else {
 		fmt.Printf("Config file: %s\n", configPath)
 	}
+	if cfgFile == "" {
+		fmt.Printf("Profile: %s\n", activeProfile(getConfigDir()))
+	}
 	fmt.Println()
 
 	// Get all configuration keys and organize them
@@ -249,6 +287,19 @@ func setConfigValue(key, value string) error {
 	// Set the value in viper
 	viper.Set(key, convertedValue)
 
+	configPath, err := writeConfigFile()
+	if err != nil {
+		return err
+	}
+
+	log.Debug().Str("key", key).Interface("value", convertedValue).Str("path", configPath).Msg("Configuration updated")
+
+	return nil
+}
+
+// writeConfigFile writes the current settings to the config file in use, creating
+// the default config file when there is none, and returns its path.
+func writeConfigFile() (string, error) {
 	// Determine config file path
 	configPath := viper.ConfigFileUsed()
 	if configPath == "" {
@@ -259,7 +310,7 @@ func setConfigValue(key, value string) error {
 	// Ensure config directory exists
 	configDir := filepath.Dir(configPath)
 	if err := os.MkdirAll(configDir, 0o750); err != nil {
-		return fmt.Errorf("failed to create config directory: %w", err)
+		return "", fmt.Errorf("failed to create config directory: %w", err)
 	}
 
 	// Write the configuration
@@ -267,38 +318,14 @@ func setConfigValue(key, value string) error {
 		// If config file doesn't exist, create it
 		if os.IsNotExist(err) {
 			if err := viper.SafeWriteConfig(); err != nil {
-				return fmt.Errorf("failed to create config file: %w", err)
+				return "", fmt.Errorf("failed to create config file: %w", err)
 			}
 		} else {
-			return fmt.Errorf("failed to write config file: %w", err)
+			return "", fmt.Errorf("failed to write config file: %w", err)
 		}
 	}
 
-	log.Debug().Str("key", key).Interface("value", convertedValue).Str("path", configPath).Msg("Configuration updated")
-
-	return nil
-}
-
-func getConfigSource(key string) string {
-	// This is a simplified version - viper doesn't expose the actual source
-	// We make educated guesses based on common patterns
-
-	if viper.IsSet(key) {
-		if viper.ConfigFileUsed() != "" {
-			return "config file"
-		}
-
-		// Check if it might be from environment
-		envKey := strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
-		envKey = "SHOTGUN_" + envKey
-		if os.Getenv(envKey) != "" {
-			return "environment"
-		}
-
-		return "flag/default"
-	}
-
-	return "default"
+	return configPath, nil
 }
 
 func formatValue(value interface{}) string {
@@ -326,6 +353,7 @@ func getDefaultConfigPath() string {
 }
 
 func launchConfigTUI() error {
+	applyTheme()
 	wizard := ui.NewConfigWizard()
 
 	program := tea.NewProgram(
@@ -344,5 +372,7 @@ func launchConfigTUI() error {
 func init() {
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configListCmd)
 	rootCmd.AddCommand(configCmd)
 }
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/quantmind-br/shotgun-cli/internal/config"
+	"github.com/quantmind-br/shotgun-cli/internal/core/llm"
+)
+
+// Sources reported by getConfigSource.
+const (
+	sourceDefault     = "default"
+	sourceConfigFile  = "config file"
+	sourceEnvironment = "environment"
+	sourceFlag        = "flag"
+)
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the effective value of a configuration key",
+	Long: `Print the effective value of a configuration key, after defaults, the config
+file and SHOTGUN_* environment variables are applied. The value is printed on its
+own, unquoted, so it can be used in scripts.
+
+Examples:
+  shotgun-cli config get llm.provider
+  shotgun-cli config get context.max-size`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return checkConfigKey(args[0])
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fmt.Println(viper.GetString(args[0]))
+		return nil
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List every configuration key with its value and source",
+	Long: `List every configuration key with its effective value and where the value
+comes from: the built-in default, the config file or an environment variable.
+The API key is masked; use 'config get llm.api-key' to print it.
+
+Example:
+  shotgun-cli config list`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		listConfig(cmd.OutOrStdout())
+		return nil
+	},
+}
+
+// checkConfigKey rejects keys that are not configuration keys, to catch typos.
+func checkConfigKey(key string) error {
+	if !config.IsValidKey(key) {
+		return withExitCode(ExitUsage, fmt.Errorf(
+			"invalid configuration key '%s'. Use 'shotgun-cli config list' to see available keys", key))
+	}
+	return nil
+}
+
+// listConfig writes one line per configuration key: key, effective value and source.
+func listConfig(w io.Writer) {
+	for _, key := range config.ValidKeys() {
+		value := formatValue(viper.Get(key))
+		if key == config.KeyLLMAPIKey && viper.GetString(key) != "" {
+			value = (&llm.Config{APIKey: viper.GetString(key)}).MaskAPIKey()
+		}
+		_, _ = fmt.Fprintf(w, "%-30s %-28s (%s)\n", key, value, getConfigSource(key))
+	}
+}
+
+// configEnvVar returns the environment variable viper reads for key, e.g.
+// SHOTGUN_LLM_TIMEOUT for llm.timeout.
+func configEnvVar(key string) string {
+	return "SHOTGUN_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// getConfigSource reports where the effective value of key comes from.
+func getConfigSource(key string) string {
+	if _, ok := os.LookupEnv(configEnvVar(key)); ok {
+		return sourceEnvironment
+	}
+	if flag := rootCmd.PersistentFlags().Lookup(key); flag != nil && flag.Changed {
+		return sourceFlag
+	}
+	if viper.InConfig(key) {
+		return sourceConfigFile
+	}
+
+	return sourceDefault
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quantmind-br/shotgun-cli/internal/config"
+)
+
+func TestListConfig(t *testing.T) {
+	restoreViperState()
+	t.Cleanup(viper.Reset)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "llm:\n  provider: anthropic\n  api-key: sk-ant-1234567890abcdef\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	viper.SetConfigFile(path)
+	require.NoError(t, viper.ReadInConfig())
+	viper.SetDefault(config.KeyLLMTimeout, 300)
+
+	var out bytes.Buffer
+	listConfig(&out)
+
+	lines := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		lines[strings.Fields(line)[0]] = line
+	}
+
+	assert.Len(t, lines, len(config.ValidKeys()))
+	assert.Contains(t, lines[config.KeyLLMProvider], `"anthropic"`)
+	assert.Contains(t, lines[config.KeyLLMProvider], "(config file)")
+	assert.Contains(t, lines[config.KeyLLMTimeout], "(default)")
+	assert.NotContains(t, lines[config.KeyLLMAPIKey], "1234567890abcdef")
+}
+
+func TestCheckConfigKey(t *testing.T) {
+	assert.NoError(t, checkConfigKey(config.KeyLLMProvider))
+
+	err := checkConfigKey("llm.provder")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "config list")
+}
@@ -20,6 +20,10 @@ func BuildLLMConfig() llm.Config {
 		BaseURL:  viper.GetString(config.KeyLLMBaseURL),
 		Model:    viper.GetString(config.KeyLLMModel),
 		Timeout:  viper.GetInt(config.KeyLLMTimeout),
+		// Network settings
+		Proxy:              viper.GetString(config.KeyLLMProxy),
+		CACert:             viper.GetString(config.KeyLLMCACert),
+		InsecureSkipVerify: viper.GetBool(config.KeyLLMInsecureSkipVerify),
 	}
 
 	// Apply defaults if not configured.
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// presetsKey is the config map holding the presets, keyed by name; each preset maps
+// context generate flag names to values.
+const presetsKey = "presets"
+
+var configPresetCmd = &cobra.Command{
+	Use:   "preset",
+	Short: "Save and list named bundles of context generate flags",
+	Long: `Save and list presets: named bundles of context generate flags stored in the
+config under presets.<name>.
+
+'context generate --preset <name>' starts from the preset's flags; any flag given on
+the command line overrides the preset, and the preset overrides the config.
+
+Examples:
+  shotgun-cli config preset save go-review --lang go --no-tests --template analyzeBug --max-size 2MB
+  shotgun-cli config preset list
+  shotgun-cli context generate --preset go-review --task "Review the error handling"`,
+}
+
+var configPresetSaveCmd = &cobra.Command{
+	Use:   "save <name> [context generate flags]",
+	Short: "Save the given context generate flags as a preset",
+	Long: `Save the context generate flags given after the name as a preset, replacing any
+preset of that name. Only the flags given are saved; the rest keep their defaults when
+the preset is used.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if !profileNameRe.MatchString(name) {
+			return withExitCode(ExitUsage,
+				fmt.Errorf("invalid preset name %q (use letters, digits, '-' and '_')", name))
+		}
+
+		preset := presetFromFlags(cmd.Flags())
+		if len(preset) == 0 {
+			return withExitCode(ExitUsage,
+				fmt.Errorf("no flags to save; give the context generate flags after the name, e.g. --lang go"))
+		}
+
+		viper.Set(presetsKey+"."+name, preset)
+		configPath, err := writeConfigFile()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "✅ Preset %q saved: %s\n", name, formatPreset(preset))
+		fmt.Fprintf(cmd.OutOrStdout(), "📁 Config file: %s\n", configPath)
+		return nil
+	},
+}
+
+var configPresetListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List presets with their flags",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		presets := viper.GetStringMap(presetsKey)
+		if len(presets) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(),
+				"No presets. Save one with 'shotgun-cli config preset save <name> [flags]'.")
+			return nil
+		}
+
+		names := make([]string, 0, len(presets))
+		width := 0
+		for name := range presets {
+			names = append(names, name)
+			width = max(width, len(name))
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			preset, _ := presets[name].(map[string]any)
+			fmt.Fprintf(cmd.OutOrStdout(), "%-*s  %s\n", width, name, formatPreset(preset))
+		}
+		return nil
+	},
+}
+
+// presetFromFlags returns the flags set on the command line, as values to store in
+// the config: lists for repeatable flags, booleans, integers and strings.
+func presetFromFlags(flags *pflag.FlagSet) map[string]any {
+	preset := make(map[string]any)
+	flags.Visit(func(f *pflag.Flag) {
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			preset[f.Name] = slice.GetSlice()
+			return
+		}
+		switch f.Value.Type() {
+		case "bool":
+			if v, err := strconv.ParseBool(f.Value.String()); err == nil {
+				preset[f.Name] = v
+				return
+			}
+		case "int", "int64":
+			if v, err := strconv.ParseInt(f.Value.String(), 10, 64); err == nil {
+				preset[f.Name] = v
+				return
+			}
+		}
+		preset[f.Name] = f.Value.String()
+	})
+
+	return preset
+}
+
+// applyPreset sets the flags of the preset named by --preset that were not given on
+// the command line, so explicit flags override the preset.
+func applyPreset(flags *pflag.FlagSet) error {
+	name, _ := flags.GetString("preset")
+	if name == "" {
+		return nil
+	}
+
+	raw, ok := viper.GetStringMap(presetsKey)[strings.ToLower(name)]
+	preset, isMap := raw.(map[string]any)
+	if !ok || !isMap {
+		return fmt.Errorf("preset %q not found. Use 'shotgun-cli config preset list' to see the saved presets", name)
+	}
+
+	names := make([]string, 0, len(preset))
+	for flagName := range preset {
+		names = append(names, flagName)
+	}
+	sort.Strings(names)
+
+	for _, flagName := range names {
+		f := flags.Lookup(flagName)
+		if f == nil || flagName == "preset" {
+			return fmt.Errorf("preset %q: unknown flag --%s", name, flagName)
+		}
+		if f.Changed {
+			continue
+		}
+
+		values := presetValues(preset[flagName])
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			if err := slice.Replace(values); err != nil {
+				return fmt.Errorf("preset %q: invalid --%s: %w", name, flagName, err)
+			}
+			f.Changed = true
+			continue
+		}
+		if len(values) != 1 {
+			return fmt.Errorf("preset %q: --%s takes a single value", name, flagName)
+		}
+		if err := flags.Set(flagName, values[0]); err != nil {
+			return fmt.Errorf("preset %q: invalid --%s: %w", name, flagName, err)
+		}
+	}
+
+	return nil
+}
+
+// presetValues returns a preset value, a list or a scalar, as flag value strings.
+func presetValues(value any) []string {
+	switch v := value.(type) {
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return values
+	case []string:
+		return v
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
+
+// formatPreset renders a preset as the command line flags it stands for.
+func formatPreset(preset map[string]any) string {
+	names := make([]string, 0, len(preset))
+	for name := range preset {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var parts []string
+	for _, name := range names {
+		value := preset[name]
+		if v, ok := value.(bool); ok {
+			if v {
+				parts = append(parts, "--"+name)
+			} else {
+				parts = append(parts, "--"+name+"=false")
+			}
+			continue
+		}
+		for _, v := range presetValues(value) {
+			if v == "" || strings.ContainsAny(v, " \t\"'") {
+				v = strconv.Quote(v)
+			}
+			parts = append(parts, "--"+name+" "+v)
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func init() {
+	addGenerateFlags(configPresetSaveCmd.Flags())
+
+	configPresetCmd.AddCommand(configPresetSaveCmd)
+	configPresetCmd.AddCommand(configPresetListCmd)
+	configCmd.AddCommand(configPresetCmd)
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPresetTestCmd returns a command with the context generate flags and --preset.
+func newPresetTestCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+
+	cmd := &cobra.Command{}
+	addGenerateFlags(cmd.Flags())
+	cmd.Flags().String("preset", "", "")
+	require.NoError(t, cmd.ParseFlags(args))
+	return cmd
+}
+
+func TestPresetSaveAndApply(t *testing.T) {
+	viper.Reset()
+	setConfigDefaults()
+	t.Cleanup(viper.Reset)
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	viper.SetConfigFile(configPath)
+
+	save := &cobra.Command{RunE: configPresetSaveCmd.RunE}
+	addGenerateFlags(save.Flags())
+	require.NoError(t, save.ParseFlags([]string{
+		"--lang", "go", "--no-tests", "--template", "analyzeBug", "--max-size", "2MB",
+		"--exclude", "vendor/*,docs/*", "--max-depth", "3", "--task", "Find bugs",
+	}))
+	var out bytes.Buffer
+	save.SetOut(&out)
+	require.NoError(t, save.RunE(save, []string{"go-review"}))
+	assert.Contains(t, out.String(),
+		`--exclude vendor/* --exclude docs/* --lang go --max-depth 3 --max-size 2MB --no-tests --task "Find bugs"`)
+
+	// Read the preset back from the file, as a later invocation would
+	viper.Reset()
+	setConfigDefaults()
+	viper.SetConfigFile(configPath)
+	require.NoError(t, viper.ReadInConfig())
+
+	cmd := newPresetTestCmd(t, "--preset", "go-review", "--max-size", "5MB", "--exclude", "build/*")
+	require.NoError(t, applyPreset(cmd.Flags()))
+
+	maxSize, _ := cmd.Flags().GetString("max-size")
+	assert.Equal(t, "5MB", maxSize, "an explicit flag overrides the preset")
+	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+	assert.Equal(t, []string{"build/*"}, exclude)
+	langs, _ := cmd.Flags().GetStringSlice("lang")
+	assert.Equal(t, []string{"go"}, langs)
+	noTests, _ := cmd.Flags().GetBool("no-tests")
+	assert.True(t, noTests)
+	templateName, _ := cmd.Flags().GetString("template")
+	assert.Equal(t, "analyzeBug", templateName)
+	maxDepth, _ := cmd.Flags().GetInt("max-depth")
+	assert.Equal(t, 3, maxDepth)
+
+	cfg, err := buildGenerateConfig(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, []string{".go"}, cfg.Extensions)
+	assert.Equal(t, "Find bugs", cfg.Task)
+	assert.Contains(t, cfg.Exclude, "build/*")
+	assert.Contains(t, cfg.Exclude, "*_test.go")
+
+	var list bytes.Buffer
+	configPresetListCmd.SetOut(&list)
+	t.Cleanup(func() { configPresetListCmd.SetOut(nil) })
+	require.NoError(t, configPresetListCmd.RunE(configPresetListCmd, nil))
+	assert.Contains(t, list.String(), "go-review  --exclude vendor/*")
+}
+
+func TestApplyPreset_Errors(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set("presets.broken", map[string]any{"no-such-flag": true})
+	viper.Set("presets.scalar", map[string]any{"template": []any{"a", "b"}})
+
+	tests := []struct {
+		preset  string
+		wantErr string
+	}{
+		{"missing", `preset "missing" not found`},
+		{"broken", "unknown flag --no-such-flag"},
+		{"scalar", "--template takes a single value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.preset, func(t *testing.T) {
+			err := applyPreset(newPresetTestCmd(t, "--preset", tt.preset).Flags())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	assert.NoError(t, applyPreset(newPresetTestCmd(t).Flags()), "no --preset leaves the flags alone")
+}
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// defaultProfileName refers to the base config.yaml rather than a named profile.
+const defaultProfileName = "default"
+
+const (
+	profilesDirName       = "profiles"
+	activeProfileFileName = "active-profile"
+)
+
+var profileNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+var configProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage named configuration profiles",
+	Long: `Manage named configuration profiles.
+
+Each profile is a complete config file stored under the profiles directory next
+to config.yaml. While a profile is active it replaces config.yaml: every command,
+the config TUI and 'config set' read and write the profile instead, so switching
+profiles also switches the LLM provider, API key, base URL and model.
+
+Use the name "default" to switch back to config.yaml.
+
+Examples:
+  shotgun-cli config profile create work
+  shotgun-cli config profile use work
+  shotgun-cli config set llm.api-key sk-work-key
+  shotgun-cli config profile list
+  shotgun-cli config profile use default
+  shotgun-cli config profile delete work`,
+}
+
+var configProfileCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a profile from the current configuration",
+	Long: `Create a new profile as a copy of the configuration currently in use.
+
+The new profile is not activated; run 'config profile use <name>' to switch to it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := createProfile(getConfigDir(), args[0], viper.ConfigFileUsed())
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Profile %q created\n", args[0])
+		fmt.Printf("📁 Config file: %s\n", path)
+		return nil
+	},
+}
+
+var configProfileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Switch the active profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := useProfile(getConfigDir(), args[0]); err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Active profile: %s\n", args[0])
+		return nil
+	},
+}
+
+var configProfileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles (* marks the active one)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configDir := getConfigDir()
+
+		profiles, err := listProfiles(configDir)
+		if err != nil {
+			return err
+		}
+
+		active := activeProfile(configDir)
+		for _, name := range append([]string{defaultProfileName}, profiles...) {
+			marker := "  "
+			if name == active {
+				marker = "* "
+			}
+			fmt.Printf("%s%s\n", marker, name)
+		}
+		return nil
+	},
+}
+
+var configProfileDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := deleteProfile(getConfigDir(), args[0]); err != nil {
+			return err
+		}
+
+		fmt.Printf("🗑️  Profile %q deleted\n", args[0])
+		return nil
+	},
+}
+
+func validateProfileName(name string) error {
+	if name == defaultProfileName {
+		return fmt.Errorf("%q is reserved for the base config file", defaultProfileName)
+	}
+	if !profileNameRe.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q (use letters, digits, '-' and '_')", name)
+	}
+	return nil
+}
+
+func profilePath(configDir, name string) string {
+	return filepath.Join(configDir, profilesDirName, name+".yaml")
+}
+
+// activeProfile returns the name of the active profile, or "default" when none is
+// active or the recorded profile no longer exists.
+func activeProfile(configDir string) string {
+	data, err := os.ReadFile(filepath.Join(configDir, activeProfileFileName)) //nolint:gosec // path under config dir
+	if err != nil {
+		return defaultProfileName
+	}
+
+	name := strings.TrimSpace(string(data))
+	if validateProfileName(name) != nil {
+		return defaultProfileName
+	}
+	if _, err := os.Stat(profilePath(configDir, name)); err != nil {
+		return defaultProfileName
+	}
+
+	return name
+}
+
+// activeProfileConfigFile returns the config file of the active profile, or an
+// empty string when the base config should be used.
+func activeProfileConfigFile(configDir string) string {
+	name := activeProfile(configDir)
+	if name == defaultProfileName {
+		return ""
+	}
+	return profilePath(configDir, name)
+}
+
+func listProfiles(configDir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(configDir, profilesDirName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read profiles directory: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".yaml")
+		if entry.IsDir() || !ok || validateProfileName(name) != nil {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// createProfile creates a profile as a copy of sourcePath (the config file in use).
+// An empty or missing source yields an empty profile that falls back to defaults.
+func createProfile(configDir, name, sourcePath string) (string, error) {
+	if err := validateProfileName(name); err != nil {
+		return "", err
+	}
+
+	path := profilePath(configDir, name)
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("profile %q already exists", name)
+	}
+
+	var content []byte
+	if sourcePath != "" {
+		data, err := os.ReadFile(sourcePath) //nolint:gosec // path is the config file viper loaded
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("failed to read current config: %w", err)
+		}
+		content = data
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", fmt.Errorf("failed to create profiles directory: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write profile: %w", err)
+	}
+
+	return path, nil
+}
+
+// useProfile records name as the active profile. "default" clears the selection.
+func useProfile(configDir, name string) error {
+	marker := filepath.Join(configDir, activeProfileFileName)
+
+	if name == defaultProfileName {
+		if err := os.Remove(marker); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to clear active profile: %w", err)
+		}
+		return nil
+	}
+
+	if err := validateProfileName(name); err != nil {
+		return err
+	}
+	if _, err := os.Stat(profilePath(configDir, name)); err != nil {
+		return fmt.Errorf("profile %q does not exist; create it with 'shotgun-cli config profile create %s'", name, name)
+	}
+
+	if err := os.MkdirAll(configDir, 0o750); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(marker, []byte(name+"\n"), 0o600); err != nil {
+		return fmt.Errorf("failed to record active profile: %w", err)
+	}
+
+	return nil
+}
+
+func deleteProfile(configDir, name string) error {
+	if err := validateProfileName(name); err != nil {
+		return err
+	}
+	if activeProfile(configDir) == name {
+		return fmt.Errorf(
+			"cannot delete the active profile %q; switch with 'shotgun-cli config profile use default' first", name)
+	}
+
+	if err := os.Remove(profilePath(configDir, name)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("profile %q does not exist", name)
+		}
+		return fmt.Errorf("failed to delete profile: %w", err)
+	}
+
+	return nil
+}
+
+func init() {
+	configProfileCmd.AddCommand(configProfileCreateCmd)
+	configProfileCmd.AddCommand(configProfileUseCmd)
+	configProfileCmd.AddCommand(configProfileListCmd)
+	configProfileCmd.AddCommand(configProfileDeleteCmd)
+	configCmd.AddCommand(configProfileCmd)
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quantmind-br/shotgun-cli/internal/config"
+)
+
+func TestProfileLifecycle(t *testing.T) {
+	configDir := t.TempDir()
+	base := filepath.Join(configDir, "config.yaml")
+	require.NoError(t, os.WriteFile(base, []byte("llm:\n  provider: openai\n  api-key: personal-key\n"), 0o600))
+
+	assert.Equal(t, defaultProfileName, activeProfile(configDir))
+	assert.Empty(t, activeProfileConfigFile(configDir))
+
+	path, err := createProfile(configDir, "work", base)
+	require.NoError(t, err)
+	assert.Equal(t, profilePath(configDir, "work"), path)
+
+	_, err = createProfile(configDir, "work", base)
+	assert.ErrorContains(t, err, "already exists")
+
+	_, err = createProfile(configDir, "empty", "")
+	require.NoError(t, err)
+
+	profiles, err := listProfiles(configDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"empty", "work"}, profiles)
+
+	require.NoError(t, useProfile(configDir, "work"))
+	assert.Equal(t, "work", activeProfile(configDir))
+	assert.Equal(t, path, activeProfileConfigFile(configDir))
+
+	assert.ErrorContains(t, deleteProfile(configDir, "work"), "active profile")
+	require.NoError(t, deleteProfile(configDir, "empty"))
+	assert.ErrorContains(t, deleteProfile(configDir, "empty"), "does not exist")
+
+	require.NoError(t, useProfile(configDir, defaultProfileName))
+	assert.Equal(t, defaultProfileName, activeProfile(configDir))
+	require.NoError(t, deleteProfile(configDir, "work"))
+}
+
+func TestProfileNameValidation(t *testing.T) {
+	configDir := t.TempDir()
+
+	for _, name := range []string{defaultProfileName, "", "../escape", "with space", "-leading"} {
+		_, err := createProfile(configDir, name, "")
+		assert.Error(t, err, "name %q", name)
+	}
+
+	assert.ErrorContains(t, useProfile(configDir, "missing"), "does not exist")
+}
+
+func TestActiveProfileSwitchesLLMConfig(t *testing.T) {
+	configDir := t.TempDir()
+	base := filepath.Join(configDir, "config.yaml")
+	require.NoError(t, os.WriteFile(base, []byte("llm:\n  provider: openai\n  api-key: personal-key\n"), 0o600))
+
+	workPath, err := createProfile(configDir, "work", base)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(workPath,
+		[]byte("llm:\n  provider: anthropic\n  api-key: work-key\n  model: claude-work\n"), 0o600))
+	require.NoError(t, useProfile(configDir, "work"))
+
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.SetConfigFile(activeProfileConfigFile(configDir))
+	require.NoError(t, viper.ReadInConfig())
+
+	cfg := BuildLLMConfig()
+	assert.Equal(t, "anthropic", string(cfg.Provider))
+	assert.Equal(t, "work-key", cfg.APIKey)
+	assert.Equal(t, "claude-work", cfg.Model)
+
+	// Writes go to the active profile, leaving the base config untouched
+	require.NoError(t, setConfigValue(config.KeyLLMModel, "claude-other"))
+	data, err := os.ReadFile(workPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "claude-other")
+	data, err = os.ReadFile(base)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "claude-other")
+}
@@ -278,9 +278,11 @@ func TestGetConfigSource_FromConfigFile(t *testing.T) {
 		viper.Reset()
 	})
 
-	// Set config file and a value
-	viper.SetConfigFile(t.TempDir() + "/config.yaml")
-	viper.Set("scanner.max-files", 5000)
+	// Read a config file that sets the value
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("scanner:\n  max-files: 5000\n"), 0o600))
+	viper.SetConfigFile(path)
+	require.NoError(t, viper.ReadInConfig())
 
 	source := getConfigSource("scanner.max-files")
 	if source != "config file" {
@@ -288,6 +290,18 @@ func TestGetConfigSource_FromConfigFile(t *testing.T) {
 	}
 }
 
+func TestGetConfigSource_FromEnvironment(t *testing.T) {
+	restoreViperState()
+	t.Cleanup(func() {
+		viper.Reset()
+	})
+	t.Setenv("SHOTGUN_LLM_TIMEOUT", "60")
+
+	if source := getConfigSource("llm.timeout"); source != "environment" {
+		t.Errorf("expected 'environment', got: %s", source)
+	}
+}
+
 func TestGetConfigSource_FromFlag(t *testing.T) {
 	restoreViperState()
 	t.Cleanup(func() {
@@ -1,23 +1,34 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 
 	"github.com/quantmind-br/shotgun-cli/internal/app"
 	cfgkeys "github.com/quantmind-br/shotgun-cli/internal/config"
+	"github.com/quantmind-br/shotgun-cli/internal/core/contextgen"
 	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
 	"github.com/quantmind-br/shotgun-cli/internal/core/template"
 	"github.com/quantmind-br/shotgun-cli/internal/core/tokens"
+	"github.com/quantmind-br/shotgun-cli/internal/platform/fswatch"
+	"github.com/quantmind-br/shotgun-cli/internal/platform/git"
 	"github.com/quantmind-br/shotgun-cli/internal/utils"
 )
 
@@ -44,20 +55,90 @@ type GenerateConfig struct {
 	RootPath     string
 	Include      []string
 	Exclude      []string
+	Extensions   []string // File extensions selected by --lang, on top of Include (nil = any)
+	TestPatterns []string // Test file patterns required by --only-tests, on top of Include (nil = any)
 	Output       string
+	NoOutputFile bool   // Skip writing Output and copy to clipboard only
+	OutputDir    string // Directory for relative Output paths (empty = current directory)
 	MaxSize      int64
 	EnforceLimit bool
+	Priority     contextgen.Priority // Which files to keep when contents exceed MaxSize (empty = fail)
+	Order        contextgen.Order    // Sequence of the embedded file blocks
+	CustomOrder  []string            // Relative paths from --selection, for --order custom
+	Strict       bool                // Fail on an unreadable file instead of embedding a placeholder
+	// ContentMaxSize embeds a placeholder instead of the content of larger files (0 = no limit)
+	ContentMaxSize int64
+	// PerFileMaxTokens keeps the head and tail of files estimated over this many tokens (0 = no limit)
+	PerFileMaxTokens int
+	// ContextWindow is the token window from --context-window or --model-window; it sets MaxSize (0 = none)
+	ContextWindow int
+	// Zip is the path of an archive with the context and the selected files (empty = none)
+	Zip string
+	// Manifest writes a manifest next to Output for context regenerate
+	Manifest bool
+	// InMemory keeps the context in the result only, with no output file or clipboard copy
+	InMemory bool
+	// Append is an existing context file to add the selected files to, instead of
+	// writing a new one (empty = none)
+	Append string
+	// SkipPaths are relative paths left out of the selection, e.g. those Append already embeds
+	SkipPaths map[string]bool
+	// ConfirmThreshold asks before embedding larger files or more files than it allows,
+	// unless Yes is set (zero = never ask)
+	ConfirmThreshold confirmThreshold
+	Yes              bool
+	// Selections is the exact file set read from --selection, keyed by absolute path (nil = every scanned file)
+	Selections map[string]bool
 	// Template configuration
 	Template   string            // Template name to use
 	Task       string            // Task description for LLM
 	Rules      string            // Rules/constraints for LLM
 	CustomVars map[string]string // Custom template variables (KEY=VALUE)
+	RawContext bool              // Emit only tree and file contents, no template framing
 	// Scanner overrides (0/false = use config)
 	Workers        int
 	IncludeHidden  bool
 	IncludeIgnored bool
+	Since          time.Time // Only include files modified after this time (zero = no filter)
+	MaxDepth       int       // Directory levels below the root to scan (0 = no limit)
+	MaxFiles       int64     // Files the scan may find before failing (0 = use config)
+	// IncludeGenerated embeds generated and lock files (go.sum, *.pb.go) instead of a placeholder
+	IncludeGenerated bool
+	// Ignore file overrides for this run: skip .gitignore or .shotgunignore, or force
+	// .gitignore on when scanner.respect-gitignore is off
+	NoGitignore      bool
+	NoShotgunignore  bool
+	RespectGitignore bool
+	// Symlink policy: follow links, optionally to targets outside RootPath
+	FollowSymlinks        bool
+	AllowExternalSymlinks bool
+	// Tree and summary output, from context.include-tree/include-summary unless overridden
+	IncludeTree    bool
+	IncludeSummary bool
+	TreeDepth      int // Directory levels of the embedded tree to expand (0 = no limit)
+	// Content transforms
+	StripComments bool
+	Dedup         bool     // Embed identical files once, referencing them from the copies
+	Summarize     []string // Patterns of files embedded as a one-line summary (--summarize)
+	// OnlyChangedFunctions reduces Go files to the functions changed since HEAD
+	OnlyChangedFunctions bool
+	// Redact replaces secrets with [REDACTED], matching the built-in patterns and
+	// RedactPatterns from context.redact-patterns
+	Redact         bool
+	RedactPatterns []string
+	// FileDelimiters are the lines around each file, from context.file-header/footer
+	FileDelimiters *contextgen.FileDelimiters
 	// Progress output
 	ProgressMode ProgressMode
+	// SummaryJSON prints the summary as a single JSON object on stdout instead of the human summary
+	SummaryJSON bool
+	// Watch mode: regenerate on changes under RootPath, coalescing events within WatchDebounce
+	Watch         bool
+	WatchDebounce time.Duration
+	// Explain prints why these paths are included or excluded, and ExplainExcluded why
+	// every excluded entry is, instead of generating
+	Explain         []string
+	ExplainExcluded bool
 }
 
 var contextCmd = &cobra.Command{
@@ -77,56 +158,74 @@ file summaries, and file contents within the specified size limits.
 
 By default, the context generation will fail if the output exceeds the max-size limit.
 Use --no-enforce-limit to allow generation that exceeds the limit with a warning.
+The summary also warns once the context passes 80% of the limit, or of the token
+window when --context-window or --model-window is given.
+
+Exit codes: 0 success, 2 invalid flags or arguments, 3 size limit exceeded,
+4 scan failed, 5 generation failed, 6 LLM request failed, 1 anything else.
 
 Examples:
   shotgun-cli context generate --root . --include "*.go"
   shotgun-cli context generate --exclude "vendor/*,*.test.go" --max-size 5MB
   shotgun-cli context generate --output my-context.md --root ./src
   shotgun-cli context generate --include "*.py,*.js" --exclude "node_modules/*"
-  shotgun-cli context generate --no-enforce-limit --max-size 5MB`,
+  shotgun-cli context generate --lang go --lang typescript
+  shotgun-cli context generate --no-tests
+  shotgun-cli context generate --only-tests --lang python
+  shotgun-cli context generate --no-enforce-limit --max-size 5MB
+  shotgun-cli context generate --max-size 400KB --priority smallest-first
+  shotgun-cli context generate --context-window 32000 --priority importance
+  shotgun-cli context generate --order size-desc
+  shotgun-cli context generate --selection shotgun-selection.json --order custom
+  shotgun-cli context generate --include "*.go" --strip-comments
+  shotgun-cli context generate --include "*.go" --only-changed-functions
+  shotgun-cli context generate --dedup
+  shotgun-cli context generate --confirm-threshold 1MB,5000
+  shotgun-cli context generate --redact
+  shotgun-cli context generate --include-generated
+  shotgun-cli context generate --no-gitignore --include "generated/*"
+  shotgun-cli context generate --append shotgun-prompt.md --include "newdir/*"
+  shotgun-cli context generate --summarize "*.pb.go" --summarize "api/gen/"
+  shotgun-cli context generate --per-file-max-tokens 2000
+  shotgun-cli context generate --since 7d --include "*.go"
+  shotgun-cli context generate --max-depth 3
+  shotgun-cli context generate --max-files 20000
+  shotgun-cli context generate --no-output-file --include "*.go"
+  shotgun-cli context generate --raw-context --include "*.go"
+  shotgun-cli context generate --output-dir ~/.cache/shotgun-cli
+  shotgun-cli context generate --watch --include "*.go"
+  shotgun-cli context generate --recent
+  shotgun-cli context generate --include "*.go" --zip handoff.zip
+  shotgun-cli context generate --include "*.go" --manifest
+  shotgun-cli context generate --selection shotgun-selection.json
+  shotgun-cli context generate --summary-json --progress json
+  shotgun-cli context generate --preset go-review --task "Review the error handling"
+  shotgun-cli context generate --include "*.go" --explain internal/app/service.go
+  shotgun-cli context generate --explain-excluded`,
 
 	PreRunE: func(cmd *cobra.Command, args []string) error {
-		// Validate root path
-		rootPath, _ := cmd.Flags().GetString("root")
-		if rootPath == "" {
-			return fmt.Errorf("root path cannot be empty")
-		}
-
-		// Convert to absolute path
-		absPath, err := filepath.Abs(rootPath)
-		if err != nil {
-			return fmt.Errorf("invalid root path '%s': %w", rootPath, err)
-		}
-
-		// Check if path exists
-		if _, err := os.Stat(absPath); os.IsNotExist(err) {
-			return fmt.Errorf("root path does not exist: %s", absPath)
-		}
-
-		// Check if path is a directory
-		if info, err := os.Stat(absPath); err != nil {
-			return fmt.Errorf("cannot access root path '%s': %w", absPath, err)
-		} else if !info.IsDir() {
-			return fmt.Errorf("root path must be a directory: %s", absPath)
-		}
-
-		// Validate max-size format
-		maxSizeStr, _ := cmd.Flags().GetString("max-size")
-		if _, err := utils.ParseSize(maxSizeStr); err != nil {
-			return fmt.Errorf("invalid max-size format '%s': %w (use formats like 1MB, 5GB, 500KB)", maxSizeStr, err)
+		if err := applyPreset(cmd.Flags()); err != nil {
+			return withExitCode(ExitUsage, err)
 		}
-
-		return nil
+		return withExitCode(ExitUsage, validateGenerateFlags(cmd))
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Build configuration from flags
 		config, err := buildGenerateConfig(cmd)
 		if err != nil {
-			return fmt.Errorf("failed to build configuration: %w", err)
+			return withExitCode(ExitUsage, fmt.Errorf("failed to build configuration: %w", err))
+		}
+
+		if len(config.Explain) > 0 || config.ExplainExcluded {
+			return explainSelection(cmd.OutOrStdout(), config)
+		}
+		if config.Append != "" {
+			return appendContext(config)
 		}
 
 		// Generate context
 		log.Info().Str("root", config.RootPath).Msg("Starting context generation...")
+		rememberRoot(config.RootPath)
 
 		if err := generateContextHeadless(config); err != nil {
 			return fmt.Errorf("context generation failed: %w", err)
@@ -134,28 +233,228 @@ Examples:
 
 		log.Info().Msg("Context generated successfully")
 
+		if config.Watch {
+			return watchContext(config)
+		}
+
 		return nil
 	},
 }
 
+// validateGenerateFlags resolves --recent and checks the root path and max-size
+// before any work is done.
+func validateGenerateFlags(cmd *cobra.Command) error {
+	// Pick the root from the recent roots list
+	if recent, _ := cmd.Flags().GetBool("recent"); recent {
+		if cmd.Flags().Changed("root") {
+			return fmt.Errorf("--recent cannot be combined with --root")
+		}
+		selected, err := selectRecentRoot()
+		if err != nil {
+			return err
+		}
+		_ = cmd.Flags().Set("root", selected)
+	}
+
+	// Validate root path
+	rootPath, _ := cmd.Flags().GetString("root")
+	if rootPath == "" {
+		return fmt.Errorf("root path cannot be empty")
+	}
+
+	// Convert to absolute path
+	absPath, err := filepath.Abs(rootPath)
+	if err != nil {
+		return fmt.Errorf("invalid root path '%s': %w", rootPath, err)
+	}
+
+	// Check if path exists
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return fmt.Errorf("root path does not exist: %s", absPath)
+	}
+
+	// Check if path is a directory
+	if info, err := os.Stat(absPath); err != nil {
+		return fmt.Errorf("cannot access root path '%s': %w", absPath, err)
+	} else if !info.IsDir() {
+		return fmt.Errorf("root path must be a directory: %s", absPath)
+	}
+
+	// Validate max-size format
+	maxSizeStr, _ := cmd.Flags().GetString("max-size")
+	if _, err := utils.ParseSize(maxSizeStr); err != nil {
+		return fmt.Errorf("invalid max-size format '%s': %w (use formats like 1MB, 5GB, 500KB)", maxSizeStr, err)
+	}
+
+	return nil
+}
+
 func buildGenerateConfig(cmd *cobra.Command) (GenerateConfig, error) {
 	rootPath, _ := cmd.Flags().GetString("root")
 	include, _ := cmd.Flags().GetStringSlice("include")
 	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+	excludeFrom, _ := cmd.Flags().GetStringArray("exclude-from")
+	for _, path := range excludeFrom {
+		patterns, err := readExcludeFile(path)
+		if err != nil {
+			return GenerateConfig{}, err
+		}
+		log.Debug().Str("file", path).Int("patterns", len(patterns)).Msg("Loaded exclude patterns")
+		exclude = append(exclude, patterns...)
+	}
+	langs, _ := cmd.Flags().GetStringSlice("lang")
+	extensions, err := languageExtensions(langs)
+	if err != nil {
+		return GenerateConfig{}, err
+	}
+	noTests, _ := cmd.Flags().GetBool("no-tests")
+	onlyTests, _ := cmd.Flags().GetBool("only-tests")
+	var testPatterns []string
+	switch {
+	case noTests && onlyTests:
+		return GenerateConfig{}, fmt.Errorf("--no-tests cannot be combined with --only-tests")
+	case noTests:
+		exclude = append(exclude, contextgen.TestFilePatterns()...)
+	case onlyTests:
+		testPatterns = contextgen.TestFilePatterns()
+	}
 	output, _ := cmd.Flags().GetString("output")
+	noOutputFile, _ := cmd.Flags().GetBool("no-output-file")
+	zipPath, _ := cmd.Flags().GetString("zip")
+	appendPath, _ := cmd.Flags().GetString("append")
+	confirmStr, _ := cmd.Flags().GetString("confirm-threshold")
+	confirm, err := parseConfirmThreshold(confirmStr)
+	if err != nil {
+		return GenerateConfig{}, fmt.Errorf("invalid --confirm-threshold: %w", err)
+	}
+	yes, _ := cmd.Flags().GetBool("yes")
+	selectionPath, _ := cmd.Flags().GetString("selection")
+	outputDir := outputDirFromFlags(cmd)
 	maxSizeStr, _ := cmd.Flags().GetString("max-size")
+	contentMaxSizeStr, _ := cmd.Flags().GetString("content-max-size")
+	perFileMaxTokens, _ := cmd.Flags().GetInt("per-file-max-tokens")
 	enforceLimit, _ := cmd.Flags().GetBool("enforce-limit")
+	strict, _ := cmd.Flags().GetBool("strict")
+	priorityStr, _ := cmd.Flags().GetString("priority")
+	priority, err := contextgen.ParsePriority(priorityStr)
+	if err != nil {
+		return GenerateConfig{}, fmt.Errorf("invalid --priority: %w", err)
+	}
+	orderStr, _ := cmd.Flags().GetString("order")
+	order, err := contextgen.ParseOrder(orderStr)
+	if err != nil {
+		return GenerateConfig{}, fmt.Errorf("invalid --order: %w", err)
+	}
+	if order == contextgen.OrderCustom && selectionPath == "" {
+		return GenerateConfig{}, fmt.Errorf("--order custom requires --selection, whose file order it follows")
+	}
 
 	// Template flags
 	templateName, _ := cmd.Flags().GetString("template")
 	task, _ := cmd.Flags().GetString("task")
 	rules, _ := cmd.Flags().GetString("rules")
 	varFlags, _ := cmd.Flags().GetStringArray("var")
+	varFile, _ := cmd.Flags().GetString("var-file")
+	rawContext, _ := cmd.Flags().GetBool("raw-context")
+	manifest, _ := cmd.Flags().GetBool("manifest")
 
 	// Scanner override flags
 	workers, _ := cmd.Flags().GetInt("workers")
 	includeHidden, _ := cmd.Flags().GetBool("include-hidden")
 	includeIgnored, _ := cmd.Flags().GetBool("include-ignored")
+	includeGenerated, _ := cmd.Flags().GetBool("include-generated")
+	noGitignore, _ := cmd.Flags().GetBool("no-gitignore")
+	respectGitignore, _ := cmd.Flags().GetBool("respect-gitignore")
+	if noGitignore && respectGitignore {
+		return GenerateConfig{}, fmt.Errorf("--no-gitignore cannot be combined with --respect-gitignore")
+	}
+	noShotgunignore, _ := cmd.Flags().GetBool("no-shotgunignore")
+	sinceStr, _ := cmd.Flags().GetString("since")
+	maxDepth, _ := cmd.Flags().GetInt("max-depth")
+	if maxDepth < 0 {
+		return GenerateConfig{}, fmt.Errorf("invalid --max-depth: %d (must not be negative)", maxDepth)
+	}
+	maxFiles, _ := cmd.Flags().GetInt64("max-files")
+	if maxFiles < 0 {
+		return GenerateConfig{}, fmt.Errorf("invalid --max-files: %d (must not be negative)", maxFiles)
+	}
+	followSymlinks, _ := cmd.Flags().GetBool("follow-symlinks")
+	allowExternalSymlinks, _ := cmd.Flags().GetBool("allow-external-symlinks")
+	if allowExternalSymlinks && !followSymlinks {
+		return GenerateConfig{}, fmt.Errorf("--allow-external-symlinks requires --follow-symlinks")
+	}
+
+	// Content transform flags (fall back to config when not given)
+	stripComments := viper.GetBool(cfgkeys.KeyContextStripComments)
+	if cmd.Flags().Changed("strip-comments") {
+		stripComments, _ = cmd.Flags().GetBool("strip-comments")
+	}
+
+	redact := viper.GetBool(cfgkeys.KeyContextRedact)
+	if cmd.Flags().Changed("redact") {
+		redact, _ = cmd.Flags().GetBool("redact")
+	}
+	var redactPatterns []string
+	if redact {
+		if redactPatterns, err = redactPatternsFromConfig(); err != nil {
+			return GenerateConfig{}, err
+		}
+	}
+
+	dedup, _ := cmd.Flags().GetBool("dedup")
+	onlyChangedFunctions, _ := cmd.Flags().GetBool("only-changed-functions")
+	summarize, _ := cmd.Flags().GetStringArray("summarize")
+	for _, pattern := range summarize {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return GenerateConfig{}, fmt.Errorf("invalid --summarize pattern %q: %w", pattern, err)
+		}
+	}
+
+	// Tree and summary flags (fall back to config when not given)
+	includeTree := viper.GetBool(cfgkeys.KeyContextIncludeTree)
+	if cmd.Flags().Changed("no-tree") {
+		noTree, _ := cmd.Flags().GetBool("no-tree")
+		includeTree = !noTree
+	}
+	includeSummary := viper.GetBool(cfgkeys.KeyContextIncludeSummary)
+	if cmd.Flags().Changed("no-summary") {
+		noSummary, _ := cmd.Flags().GetBool("no-summary")
+		includeSummary = !noSummary
+	}
+	treeDepth, _ := cmd.Flags().GetInt("tree-depth")
+	if treeDepth < 0 {
+		return GenerateConfig{}, fmt.Errorf("invalid --tree-depth: %d (must not be negative)", treeDepth)
+	}
+	fileDelimiters, err := fileDelimitersFromConfig()
+	if err != nil {
+		return GenerateConfig{}, err
+	}
+
+	// Watch flags
+	watch, _ := cmd.Flags().GetBool("watch")
+	watchDebounce, _ := cmd.Flags().GetDuration("watch-debounce")
+	if watchDebounce < 0 {
+		return GenerateConfig{}, fmt.Errorf("invalid --watch-debounce: %s (must not be negative)", watchDebounce)
+	}
+
+	summaryJSON, _ := cmd.Flags().GetBool("summary-json")
+	if summaryJSON && watch {
+		return GenerateConfig{}, fmt.Errorf("--summary-json cannot be combined with --watch")
+	}
+
+	if appendPath != "" {
+		for _, flag := range []string{"output", "no-output-file", "zip", "manifest", "watch", "summary-json"} {
+			if cmd.Flags().Changed(flag) {
+				return GenerateConfig{}, fmt.Errorf("--append cannot be combined with --%s", flag)
+			}
+		}
+	}
+
+	explain, _ := cmd.Flags().GetStringArray("explain")
+	explainExcluded, _ := cmd.Flags().GetBool("explain-excluded")
+	if (len(explain) > 0 || explainExcluded) && watch {
+		return GenerateConfig{}, fmt.Errorf("--explain and --explain-excluded cannot be combined with --watch")
+	}
 
 	// Progress flag
 	progressStr, _ := cmd.Flags().GetString("progress")
@@ -171,8 +470,17 @@ func buildGenerateConfig(cmd *cobra.Command) (GenerateConfig, error) {
 		return GenerateConfig{}, fmt.Errorf("invalid --progress value: %q (expected: none, human, json)", progressStr)
 	}
 
-	// Parse custom variables
+	// Parse custom variables; inline --var values override those from --var-file
 	customVars := make(map[string]string)
+	if varFile != "" {
+		fileVars, err := readVarFile(varFile)
+		if err != nil {
+			return GenerateConfig{}, err
+		}
+		for k, v := range fileVars {
+			customVars[k] = v
+		}
+	}
 	for _, v := range varFlags {
 		parts := strings.SplitN(v, "=", 2)
 		if len(parts) != 2 {
@@ -187,63 +495,445 @@ func buildGenerateConfig(cmd *cobra.Command) (GenerateConfig, error) {
 		return GenerateConfig{}, fmt.Errorf("failed to resolve absolute path: %w", err)
 	}
 
-	// Parse max size
+	// Parse max size; a token window takes precedence over --max-size
 	maxSize, err := utils.ParseSize(maxSizeStr)
 	if err != nil {
 		return GenerateConfig{}, fmt.Errorf("failed to parse max-size: %w", err)
 	}
+	contextWindow, err := contextWindowFromFlags(cmd)
+	if err != nil {
+		return GenerateConfig{}, err
+	}
+	if contextWindow > 0 {
+		maxSize = tokens.BytesFromTokens(contextWindow)
+	}
+
+	var contentMaxSize int64
+	if contentMaxSizeStr != "" {
+		contentMaxSize, err = utils.ParseSize(contentMaxSizeStr)
+		if err != nil {
+			return GenerateConfig{}, fmt.Errorf("failed to parse --content-max-size: %w", err)
+		}
+	}
+	if perFileMaxTokens < 0 {
+		return GenerateConfig{}, fmt.Errorf("invalid --per-file-max-tokens: %d (must not be negative)",
+			perFileMaxTokens)
+	}
+
+	// Parse time window
+	var since time.Time
+	if sinceStr != "" {
+		since, err = utils.ParseSince(sinceStr, time.Now())
+		if err != nil {
+			return GenerateConfig{}, fmt.Errorf("failed to parse --since: %w", err)
+		}
+	}
+
+	if noOutputFile && output != "" {
+		return GenerateConfig{}, fmt.Errorf("--output cannot be combined with --no-output-file")
+	}
+
+	if rawContext && (templateName != "" || task != "" || rules != "" || len(customVars) > 0) {
+		return GenerateConfig{}, fmt.Errorf("--raw-context cannot be combined with --template, --task, --rules or --var")
+	}
+	if manifest && (noOutputFile || rawContext) {
+		return GenerateConfig{}, fmt.Errorf("--manifest cannot be combined with --no-output-file or --raw-context")
+	}
+
+	var selections map[string]bool
+	if selectionPath != "" {
+		selections, err = app.ReadSelectionFile(selectionPath, absPath)
+		if err != nil {
+			return GenerateConfig{}, err
+		}
+	}
+	var customOrder []string
+	if order == contextgen.OrderCustom {
+		customOrder, err = app.ReadSelectionOrder(selectionPath)
+		if err != nil {
+			return GenerateConfig{}, err
+		}
+	}
 
 	// Generate default output filename if not specified
-	if output == "" {
-		timestamp := time.Now().Format("20060102-150405")
-		output = fmt.Sprintf("shotgun-prompt-%s.md", timestamp)
+	if output == "" && !noOutputFile {
+		output = defaultOutputName
 	}
+	output = expandOutputName(output, absPath, templateName, time.Now())
 
 	return GenerateConfig{
-		RootPath:       absPath,
-		Include:        include,
-		Exclude:        exclude,
-		Output:         output,
-		MaxSize:        maxSize,
-		EnforceLimit:   enforceLimit,
-		Template:       templateName,
-		Task:           task,
-		Rules:          rules,
-		CustomVars:     customVars,
-		Workers:        workers,
-		IncludeHidden:  includeHidden,
-		IncludeIgnored: includeIgnored,
-		ProgressMode:   progressMode,
+		RootPath:              absPath,
+		Include:               include,
+		Exclude:               exclude,
+		Extensions:            extensions,
+		TestPatterns:          testPatterns,
+		Output:                output,
+		NoOutputFile:          noOutputFile,
+		OutputDir:             outputDir,
+		MaxSize:               maxSize,
+		ContextWindow:         contextWindow,
+		Zip:                   zipPath,
+		Manifest:              manifest,
+		Append:                appendPath,
+		ConfirmThreshold:      confirm,
+		Yes:                   yes,
+		Selections:            selections,
+		EnforceLimit:          enforceLimit,
+		Strict:                strict,
+		Priority:              priority,
+		Order:                 order,
+		CustomOrder:           customOrder,
+		ContentMaxSize:        contentMaxSize,
+		PerFileMaxTokens:      perFileMaxTokens,
+		Template:              templateName,
+		Task:                  task,
+		Rules:                 rules,
+		CustomVars:            customVars,
+		RawContext:            rawContext,
+		Workers:               workers,
+		IncludeHidden:         includeHidden,
+		IncludeIgnored:        includeIgnored,
+		IncludeGenerated:      includeGenerated,
+		NoGitignore:           noGitignore,
+		NoShotgunignore:       noShotgunignore,
+		RespectGitignore:      respectGitignore,
+		Since:                 since,
+		MaxDepth:              maxDepth,
+		MaxFiles:              maxFiles,
+		FollowSymlinks:        followSymlinks,
+		AllowExternalSymlinks: allowExternalSymlinks,
+		StripComments:         stripComments,
+		Redact:                redact,
+		RedactPatterns:        redactPatterns,
+		Dedup:                 dedup,
+		Summarize:             summarize,
+		OnlyChangedFunctions:  onlyChangedFunctions,
+		IncludeTree:           includeTree,
+		IncludeSummary:        includeSummary,
+		TreeDepth:             treeDepth,
+		FileDelimiters:        fileDelimiters,
+		ProgressMode:          progressMode,
+		SummaryJSON:           summaryJSON,
+		Watch:                 watch,
+		WatchDebounce:         watchDebounce,
+		Explain:               explain,
+		ExplainExcluded:       explainExcluded,
 	}, nil
 }
 
+// languageExtensions maps --lang names to the file extensions of those languages.
+func languageExtensions(langs []string) ([]string, error) {
+	var extensions []string
+	for _, lang := range langs {
+		exts, ok := contextgen.ExtensionsForLanguage(lang)
+		if !ok {
+			return nil, fmt.Errorf("unknown --lang %q (supported: %s)",
+				lang, strings.Join(contextgen.LanguageNames(), ", "))
+		}
+		extensions = append(extensions, exts...)
+	}
+
+	return extensions, nil
+}
+
+// contextWindowFromFlags returns the token window given by --context-window, as a
+// token count or a known model name, or looked up from --model-window (0 = neither given).
+func contextWindowFromFlags(cmd *cobra.Command) (int, error) {
+	windowStr, _ := cmd.Flags().GetString("context-window")
+	model, _ := cmd.Flags().GetString("model-window")
+	windowStr = strings.TrimSpace(windowStr)
+
+	switch {
+	case windowStr != "" && windowStr != "0" && model != "":
+		return 0, fmt.Errorf("--context-window cannot be combined with --model-window")
+	case model != "":
+		return windowForModelFlag("--model-window", model)
+	case windowStr == "":
+		return 0, nil
+	}
+
+	window, err := strconv.Atoi(windowStr)
+	if err != nil {
+		return windowForModelFlag("--context-window", windowStr)
+	}
+	if window < 0 {
+		return 0, fmt.Errorf("invalid --context-window: %d (must not be negative)", window)
+	}
+
+	return window, nil
+}
+
+// windowForModelFlag returns the window of the model named by flag, or an error
+// listing the known model families.
+func windowForModelFlag(flag, model string) (int, error) {
+	window, ok := tokens.WindowForModel(model)
+	if !ok {
+		return 0, fmt.Errorf("unknown %s model %q (known: %s); give the window in tokens instead",
+			flag, model, strings.Join(tokens.KnownModelPrefixes(), ", "))
+	}
+
+	return window, nil
+}
+
+// defaultOutputName is the --output used when none is given.
+const defaultOutputName = "shotgun-prompt-{date}-{time}.md"
+
+// expandOutputName fills in the {date}, {time}, {root}, {branch} and {template}
+// placeholders of an --output name. {branch} is empty outside a git branch and
+// {template} is "default" without --template. Substituted values are reduced to
+// filesystem-safe characters.
+func expandOutputName(name, rootPath, templateName string, now time.Time) string {
+	if !strings.Contains(name, "{") {
+		return name
+	}
+
+	var branch string
+	if strings.Contains(name, "{branch}") {
+		branch, _ = git.CurrentBranch(context.Background(), rootPath)
+	}
+	if templateName == "" {
+		templateName = "default"
+	}
+
+	return strings.NewReplacer(
+		"{date}", now.Format("20060102"),
+		"{time}", now.Format("150405"),
+		"{root}", sanitizeFileNamePart(filepath.Base(rootPath)),
+		"{branch}", sanitizeFileNamePart(branch),
+		"{template}", sanitizeFileNamePart(templateName),
+	).Replace(name)
+}
+
+// sanitizeFileNamePart replaces characters other than letters, digits, '.', '-'
+// and '_' with '-', so a value cannot add path separators to a file name.
+func sanitizeFileNamePart(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' || r == '_' {
+			return r
+		}
+		return '-'
+	}, value)
+}
+
+// readExcludeFile reads newline-delimited exclude patterns from path, skipping
+// blank lines and # comments.
+func readExcludeFile(path string) ([]string, error) {
+	file, err := os.Open(path) //nolint:gosec // user-supplied pattern file
+	if err != nil {
+		return nil, fmt.Errorf("failed to open --exclude-from file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var patterns []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read --exclude-from file %s: %w", path, err)
+	}
+
+	return patterns, nil
+}
+
+// readVarFile loads template variables from a JSON or YAML file whose top-level
+// keys are variable names. Numbers and booleans are converted to strings; nested
+// values are rejected.
+func readVarFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // user-supplied variables file
+	if err != nil {
+		return nil, fmt.Errorf("failed to read --var-file: %w", err)
+	}
+
+	// YAML is a superset of JSON, so one decoder covers both formats
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse --var-file %s: %w", path, err)
+	}
+
+	vars := make(map[string]string, len(raw))
+	for key, value := range raw {
+		switch v := value.(type) {
+		case nil:
+			vars[key] = ""
+		case string:
+			vars[key] = v
+		case bool, int, int64, uint64, float64:
+			vars[key] = fmt.Sprint(v)
+		default:
+			return nil, fmt.Errorf("invalid --var-file %s: value of %q must be a string, number or boolean, got %T",
+				path, key, value)
+		}
+	}
+
+	return vars, nil
+}
+
+// fileDelimitersFromConfig returns the lines written around each embedded file,
+// failing on malformed placeholders before any scanning is done.
+func fileDelimitersFromConfig() (*contextgen.FileDelimiters, error) {
+	delimiters := &contextgen.FileDelimiters{
+		Header: viper.GetString(cfgkeys.KeyContextFileHeader),
+		Footer: viper.GetString(cfgkeys.KeyContextFileFooter),
+	}
+	if err := delimiters.Validate(); err != nil {
+		return nil, err
+	}
+
+	return delimiters, nil
+}
+
+// redactPatternsFromConfig returns the regular expressions in context.redact-patterns,
+// a list in the config file or a single pattern set with 'config set'.
+func redactPatternsFromConfig() ([]string, error) {
+	var patterns []string
+	switch value := viper.Get(cfgkeys.KeyContextRedactPatterns).(type) {
+	case nil:
+	case string:
+		if value != "" {
+			patterns = []string{value}
+		}
+	default:
+		patterns = viper.GetStringSlice(cfgkeys.KeyContextRedactPatterns)
+	}
+
+	for _, pattern := range patterns {
+		if err := contextgen.ValidateRedactPattern(pattern); err != nil {
+			return nil, fmt.Errorf("%s: %w", cfgkeys.KeyContextRedactPatterns, err)
+		}
+	}
+
+	return patterns, nil
+}
+
+// outputDirFromFlags returns the --output-dir flag when given, else the output.dir config value.
+func outputDirFromFlags(cmd *cobra.Command) string {
+	if cmd.Flags().Changed("output-dir") {
+		dir, _ := cmd.Flags().GetString("output-dir")
+		return dir
+	}
+	return viper.GetString(cfgkeys.KeyOutputDir)
+}
+
 func generateContextHeadless(cfg GenerateConfig) error {
+	start := time.Now()
+	result, err := runContextGeneration(cfg)
+	if err != nil {
+		return err
+	}
+
+	log.Info().Int("files", result.FileCount).Msg("Files scanned")
+	if result.CopiedToClipboard {
+		log.Info().Msg(clipboardCopiedMessage(result))
+	}
+	if warning := clipboardMismatchWarning(result); warning != "" {
+		log.Warn().Msg(warning)
+	}
+
+	var archive *zipArchive
+	if cfg.Zip != "" {
+		var redactor *contextgen.Redactor
+		if cfg.Redact {
+			if redactor, err = contextgen.NewRedactor(cfg.RedactPatterns); err != nil {
+				return err
+			}
+		}
+		if archive, err = writeContextZip(cfg.Zip, result, redactor); err != nil {
+			return err
+		}
+	}
+
+	if cfg.SummaryJSON {
+		if warning := allIgnoredWarning(result); warning != "" {
+			log.Warn().Msg(warning)
+		}
+		if warning, _ := sizeLimitWarning(result, cfg); warning != "" {
+			log.Warn().Msg(warning)
+		}
+		summary := newGenerationSummary(result, cfg, time.Since(start))
+		summary.Zip = archive
+		return printGenerationSummaryJSON(os.Stdout, summary)
+	}
+
+	printGenerationSummary(result, cfg)
+	if archive != nil {
+		fmt.Fprintf(summaryOutput(), "🗜️  Zip archive: %s (%d entries, %s)\n",
+			archive.Path, archive.Entries, utils.FormatBytes(archive.Bytes))
+	}
+
+	return nil
+}
+
+// runContextGeneration scans, renders and saves the context described by cfg.
+func runContextGeneration(cfg GenerateConfig) (*app.GenerateResult, error) {
 	scannerConfig := buildScannerConfig(cfg)
 	log.Debug().Interface("config", scannerConfig).Msg("Scanner configuration")
 
-	templateVars := buildTemplateVars(cfg)
-	templateContent, err := loadTemplateContent(cfg.Template)
+	templateContent, templateDefaults, err := loadTemplateContent(cfg.Template)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	templateVars := buildTemplateVars(cfg, templateContent, templateDefaults)
+
+	ctx := context.Background()
+	var transforms []contextgen.ContentTransform
+	if cfg.OnlyChangedFunctions {
+		filter, err := changedFunctionFilter(ctx, cfg.RootPath, git.DiffOptions{Base: "HEAD"})
+		if err != nil {
+			if errors.Is(err, git.ErrNotRepository) {
+				return nil, fmt.Errorf("'%s' is not inside a git repository; "+
+					"--only-changed-functions needs git history", cfg.RootPath)
+			}
+			return nil, err
+		}
+		transforms = append(transforms, filter.Transform)
 	}
 
 	svc := app.NewContextService()
 	svcCfg := app.GenerateConfig{
-		RootPath:        cfg.RootPath,
-		ScanConfig:      &scannerConfig,
-		Template:        templateContent,
-		TemplateVars:    templateVars,
-		MaxSize:         cfg.MaxSize,
-		EnforceLimit:    cfg.EnforceLimit,
-		OutputPath:      cfg.Output,
-		CopyToClipboard: viper.GetBool(cfgkeys.KeyOutputClipboard),
-		IncludeTree:     viper.GetBool(cfgkeys.KeyContextIncludeTree),
-		IncludeSummary:  viper.GetBool(cfgkeys.KeyContextIncludeSummary),
-		SkipBinary:      viper.GetBool(cfgkeys.KeyScannerSkipBinary),
+		RootPath:         cfg.RootPath,
+		ScanConfig:       &scannerConfig,
+		Selections:       cfg.Selections,
+		Template:         templateContent,
+		TemplateVars:     templateVars,
+		MaxSize:          cfg.MaxSize,
+		EnforceLimit:     cfg.EnforceLimit,
+		Priority:         cfg.Priority,
+		Order:            cfg.Order,
+		CustomOrder:      cfg.CustomOrder,
+		ContentMaxSize:   cfg.ContentMaxSize,
+		PerFileMaxTokens: cfg.PerFileMaxTokens,
+		OutputPath:       cfg.Output,
+		OutputDir:        cfg.OutputDir,
+		SkipPaths:        cfg.SkipPaths,
+		ConfirmSelection: selectionConfirmer(cfg),
+		SkipOutputFile:   cfg.NoOutputFile,
+		InMemory:         cfg.InMemory,
+		Manifest:         cfg.Manifest,
+		CopyToClipboard:  viper.GetBool(cfgkeys.KeyOutputClipboard),
+		ClipboardMode:    app.ClipboardMode(viper.GetString(cfgkeys.KeyOutputClipboardMode)),
+		VerifyClipboard:  viper.GetBool(cfgkeys.KeyOutputClipboardVerify),
+		IncludeTree:      cfg.IncludeTree,
+		IncludeSummary:   cfg.IncludeSummary,
+		TreeDepth:        cfg.TreeDepth,
+		SkipBinary:       viper.GetBool(cfgkeys.KeyScannerSkipBinary),
+		StripComments:    cfg.StripComments,
+		Redact:           cfg.Redact,
+		RedactPatterns:   cfg.RedactPatterns,
+		Dedup:            cfg.Dedup,
+		Summarize:        cfg.Summarize,
+		Transforms:       transforms,
+		FoldSiblings:     viper.GetBool(cfgkeys.KeyContextFoldSiblings),
+		FileDelimiters:   cfg.FileDelimiters,
+		RawContext:       cfg.RawContext,
+		Strict:           cfg.Strict,
 	}
 
 	var result *app.GenerateResult
-	ctx := context.Background()
 
 	if cfg.ProgressMode != ProgressNone {
 		result, err = svc.GenerateWithProgress(ctx, svcCfg, func(stage, msg string, cur, total int64) {
@@ -266,97 +956,370 @@ func generateContextHeadless(cfg GenerateConfig) error {
 	}
 
 	if err != nil {
-		return fmt.Errorf("context generation failed: %w", err)
+		return nil, fmt.Errorf("context generation failed: %w", err)
 	}
 
-	log.Info().Int("files", result.FileCount).Msg("Files scanned")
-	if result.CopiedToClipboard {
-		log.Info().Msg("Context copied to clipboard")
+	if cfg.Selections != nil {
+		if skipped := len(cfg.Selections) - len(scanner.SelectedFiles(result.Tree, cfg.Selections)); skipped > 0 {
+			log.Warn().Int("files", skipped).
+				Msg("Selected files left out by the scan filters (--include, --exclude, ignore rules)")
+		}
 	}
 
-	printGenerationSummary(result, cfg)
-
-	return nil
+	return result, nil
 }
 
 func buildScannerConfig(cfg GenerateConfig) scanner.ScanConfig {
 	scannerConfig := scanner.ScanConfig{
-		MaxFiles:             viper.GetInt64(cfgkeys.KeyScannerMaxFiles),
-		MaxFileSize:          utils.ParseSizeWithDefault(viper.GetString(cfgkeys.KeyScannerMaxFileSize), 1024*1024),
-		MaxMemory:            utils.ParseSizeWithDefault(viper.GetString(cfgkeys.KeyScannerMaxMemory), 500*1024*1024),
-		SkipBinary:           viper.GetBool(cfgkeys.KeyScannerSkipBinary),
-		IncludeHidden:        viper.GetBool(cfgkeys.KeyScannerIncludeHidden),
-		IncludeIgnored:       viper.GetBool(cfgkeys.KeyScannerIncludeIgnored),
-		Workers:              viper.GetInt(cfgkeys.KeyScannerWorkers),
-		RespectGitignore:     viper.GetBool(cfgkeys.KeyScannerRespectGitignore),
-		RespectShotgunignore: viper.GetBool(cfgkeys.KeyScannerRespectShotgunignore),
-		IgnorePatterns:       cfg.Exclude,
-		IncludePatterns:      cfg.Include,
+		MaxFiles:              viper.GetInt64(cfgkeys.KeyScannerMaxFiles),
+		MaxFileSize:           utils.ParseSizeWithDefault(viper.GetString(cfgkeys.KeyScannerMaxFileSize), 1024*1024),
+		MaxMemory:             utils.ParseSizeWithDefault(viper.GetString(cfgkeys.KeyScannerMaxMemory), 500*1024*1024),
+		SkipBinary:            viper.GetBool(cfgkeys.KeyScannerSkipBinary),
+		IncludeHidden:         viper.GetBool(cfgkeys.KeyScannerIncludeHidden),
+		IncludeHiddenDirs:     viper.GetBool(cfgkeys.KeyScannerIncludeHiddenDirs),
+		IncludeHiddenFiles:    viper.GetBool(cfgkeys.KeyScannerIncludeHiddenFiles),
+		IncludeIgnored:        viper.GetBool(cfgkeys.KeyScannerIncludeIgnored),
+		Workers:               viper.GetInt(cfgkeys.KeyScannerWorkers),
+		RespectGitignore:      viper.GetBool(cfgkeys.KeyScannerRespectGitignore),
+		RespectShotgunignore:  viper.GetBool(cfgkeys.KeyScannerRespectShotgunignore),
+		RespectGitattributes:  viper.GetBool(cfgkeys.KeyScannerRespectGitattributes),
+		AllowlistMode:         viper.GetBool(cfgkeys.KeyScannerAllowlistMode),
+		IgnorePatterns:        cfg.Exclude,
+		IncludePatterns:       cfg.Include,
+		IncludeExtensions:     cfg.Extensions,
+		RequirePatterns:       cfg.TestPatterns,
+		ModifiedSince:         cfg.Since,
+		MaxDepth:              cfg.MaxDepth,
+		FollowSymlinks:        cfg.FollowSymlinks,
+		AllowExternalSymlinks: cfg.AllowExternalSymlinks,
+		IncludeGenerated:      cfg.IncludeGenerated,
 	}
 
 	if cfg.Workers > 0 {
 		scannerConfig.Workers = cfg.Workers
 	}
+	if cfg.MaxFiles > 0 {
+		scannerConfig.MaxFiles = cfg.MaxFiles
+	}
 	if cfg.IncludeHidden {
 		scannerConfig.IncludeHidden = true
 	}
 	if cfg.IncludeIgnored {
 		scannerConfig.IncludeIgnored = true
 	}
+	if cfg.NoGitignore {
+		scannerConfig.RespectGitignore = false
+	}
+	if cfg.RespectGitignore {
+		scannerConfig.RespectGitignore = true
+	}
+	if cfg.NoShotgunignore {
+		scannerConfig.RespectShotgunignore = false
+	}
 
 	return scannerConfig
 }
 
-func buildTemplateVars(cfg GenerateConfig) map[string]string {
-	taskValue := cfg.Task
-	if taskValue == "" {
-		taskValue = "Context generation"
-	}
-
+// buildTemplateVars collects the template variables from cfg, plus the git metadata
+// of the root when templateContent uses it. defaults, from the template's
+// front-matter, fill in any variable left empty.
+func buildTemplateVars(cfg GenerateConfig, templateContent string, defaults map[string]string) map[string]string {
 	templateVars := map[string]string{
-		"TASK":           taskValue,
+		"TASK":           cfg.Task,
 		"RULES":          cfg.Rules,
 		"FILE_STRUCTURE": "",
 		"CURRENT_DATE":   time.Now().Format("2006-01-02"),
 	}
 
+	for k, v := range app.GitTemplateVars(context.Background(), cfg.RootPath, templateContent) {
+		templateVars[k] = v
+	}
+
 	for k, v := range cfg.CustomVars {
 		templateVars[k] = v
 	}
 
+	templateVars = template.MergeDefaults(templateVars, defaults)
+	if templateVars["TASK"] == "" {
+		templateVars["TASK"] = "Context generation"
+	}
+
 	return templateVars
 }
 
-func loadTemplateContent(templateName string) (string, error) {
+// loadTemplateContent returns the content and front-matter defaults of the named template.
+func loadTemplateContent(templateName string) (string, map[string]string, error) {
 	if templateName == "" {
-		return "", nil
+		return "", nil, nil
 	}
 
 	tmplMgr, err := template.NewManager(template.ManagerConfig{
 		CustomPath: viper.GetString(cfgkeys.KeyTemplateCustomPath),
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to initialize template manager: %w", err)
+		return "", nil, fmt.Errorf("failed to initialize template manager: %w", err)
 	}
 
 	tmpl, err := tmplMgr.GetTemplate(templateName)
 	if err != nil {
-		return "", fmt.Errorf("failed to load template %q: %w", templateName, err)
+		return "", nil, fmt.Errorf("failed to load template %q: %w", templateName, err)
+	}
+
+	// Surface template problems before they turn into a garbled context
+	result := template.Validate(tmpl.Content)
+	for _, msg := range result.Errors {
+		log.Warn().Str("template", templateName).Msg(msg)
+	}
+	for _, msg := range result.Warnings {
+		log.Warn().Str("template", templateName).Msg(msg)
 	}
 
 	log.Debug().Str("template", templateName).Msg("Using custom template")
-	return tmpl.Content, nil
+	return tmpl.Content, tmpl.Defaults, nil
 }
 
 func printGenerationSummary(result *app.GenerateResult, cfg GenerateConfig) {
-	fmt.Printf("✅ Context generated successfully!\n")
-	fmt.Printf("📁 Root path: %s\n", cfg.RootPath)
-	fmt.Printf("📄 Output file: %s\n", result.OutputPath)
-	fmt.Printf("📊 Files processed: %d\n", result.FileCount)
-	fmt.Printf("📏 Total size: %s (~%s tokens)\n",
+	out := summaryOutput()
+	fmt.Fprintf(out, "✅ Context generated successfully!\n")
+	fmt.Fprintf(out, "📁 Root path: %s\n", cfg.RootPath)
+	if cfg.NoOutputFile {
+		fmt.Fprintf(out, "📋 Copied to clipboard: %s (~%s tokens), no file written\n",
+			utils.FormatBytes(result.ContentSize),
+			tokens.FormatTokens(int(result.TokenEstimate)))
+	} else {
+		fmt.Fprintf(out, "📄 Output file: %s\n", result.OutputPath)
+	}
+	if result.ManifestPath != "" {
+		fmt.Fprintf(out, "🧾 Manifest: %s (for context regenerate)\n", result.ManifestPath)
+	}
+	fmt.Fprintf(out, "📊 Files processed: %d\n", result.FileCount)
+	fmt.Fprintf(out, "📏 Total size: %s (~%s tokens)\n",
 		utils.FormatBytes(result.ContentSize),
 		tokens.FormatTokens(int(result.TokenEstimate)))
-	fmt.Printf("🎯 Size limit: %s\n", utils.FormatBytes(cfg.MaxSize))
+	if cfg.ContextWindow > 0 {
+		fit := tokens.CheckContextFit(int(result.TokenEstimate), cfg.ContextWindow)
+		fmt.Fprintf(out, "🎯 Context window: ≈%d / %d tokens (%.1f%%)\n", fit.UsedTokens, fit.WindowSize, fit.Percentage)
+	} else {
+		fmt.Fprintf(out, "🎯 Size limit: %s\n", utils.FormatBytes(cfg.MaxSize))
+	}
+	if warning, over := sizeLimitWarning(result, cfg); over {
+		fmt.Fprintf(out, "⛔ %s\n", warning)
+	} else if warning != "" {
+		fmt.Fprintf(out, "⚠️  %s\n", warning)
+	}
+	if cfg.StripComments {
+		fmt.Fprintf(out, "✂️  Comments stripped: %s saved\n", utils.FormatBytes(result.CommentBytesSaved))
+	}
+	if cfg.Redact {
+		printRedacted(out, result.Redacted)
+	}
+	if cfg.Dedup {
+		fmt.Fprintf(out, "🔁 Deduplicated: %d identical files, %s (~%s tokens) saved\n", result.DedupFiles,
+			utils.FormatBytes(result.DedupBytesSaved), tokens.FormatTokens(int(result.DedupTokensSaved)))
+	}
+	if len(cfg.Summarize) > 0 {
+		fmt.Fprintf(out, "📝 Summarized: %d files, %d embedded in full\n", result.SummarizedFiles, result.EmbeddedFiles)
+	}
+	if !cfg.Since.IsZero() {
+		fmt.Fprintf(out, "🕒 Modified since %s: %d older files filtered out\n",
+			cfg.Since.Format(time.RFC3339), result.FilesFilteredByTime)
+	}
+	if generated := generatedFileCount(result, cfg.Summarize); generated > 0 {
+		fmt.Fprintf(out, "🧬 Generated/lock files: %d in the tree, content omitted (--include-generated embeds them)\n",
+			generated)
+	}
+	if warning := allIgnoredWarning(result); warning != "" {
+		fmt.Fprintf(out, "⚠️  %s\n", warning)
+	}
+	if len(result.Inaccessible) > 0 {
+		fmt.Fprintf(out, "⚠️  %s:\n", inaccessibleWarning(result.Inaccessible))
+		for _, dir := range result.Inaccessible {
+			fmt.Fprintf(out, "   - %s\n", dir)
+		}
+	}
+	if len(result.Unreadable) > 0 {
+		fmt.Fprintf(out, "⚠️  Skipped %d unreadable files (use --strict to fail instead):\n", len(result.Unreadable))
+		for _, file := range result.Unreadable {
+			fmt.Fprintf(out, "   - %s: %s\n", file.RelPath, file.Reason)
+		}
+	}
+	if len(result.Truncated) > 0 {
+		fmt.Fprintf(out, "✂️  Truncated %d files to ~%s tokens, keeping head and tail:\n",
+			len(result.Truncated), tokens.FormatTokens(cfg.PerFileMaxTokens))
+		for _, file := range result.Truncated {
+			fmt.Fprintf(out, "   - %s: %s omitted (~%s tokens in full)\n",
+				file.RelPath, file.Omitted, tokens.FormatTokens(file.Tokens))
+		}
+	}
+}
+
+// generationSummary is the machine-readable summary printed by --summary-json.
+type generationSummary struct {
+	Output          string `json:"output"`
+	Manifest        string `json:"manifest,omitempty"`
+	FilesProcessed  int    `json:"files_processed"`
+	TotalBytes      int64  `json:"total_bytes"`
+	EstimatedTokens int64  `json:"estimated_tokens"`
+	MaxSize         int64  `json:"max_size"`
+	ContextWindow   int    `json:"context_window,omitempty"`
+	ExceededLimit   bool   `json:"exceeded_limit"`
+	DurationMs      int64  `json:"duration_ms"`
+	// Unreadable lists the files embedded as a placeholder because they could not be read
+	Unreadable []contextgen.UnreadableFile `json:"unreadable,omitempty"`
+	// Inaccessible lists the directories the scan skipped because they could not be read
+	Inaccessible []string `json:"inaccessible,omitempty"`
+	// Truncated lists the files cut to their head and tail by --per-file-max-tokens
+	Truncated []contextgen.TruncatedFile `json:"truncated,omitempty"`
+	// Redacted counts the secrets --redact replaced in each file
+	Redacted []contextgen.RedactedFile `json:"redacted,omitempty"`
+	// SummarizedFiles and EmbeddedFiles count the files embedded as a summary and in
+	// full; they are only set with --summarize
+	SummarizedFiles int `json:"summarized_files,omitempty"`
+	EmbeddedFiles   int `json:"embedded_files,omitempty"`
+	// Zip describes the archive written by --zip
+	Zip *zipArchive `json:"zip,omitempty"`
+}
+
+func newGenerationSummary(result *app.GenerateResult, cfg GenerateConfig, elapsed time.Duration) generationSummary {
+	return generationSummary{
+		Output:          result.OutputPath,
+		Manifest:        result.ManifestPath,
+		FilesProcessed:  result.FileCount,
+		TotalBytes:      result.ContentSize,
+		EstimatedTokens: result.TokenEstimate,
+		MaxSize:         cfg.MaxSize,
+		ContextWindow:   cfg.ContextWindow,
+		ExceededLimit:   cfg.MaxSize > 0 && result.ContentSize > cfg.MaxSize,
+		DurationMs:      elapsed.Milliseconds(),
+		Unreadable:      result.Unreadable,
+		Inaccessible:    result.Inaccessible,
+		Truncated:       result.Truncated,
+		Redacted:        result.Redacted,
+		SummarizedFiles: result.SummarizedFiles,
+		EmbeddedFiles:   result.EmbeddedFiles,
+	}
+}
+
+// printGenerationSummaryJSON writes the summary as a single line of JSON, so stdout
+// stays parseable while progress and logs go to stderr.
+func printGenerationSummaryJSON(w io.Writer, summary generationSummary) error {
+	if err := json.NewEncoder(w).Encode(summary); err != nil {
+		return fmt.Errorf("failed to write JSON summary: %w", err)
+	}
+	return nil
+}
+
+// clipboardCopiedMessage describes what a generation copied to the clipboard.
+func clipboardCopiedMessage(result *app.GenerateResult) string {
+	if result.ClipboardMode == app.ClipboardPath && result.OutputPath != "" {
+		return "Output file path copied to clipboard"
+	}
+	return fmt.Sprintf("Context copied to clipboard (%s)", utils.FormatBytes(result.ClipboardSize))
+}
+
+// clipboardMismatchWarning returns the warning for a clipboard that output.clipboard-verify
+// read back short of the copy, or "" when there is nothing to report.
+func clipboardMismatchWarning(result *app.GenerateResult) string {
+	if !result.ClipboardMismatch {
+		return ""
+	}
+	warning := fmt.Sprintf("The clipboard does not hold the full copy (%s copied); the backend may have truncated it",
+		utils.FormatBytes(result.ClipboardSize))
+	if result.OutputPath == "" {
+		return warning + ". Run again without --no-output-file to write the context to a file"
+	}
+	return warning + ". Read the context from " + result.OutputPath
+}
+
+// printRedacted writes the summary lines for --redact: the total and each file's count.
+func printRedacted(out io.Writer, files []contextgen.RedactedFile) {
+	fmt.Fprintf(out, "🔒 Redacted: %d secrets in %d files\n", redactedTotal(files), len(files))
+	for _, file := range files {
+		fmt.Fprintf(out, "   - %s: %d\n", file.RelPath, file.Count)
+	}
+}
+
+// redactedTotal returns the number of secrets redacted across files.
+func redactedTotal(files []contextgen.RedactedFile) int {
+	total := 0
+	for _, file := range files {
+		total += file.Count
+	}
+	return total
+}
+
+// generatedFileCount returns how many selected files were embedded as a generated
+// file placeholder; those matched by a --summarize pattern are summarized instead.
+func generatedFileCount(result *app.GenerateResult, summarize []string) int {
+	count := 0
+	for _, file := range scanner.SelectedFiles(result.Tree, result.Selections) {
+		if file.IsGenerated && !matchesAnyPattern(summarize, file.RelPath) {
+			count++
+		}
+	}
+	return count
+}
+
+func matchesAnyPattern(patterns []string, relPath string) bool {
+	for _, pattern := range patterns {
+		if scanner.MatchesPattern(pattern, relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// sizeLimitWarnThreshold is the share of the limit, in percent, above which the summary
+// warns that the context is close to it, as the TUI size bar does.
+const sizeLimitWarnThreshold = 80
+
+// sizeLimitWarning warns when the context uses more than sizeLimitWarnThreshold percent of
+// its budget: the token estimate against the context window when one is given, otherwise
+// the size against the size limit. over reports a context past the limit, which only
+// happens with --no-enforce-limit or a context window. It returns "" when there is room.
+func sizeLimitWarning(result *app.GenerateResult, cfg GenerateConfig) (warning string, over bool) {
+	var percentage float64
+	var usage string
+	switch {
+	case cfg.ContextWindow > 0:
+		fit := tokens.CheckContextFit(int(result.TokenEstimate), cfg.ContextWindow)
+		percentage = fit.Percentage
+		usage = fmt.Sprintf("~%s tokens is %.0f%% of the %s-token context window",
+			tokens.FormatTokens(fit.UsedTokens), percentage, tokens.FormatTokens(fit.WindowSize))
+	case cfg.MaxSize > 0:
+		percentage = float64(result.ContentSize) / float64(cfg.MaxSize) * 100
+		usage = fmt.Sprintf("%s is %.0f%% of the %s size limit",
+			utils.FormatBytes(result.ContentSize), percentage, utils.FormatBytes(cfg.MaxSize))
+	default:
+		return "", false
+	}
+
+	switch {
+	case percentage > 100:
+		return "Over the size limit: " + usage + "; the LLM may reject or truncate it", true
+	case percentage > sizeLimitWarnThreshold:
+		return "Near the size limit: " + usage, false
+	default:
+		return "", false
+	}
+}
+
+// allIgnoredWarning explains an empty result caused by ignore rules, or returns "" otherwise.
+func allIgnoredWarning(result *app.GenerateResult) string {
+	if result.FileCount > 0 || result.IgnoredEntries == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("No selectable files: all %d entries under the root are ignored. "+
+		"Use --include-ignored to include them.", result.IgnoredEntries)
+}
+
+// inaccessibleWarning reports how many directories the scan skipped as unreadable.
+func inaccessibleWarning(dirs []string) string {
+	if len(dirs) == 1 {
+		return "1 directory skipped (permission denied or unreadable)"
+	}
+	return fmt.Sprintf("%d directories skipped (permission denied or unreadable)", len(dirs))
 }
 
 // renderProgressHuman renders progress for humans
@@ -394,28 +1357,126 @@ func clearProgressLine(mode ProgressMode) {
 	}
 }
 
-func init() {
-	// Context generate flags
-	contextGenerateCmd.Flags().StringP("root", "r", ".", "Root directory to scan")
-	contextGenerateCmd.Flags().StringSliceP("include", "i", []string{"*"}, "File patterns to include (glob patterns)")
-	contextGenerateCmd.Flags().StringSliceP("exclude", "e", []string{}, "File patterns to exclude (glob patterns)")
-	contextGenerateCmd.Flags().StringP("output", "o", "", "Output file (default: shotgun-prompt-YYYYMMDD-HHMMSS.md)")
-	contextGenerateCmd.Flags().String("max-size", "10MB", "Maximum context size (e.g., 5MB, 1GB, 500KB)")
-	contextGenerateCmd.Flags().Bool("enforce-limit", true, "Enforce context size limit (default: true)")
+// addGenerateFlags defines the context generate flags on flags; config preset save
+// takes the same flags.
+func addGenerateFlags(flags *pflag.FlagSet) {
+	flags.StringP("root", "r", ".", "Root directory to scan")
+	flags.Bool("recent", false, "Choose the root directory from the recently used roots")
+	flags.StringSliceP("include", "i", []string{"*"}, "File patterns to include (glob patterns)")
+	flags.StringSliceP("exclude", "e", []string{}, "File patterns to exclude (glob patterns)")
+	flags.StringArray("exclude-from", []string{},
+		"Read exclude patterns from a file, one per line; # starts a comment (repeatable)")
+	flags.StringSlice("lang", []string{},
+		"Only include files of these languages (repeatable): "+strings.Join(contextgen.LanguageNames(), ", "))
+	flags.Bool("no-tests", false,
+		"Exclude test files by common Go, JS/TS, Python and Rust conventions (*_test.go, *.spec.ts, tests/, ...)")
+	flags.Bool("only-tests", false,
+		"Only include test files by the conventions of --no-tests; combines with --include and --lang")
+	flags.StringP("output", "o", "",
+		"Output file; supports {date}, {time}, {root}, {branch} and {template} (default: "+defaultOutputName+")")
+	flags.Bool("no-output-file", false, "Copy context to clipboard only, without writing a file")
+	flags.String("selection", "",
+		"Generate from exactly the files listed in a selection JSON exported from the wizard (key e)")
+	flags.String("append", "",
+		"Add the selected files to an existing context file, skipping those it already embeds; "+
+			"its directory tree is not updated")
+	flags.String("confirm-threshold", "",
+		"Ask before embedding files over a size, or more files than a count (e.g., 1MB, 5000 or 1MB,5000)")
+	flags.Bool("yes", false, "Continue past --confirm-threshold without asking")
+	flags.String("zip", "",
+		"Also write a zip archive with the context as "+zipPromptName+" and the selected files")
+	flags.String("output-dir", "", "Directory for generated files (default: output.dir config)")
+	flags.String("max-size", "10MB", "Maximum context size (e.g., 5MB, 1GB, 500KB)")
+	flags.Bool("enforce-limit", true, "Enforce context size limit (default: true)")
+	flags.String("context-window", "",
+		"Token window to fit, in tokens or as a known model (e.g., 128000, gpt-4o); sets the size limit and "+
+			"takes precedence over --max-size")
+	flags.String("model-window", "",
+		"Like --context-window, using the window of a known model (e.g., gpt-4o, claude-sonnet-4)")
+	flags.String("content-max-size", "",
+		"Keep larger files in the tree but replace their content with a placeholder (e.g., 100KB)")
+	flags.Int("per-file-max-tokens", 0,
+		"Embed only the first and last lines of files estimated over this many tokens (0 = no limit)")
+	flags.Bool("strict", false,
+		"Fail on the first unreadable file instead of embedding an [unreadable: reason] placeholder")
+	flags.String("priority", "",
+		"Fit files within --max-size, keeping them in this order and listing the rest as omitted: "+
+			"smallest-first, path-order, recently-modified, importance (best-effort: entrypoints, docs and "+
+			"files imported by others first)")
+	flags.String("order", string(contextgen.OrderTree),
+		"Order of the embedded files: tree, path, size-asc, size-desc, or custom to follow the --selection file")
 
 	// Template configuration flags
-	contextGenerateCmd.Flags().StringP("template", "t", "", "Template name (e.g., makePlan, analyzeBug)")
-	contextGenerateCmd.Flags().String("task", "", "Task description for the LLM")
-	contextGenerateCmd.Flags().String("rules", "", "Rules/constraints for the LLM")
-	contextGenerateCmd.Flags().StringArrayP("var", "V", []string{}, "Custom template vars KEY=VALUE (repeatable)")
+	flags.StringP("template", "t", "", "Template name (e.g., makePlan, analyzeBug)")
+	flags.String("task", "", "Task description for the LLM")
+	flags.String("rules", "", "Rules/constraints for the LLM")
+	flags.StringArrayP("var", "V", []string{}, "Custom template vars KEY=VALUE (repeatable)")
+	flags.String("var-file", "",
+		"Load template vars from a JSON or YAML file of top-level keys (--var overrides)")
+	flags.Bool("raw-context", false, "Emit only the tree and file contents, without template framing")
+	flags.Bool("manifest", false,
+		"Write a .manifest.json next to the output, so context regenerate can re-render it with a new task")
 
 	// Scanner override flags
-	contextGenerateCmd.Flags().Int("workers", 0, "Number of parallel workers (0 = use config)")
-	contextGenerateCmd.Flags().Bool("include-hidden", false, "Include hidden files")
-	contextGenerateCmd.Flags().Bool("include-ignored", false, "Include ignored files")
+	flags.Int("workers", 0, "Number of parallel workers (0 = use config)")
+	flags.Bool("include-hidden", false, "Include hidden files")
+	flags.Bool("include-ignored", false, "Include ignored files")
+	flags.Bool("include-generated", false,
+		"Embed generated and lock files (go.sum, package-lock.json, *.pb.go) instead of a placeholder")
+	flags.Bool("no-gitignore", false, "Ignore .gitignore files for this run (default: scanner.respect-gitignore)")
+	flags.Bool("respect-gitignore", false,
+		"Apply .gitignore files for this run even when scanner.respect-gitignore is false")
+	flags.Bool("no-shotgunignore", false,
+		"Ignore .shotgunignore files for this run (default: scanner.respect-shotgunignore)")
+	flags.String("since", "",
+		"Only include files modified within a window (48h, 7d) or since an RFC3339 time")
+	flags.Int("max-depth", 0,
+		"Directory levels below the root to scan; deeper directories are shown collapsed (0 = no limit)")
+	flags.Int64("max-files", 0,
+		"Fail if the scan finds more non-ignored files than this (0 = use scanner.max-files)")
+	flags.Bool("follow-symlinks", false,
+		"Follow symbolic links instead of listing them as unfollowed leaves")
+	flags.Bool("allow-external-symlinks", false,
+		"With --follow-symlinks, also follow links that point outside the root")
+
+	// Content transform flags
+	flags.Bool("strip-comments", false, "Strip code comments from files (default: from config)")
+	flags.Bool("redact", false,
+		"Replace secrets such as API keys, tokens and passwords with [REDACTED] (default: context.redact config)")
+	flags.Bool("no-tree", false, "Leave out the directory tree (default: from config)")
+	flags.Bool("no-summary", false,
+		"Leave out README descriptions of directories in the tree (default: from config)")
+	flags.Int("tree-depth", 0,
+		"Directory levels of the tree to expand; deeper directories are shown as dir/ (...) (0 = no limit)")
+	flags.Bool("dedup", false,
+		"Embed identical files once; later copies reference the first (// identical to path)")
+	flags.Bool("only-changed-functions", false,
+		"Reduce Go files changed since HEAD to the functions containing changed lines")
+	flags.StringArray("summarize", []string{},
+		"Embed files matching this glob (or below a dir/) as a one-line summary of size and first "+
+			"declaration (repeatable)")
 
 	// Progress output flag
-	contextGenerateCmd.Flags().String("progress", "none", "Progress output mode: none, human, json")
+	flags.String("progress", "none", "Progress output mode: none, human, json")
+	flags.Bool("summary-json", false,
+		"Print the summary as a single JSON object on stdout instead of the human summary")
+
+	// Watch mode flags
+	flags.Bool("watch", false, "Regenerate the context whenever files under the root change")
+	flags.Duration("watch-debounce", fswatch.DefaultDebounce,
+		"Quiet period used to coalesce bursts of changes in --watch mode")
+
+	// Selection debugging flags
+	flags.StringArray("explain", []string{},
+		"Print why PATH (relative to the root) is included or excluded, without generating (repeatable)")
+	flags.Bool("explain-excluded", false,
+		"Print every excluded file and directory with the rule that excluded it, without generating")
+}
+
+func init() {
+	addGenerateFlags(contextGenerateCmd.Flags())
+	contextGenerateCmd.Flags().String("preset", "",
+		"Start from the flags saved in presets.<name> (see 'config preset'); flags given here override it")
 
 	// Mark root as required would be too restrictive since we have a default
 	// But we validate it in PreRunE instead
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/quantmind-br/shotgun-cli/internal/app"
+	"github.com/quantmind-br/shotgun-cli/internal/core/contextgen"
+	"github.com/quantmind-br/shotgun-cli/internal/core/tokens"
+	"github.com/quantmind-br/shotgun-cli/internal/utils"
+)
+
+// appendContext adds the files cfg selects to the context file cfg.Append, leaving out
+// those it already embeds. Only the new file blocks are rendered and inserted after the
+// last embedded file; the tree and template text of the file are kept as they are.
+func appendContext(cfg GenerateConfig) error {
+	data, err := os.ReadFile(cfg.Append)
+	if err != nil {
+		return fmt.Errorf("failed to read the context to append to: %w", err)
+	}
+	existing := string(data)
+
+	delimiters := contextgen.DefaultFileDelimiters()
+	if cfg.FileDelimiters != nil {
+		delimiters = *cfg.FileDelimiters
+	}
+	embedded, err := contextgen.EmbeddedPaths(existing, delimiters)
+	if err != nil {
+		return err
+	}
+	if len(embedded) == 0 {
+		return fmt.Errorf("found no embedded files in %s; append to a context generated with a template "+
+			"and the same file delimiters", cfg.Append)
+	}
+
+	cfg.SkipPaths = make(map[string]bool, len(embedded))
+	for _, relPath := range embedded {
+		cfg.SkipPaths[filepath.ToSlash(relPath)] = true
+	}
+	// Render only the file blocks, and check the size of the whole file afterwards
+	staleTree := cfg.IncludeTree
+	cfg.InMemory = true
+	cfg.RawContext = true
+	cfg.IncludeTree = false
+	cfg.EnforceLimit = false
+
+	result, err := runContextGeneration(cfg)
+	if err != nil {
+		return err
+	}
+
+	out := summaryOutput()
+	added, _ := contextgen.EmbeddedPaths(result.Content, delimiters)
+	if len(added) == 0 {
+		fmt.Fprintf(out, "✅ Nothing to append: %s already embeds the %d selected files\n",
+			cfg.Append, result.SkippedFiles)
+		return nil
+	}
+
+	content := contextgen.AppendFileBlocks(existing, result.Content, delimiters)
+	if err := os.WriteFile(cfg.Append, []byte(content), 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", cfg.Append, err)
+	}
+
+	totals := &app.GenerateResult{
+		ContentSize:   int64(len(content)),
+		TokenEstimate: int64(tokens.Estimate(content)),
+	}
+	fmt.Fprintf(out, "✅ Appended %d files to %s\n", len(added), cfg.Append)
+	if result.SkippedFiles > 0 {
+		fmt.Fprintf(out, "⏭️  Skipped %d files already embedded\n", result.SkippedFiles)
+	}
+	fmt.Fprintf(out, "📊 Files embedded: %d\n", len(embedded)+len(added))
+	if staleTree {
+		fmt.Fprintf(out, "🌳 The directory tree in %s still lists the original files only\n", cfg.Append)
+	}
+	fmt.Fprintf(out, "📏 Total size: %s (~%s tokens)\n",
+		utils.FormatBytes(totals.ContentSize), tokens.FormatTokens(int(totals.TokenEstimate)))
+	if cfg.Redact {
+		printRedacted(out, result.Redacted)
+	}
+
+	if warning, over := sizeLimitWarning(totals, cfg); over {
+		log.Warn().Msgf("Appending pushed %s over the limit. %s", cfg.Append, warning)
+	} else if warning != "" {
+		fmt.Fprintf(out, "⚠️  %s\n", warning)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quantmind-br/shotgun-cli/internal/core/contextgen"
+)
+
+func TestAppendContext(t *testing.T) {
+	root := t.TempDir()
+	for path, content := range map[string]string{
+		"main.go":        "package main\n",
+		"newdir/util.go": "package newdir\n",
+		"newdir/doc.txt": "notes\n",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(root, path)), 0o750))
+		require.NoError(t, os.WriteFile(filepath.Join(root, path), []byte(content), 0o600))
+	}
+
+	viper.Reset()
+	setConfigDefaults()
+	viper.Set("output.clipboard", false)
+	viper.Set("quiet", true)
+	t.Cleanup(viper.Reset)
+
+	output := filepath.Join(t.TempDir(), "prompt.md")
+	_, err := runContextGeneration(GenerateConfig{
+		RootPath: root,
+		Include:  []string{"main.go"},
+		Output:   output,
+		MaxSize:  contextgen.DefaultMaxSize,
+		Template: "makePlan",
+		Task:     "Review",
+	})
+	require.NoError(t, err)
+	original, err := os.ReadFile(output)
+	require.NoError(t, err)
+	templateTail := string(original)[strings.LastIndex(string(original), "</file>")+len("</file>"):]
+
+	cfg := GenerateConfig{
+		RootPath: root,
+		Include:  []string{"*"},
+		MaxSize:  contextgen.DefaultMaxSize,
+		Append:   output,
+	}
+	require.NoError(t, appendContext(cfg))
+
+	data, err := os.ReadFile(output)
+	require.NoError(t, err)
+	content := string(data)
+	paths, err := contextgen.EmbeddedPaths(content, contextgen.DefaultFileDelimiters())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"main.go", "newdir/doc.txt", "newdir/util.go"}, paths)
+	assert.Equal(t, 1, strings.Count(content, "package main"), "files already embedded are skipped")
+	assert.True(t, strings.HasSuffix(content, "</file>"+templateTail),
+		"new files go before the template text that follows the embedded files")
+
+	// Appending again finds nothing new and leaves the file alone
+	require.NoError(t, appendContext(cfg))
+	again, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(again))
+
+	cfg.Append = filepath.Join(t.TempDir(), "missing.md")
+	assert.Error(t, appendContext(cfg))
+
+	plain := filepath.Join(t.TempDir(), "plain.md")
+	require.NoError(t, os.WriteFile(plain, []byte("# Notes\n"), 0o600))
+	cfg.Append = plain
+	assert.ErrorContains(t, appendContext(cfg), "found no embedded files")
+}
+
+func TestBuildGenerateConfig_AppendConflicts(t *testing.T) {
+	viper.Reset()
+	setConfigDefaults()
+	t.Cleanup(viper.Reset)
+
+	for _, flag := range []string{"--output=out.md", "--zip=out.zip", "--manifest", "--watch"} {
+		cmd := &cobra.Command{}
+		addGenerateFlags(cmd.Flags())
+		require.NoError(t, cmd.ParseFlags([]string{"--root", t.TempDir(), "--append", "prompt.md", flag}))
+
+		_, err := buildGenerateConfig(cmd)
+		assert.ErrorContains(t, err, "--append cannot be combined with", flag)
+	}
+}
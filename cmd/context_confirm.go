@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
+	"github.com/quantmind-br/shotgun-cli/internal/utils"
+)
+
+// errSelectionAborted is returned when the user aborts at the --confirm-threshold prompt.
+var errSelectionAborted = errors.New("aborted: the selection is over --confirm-threshold")
+
+// confirmThreshold holds the --confirm-threshold limits; a zero field is off.
+type confirmThreshold struct {
+	FileSize  int64 // Confirm before embedding a file larger than this
+	FileCount int   // Confirm before embedding more files than this
+}
+
+// parseConfirmThreshold parses a size (e.g. 1MB), a file count (e.g. 5000) or both
+// separated by a comma. A bare number is a count.
+func parseConfirmThreshold(value string) (confirmThreshold, error) {
+	var threshold confirmThreshold
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if count, err := strconv.Atoi(part); err == nil {
+			if count <= 0 {
+				return confirmThreshold{}, fmt.Errorf("file count %d must be positive", count)
+			}
+			threshold.FileCount = count
+			continue
+		}
+		size, err := utils.ParseSize(part)
+		if err != nil || size <= 0 {
+			return confirmThreshold{}, fmt.Errorf("%q is neither a size (e.g. 1MB) nor a file count (e.g. 5000)", part)
+		}
+		threshold.FileSize = size
+	}
+
+	return threshold, nil
+}
+
+func (t confirmThreshold) String() string {
+	var parts []string
+	if t.FileSize > 0 {
+		parts = append(parts, utils.FormatBytes(t.FileSize)+" per file")
+	}
+	if t.FileCount > 0 {
+		parts = append(parts, fmt.Sprintf("%d files", t.FileCount))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// thresholdReport lists what a selection has over a confirmThreshold.
+type thresholdReport struct {
+	Total int                 // Files selected
+	Large []*scanner.FileNode // Files larger than FileSize, largest first
+	// Dirs are the top-level directories holding the most files, taken until the
+	// rest of the selection is within FileCount; empty when the count is within it
+	Dirs []crowdedDir
+}
+
+// crowdedDir is a top-level directory, or "." for the files at the root, and its
+// selected files.
+type crowdedDir struct {
+	Path  string
+	Files []*scanner.FileNode
+}
+
+// checkThreshold returns what files has over threshold.
+func checkThreshold(files []*scanner.FileNode, threshold confirmThreshold) thresholdReport {
+	report := thresholdReport{Total: len(files)}
+
+	if threshold.FileSize > 0 {
+		for _, file := range files {
+			if file.Size > threshold.FileSize {
+				report.Large = append(report.Large, file)
+			}
+		}
+		sort.SliceStable(report.Large, func(a, b int) bool {
+			return report.Large[a].Size > report.Large[b].Size
+		})
+	}
+
+	if threshold.FileCount > 0 && len(files) > threshold.FileCount {
+		byDir := map[string][]*scanner.FileNode{}
+		for _, file := range files {
+			top, _, nested := strings.Cut(filepath.ToSlash(file.RelPath), "/")
+			if !nested {
+				top = "."
+			}
+			byDir[top] = append(byDir[top], file)
+		}
+		dirs := make([]crowdedDir, 0, len(byDir))
+		for dir, dirFiles := range byDir {
+			dirs = append(dirs, crowdedDir{Path: dir, Files: dirFiles})
+		}
+		sort.Slice(dirs, func(a, b int) bool {
+			if len(dirs[a].Files) != len(dirs[b].Files) {
+				return len(dirs[a].Files) > len(dirs[b].Files)
+			}
+			return dirs[a].Path < dirs[b].Path
+		})
+
+		remaining := len(files)
+		for _, dir := range dirs {
+			if remaining <= threshold.FileCount {
+				break
+			}
+			report.Dirs = append(report.Dirs, dir)
+			remaining -= len(dir.Files)
+		}
+	}
+
+	return report
+}
+
+// Over reports whether the selection has anything over the threshold.
+func (r thresholdReport) Over() bool {
+	return len(r.Large) > 0 || len(r.Dirs) > 0
+}
+
+// Paths returns the slash-separated relative paths of every listed file.
+func (r thresholdReport) Paths() map[string]bool {
+	paths := make(map[string]bool)
+	for _, file := range r.Large {
+		paths[filepath.ToSlash(file.RelPath)] = true
+	}
+	for _, dir := range r.Dirs {
+		for _, file := range dir.Files {
+			paths[filepath.ToSlash(file.RelPath)] = true
+		}
+	}
+	return paths
+}
+
+// write lists the large files and crowded directories.
+func (r thresholdReport) write(out io.Writer, threshold confirmThreshold) {
+	fmt.Fprintf(out, "⚠️  The selection of %d files is over --confirm-threshold (%s):\n", r.Total, threshold)
+	for _, file := range r.Large {
+		fmt.Fprintf(out, "  %s (%s)\n", filepath.ToSlash(file.RelPath), utils.FormatBytes(file.Size))
+	}
+	for _, dir := range r.Dirs {
+		fmt.Fprintf(out, "  %s/ (%d files)\n", dir.Path, len(dir.Files))
+	}
+}
+
+// confirmSelection checks files against threshold and, when something is over it,
+// asks on in whether to continue, exclude the listed files or abort. It returns the
+// paths to exclude. Without a terminal to ask on it fails with guidance instead.
+func confirmSelection(
+	files []*scanner.FileNode, threshold confirmThreshold, in io.Reader, out io.Writer, interactive bool,
+) (map[string]bool, error) {
+	report := checkThreshold(files, threshold)
+	if !report.Over() {
+		return nil, nil
+	}
+
+	report.write(out, threshold)
+	if !interactive {
+		return nil, fmt.Errorf("%w and stdin is not a terminal to confirm; pass --yes to continue, "+
+			"--exclude the files listed, or raise --confirm-threshold", errSelectionAborted)
+	}
+
+	fmt.Fprint(out, "Continue, exclude these, or abort? [c/e/A]: ")
+	response, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read user input: %w", err)
+	}
+
+	switch strings.TrimSpace(strings.ToLower(response)) {
+	case "c", "continue":
+		return nil, nil
+	case "e", "exclude":
+		excluded := report.Paths()
+		fmt.Fprintf(out, "Excluding %d files\n", len(excluded))
+		return excluded, nil
+	default:
+		return nil, errSelectionAborted
+	}
+}
+
+// selectionConfirmer returns the app.GenerateConfig.ConfirmSelection hook for cfg, or
+// nil when there is nothing to confirm. The prompt goes to stderr, so it does not mix
+// with a summary or context written to stdout.
+func selectionConfirmer(cfg GenerateConfig) func([]*scanner.FileNode) (map[string]bool, error) {
+	if cfg.Yes || (cfg.ConfirmThreshold == confirmThreshold{}) {
+		return nil
+	}
+
+	return func(files []*scanner.FileNode) (map[string]bool, error) {
+		stat, err := os.Stdin.Stat()
+		interactive := err == nil && stat.Mode()&os.ModeCharDevice != 0
+		return confirmSelection(files, cfg.ConfirmThreshold, os.Stdin, os.Stderr, interactive)
+	}
+}
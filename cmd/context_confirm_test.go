@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
+)
+
+func TestParseConfirmThreshold(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    confirmThreshold
+		wantErr bool
+	}{
+		{value: "", want: confirmThreshold{}},
+		{value: "1MB", want: confirmThreshold{FileSize: 1024 * 1024}},
+		{value: "5000", want: confirmThreshold{FileCount: 5000}},
+		{value: "500KB, 200", want: confirmThreshold{FileSize: 500 * 1024, FileCount: 200}},
+		{value: "0", wantErr: true},
+		{value: "lots", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseConfirmThreshold(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func thresholdTestFiles() []*scanner.FileNode {
+	files := []*scanner.FileNode{
+		{RelPath: "main.go", Size: 100},
+		{RelPath: "dist/bundle.js", Size: 4 << 20},
+		{RelPath: "src/app.ts", Size: 200},
+	}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		files = append(files, &scanner.FileNode{RelPath: "node_modules/pkg/" + name + ".js", Size: 10})
+	}
+	return files
+}
+
+func TestCheckThreshold(t *testing.T) {
+	files := thresholdTestFiles()
+
+	report := checkThreshold(files, confirmThreshold{FileSize: 1 << 20, FileCount: 5})
+	require.True(t, report.Over())
+	require.Len(t, report.Large, 1)
+	assert.Equal(t, "dist/bundle.js", report.Large[0].RelPath)
+	require.Len(t, report.Dirs, 1, "excluding node_modules alone brings 9 files within 5")
+	assert.Equal(t, "node_modules", report.Dirs[0].Path)
+	assert.Len(t, report.Paths(), 7)
+
+	var out bytes.Buffer
+	report.write(&out, confirmThreshold{FileSize: 1 << 20, FileCount: 5})
+	assert.Contains(t, out.String(), "The selection of 9 files is over --confirm-threshold (1.0 MB per file, 5 files)")
+	assert.Contains(t, out.String(), "  dist/bundle.js (4.0 MB)\n")
+	assert.Contains(t, out.String(), "  node_modules/ (6 files)\n")
+
+	assert.False(t, checkThreshold(files, confirmThreshold{FileSize: 8 << 20, FileCount: 9}).Over())
+
+	rootOnly := []*scanner.FileNode{{RelPath: "a.go"}, {RelPath: "b.go"}, {RelPath: "c.go"}}
+	report = checkThreshold(rootOnly, confirmThreshold{FileCount: 2})
+	require.Len(t, report.Dirs, 1, "files at the root are grouped too")
+	assert.Equal(t, ".", report.Dirs[0].Path)
+}
+
+func TestConfirmSelection(t *testing.T) {
+	files := thresholdTestFiles()
+	threshold := confirmThreshold{FileSize: 1 << 20}
+
+	t.Run("continue", func(t *testing.T) {
+		var out bytes.Buffer
+		exclude, err := confirmSelection(files, threshold, strings.NewReader("c\n"), &out, true)
+		require.NoError(t, err)
+		assert.Empty(t, exclude)
+		assert.Contains(t, out.String(), "[c/e/A]")
+	})
+
+	t.Run("exclude", func(t *testing.T) {
+		exclude, err := confirmSelection(files, threshold, strings.NewReader("e\n"), &bytes.Buffer{}, true)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]bool{"dist/bundle.js": true}, exclude)
+	})
+
+	t.Run("abort by default", func(t *testing.T) {
+		_, err := confirmSelection(files, threshold, strings.NewReader("\n"), &bytes.Buffer{}, true)
+		assert.ErrorIs(t, err, errSelectionAborted)
+	})
+
+	t.Run("not a terminal", func(t *testing.T) {
+		var out bytes.Buffer
+		_, err := confirmSelection(files, threshold, strings.NewReader("c\n"), &out, false)
+		require.ErrorIs(t, err, errSelectionAborted)
+		assert.Contains(t, err.Error(), "--yes")
+		assert.Contains(t, out.String(), "dist/bundle.js", "the offending files are listed before aborting")
+	})
+
+	t.Run("within threshold", func(t *testing.T) {
+		var out bytes.Buffer
+		exclude, err := confirmSelection(files, confirmThreshold{FileCount: 100}, strings.NewReader(""), &out, false)
+		require.NoError(t, err)
+		assert.Nil(t, exclude)
+		assert.Empty(t, out.String())
+	})
+}
+
+func TestSelectionConfirmer(t *testing.T) {
+	assert.Nil(t, selectionConfirmer(GenerateConfig{}))
+	assert.Nil(t, selectionConfirmer(GenerateConfig{ConfirmThreshold: confirmThreshold{FileCount: 1}, Yes: true}))
+	assert.NotNil(t, selectionConfirmer(GenerateConfig{ConfirmThreshold: confirmThreshold{FileCount: 1}}))
+}
@@ -0,0 +1,323 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/quantmind-br/shotgun-cli/internal/app"
+	cfgkeys "github.com/quantmind-br/shotgun-cli/internal/config"
+	"github.com/quantmind-br/shotgun-cli/internal/core/contextgen"
+	"github.com/quantmind-br/shotgun-cli/internal/core/diff"
+	"github.com/quantmind-br/shotgun-cli/internal/core/ignore"
+	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
+	"github.com/quantmind-br/shotgun-cli/internal/platform/git"
+	"github.com/quantmind-br/shotgun-cli/internal/utils"
+)
+
+// ChangedFile describes a changed file and whether it made it into the context.
+type ChangedFile struct {
+	RelPath  string
+	Included bool
+	Reason   string // Why the file was skipped (empty when included)
+}
+
+var contextDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Generate context from changed files only",
+	Long: `Generate context from the files changed in the git working tree.
+
+The list of changed files comes from 'git diff --name-only'. Deleted files are
+dropped, and the remaining files are filtered through the same ignore rules used
+by 'context generate' (.gitignore, .shotgunignore, built-in patterns and
+--exclude), so nothing that would normally be ignored is sent to an LLM.
+
+With --only-changed-functions, Go files are reduced to the top-level functions
+and declarations that contain changed lines, plus the package clause and imports.
+Other files are still included whole.
+
+Examples:
+  shotgun-cli context diff
+  shotgun-cli context diff --staged
+  shotgun-cli context diff --staged --redact
+  shotgun-cli context diff --base main --task "Review these changes"
+  shotgun-cli context diff --base HEAD~3 --template analyzeBug -o review.md
+  shotgun-cli context diff --base main --only-changed-functions`,
+
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		rootPath, _ := cmd.Flags().GetString("root")
+		absPath, err := filepath.Abs(rootPath)
+		if err != nil {
+			return fmt.Errorf("invalid root path '%s': %w", rootPath, err)
+		}
+
+		if info, err := os.Stat(absPath); err != nil {
+			return fmt.Errorf("cannot access root path '%s': %w", absPath, err)
+		} else if !info.IsDir() {
+			return fmt.Errorf("root path must be a directory: %s", absPath)
+		}
+
+		maxSizeStr, _ := cmd.Flags().GetString("max-size")
+		if _, err := utils.ParseSize(maxSizeStr); err != nil {
+			return fmt.Errorf("invalid max-size format '%s': %w (use formats like 1MB, 5GB, 500KB)", maxSizeStr, err)
+		}
+
+		return nil
+	},
+	RunE: runContextDiff,
+}
+
+func runContextDiff(cmd *cobra.Command, args []string) error {
+	cfg, err := buildGenerateConfig(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to build configuration: %w", err)
+	}
+
+	staged, _ := cmd.Flags().GetBool("staged")
+	base, _ := cmd.Flags().GetString("base")
+	onlyChangedFunctions, _ := cmd.Flags().GetBool("only-changed-functions")
+	diffOpts := git.DiffOptions{Staged: staged, Base: base}
+
+	ctx := context.Background()
+	changed, err := git.ChangedFiles(ctx, cfg.RootPath, diffOpts)
+	if err != nil {
+		if errors.Is(err, git.ErrNotRepository) {
+			return fmt.Errorf("'%s' is not inside a git repository; 'context diff' needs git history", cfg.RootPath)
+		}
+		return fmt.Errorf("failed to list changed files: %w", err)
+	}
+
+	files, err := classifyChangedFiles(cfg, changed)
+	if err != nil {
+		return err
+	}
+
+	selections := make(map[string]bool)
+	for _, f := range files {
+		if f.Included {
+			selections[filepath.Join(cfg.RootPath, filepath.FromSlash(f.RelPath))] = true
+		}
+	}
+
+	printChangedFiles(files)
+
+	if len(selections) == 0 {
+		return fmt.Errorf("no changed files to include in context")
+	}
+
+	var transforms []contextgen.ContentTransform
+	if onlyChangedFunctions {
+		filter, err := changedFunctionFilter(ctx, cfg.RootPath, diffOpts)
+		if err != nil {
+			return err
+		}
+		transforms = append(transforms, filter.Transform)
+	}
+
+	log.Info().Str("root", cfg.RootPath).Int("files", len(selections)).Msg("Generating context from changed files...")
+
+	return generateDiffContext(ctx, cfg, selections, transforms...)
+}
+
+// changedFunctionFilter builds a transform that trims Go files to the declarations
+// touched by the diff described by opts.
+func changedFunctionFilter(
+	ctx context.Context,
+	rootPath string,
+	opts git.DiffOptions,
+) (*diff.ChangedFunctionFilter, error) {
+	unified, err := git.UnifiedDiff(ctx, rootPath, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read changed lines: %w", err)
+	}
+
+	return diff.NewChangedFunctionFilter(diff.ParseChangedLines(unified)), nil
+}
+
+// classifyChangedFiles decides, for each changed path, whether it belongs in the context.
+// Files that no longer exist, are not regular files, or are matched by ignore rules are skipped.
+func classifyChangedFiles(cfg GenerateConfig, changed []string) ([]ChangedFile, error) {
+	scanConfig := buildScannerConfig(cfg)
+
+	engine := ignore.NewIgnoreEngine()
+	// Changed generated files are kept; the scan marks them and leaves out their content
+	engine.SetIgnoreGenerated(false)
+	if scanConfig.RespectGitignore {
+		if err := engine.LoadGitignore(cfg.RootPath); err != nil {
+			return nil, fmt.Errorf("failed to load gitignore rules: %w", err)
+		}
+	}
+	if scanConfig.RespectShotgunignore {
+		if err := engine.LoadShotgunignore(cfg.RootPath); err != nil {
+			return nil, fmt.Errorf("failed to load shotgunignore rules: %w", err)
+		}
+	}
+	if scanConfig.RespectGitattributes {
+		if err := engine.LoadGitattributes(cfg.RootPath); err != nil {
+			return nil, fmt.Errorf("failed to load gitattributes rules: %w", err)
+		}
+	}
+	if scanConfig.AllowlistMode {
+		if err := engine.LoadShotgunkeep(cfg.RootPath); err != nil {
+			return nil, fmt.Errorf("failed to load shotgunkeep rules: %w", err)
+		}
+	}
+	if len(scanConfig.IgnorePatterns) > 0 {
+		if err := engine.AddCustomRules(scanConfig.IgnorePatterns); err != nil {
+			return nil, fmt.Errorf("failed to add exclude patterns: %w", err)
+		}
+	}
+
+	files := make([]ChangedFile, 0, len(changed))
+	for _, relPath := range changed {
+		file := ChangedFile{RelPath: relPath}
+
+		info, err := os.Stat(filepath.Join(cfg.RootPath, filepath.FromSlash(relPath)))
+		switch {
+		case err != nil:
+			file.Reason = "deleted"
+		case !info.Mode().IsRegular():
+			file.Reason = "not a regular file"
+		case isHiddenPath(relPath, &scanConfig):
+			file.Reason = "hidden"
+		default:
+			if ignored, reason := engine.ShouldIgnore(relPath); ignored {
+				file.Reason = "ignored (" + reason.String() + ")"
+			} else {
+				file.Included = true
+			}
+		}
+
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// isHiddenPath reports whether a slash-separated file path passes through a hidden
+// directory, or is itself a hidden file, that the scanner config would exclude.
+func isHiddenPath(relPath string, scanConfig *scanner.ScanConfig) bool {
+	parts := strings.Split(relPath, "/")
+	for i, part := range parts {
+		if !strings.HasPrefix(part, ".") || part == "." || part == ".." {
+			continue
+		}
+		if !scanConfig.IncludesHidden(i < len(parts)-1) {
+			return true
+		}
+	}
+	return false
+}
+
+func printChangedFiles(files []ChangedFile) {
+	var included, skipped []ChangedFile
+	for _, f := range files {
+		if f.Included {
+			included = append(included, f)
+		} else {
+			skipped = append(skipped, f)
+		}
+	}
+
+	out := summaryOutput()
+	fmt.Fprintf(out, "🔀 Changed files: %d (%d included, %d skipped)\n", len(files), len(included), len(skipped))
+	for _, f := range included {
+		fmt.Fprintf(out, "  + %s\n", f.RelPath)
+	}
+	for _, f := range skipped {
+		fmt.Fprintf(out, "  - %s (%s)\n", f.RelPath, f.Reason)
+	}
+}
+
+func generateDiffContext(
+	ctx context.Context,
+	cfg GenerateConfig,
+	selections map[string]bool,
+	transforms ...contextgen.ContentTransform,
+) error {
+	scannerConfig := buildScannerConfig(cfg)
+
+	templateContent, templateDefaults, err := loadTemplateContent(cfg.Template)
+	if err != nil {
+		return err
+	}
+
+	svc := app.NewContextService()
+	result, err := svc.Generate(ctx, app.GenerateConfig{
+		RootPath:        cfg.RootPath,
+		ScanConfig:      &scannerConfig,
+		Selections:      selections,
+		Template:        templateContent,
+		TemplateVars:    buildTemplateVars(cfg, templateContent, templateDefaults),
+		MaxSize:         cfg.MaxSize,
+		EnforceLimit:    cfg.EnforceLimit,
+		OutputPath:      cfg.Output,
+		OutputDir:       cfg.OutputDir,
+		CopyToClipboard: viper.GetBool(cfgkeys.KeyOutputClipboard),
+		ClipboardMode:   app.ClipboardMode(viper.GetString(cfgkeys.KeyOutputClipboardMode)),
+		VerifyClipboard: viper.GetBool(cfgkeys.KeyOutputClipboardVerify),
+		IncludeTree:     cfg.IncludeTree,
+		IncludeSummary:  cfg.IncludeSummary,
+		SkipBinary:      viper.GetBool(cfgkeys.KeyScannerSkipBinary),
+		StripComments:   cfg.StripComments,
+		Redact:          cfg.Redact,
+		RedactPatterns:  cfg.RedactPatterns,
+		FoldSiblings:    viper.GetBool(cfgkeys.KeyContextFoldSiblings),
+		FileDelimiters:  cfg.FileDelimiters,
+		Transforms:      transforms,
+	})
+	if err != nil {
+		return fmt.Errorf("context generation failed: %w", err)
+	}
+
+	if result.CopiedToClipboard {
+		log.Info().Msg(clipboardCopiedMessage(result))
+	}
+	if warning := clipboardMismatchWarning(result); warning != "" {
+		log.Warn().Msg(warning)
+	}
+
+	out := summaryOutput()
+	fmt.Fprintf(out, "✅ Context generated successfully!\n")
+	fmt.Fprintf(out, "📄 Output file: %s\n", result.OutputPath)
+	fmt.Fprintf(out, "📊 Files included: %d\n", len(selections))
+	fmt.Fprintf(out, "📏 Total size: %s\n", utils.FormatBytes(result.ContentSize))
+	if cfg.StripComments {
+		fmt.Fprintf(out, "✂️  Comments stripped: %s saved\n", utils.FormatBytes(result.CommentBytesSaved))
+	}
+	if cfg.Redact {
+		printRedacted(out, result.Redacted)
+	}
+
+	return nil
+}
+
+func init() {
+	contextDiffCmd.Flags().StringP("root", "r", ".", "Root directory (must be inside a git repository)")
+	contextDiffCmd.Flags().Bool("staged", false, "Only include staged changes")
+	contextDiffCmd.Flags().String("base", "", "Compare against this git ref (e.g., main, HEAD~3)")
+	contextDiffCmd.Flags().StringSliceP("exclude", "e", []string{}, "File patterns to exclude (glob patterns)")
+	contextDiffCmd.Flags().StringP("output", "o", "", "Output file (default: shotgun-prompt-YYYYMMDD-HHMMSS.md)")
+	contextDiffCmd.Flags().String("output-dir", "", "Directory for generated files (default: output.dir config)")
+	contextDiffCmd.Flags().String("max-size", "10MB", "Maximum context size (e.g., 5MB, 1GB, 500KB)")
+	contextDiffCmd.Flags().Bool("enforce-limit", true, "Enforce context size limit (default: true)")
+	contextDiffCmd.Flags().StringP("template", "t", "", "Template name (e.g., makePlan, analyzeBug)")
+	contextDiffCmd.Flags().String("task", "", "Task description for the LLM")
+	contextDiffCmd.Flags().String("rules", "", "Rules/constraints for the LLM")
+	contextDiffCmd.Flags().StringArrayP("var", "V", []string{}, "Custom template vars KEY=VALUE (repeatable)")
+	contextDiffCmd.Flags().Bool("include-hidden", false, "Include hidden files")
+	contextDiffCmd.Flags().Bool("strip-comments", false, "Strip code comments from files (default: from config)")
+	contextDiffCmd.Flags().Bool("redact", false,
+		"Replace secrets such as API keys, tokens and passwords with [REDACTED] (default: context.redact config)")
+	contextDiffCmd.Flags().Bool("only-changed-functions", false,
+		"Reduce Go files to the functions containing changed lines")
+
+	contextCmd.AddCommand(contextDiffCmd)
+}
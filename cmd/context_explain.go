@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/quantmind-br/shotgun-cli/internal/app"
+	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
+)
+
+// explainReport is the output of --explain and --explain-excluded with --summary-json.
+type explainReport struct {
+	Explained []scanner.Explanation `json:"explained,omitempty"`
+	Excluded  []scanner.Explanation `json:"excluded,omitempty"`
+}
+
+// explainSelection scans the root as context generate would, then prints the decision
+// chain for each --explain path and, with --explain-excluded, every excluded entry.
+func explainSelection(w io.Writer, cfg GenerateConfig) error {
+	scanConfig := buildScannerConfig(cfg)
+	fs := scanner.NewFileSystemScanner()
+	if _, err := fs.Scan(cfg.RootPath, &scanConfig); err != nil {
+		return fmt.Errorf("%w: %w", app.ErrScanFailed, err)
+	}
+
+	var report explainReport
+	for _, path := range cfg.Explain {
+		explanation, err := fs.Explain(cfg.RootPath, path, &scanConfig)
+		if err != nil {
+			return withExitCode(ExitUsage, err)
+		}
+		report.Explained = append(report.Explained, *explanation)
+	}
+	if cfg.ExplainExcluded {
+		excluded, err := fs.ExplainExcluded(cfg.RootPath, &scanConfig)
+		if err != nil {
+			return fmt.Errorf("%w: %w", app.ErrScanFailed, err)
+		}
+		report.Excluded = excluded
+	}
+
+	if cfg.SummaryJSON {
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			return fmt.Errorf("failed to write explanation: %w", err)
+		}
+		return nil
+	}
+	printExplainReport(w, report, cfg.ExplainExcluded)
+
+	return nil
+}
+
+func printExplainReport(w io.Writer, report explainReport, listExcluded bool) {
+	for i, explanation := range report.Explained {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		_, _ = fmt.Fprintf(w, "%s: %s\n", explanation.RelPath, explanation.Verdict)
+		width := 0
+		for _, step := range explanation.Steps {
+			width = max(width, len(step.Check))
+		}
+		for _, step := range explanation.Steps {
+			_, _ = fmt.Fprintf(w, "  %-*s  %s\n", width, step.Check, step.Result)
+		}
+	}
+
+	if !listExcluded {
+		return
+	}
+	if len(report.Explained) > 0 {
+		_, _ = fmt.Fprintln(w)
+	}
+	_, _ = fmt.Fprintf(w, "Excluded entries (an excluded directory counts once): %d\n", len(report.Excluded))
+	width := 0
+	for _, explanation := range report.Excluded {
+		width = max(width, len(explanation.RelPath))
+	}
+	for _, explanation := range report.Excluded {
+		reason := strings.TrimPrefix(explanation.Verdict, "excluded: ")
+		_, _ = fmt.Fprintf(w, "  %-*s  %s\n", width, explanation.RelPath, reason)
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestExplainSelection(t *testing.T) {
+	root := t.TempDir()
+	for file, content := range map[string]string{
+		".gitignore":     "*.tmp\n",
+		"main.go":        "package main\n",
+		"cache.tmp":      "cache",
+		"node_modules/x": "dep",
+	} {
+		path := filepath.Join(root, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	viper.Reset()
+	setConfigDefaults()
+	t.Cleanup(viper.Reset)
+
+	cfg := GenerateConfig{
+		RootPath:        root,
+		Include:         []string{"*"},
+		Explain:         []string{"main.go", "cache.tmp"},
+		ExplainExcluded: true,
+	}
+
+	var out bytes.Buffer
+	if err := explainSelection(&out, cfg); err != nil {
+		t.Fatalf("explainSelection() error: %v", err)
+	}
+	for _, want := range []string{
+		"main.go: included",
+		`cache.tmp: excluded: ignored by gitignore rule "*.tmp"`,
+		"Excluded entries (an excluded directory counts once): 3",
+		`node_modules  ignored by built-in rule "node_modules/"`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out.String())
+		}
+	}
+
+	cfg.SummaryJSON = true
+	out.Reset()
+	if err := explainSelection(&out, cfg); err != nil {
+		t.Fatalf("explainSelection() error: %v", err)
+	}
+	var report explainReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(report.Explained) != 2 || !report.Explained[0].Included || report.Explained[1].Included {
+		t.Errorf("unexpected explanations: %+v", report.Explained)
+	}
+
+	cfg.Explain = []string{"../outside.go"}
+	if err := explainSelection(&out, cfg); ExitCode(err) != ExitUsage {
+		t.Errorf("expected a usage error for a path outside the root, got %v", err)
+	}
+}
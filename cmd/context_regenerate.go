@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/quantmind-br/shotgun-cli/internal/app"
+	cfgkeys "github.com/quantmind-br/shotgun-cli/internal/config"
+	"github.com/quantmind-br/shotgun-cli/internal/core/contextgen"
+	"github.com/quantmind-br/shotgun-cli/internal/core/tokens"
+	"github.com/quantmind-br/shotgun-cli/internal/platform/clipboard"
+	"github.com/quantmind-br/shotgun-cli/internal/utils"
+)
+
+var contextRegenerateCmd = &cobra.Command{
+	Use:   "regenerate",
+	Short: "Re-render a generated context with a new task, rules or variables",
+	Long: `Re-render a context written by 'context generate --manifest' with new template
+variables, without scanning or reading any file again.
+
+--from names the generated prompt file or its .manifest.json. The embedded files and
+the template are taken from the manifest; --task, --rules and --var replace the values
+the context was generated with, and every other variable is kept. The new output gets
+a manifest of its own, so it can be regenerated in turn.
+
+Examples:
+  shotgun-cli context regenerate --from shotgun-prompt-20240501-120000.md --task "Add tests"
+  shotgun-cli context regenerate --from plan.manifest.json --rules "No new dependencies" -o plan-v2.md`,
+	Args: cobra.NoArgs,
+	RunE: runContextRegenerate,
+}
+
+func runContextRegenerate(cmd *cobra.Command, args []string) error {
+	from, _ := cmd.Flags().GetString("from")
+	output, _ := cmd.Flags().GetString("output")
+	maxSizeStr, _ := cmd.Flags().GetString("max-size")
+
+	maxSize, err := utils.ParseSize(maxSizeStr)
+	if err != nil {
+		return withExitCode(ExitUsage, fmt.Errorf("invalid max-size format '%s': %w", maxSizeStr, err))
+	}
+	vars, err := regenerateVarsFromFlags(cmd)
+	if err != nil {
+		return withExitCode(ExitUsage, err)
+	}
+
+	manifest, err := app.ReadManifest(from)
+	if err != nil {
+		return err
+	}
+
+	templateVars := make(map[string]string, len(manifest.TemplateVars)+len(vars)+1)
+	for k, v := range manifest.TemplateVars {
+		templateVars[k] = v
+	}
+	templateVars["CURRENT_DATE"] = time.Now().Format("2006-01-02")
+	for k, v := range vars {
+		templateVars[k] = v
+	}
+
+	content, err := contextgen.NewDefaultContextGenerator().RenderManifest(manifest, contextgen.GenerateConfig{
+		MaxTotalSize: maxSize,
+		Template:     manifest.Template,
+		TemplateVars: templateVars,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to regenerate context: %w: %w", app.ErrGenerationFailed, err)
+	}
+
+	if output == "" {
+		output = defaultOutputName
+	}
+	cwd, _ := os.Getwd()
+	outputPath, err := app.ResolveOutputPath(outputDirFromFlags(cmd), expandOutputName(output, cwd, "", time.Now()))
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(outputPath, []byte(content), 0600); err != nil {
+		return fmt.Errorf("failed to save output: %w", err)
+	}
+
+	next := *manifest
+	next.TemplateVars = templateVars
+	manifestPath := app.ManifestPath(outputPath)
+	if err := app.WriteManifest(manifestPath, &next); err != nil {
+		return err
+	}
+
+	out := summaryOutput()
+	fmt.Fprintf(out, "✅ Context regenerated from %s\n", from)
+	fmt.Fprintf(out, "📄 Output file: %s\n", outputPath)
+	fmt.Fprintf(out, "🧾 Manifest: %s\n", manifestPath)
+	fmt.Fprintf(out, "📊 Files embedded: %d\n", len(manifest.Files))
+	fmt.Fprintf(out, "📏 Total size: %s (~%s tokens)\n",
+		utils.FormatBytes(int64(len(content))), tokens.FormatTokens(tokens.Estimate(content)))
+
+	if viper.GetBool(cfgkeys.KeyOutputClipboard) {
+		mode := app.ClipboardMode(viper.GetString(cfgkeys.KeyOutputClipboardMode))
+		if text, ok := app.ClipboardText(mode, content, outputPath); ok {
+			if err := clipboard.Copy(text); err != nil {
+				log.Warn().Err(err).Msg("Failed to copy to clipboard")
+			} else {
+				fmt.Fprintf(out, "📋 Copied to clipboard: %s\n", utils.FormatBytes(int64(len(text))))
+				if viper.GetBool(cfgkeys.KeyOutputClipboardVerify) {
+					if ok, err := clipboard.Verify(text); err == nil && !ok {
+						log.Warn().Msg(clipboardMismatchWarning(&app.GenerateResult{
+							OutputPath:        outputPath,
+							ClipboardSize:     int64(len(text)),
+							ClipboardMismatch: true,
+						}))
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// regenerateVarsFromFlags returns the template variables given by --task, --rules,
+// --var-file and --var; inline --var values override those from --var-file.
+func regenerateVarsFromFlags(cmd *cobra.Command) (map[string]string, error) {
+	vars := make(map[string]string)
+
+	if varFile, _ := cmd.Flags().GetString("var-file"); varFile != "" {
+		fileVars, err := readVarFile(varFile)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range fileVars {
+			vars[k] = v
+		}
+	}
+	varFlags, _ := cmd.Flags().GetStringArray("var")
+	for _, v := range varFlags {
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid --var format: %q (expected KEY=VALUE)", v)
+		}
+		vars[parts[0]] = parts[1]
+	}
+
+	if cmd.Flags().Changed("task") {
+		vars["TASK"], _ = cmd.Flags().GetString("task")
+	}
+	if cmd.Flags().Changed("rules") {
+		vars["RULES"], _ = cmd.Flags().GetString("rules")
+	}
+
+	return vars, nil
+}
+
+func init() {
+	contextRegenerateCmd.Flags().String("from", "",
+		"Generated prompt file, or its .manifest.json, to re-render (required)")
+	contextRegenerateCmd.Flags().String("task", "", "New task description for the LLM")
+	contextRegenerateCmd.Flags().String("rules", "", "New rules/constraints for the LLM")
+	contextRegenerateCmd.Flags().StringArrayP("var", "V", []string{}, "Template vars KEY=VALUE to replace (repeatable)")
+	contextRegenerateCmd.Flags().String("var-file", "",
+		"YAML or JSON file of template vars (KEY: VALUE); --var values override it")
+	contextRegenerateCmd.Flags().StringP("output", "o", "",
+		"Output file (default: shotgun-prompt-YYYYMMDD-HHMMSS.md)")
+	contextRegenerateCmd.Flags().String("output-dir", "", "Directory for generated files (default: output.dir config)")
+	contextRegenerateCmd.Flags().String("max-size", "10MB", "Maximum context size (e.g., 5MB, 1GB, 500KB)")
+	_ = contextRegenerateCmd.MarkFlagRequired("from")
+
+	contextCmd.AddCommand(contextRegenerateCmd)
+}
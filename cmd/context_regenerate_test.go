@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quantmind-br/shotgun-cli/internal/app"
+	"github.com/quantmind-br/shotgun-cli/internal/core/contextgen"
+)
+
+func TestRunContextRegenerate(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n"), 0o600))
+	outDir := t.TempDir()
+
+	viper.Reset()
+	setConfigDefaults()
+	viper.Set("output.clipboard", false)
+	viper.Set("quiet", true)
+	t.Cleanup(viper.Reset)
+
+	output := filepath.Join(outDir, "first.md")
+	result, err := runContextGeneration(GenerateConfig{
+		RootPath:   root,
+		Include:    []string{"*"},
+		Output:     output,
+		MaxSize:    contextgen.DefaultMaxSize,
+		Task:       "Old task",
+		CustomVars: map[string]string{"AUDIENCE": "reviewers"},
+		Manifest:   true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, app.ManifestPath(output), result.ManifestPath)
+
+	// Remove the source, so regenerating cannot read it again
+	require.NoError(t, os.Remove(filepath.Join(root, "main.go")))
+
+	second := filepath.Join(outDir, "second.md")
+	require.NoError(t, contextRegenerateCmd.ParseFlags([]string{
+		"--from", output, "--task", "New task", "-o", second,
+	}))
+	require.NoError(t, runContextRegenerate(contextRegenerateCmd, nil))
+
+	data, err := os.ReadFile(second)
+	require.NoError(t, err)
+	content := string(data)
+	assert.Contains(t, content, "New task")
+	assert.NotContains(t, content, "Old task")
+	assert.Contains(t, content, "package main")
+
+	manifest, err := app.ReadManifest(second)
+	require.NoError(t, err)
+	assert.Equal(t, "New task", manifest.TemplateVars["TASK"])
+	assert.Equal(t, "reviewers", manifest.TemplateVars["AUDIENCE"])
+
+	require.NoError(t, contextRegenerateCmd.ParseFlags([]string{"--from", filepath.Join(outDir, "none.md")}))
+	err = runContextRegenerate(contextRegenerateCmd, nil)
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "no manifest found"), err.Error())
+}
@@ -2,12 +2,20 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/quantmind-br/shotgun-cli/internal/app"
+	cfgkeys "github.com/quantmind-br/shotgun-cli/internal/config"
+	"github.com/quantmind-br/shotgun-cli/internal/core/contextgen"
+	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
+	"github.com/quantmind-br/shotgun-cli/internal/core/tokens"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -225,6 +233,76 @@ func TestGenerateContextHeadlessEmptyDirectory(t *testing.T) {
 	}
 }
 
+func TestGenerateContextHeadlessAllIgnoredWarning(t *testing.T) {
+	viper.Reset()
+	viper.Set("scanner.respect-gitignore", true)
+	t.Cleanup(viper.Reset)
+
+	dir := t.TempDir()
+	files := map[string]string{
+		".gitignore":  "*.js\n*.txt\n",
+		"index.js":    "console.log('hi')",
+		"lib/util.js": "module.exports = 1",
+		"notes.txt":   "todo",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to create file: %v", err)
+		}
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := generateContextHeadless(GenerateConfig{
+		RootPath:     dir,
+		Include:      []string{"*"},
+		Output:       filepath.Join(t.TempDir(), "out.md"),
+		MaxSize:      1024 * 1024,
+		ProgressMode: ProgressNone,
+	})
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	expected := "No selectable files: all 3 entries under the root are ignored. Use --include-ignored to include them."
+	if !strings.Contains(buf.String(), expected) {
+		t.Errorf("expected warning %q, got:\n%s", expected, buf.String())
+	}
+}
+
+func TestAllIgnoredWarning(t *testing.T) {
+	tests := []struct {
+		name   string
+		result app.GenerateResult
+		want   bool
+	}{
+		{"files selected", app.GenerateResult{FileCount: 3, IgnoredEntries: 5}, false},
+		{"empty root", app.GenerateResult{}, false},
+		{"everything ignored", app.GenerateResult{IgnoredEntries: 4}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := allIgnoredWarning(&tt.result)
+			if (got != "") != tt.want {
+				t.Errorf("allIgnoredWarning() = %q, want warning: %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestGenerateContextHeadlessWithMaxSize(t *testing.T) {
 	dir := t.TempDir()
 
@@ -535,7 +613,7 @@ func TestRenderProgress(t *testing.T) {
 }
 
 func TestLoadTemplateContent_EmptyTemplateName(t *testing.T) {
-	content, err := loadTemplateContent("")
+	content, _, err := loadTemplateContent("")
 	if err != nil {
 		t.Fatalf("loadTemplateContent('') should return nil error, got: %v", err)
 	}
@@ -545,12 +623,46 @@ func TestLoadTemplateContent_EmptyTemplateName(t *testing.T) {
 }
 
 func TestLoadTemplateContent_NonExistentTemplate(t *testing.T) {
-	_, err := loadTemplateContent("nonexistent-template-xyz")
+	_, _, err := loadTemplateContent("nonexistent-template-xyz")
 	if err == nil {
 		t.Error("loadTemplateContent() with nonexistent template should return error")
 	}
 }
 
+func TestSizeLimitWarning(t *testing.T) {
+	tests := []struct {
+		name        string
+		result      app.GenerateResult
+		cfg         GenerateConfig
+		wantWarning string
+		wantOver    bool
+	}{
+		{"well under", app.GenerateResult{ContentSize: 500}, GenerateConfig{MaxSize: 1000}, "", false},
+		{"at the threshold", app.GenerateResult{ContentSize: 800}, GenerateConfig{MaxSize: 1000}, "", false},
+		{"near the limit", app.GenerateResult{ContentSize: 850}, GenerateConfig{MaxSize: 1000},
+			"Near the size limit: 850 B is 85% of the 1000 B size limit", false},
+		{"over the limit", app.GenerateResult{ContentSize: 1500}, GenerateConfig{MaxSize: 1000},
+			"Over the size limit: 1.5 KB is 150% of the 1000 B size limit", true},
+		{"no limit", app.GenerateResult{ContentSize: 1500}, GenerateConfig{}, "", false},
+		{"window takes precedence", app.GenerateResult{ContentSize: 100, TokenEstimate: 9_500},
+			GenerateConfig{MaxSize: 1000, ContextWindow: 10_000},
+			"Near the size limit: ~9.5K tokens is 95% of the 10.0K-token context window", false},
+		{"over the window", app.GenerateResult{ContentSize: 100, TokenEstimate: 12_000},
+			GenerateConfig{MaxSize: 1_000_000, ContextWindow: 10_000},
+			"Over the size limit: ~12.0K tokens is 120% of the 10.0K-token context window", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warning, over := sizeLimitWarning(&tt.result, tt.cfg)
+			matches := strings.HasPrefix(warning, tt.wantWarning) && (tt.wantWarning == "") == (warning == "")
+			if over != tt.wantOver || !matches {
+				t.Errorf("sizeLimitWarning() = (%q, %v), want (%q..., %v)", warning, over, tt.wantWarning, tt.wantOver)
+			}
+		})
+	}
+}
+
 func TestPrintGenerationSummary(t *testing.T) {
 	oldStdout := os.Stdout
 	r, w, _ := os.Pipe()
@@ -590,6 +702,149 @@ func TestPrintGenerationSummary(t *testing.T) {
 	}
 }
 
+func TestPrintGenerationSummary_NoOutputFile(t *testing.T) {
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	result := &app.GenerateResult{
+		FileCount:         3,
+		ContentSize:       2048,
+		TokenEstimate:     512,
+		CopiedToClipboard: true,
+	}
+	printGenerationSummary(result, GenerateConfig{RootPath: "/tmp/project", NoOutputFile: true})
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r)
+	output := buf.String()
+
+	if strings.Contains(output, "Output file") {
+		t.Error("output should not mention an output file")
+	}
+	if !strings.Contains(output, "Copied to clipboard: 2.0 KB") {
+		t.Errorf("output should confirm clipboard size, got:\n%s", output)
+	}
+}
+
+func TestClipboardMismatchWarning(t *testing.T) {
+	result := &app.GenerateResult{OutputPath: "/tmp/prompt.md", ClipboardSize: 2048, CopiedToClipboard: true}
+	if got := clipboardMismatchWarning(result); got != "" {
+		t.Errorf("expected no warning for a verified copy, got %q", got)
+	}
+	if got := clipboardCopiedMessage(result); got != "Context copied to clipboard (2.0 KB)" {
+		t.Errorf("unexpected copied message %q", got)
+	}
+
+	result.ClipboardMismatch = true
+	got := clipboardMismatchWarning(result)
+	if !strings.Contains(got, "2.0 KB copied") || !strings.Contains(got, "Read the context from /tmp/prompt.md") {
+		t.Errorf("warning should give the copied size and the output file, got %q", got)
+	}
+
+	result.OutputPath = ""
+	if got := clipboardMismatchWarning(result); !strings.Contains(got, "--no-output-file") {
+		t.Errorf("warning without an output file should suggest writing one, got %q", got)
+	}
+}
+
+func TestBuildGenerateConfig_NoOutputFile(t *testing.T) {
+	newCmd := func(output string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("root", t.TempDir(), "")
+		cmd.Flags().StringSlice("include", []string{"*"}, "")
+		cmd.Flags().StringSlice("exclude", nil, "")
+		cmd.Flags().String("output", output, "")
+		cmd.Flags().Bool("no-output-file", true, "")
+		cmd.Flags().String("max-size", "10MB", "")
+		cmd.Flags().Bool("enforce-limit", true, "")
+		return cmd
+	}
+
+	cfg, err := buildGenerateConfig(newCmd(""))
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if !cfg.NoOutputFile {
+		t.Error("expected NoOutputFile to be set")
+	}
+	if cfg.Output != "" {
+		t.Errorf("expected no default output filename, got %q", cfg.Output)
+	}
+
+	_, err = buildGenerateConfig(newCmd("custom.md"))
+	if err == nil || !strings.Contains(err.Error(), "--no-output-file") {
+		t.Errorf("expected conflict error with --output, got %v", err)
+	}
+}
+
+func TestBuildGenerateConfig_RawContext(t *testing.T) {
+	newCmd := func(template string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("root", t.TempDir(), "")
+		cmd.Flags().StringSlice("include", []string{"*"}, "")
+		cmd.Flags().StringSlice("exclude", nil, "")
+		cmd.Flags().String("output", "", "")
+		cmd.Flags().String("max-size", "10MB", "")
+		cmd.Flags().Bool("enforce-limit", true, "")
+		cmd.Flags().String("template", template, "")
+		cmd.Flags().Bool("raw-context", true, "")
+		return cmd
+	}
+
+	cfg, err := buildGenerateConfig(newCmd(""))
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if !cfg.RawContext {
+		t.Error("expected RawContext to be set")
+	}
+
+	_, err = buildGenerateConfig(newCmd("makePlan"))
+	if err == nil || !strings.Contains(err.Error(), "--raw-context") {
+		t.Errorf("expected conflict error with --template, got %v", err)
+	}
+}
+
+func TestBuildGenerateConfig_OutputDir(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set(cfgkeys.KeyOutputDir, "/from/config")
+
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("root", t.TempDir(), "")
+		cmd.Flags().StringSlice("include", []string{"*"}, "")
+		cmd.Flags().StringSlice("exclude", nil, "")
+		cmd.Flags().String("output", "", "")
+		cmd.Flags().String("output-dir", "", "")
+		cmd.Flags().String("max-size", "10MB", "")
+		cmd.Flags().Bool("enforce-limit", true, "")
+		return cmd
+	}
+
+	cfg, err := buildGenerateConfig(newCmd())
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if cfg.OutputDir != "/from/config" {
+		t.Errorf("expected OutputDir from config, got %q", cfg.OutputDir)
+	}
+
+	cmd := newCmd()
+	_ = cmd.Flags().Set("output-dir", "/from/flag")
+	cfg, err = buildGenerateConfig(cmd)
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if cfg.OutputDir != "/from/flag" {
+		t.Errorf("expected --output-dir to override config, got %q", cfg.OutputDir)
+	}
+}
+
 func TestBuildGenerateConfig_InvalidProgressMode(t *testing.T) {
 	cmd := &cobra.Command{}
 	cmd.Flags().String("root", ".", "")
@@ -726,6 +981,606 @@ func TestBuildScannerConfig(t *testing.T) {
 	}
 }
 
+func TestBuildGenerateConfig_Since(t *testing.T) {
+	newCmd := func(since string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("root", t.TempDir(), "")
+		cmd.Flags().StringSlice("include", []string{"*"}, "")
+		cmd.Flags().StringSlice("exclude", nil, "")
+		cmd.Flags().String("output", "", "")
+		cmd.Flags().String("max-size", "10MB", "")
+		cmd.Flags().Bool("enforce-limit", true, "")
+		cmd.Flags().String("since", since, "")
+		return cmd
+	}
+
+	cfg, err := buildGenerateConfig(newCmd("48h"))
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	window := time.Since(cfg.Since)
+	if window < 47*time.Hour || window > 49*time.Hour {
+		t.Errorf("expected Since about 48h ago, got %v ago", window)
+	}
+	if !buildScannerConfig(cfg).ModifiedSince.Equal(cfg.Since) {
+		t.Error("expected ModifiedSince to be passed to scanner config")
+	}
+
+	cfg, err = buildGenerateConfig(newCmd(""))
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if !cfg.Since.IsZero() {
+		t.Errorf("expected zero Since when flag is empty, got %v", cfg.Since)
+	}
+
+	_, err = buildGenerateConfig(newCmd("last week"))
+	if err == nil || !strings.Contains(err.Error(), "--since") {
+		t.Errorf("expected --since parse error, got %v", err)
+	}
+}
+
+func TestBuildGenerateConfig_MaxDepth(t *testing.T) {
+	newCmd := func(maxDepth string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("root", t.TempDir(), "")
+		cmd.Flags().String("max-size", "10MB", "")
+		cmd.Flags().Int("max-depth", 0, "")
+		if err := cmd.Flags().Set("max-depth", maxDepth); err != nil {
+			t.Fatalf("failed to set --max-depth: %v", err)
+		}
+		return cmd
+	}
+
+	cfg, err := buildGenerateConfig(newCmd("3"))
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if got := buildScannerConfig(cfg).MaxDepth; got != 3 {
+		t.Errorf("expected scanner MaxDepth=3, got %d", got)
+	}
+
+	_, err = buildGenerateConfig(newCmd("-1"))
+	if err == nil || !strings.Contains(err.Error(), "--max-depth") {
+		t.Errorf("expected --max-depth error, got %v", err)
+	}
+}
+
+func TestBuildGenerateConfig_Lang(t *testing.T) {
+	newCmd := func(langs ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("root", t.TempDir(), "")
+		cmd.Flags().String("max-size", "10MB", "")
+		cmd.Flags().StringSlice("lang", []string{}, "")
+		for _, lang := range langs {
+			if err := cmd.Flags().Set("lang", lang); err != nil {
+				t.Fatalf("failed to set --lang: %v", err)
+			}
+		}
+		return cmd
+	}
+
+	cfg, err := buildGenerateConfig(newCmd("go", "typescript"))
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	want := []string{".go", ".ts", ".tsx"}
+	if got := buildScannerConfig(cfg).IncludeExtensions; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected scanner IncludeExtensions=%v, got %v", want, got)
+	}
+
+	_, err = buildGenerateConfig(newCmd("klingon"))
+	if err == nil || !strings.Contains(err.Error(), `unknown --lang "klingon"`) {
+		t.Errorf("expected unknown --lang error, got %v", err)
+	}
+}
+
+func TestBuildGenerateConfig_Tests(t *testing.T) {
+	rootDir := t.TempDir()
+	files := []string{"main.go", "main_test.go", "pkg/testdata/in.txt", "app/test_views.py", "app/views.py"}
+	for _, file := range files {
+		fullPath := filepath.Join(rootDir, file)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte("x"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scanFiles := func(flags ...string) []string {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("root", rootDir, "")
+		cmd.Flags().String("max-size", "10MB", "")
+		cmd.Flags().StringSlice("include", []string{}, "")
+		cmd.Flags().Bool("no-tests", false, "")
+		cmd.Flags().Bool("only-tests", false, "")
+		if err := cmd.Flags().Parse(flags); err != nil {
+			t.Fatalf("failed to parse flags: %v", err)
+		}
+
+		cfg, err := buildGenerateConfig(cmd)
+		if err != nil {
+			t.Fatalf("buildGenerateConfig() error: %v", err)
+		}
+		scanConfig := buildScannerConfig(cfg)
+		tree, err := scanner.NewFileSystemScanner().Scan(rootDir, &scanConfig)
+		if err != nil {
+			t.Fatalf("Scan() error: %v", err)
+		}
+
+		var files []string
+		var walk func(*scanner.FileNode)
+		walk = func(node *scanner.FileNode) {
+			for _, child := range node.Children {
+				if !child.IsDir {
+					files = append(files, filepath.ToSlash(child.RelPath))
+				}
+				walk(child)
+			}
+		}
+		walk(tree)
+		sort.Strings(files)
+		return files
+	}
+
+	if got, want := scanFiles("--no-tests"), []string{"app/views.py", "main.go"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("--no-tests: expected %v, got %v", want, got)
+	}
+	want := []string{"app/test_views.py", "main_test.go", "pkg/testdata/in.txt"}
+	if got := scanFiles("--only-tests"); !reflect.DeepEqual(got, want) {
+		t.Errorf("--only-tests: expected %v, got %v", want, got)
+	}
+	want = []string{"main_test.go"}
+	if got := scanFiles("--only-tests", "--include", "*.go"); !reflect.DeepEqual(got, want) {
+		t.Errorf("--only-tests --include: expected %v, got %v", want, got)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("root", rootDir, "")
+	cmd.Flags().String("max-size", "10MB", "")
+	cmd.Flags().Bool("no-tests", true, "")
+	cmd.Flags().Bool("only-tests", true, "")
+	if _, err := buildGenerateConfig(cmd); err == nil || !strings.Contains(err.Error(), "--only-tests") {
+		t.Errorf("expected --no-tests/--only-tests conflict error, got %v", err)
+	}
+}
+
+func TestBuildGenerateConfig_Summarize(t *testing.T) {
+	newCmd := func(patterns ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("root", t.TempDir(), "")
+		cmd.Flags().String("max-size", "10MB", "")
+		cmd.Flags().StringArray("summarize", []string{}, "")
+		for _, pattern := range patterns {
+			if err := cmd.Flags().Set("summarize", pattern); err != nil {
+				t.Fatalf("failed to set --summarize: %v", err)
+			}
+		}
+		return cmd
+	}
+
+	cfg, err := buildGenerateConfig(newCmd("*.pb.go", "api/gen/"))
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if want := []string{"*.pb.go", "api/gen/"}; !reflect.DeepEqual(cfg.Summarize, want) {
+		t.Errorf("expected Summarize=%v, got %v", want, cfg.Summarize)
+	}
+
+	_, err = buildGenerateConfig(newCmd("[gen"))
+	if err == nil || !strings.Contains(err.Error(), "invalid --summarize pattern") {
+		t.Errorf("expected invalid --summarize pattern error, got %v", err)
+	}
+}
+
+func TestBuildGenerateConfig_PerFileMaxTokens(t *testing.T) {
+	newCmd := func(value string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("root", t.TempDir(), "")
+		cmd.Flags().String("max-size", "10MB", "")
+		cmd.Flags().Int("per-file-max-tokens", 0, "")
+		if err := cmd.Flags().Set("per-file-max-tokens", value); err != nil {
+			t.Fatalf("failed to set --per-file-max-tokens: %v", err)
+		}
+		return cmd
+	}
+
+	cfg, err := buildGenerateConfig(newCmd("2000"))
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if cfg.PerFileMaxTokens != 2000 {
+		t.Errorf("expected PerFileMaxTokens=2000, got %d", cfg.PerFileMaxTokens)
+	}
+
+	_, err = buildGenerateConfig(newCmd("-1"))
+	if err == nil || !strings.Contains(err.Error(), "invalid --per-file-max-tokens") {
+		t.Errorf("expected invalid --per-file-max-tokens error, got %v", err)
+	}
+}
+
+func TestBuildGenerateConfig_Priority(t *testing.T) {
+	newCmd := func(priority string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("root", t.TempDir(), "")
+		cmd.Flags().String("max-size", "10MB", "")
+		cmd.Flags().String("priority", priority, "")
+		return cmd
+	}
+
+	cfg, err := buildGenerateConfig(newCmd("smallest-first"))
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if cfg.Priority != contextgen.PrioritySmallestFirst {
+		t.Errorf("expected Priority=smallest-first, got %q", cfg.Priority)
+	}
+
+	_, err = buildGenerateConfig(newCmd("biggest-first"))
+	if err == nil || !strings.Contains(err.Error(), "--priority") {
+		t.Errorf("expected --priority error, got %v", err)
+	}
+}
+
+func TestBuildGenerateConfig_Dedup(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("root", t.TempDir(), "")
+	cmd.Flags().String("max-size", "10MB", "")
+	cmd.Flags().Bool("dedup", false, "")
+
+	cfg, err := buildGenerateConfig(cmd)
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if cfg.Dedup {
+		t.Error("expected Dedup=false by default")
+	}
+
+	_ = cmd.Flags().Set("dedup", "true")
+	cfg, err = buildGenerateConfig(cmd)
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if !cfg.Dedup {
+		t.Error("expected Dedup=true with --dedup")
+	}
+}
+
+func TestBuildGenerateConfig_ExcludeFrom(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.txt")
+	second := filepath.Join(dir, "second.txt")
+	if err := os.WriteFile(first, []byte("# shared excludes\n*.log\n\n  fixtures/**  \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(second, []byte("*.tmp\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("root", dir, "")
+	cmd.Flags().String("max-size", "10MB", "")
+	cmd.Flags().StringSlice("exclude", nil, "")
+	cmd.Flags().StringArray("exclude-from", nil, "")
+	_ = cmd.Flags().Set("exclude", "*.bak")
+	_ = cmd.Flags().Set("exclude-from", first)
+	_ = cmd.Flags().Set("exclude-from", second)
+
+	cfg, err := buildGenerateConfig(cmd)
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	want := []string{"*.bak", "*.log", "fixtures/**", "*.tmp"}
+	if strings.Join(cfg.Exclude, ",") != strings.Join(want, ",") {
+		t.Errorf("Exclude = %v, want %v", cfg.Exclude, want)
+	}
+
+	_ = cmd.Flags().Set("exclude-from", filepath.Join(dir, "missing.txt"))
+	if _, err := buildGenerateConfig(cmd); err == nil || !strings.Contains(err.Error(), "--exclude-from") {
+		t.Errorf("expected --exclude-from error for a missing file, got %v", err)
+	}
+}
+
+func TestBuildGenerateConfig_TreeFlags(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set(cfgkeys.KeyContextIncludeTree, true)
+	viper.Set(cfgkeys.KeyContextIncludeSummary, false)
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("root", t.TempDir(), "")
+	cmd.Flags().String("max-size", "10MB", "")
+	cmd.Flags().Bool("no-tree", false, "")
+	cmd.Flags().Bool("no-summary", false, "")
+	cmd.Flags().Int("tree-depth", 0, "")
+
+	cfg, err := buildGenerateConfig(cmd)
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if !cfg.IncludeTree || cfg.IncludeSummary || cfg.TreeDepth != 0 {
+		t.Errorf("expected config values without flags, got tree=%v summary=%v depth=%d",
+			cfg.IncludeTree, cfg.IncludeSummary, cfg.TreeDepth)
+	}
+
+	_ = cmd.Flags().Set("no-tree", "true")
+	_ = cmd.Flags().Set("no-summary", "false")
+	_ = cmd.Flags().Set("tree-depth", "2")
+	cfg, err = buildGenerateConfig(cmd)
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if cfg.IncludeTree || !cfg.IncludeSummary || cfg.TreeDepth != 2 {
+		t.Errorf("expected flags to override config, got tree=%v summary=%v depth=%d",
+			cfg.IncludeTree, cfg.IncludeSummary, cfg.TreeDepth)
+	}
+
+	_ = cmd.Flags().Set("tree-depth", "-1")
+	if _, err := buildGenerateConfig(cmd); err == nil || !strings.Contains(err.Error(), "--tree-depth") {
+		t.Errorf("expected --tree-depth error, got %v", err)
+	}
+}
+
+func TestReadVarFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	vars, err := readVarFile(write("vars.yaml", "PROJECT: shotgun\nRETRIES: 3\nRATIO: 0.5\nVERBOSE: true\nEMPTY:\n"))
+	if err != nil {
+		t.Fatalf("readVarFile(yaml) error: %v", err)
+	}
+	want := map[string]string{"PROJECT": "shotgun", "RETRIES": "3", "RATIO": "0.5", "VERBOSE": "true", "EMPTY": ""}
+	if !reflect.DeepEqual(vars, want) {
+		t.Errorf("readVarFile(yaml) = %v, want %v", vars, want)
+	}
+
+	vars, err = readVarFile(write("vars.json", `{"Project": "shotgun", "Count": 2}`))
+	if err != nil {
+		t.Fatalf("readVarFile(json) error: %v", err)
+	}
+	if vars["Project"] != "shotgun" || vars["Count"] != "2" {
+		t.Errorf("readVarFile(json) = %v", vars)
+	}
+
+	_, err = readVarFile(write("nested.yaml", "OWNER:\n  name: me\n"))
+	if err == nil || !strings.Contains(err.Error(), `"OWNER"`) {
+		t.Errorf("expected error naming the nested key, got %v", err)
+	}
+
+	if _, err := readVarFile(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("expected error for a missing file")
+	}
+}
+
+func TestBuildGenerateConfig_VarFile(t *testing.T) {
+	dir := t.TempDir()
+	varFile := filepath.Join(dir, "vars.yaml")
+	if err := os.WriteFile(varFile, []byte("KEY1: from-file\nKEY2: from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("root", dir, "")
+	cmd.Flags().String("max-size", "10MB", "")
+	cmd.Flags().StringArray("var", []string{"KEY2=inline"}, "")
+	cmd.Flags().String("var-file", varFile, "")
+
+	cfg, err := buildGenerateConfig(cmd)
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if cfg.CustomVars["KEY1"] != "from-file" || cfg.CustomVars["KEY2"] != "inline" {
+		t.Errorf("expected --var to override --var-file, got %v", cfg.CustomVars)
+	}
+}
+
+func TestExpandOutputName(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "my repo")
+	now := time.Date(2024, 3, 9, 14, 5, 6, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		output   string
+		template string
+		want     string
+	}{
+		{"default", defaultOutputName, "", "shotgun-prompt-20240309-140506.md"},
+		{"root and template", "ctx-{root}-{template}.md", "makePlan", "ctx-my-repo-makePlan.md"},
+		{"no template", "{template}.md", "", "default.md"},
+		{"branch outside git", "ctx-{branch}.md", "", "ctx-.md"},
+		{"no placeholders", "out/context.md", "x", "out/context.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expandOutputName(tt.output, root, tt.template, now); got != tt.want {
+				t.Errorf("expandOutputName(%q) = %q, want %q", tt.output, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeFileNamePart(t *testing.T) {
+	if got := sanitizeFileNamePart("feature/login fix:v2"); got != "feature-login-fix-v2" {
+		t.Errorf("sanitizeFileNamePart() = %q", got)
+	}
+}
+
+func TestBuildGenerateConfig_ContentMaxSize(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("root", t.TempDir(), "")
+	cmd.Flags().String("max-size", "10MB", "")
+	cmd.Flags().String("content-max-size", "", "")
+
+	cfg, err := buildGenerateConfig(cmd)
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if cfg.ContentMaxSize != 0 {
+		t.Errorf("expected no content limit by default, got %d", cfg.ContentMaxSize)
+	}
+
+	_ = cmd.Flags().Set("content-max-size", "100KB")
+	cfg, err = buildGenerateConfig(cmd)
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if cfg.ContentMaxSize != 100*1024 {
+		t.Errorf("ContentMaxSize = %d, want %d", cfg.ContentMaxSize, 100*1024)
+	}
+
+	_ = cmd.Flags().Set("content-max-size", "lots")
+	if _, err := buildGenerateConfig(cmd); err == nil || !strings.Contains(err.Error(), "--content-max-size") {
+		t.Errorf("expected --content-max-size parse error, got %v", err)
+	}
+}
+
+func TestBuildGenerateConfig_ContextWindow(t *testing.T) {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("root", t.TempDir(), "")
+		cmd.Flags().String("max-size", "10MB", "")
+		cmd.Flags().String("context-window", "", "")
+		cmd.Flags().String("model-window", "", "")
+		return cmd
+	}
+
+	cmd := newCmd()
+	_ = cmd.Flags().Set("context-window", "128000")
+	cfg, err := buildGenerateConfig(cmd)
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if cfg.ContextWindow != 128000 || cfg.MaxSize != tokens.BytesFromTokens(128000) {
+		t.Errorf("expected --context-window to set MaxSize, got window=%d maxSize=%d", cfg.ContextWindow, cfg.MaxSize)
+	}
+
+	cmd = newCmd()
+	_ = cmd.Flags().Set("model-window", "claude-sonnet-4-20250514")
+	cfg, err = buildGenerateConfig(cmd)
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if cfg.ContextWindow != 200_000 {
+		t.Errorf("expected the model window, got %d", cfg.ContextWindow)
+	}
+
+	cmd = newCmd()
+	_ = cmd.Flags().Set("context-window", "gpt-4o")
+	cfg, err = buildGenerateConfig(cmd)
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if cfg.ContextWindow != 128_000 {
+		t.Errorf("expected --context-window to take a model name, got %d", cfg.ContextWindow)
+	}
+
+	cmd = newCmd()
+	_ = cmd.Flags().Set("context-window", "llama-3")
+	if _, err := buildGenerateConfig(cmd); err == nil || !strings.Contains(err.Error(), "unknown --context-window") {
+		t.Errorf("expected unknown model error, got %v", err)
+	}
+
+	cmd = newCmd()
+	_ = cmd.Flags().Set("model-window", "unknown-model")
+	if _, err := buildGenerateConfig(cmd); err == nil || !strings.Contains(err.Error(), "unknown --model-window") {
+		t.Errorf("expected unknown model error, got %v", err)
+	}
+
+	cmd = newCmd()
+	_ = cmd.Flags().Set("context-window", "1000")
+	_ = cmd.Flags().Set("model-window", "gpt-4o")
+	if _, err := buildGenerateConfig(cmd); err == nil || !strings.Contains(err.Error(), "cannot be combined") {
+		t.Errorf("expected combination error, got %v", err)
+	}
+}
+
+func TestGenerationSummaryJSON(t *testing.T) {
+	result := &app.GenerateResult{
+		OutputPath:    "/tmp/out.md",
+		FileCount:     12,
+		ContentSize:   2048,
+		TokenEstimate: 512,
+	}
+	summary := newGenerationSummary(result, GenerateConfig{MaxSize: 1024}, 1500*time.Millisecond)
+
+	var buf bytes.Buffer
+	if err := printGenerationSummaryJSON(&buf, summary); err != nil {
+		t.Fatalf("printGenerationSummaryJSON() error: %v", err)
+	}
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("expected a single line of JSON, got %q", buf.String())
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("summary is not valid JSON: %v", err)
+	}
+	expected := map[string]interface{}{
+		"output":           "/tmp/out.md",
+		"files_processed":  float64(12),
+		"total_bytes":      float64(2048),
+		"estimated_tokens": float64(512),
+		"max_size":         float64(1024),
+		"exceeded_limit":   true,
+		"duration_ms":      float64(1500),
+	}
+	for key, want := range expected {
+		if decoded[key] != want {
+			t.Errorf("expected %s=%v, got %v", key, want, decoded[key])
+		}
+	}
+
+	if newGenerationSummary(result, GenerateConfig{}, 0).ExceededLimit {
+		t.Error("expected no exceeded limit without a max size")
+	}
+}
+
+func TestBuildGenerateConfig_SummaryJSONWithWatch(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("root", t.TempDir(), "")
+	cmd.Flags().String("max-size", "10MB", "")
+	cmd.Flags().Bool("summary-json", true, "")
+	cmd.Flags().Bool("watch", true, "")
+
+	_, err := buildGenerateConfig(cmd)
+	if err == nil || !strings.Contains(err.Error(), "--summary-json") {
+		t.Errorf("expected --summary-json conflict error, got %v", err)
+	}
+}
+
+func TestBuildGenerateConfig_Symlinks(t *testing.T) {
+	newCmd := func(follow, allowExternal bool) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("root", t.TempDir(), "")
+		cmd.Flags().String("max-size", "10MB", "")
+		cmd.Flags().Bool("follow-symlinks", follow, "")
+		cmd.Flags().Bool("allow-external-symlinks", allowExternal, "")
+		return cmd
+	}
+
+	cfg, err := buildGenerateConfig(newCmd(true, true))
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	scanCfg := buildScannerConfig(cfg)
+	if !scanCfg.FollowSymlinks || !scanCfg.AllowExternalSymlinks {
+		t.Errorf("expected symlink flags in scanner config, got %+v", scanCfg)
+	}
+
+	_, err = buildGenerateConfig(newCmd(false, true))
+	if err == nil || !strings.Contains(err.Error(), "--follow-symlinks") {
+		t.Errorf("expected --allow-external-symlinks to require --follow-symlinks, got %v", err)
+	}
+}
+
 func TestBuildTemplateVars(t *testing.T) {
 	cfg := GenerateConfig{
 		Task:  "Analyze this code",
@@ -735,7 +1590,7 @@ func TestBuildTemplateVars(t *testing.T) {
 		},
 	}
 
-	vars := buildTemplateVars(cfg)
+	vars := buildTemplateVars(cfg, "", nil)
 
 	if vars["TASK"] != "Analyze this code" {
 		t.Errorf("expected TASK='Analyze this code', got '%s'", vars["TASK"])
@@ -751,18 +1606,50 @@ func TestBuildTemplateVars(t *testing.T) {
 	}
 }
 
+func TestBuildTemplateVars_GitVars(t *testing.T) {
+	cfg := GenerateConfig{
+		RootPath:   t.TempDir(),
+		CustomVars: map[string]string{"GIT_COMMIT": "from --var"},
+	}
+
+	vars := buildTemplateVars(cfg, "{GIT_BRANCH} {GIT_COMMIT}", map[string]string{"GIT_BRANCH": "unknown"})
+
+	if vars["GIT_COMMIT"] != "from --var" {
+		t.Errorf("expected --var to override GIT_COMMIT, got '%s'", vars["GIT_COMMIT"])
+	}
+	if vars["GIT_BRANCH"] != "unknown" {
+		t.Errorf("expected the template default outside a repository, got '%s'", vars["GIT_BRANCH"])
+	}
+	if _, ok := vars["GIT_STATUS"]; !ok {
+		t.Error("expected GIT_STATUS to be set, empty, outside a repository")
+	}
+}
+
 func TestBuildTemplateVars_DefaultTask(t *testing.T) {
 	cfg := GenerateConfig{
 		Task: "",
 	}
 
-	vars := buildTemplateVars(cfg)
+	vars := buildTemplateVars(cfg, "", nil)
 
 	if vars["TASK"] != "Context generation" {
 		t.Errorf("expected default TASK='Context generation', got '%s'", vars["TASK"])
 	}
 }
 
+func TestBuildTemplateVars_TemplateDefaults(t *testing.T) {
+	defaults := map[string]string{"TASK": "Find the bug", "RULES": "Reproduce first"}
+
+	vars := buildTemplateVars(GenerateConfig{Rules: "Be concise"}, "", defaults)
+
+	if vars["TASK"] != "Find the bug" {
+		t.Errorf("expected TASK from template default, got '%s'", vars["TASK"])
+	}
+	if vars["RULES"] != "Be concise" {
+		t.Errorf("expected user RULES to win over the default, got '%s'", vars["RULES"])
+	}
+}
+
 func TestClearProgressLine(t *testing.T) {
 	oldStderr := os.Stderr
 	r, w, _ := os.Pipe()
@@ -800,3 +1687,242 @@ func TestClearProgressLine_NoOutput(t *testing.T) {
 		t.Error("clearProgressLine(ProgressJSON) should produce no output")
 	}
 }
+
+func TestBuildGenerateConfig_MaxFiles(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set("scanner.max-files", int64(5000))
+
+	newCmd := func(maxFiles string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("root", t.TempDir(), "")
+		cmd.Flags().String("max-size", "10MB", "")
+		cmd.Flags().Int64("max-files", 0, "")
+		if maxFiles != "" {
+			if err := cmd.Flags().Set("max-files", maxFiles); err != nil {
+				t.Fatalf("failed to set --max-files: %v", err)
+			}
+		}
+		return cmd
+	}
+
+	cfg, err := buildGenerateConfig(newCmd(""))
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if got := buildScannerConfig(cfg).MaxFiles; got != 5000 {
+		t.Errorf("expected scanner MaxFiles from config (5000), got %d", got)
+	}
+
+	cfg, err = buildGenerateConfig(newCmd("20000"))
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if got := buildScannerConfig(cfg).MaxFiles; got != 20000 {
+		t.Errorf("expected scanner MaxFiles=20000, got %d", got)
+	}
+
+	_, err = buildGenerateConfig(newCmd("-1"))
+	if err == nil || !strings.Contains(err.Error(), "--max-files") {
+		t.Errorf("expected --max-files error, got %v", err)
+	}
+}
+
+func TestBuildGenerateConfig_Selection(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	selectionPath := filepath.Join(t.TempDir(), app.SelectionFileName)
+
+	newCmd := func(selection string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("root", root, "")
+		cmd.Flags().String("max-size", "10MB", "")
+		cmd.Flags().String("selection", selection, "")
+		return cmd
+	}
+
+	if err := os.WriteFile(selectionPath, []byte(`{"files": ["main.go"]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := buildGenerateConfig(newCmd(selectionPath))
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	want := map[string]bool{filepath.Join(root, "main.go"): true}
+	if !reflect.DeepEqual(cfg.Selections, want) {
+		t.Errorf("expected selections %v, got %v", want, cfg.Selections)
+	}
+
+	if err := os.WriteFile(selectionPath, []byte(`{"files": ["gone.go"]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := buildGenerateConfig(newCmd(selectionPath)); err == nil || !strings.Contains(err.Error(), "gone.go") {
+		t.Errorf("expected missing file error, got %v", err)
+	}
+
+	cfg, err = buildGenerateConfig(newCmd(""))
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if cfg.Selections != nil {
+		t.Errorf("expected no selections without --selection, got %v", cfg.Selections)
+	}
+}
+
+func TestBuildGenerateConfig_Order(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"a.go", "b.go"} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte("package main\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	selectionPath := filepath.Join(t.TempDir(), app.SelectionFileName)
+	if err := os.WriteFile(selectionPath, []byte(`{"files": ["b.go", "./a.go"]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	newCmd := func(order, selection string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("root", root, "")
+		cmd.Flags().String("max-size", "10MB", "")
+		cmd.Flags().String("selection", selection, "")
+		cmd.Flags().String("order", order, "")
+		return cmd
+	}
+
+	cfg, err := buildGenerateConfig(newCmd("", ""))
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if cfg.Order != contextgen.OrderTree || cfg.CustomOrder != nil {
+		t.Errorf("expected tree order by default, got %q %v", cfg.Order, cfg.CustomOrder)
+	}
+
+	cfg, err = buildGenerateConfig(newCmd("custom", selectionPath))
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	want := []string{"b.go", "a.go"}
+	if cfg.Order != contextgen.OrderCustom || !reflect.DeepEqual(cfg.CustomOrder, want) {
+		t.Errorf("expected custom order %v, got %q %v", want, cfg.Order, cfg.CustomOrder)
+	}
+
+	if _, err := buildGenerateConfig(newCmd("custom", "")); err == nil ||
+		!strings.Contains(err.Error(), "--order custom requires --selection") {
+		t.Errorf("expected --selection error, got %v", err)
+	}
+	if _, err := buildGenerateConfig(newCmd("random", "")); err == nil || !strings.Contains(err.Error(), "--order") {
+		t.Errorf("expected --order error, got %v", err)
+	}
+}
+
+func TestBuildGenerateConfig_Redact(t *testing.T) {
+	viper.Reset()
+	setConfigDefaults()
+	t.Cleanup(viper.Reset)
+
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		addGenerateFlags(cmd.Flags())
+		if err := cmd.ParseFlags(append([]string{"--root", t.TempDir()}, args...)); err != nil {
+			t.Fatalf("failed to parse flags: %v", err)
+		}
+		return cmd
+	}
+
+	cfg, err := buildGenerateConfig(newCmd())
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if cfg.Redact {
+		t.Error("redaction should be off by default")
+	}
+
+	// A YAML list in the config file
+	viper.Set("context.redact", true)
+	viper.Set("context.redact-patterns", []interface{}{`tenant-[0-9]+`, `acct_\w+`})
+	cfg, err = buildGenerateConfig(newCmd())
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if !cfg.Redact || len(cfg.RedactPatterns) != 2 || cfg.RedactPatterns[1] != `acct_\w+` {
+		t.Errorf("unexpected redaction config: %v %v", cfg.Redact, cfg.RedactPatterns)
+	}
+
+	// A single pattern from 'config set', which may contain spaces
+	viper.Set("context.redact-patterns", `secret value [0-9]+`)
+	cfg, err = buildGenerateConfig(newCmd())
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if len(cfg.RedactPatterns) != 1 || cfg.RedactPatterns[0] != `secret value [0-9]+` {
+		t.Errorf("unexpected redaction patterns: %q", cfg.RedactPatterns)
+	}
+
+	cfg, err = buildGenerateConfig(newCmd("--redact=false"))
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if cfg.Redact {
+		t.Error("--redact=false should override context.redact")
+	}
+
+	viper.Set("context.redact-patterns", "(")
+	_, err = buildGenerateConfig(newCmd())
+	if err == nil || !strings.Contains(err.Error(), "context.redact-patterns") {
+		t.Errorf("expected an invalid pattern error, got %v", err)
+	}
+}
+
+func TestBuildGenerateConfig_IgnoreFileOverrides(t *testing.T) {
+	viper.Reset()
+	setConfigDefaults()
+	t.Cleanup(viper.Reset)
+
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		addGenerateFlags(cmd.Flags())
+		if err := cmd.ParseFlags(append([]string{"--root", t.TempDir()}, args...)); err != nil {
+			t.Fatalf("failed to parse flags: %v", err)
+		}
+		return cmd
+	}
+
+	tests := []struct {
+		name          string
+		respectGitCfg bool
+		args          []string
+		wantGit       bool
+		wantShotgun   bool
+	}{
+		{"config", true, nil, true, true},
+		{"no gitignore", true, []string{"--no-gitignore"}, false, true},
+		{"no shotgunignore", true, []string{"--no-shotgunignore"}, true, false},
+		{"respect gitignore", false, []string{"--respect-gitignore"}, true, true},
+		{"config off", false, nil, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set("scanner.respect-gitignore", tt.respectGitCfg)
+
+			cfg, err := buildGenerateConfig(newCmd(tt.args...))
+			if err != nil {
+				t.Fatalf("buildGenerateConfig() error: %v", err)
+			}
+			scanCfg := buildScannerConfig(cfg)
+			if scanCfg.RespectGitignore != tt.wantGit {
+				t.Errorf("RespectGitignore = %v, want %v", scanCfg.RespectGitignore, tt.wantGit)
+			}
+			if scanCfg.RespectShotgunignore != tt.wantShotgun {
+				t.Errorf("RespectShotgunignore = %v, want %v", scanCfg.RespectShotgunignore, tt.wantShotgun)
+			}
+		})
+	}
+
+	_, err := buildGenerateConfig(newCmd("--no-gitignore", "--respect-gitignore"))
+	if err == nil || !strings.Contains(err.Error(), "--no-gitignore cannot be combined") {
+		t.Errorf("expected a conflict error, got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/quantmind-br/shotgun-cli/internal/app"
+	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
+	"github.com/quantmind-br/shotgun-cli/internal/core/tokens"
+	"github.com/quantmind-br/shotgun-cli/internal/platform/fswatch"
+	"github.com/quantmind-br/shotgun-cli/internal/utils"
+)
+
+// watchContext regenerates the context each time files under the root change,
+// until interrupted with Ctrl+C. Changes to paths the scan would skip, including
+// the output file itself, do not trigger a rebuild.
+func watchContext(cfg GenerateConfig) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	outputAbs, err := watchOutputPath(cfg)
+	if err != nil {
+		return err
+	}
+	cfg.Exclude = excludeOutputFile(cfg.Exclude, cfg.RootPath, outputAbs)
+
+	skip, err := watchSkipFunc(cfg, outputAbs)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(summaryOutput(), "👀 Watching %s for changes (Ctrl+C to stop)\n", cfg.RootPath)
+
+	opts := fswatch.Options{Debounce: cfg.WatchDebounce, Skip: skip}
+	err = fswatch.Watch(ctx, cfg.RootPath, opts, func(changed []string) {
+		result, genErr := runContextGeneration(cfg)
+		fmt.Fprintln(summaryOutput(), watchStatusLine(time.Now(), changed, result, genErr))
+	})
+	if err != nil {
+		return fmt.Errorf("watch failed: %w", err)
+	}
+
+	fmt.Fprintln(summaryOutput(), "Stopped watching.")
+	return nil
+}
+
+// watchOutputPath returns the absolute path of the generated file, or "" when no
+// file is written.
+func watchOutputPath(cfg GenerateConfig) (string, error) {
+	if cfg.NoOutputFile {
+		return "", nil
+	}
+
+	outputPath, err := app.ResolveOutputPath(cfg.OutputDir, cfg.Output)
+	if err != nil {
+		return "", err
+	}
+	outputAbs, err := filepath.Abs(outputPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid output path '%s': %w", outputPath, err)
+	}
+
+	return outputAbs, nil
+}
+
+// excludeOutputFile adds an anchored exclude pattern for an output file inside the
+// root, so each regeneration does not embed the previous one.
+func excludeOutputFile(exclude []string, rootPath, outputAbs string) []string {
+	if outputAbs == "" {
+		return exclude
+	}
+
+	rel, err := filepath.Rel(rootPath, outputAbs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return exclude
+	}
+
+	return append(append([]string(nil), exclude...), "/"+filepath.ToSlash(rel))
+}
+
+// watchSkipFunc builds the watcher filter from the same ignore rules the scan uses,
+// additionally skipping the output file.
+func watchSkipFunc(cfg GenerateConfig, outputAbs string) (func(relPath string, isDir bool) bool, error) {
+	scannerConfig := buildScannerConfig(cfg)
+	filter, err := scanner.NewPathFilter(cfg.RootPath, &scannerConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(relPath string, isDir bool) bool {
+		if outputAbs != "" && filepath.Join(cfg.RootPath, filepath.FromSlash(relPath)) == outputAbs {
+			return true
+		}
+		return filter.Excluded(relPath, isDir)
+	}, nil
+}
+
+// watchStatusLine summarizes one regeneration on a single line.
+func watchStatusLine(now time.Time, changed []string, result *app.GenerateResult, err error) string {
+	trigger := fmt.Sprintf("%d files changed", len(changed))
+	if len(changed) == 1 {
+		trigger = changed[0] + " changed"
+	}
+
+	stamp := now.Format("15:04:05")
+	if err != nil {
+		return fmt.Sprintf("[%s] %s, regeneration failed: %v", stamp, trigger, err)
+	}
+
+	return fmt.Sprintf("[%s] %s, regenerated: %d files, %s (~%s tokens)",
+		stamp, trigger, result.FileCount,
+		utils.FormatBytes(result.ContentSize), tokens.FormatTokens(int(result.TokenEstimate)))
+}
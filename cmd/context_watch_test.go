@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/quantmind-br/shotgun-cli/internal/app"
+	cfgkeys "github.com/quantmind-br/shotgun-cli/internal/config"
+)
+
+func TestBuildGenerateConfig_Watch(t *testing.T) {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("root", t.TempDir(), "")
+		cmd.Flags().StringSlice("include", []string{"*"}, "")
+		cmd.Flags().String("max-size", "10MB", "")
+		cmd.Flags().Bool("watch", false, "")
+		cmd.Flags().Duration("watch-debounce", 500*time.Millisecond, "")
+		return cmd
+	}
+
+	cmd := newCmd()
+	_ = cmd.Flags().Set("watch", "true")
+	_ = cmd.Flags().Set("watch-debounce", "2s")
+	cfg, err := buildGenerateConfig(cmd)
+	if err != nil {
+		t.Fatalf("buildGenerateConfig() error: %v", err)
+	}
+	if !cfg.Watch || cfg.WatchDebounce != 2*time.Second {
+		t.Errorf("expected watch with 2s debounce, got watch=%v debounce=%s", cfg.Watch, cfg.WatchDebounce)
+	}
+
+	cmd = newCmd()
+	_ = cmd.Flags().Set("watch-debounce", "-1s")
+	if _, err := buildGenerateConfig(cmd); err == nil {
+		t.Error("expected an error for a negative --watch-debounce")
+	}
+}
+
+func TestWatchSkipFunc(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set(cfgkeys.KeyScannerRespectGitignore, true)
+
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".gitignore"), []byte("*.log\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := GenerateConfig{
+		RootPath:  root,
+		Include:   []string{"*"},
+		Output:    "context.md",
+		OutputDir: filepath.Join(root, "prompts"),
+	}
+	outputAbs, err := watchOutputPath(cfg)
+	if err != nil {
+		t.Fatalf("watchOutputPath() error: %v", err)
+	}
+	skip, err := watchSkipFunc(cfg, outputAbs)
+	if err != nil {
+		t.Fatalf("watchSkipFunc() error: %v", err)
+	}
+
+	tests := []struct {
+		relPath string
+		want    bool
+	}{
+		{"main.go", false},
+		{"debug.log", true},
+		{"prompts/context.md", true},
+		{"prompts/other.md", false},
+	}
+	for _, tt := range tests {
+		if got := skip(tt.relPath, false); got != tt.want {
+			t.Errorf("skip(%q) = %v, want %v", tt.relPath, got, tt.want)
+		}
+	}
+}
+
+func TestWatchStatusLine(t *testing.T) {
+	now := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+	result := &app.GenerateResult{FileCount: 12, ContentSize: 2048, TokenEstimate: 512}
+
+	line := watchStatusLine(now, []string{"main.go"}, result, nil)
+	for _, want := range []string{"[15:04:05]", "main.go changed", "12 files", "tokens"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("status line missing %q: %s", want, line)
+		}
+	}
+
+	line = watchStatusLine(now, []string{"a.go", "b.go"}, nil, errors.New("boom"))
+	if !strings.Contains(line, "2 files changed") || !strings.Contains(line, "regeneration failed: boom") {
+		t.Errorf("unexpected failure status line: %s", line)
+	}
+	if strings.Contains(line, "\n") {
+		t.Errorf("status line should be a single line: %q", line)
+	}
+}
+
+func TestExcludeOutputFile(t *testing.T) {
+	root := filepath.FromSlash("/work/project")
+
+	got := excludeOutputFile([]string{"*.tmp"}, root, filepath.Join(root, "prompts", "ctx.md"))
+	if want := []string{"*.tmp", "/prompts/ctx.md"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("excludeOutputFile() = %v, want %v", got, want)
+	}
+
+	outside := filepath.FromSlash("/work/ctx.md")
+	if got := excludeOutputFile([]string{"*.tmp"}, root, outside); len(got) != 1 {
+		t.Errorf("output outside the root should not be excluded, got %v", got)
+	}
+	if got := excludeOutputFile(nil, root, ""); got != nil {
+		t.Errorf("no output file should leave excludes untouched, got %v", got)
+	}
+}
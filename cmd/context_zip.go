@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/quantmind-br/shotgun-cli/internal/app"
+	"github.com/quantmind-br/shotgun-cli/internal/core/contextgen"
+	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
+)
+
+// zipPromptName is the name of the generated context inside a --zip archive.
+const zipPromptName = "shotgun-prompt.md"
+
+// zipArchive describes an archive written by --zip.
+type zipArchive struct {
+	Path    string `json:"path"`
+	Entries int    `json:"entries"`
+	Bytes   int64  `json:"bytes"`
+}
+
+// writeContextZip writes an archive with the generated context at its root and the
+// selected files under their relative paths. Only the context is bound by --max-size.
+// With a redactor the files are written with their secrets redacted, as in the context.
+func writeContextZip(path string, result *app.GenerateResult, redactor *contextgen.Redactor) (*zipArchive, error) {
+	files := scanner.SelectedFiles(result.Tree, result.Selections)
+
+	out, err := os.Create(path) //nolint:gosec // user-provided archive path
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zip archive: %w", err)
+	}
+	defer func() { _ = out.Close() }()
+
+	zw := zip.NewWriter(out)
+
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: zipPromptName, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add %s to zip archive: %w", zipPromptName, err)
+	}
+	if _, err := io.WriteString(w, result.Content); err != nil {
+		return nil, fmt.Errorf("failed to add %s to zip archive: %w", zipPromptName, err)
+	}
+
+	for _, node := range files {
+		if err := addFileToZip(zw, node, redactor); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish zip archive: %w", err)
+	}
+
+	info, err := out.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat zip archive: %w", err)
+	}
+
+	return &zipArchive{Path: path, Entries: len(files) + 1, Bytes: info.Size()}, nil
+}
+
+// addFileToZip copies the file at node into the archive under its relative path;
+// the directories are recreated from that path on extraction. A non-nil redactor
+// redacts the content on the way.
+func addFileToZip(zw *zip.Writer, node *scanner.FileNode, redactor *contextgen.Redactor) error {
+	in, err := os.Open(node.Path)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to zip archive: %w", node.RelPath, err)
+	}
+	defer func() { _ = in.Close() }()
+
+	header := &zip.FileHeader{Name: filepath.ToSlash(node.RelPath), Method: zip.Deflate}
+	if info, err := in.Stat(); err == nil {
+		header.Modified = info.ModTime()
+	}
+
+	w, err := zw.CreateHeader(header)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to zip archive: %w", node.RelPath, err)
+	}
+	if redactor == nil {
+		_, err = io.Copy(w, in)
+	} else {
+		var data []byte
+		if data, err = io.ReadAll(in); err == nil {
+			redacted, _ := redactor.Redact(string(data))
+			_, err = io.WriteString(w, redacted)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add %s to zip archive: %w", node.RelPath, err)
+	}
+
+	return nil
+}
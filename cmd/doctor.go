@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/adrg/xdg"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/quantmind-br/shotgun-cli/internal/config"
+	"github.com/quantmind-br/shotgun-cli/internal/platform/clipboard"
+	"github.com/quantmind-br/shotgun-cli/internal/ui/styles"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that the environment is ready",
+	Long: `Run a health check of the whole environment and print a checklist.
+
+Checks that the config file can be read and its values are valid, the template
+directories are usable, the LLM provider is ready, a clipboard backend is
+available, and the output directory is writable. Failed checks come with next
+steps. Exits with an error if any critical check fails; LLM and clipboard
+problems are warnings, since neither is needed to generate context.
+
+Example:
+  shotgun-cli doctor`,
+	// A failed check is a report, not a usage error
+	SilenceUsage: true,
+	RunE:         runDoctor,
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	checks := runDoctorChecks()
+
+	failed, warnings := 0, 0
+	var hints []string
+	for _, check := range checks {
+		switch {
+		case check.Passed():
+			fmt.Printf("%s %s: %s\n", styles.SuccessStyle.Render("✓"), check.Name, check.Detail)
+			continue
+		case check.Optional:
+			warnings++
+			fmt.Printf("%s %s: %v\n", styles.WarningStyle.Render("!"), check.Name, check.Err)
+		default:
+			failed++
+			fmt.Printf("%s %s: %v\n", styles.ErrorStyle.Render("✗"), check.Name, check.Err)
+		}
+		if check.Hint != "" {
+			hints = append(hints, check.Hint)
+		}
+	}
+
+	if len(hints) > 0 {
+		fmt.Println("\nNext steps:")
+		for i, hint := range hints {
+			fmt.Printf("  %d. %s\n", i+1, hint)
+		}
+	}
+
+	fmt.Println()
+	if failed > 0 {
+		return fmt.Errorf("doctor found %d critical problem(s)", failed)
+	}
+
+	if warnings > 0 {
+		fmt.Printf("Environment is ready, with %d warning(s).\n", warnings)
+		return nil
+	}
+	fmt.Println("Environment is ready.")
+	return nil
+}
+
+// runDoctorChecks runs every doctor check and returns their results in order.
+func runDoctorChecks() []ValidationCheck {
+	configValues := checkConfigValues()
+	configValues.Hint = "Correct the value with 'shotgun-cli config set <key> <value>'"
+
+	templates := checkTemplate("")
+	templates.Hint = "Check the template.custom-path setting and the custom templates it points to"
+
+	provider := checkLLMProvider()
+	provider.Hint = "Choose a provider with 'shotgun-cli config set llm.provider <openai|anthropic|gemini>'"
+
+	return []ValidationCheck{
+		checkConfigFile(),
+		configValues,
+		checkTemplateDirs(),
+		templates,
+		provider,
+		checkLLMReady(),
+		checkClipboard(),
+		checkOutputDir(),
+	}
+}
+
+func checkConfigFile() ValidationCheck {
+	check := ValidationCheck{Name: "Config file"}
+
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		check.Detail = "none found, using defaults"
+		return check
+	}
+
+	if _, err := os.ReadFile(path); err != nil { //nolint:gosec // the config file viper loaded
+		check.Err = fmt.Errorf("cannot read %s: %w", path, err)
+		check.Hint = fmt.Sprintf("Fix the permissions of %s, or remove it to use the defaults", path)
+		return check
+	}
+
+	check.Detail = path
+	return check
+}
+
+func checkTemplateDirs() ValidationCheck {
+	check := ValidationCheck{
+		Name: "Template directories",
+		Hint: "Make the template directories readable directories, or change template.custom-path",
+	}
+
+	dirs := []string{filepath.Join(xdg.ConfigHome, "shotgun-cli", "templates")}
+	if customPath := viper.GetString(config.KeyTemplateCustomPath); customPath != "" {
+		if strings.HasPrefix(customPath, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				customPath = filepath.Join(home, customPath[2:])
+			}
+		}
+		dirs = append(dirs, customPath)
+	}
+
+	for _, dir := range dirs {
+		info, err := os.Stat(dir)
+		switch {
+		case os.IsNotExist(err):
+			// The template manager creates missing directories on first use
+			continue
+		case err != nil:
+			check.Err = fmt.Errorf("cannot access %s: %w", dir, err)
+			return check
+		case !info.IsDir():
+			check.Err = fmt.Errorf("%s is not a directory", dir)
+			return check
+		}
+		if _, err := os.ReadDir(dir); err != nil {
+			check.Err = fmt.Errorf("cannot read %s: %w", dir, err)
+			return check
+		}
+	}
+
+	check.Detail = strings.Join(dirs, ", ")
+	return check
+}
+
+func checkLLMReady() ValidationCheck {
+	check := ValidationCheck{
+		Name:     "LLM provider setup",
+		Optional: true,
+		Hint:     "Run 'shotgun-cli llm doctor' for provider-specific setup steps",
+	}
+
+	cfg := BuildLLMConfig()
+	provider, err := CreateLLMProvider(cfg)
+	if err != nil {
+		check.Err = err
+		return check
+	}
+	if err := provider.ValidateConfig(); err != nil {
+		check.Err = err
+		return check
+	}
+	if !provider.IsConfigured() {
+		check.Err = fmt.Errorf("%s is not fully configured", provider.Name())
+		return check
+	}
+
+	check.Detail = fmt.Sprintf("%s (%s)", provider.Name(), cfg.Model)
+	return check
+}
+
+func checkClipboard() ValidationCheck {
+	check := ValidationCheck{Name: "Clipboard", Optional: true}
+
+	// Probing with a real copy would overwrite the user's clipboard
+	if !clipboard.IsAvailable() {
+		check.Err = fmt.Errorf("no clipboard backend found")
+		check.Hint = "Install xclip, xsel or wl-clipboard to copy contexts to the clipboard"
+		return check
+	}
+
+	check.Detail = "available"
+	return check
+}
+
+func checkOutputDir() ValidationCheck {
+	check := ValidationCheck{
+		Name: "Output directory",
+		Hint: "Set a writable directory with 'shotgun-cli config set output.dir <dir>'",
+	}
+
+	dir := viper.GetString(config.KeyOutputDir)
+	if dir == "" {
+		dir = "."
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		check.Err = fmt.Errorf("cannot create %s: %w", dir, err)
+		return check
+	}
+
+	probe, err := os.CreateTemp(dir, ".shotgun-doctor-*")
+	if err != nil {
+		check.Err = fmt.Errorf("cannot write to %s: %w", dir, err)
+		return check
+	}
+	_ = probe.Close()
+	_ = os.Remove(probe.Name())
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		absDir = dir
+	}
+	check.Detail = absDir + " is writable"
+	return check
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quantmind-br/shotgun-cli/internal/config"
+)
+
+func TestRunDoctorChecks_CriticalChecksPass(t *testing.T) {
+	viper.Reset()
+	viper.Set(config.KeyLLMProvider, "anthropic")
+	viper.Set(config.KeyOutputDir, t.TempDir())
+
+	checks := runDoctorChecks()
+
+	for _, check := range checks {
+		if !check.Optional {
+			assert.True(t, check.Passed(), "%s: %v", check.Name, check.Err)
+		}
+	}
+	assert.True(t, findCheck(t, checks, "LLM provider setup").Optional)
+	assert.True(t, findCheck(t, checks, "Clipboard").Optional)
+}
+
+func TestRunDoctorChecks_OutputDirNotWritable(t *testing.T) {
+	viper.Reset()
+	viper.Set(config.KeyLLMProvider, "openai")
+	notADir := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(notADir, []byte("x"), 0o600))
+	viper.Set(config.KeyOutputDir, notADir)
+
+	check := findCheck(t, runDoctorChecks(), "Output directory")
+	require.Error(t, check.Err)
+	assert.False(t, check.Optional)
+	assert.Contains(t, check.Hint, "output.dir")
+}
+
+func TestRunDoctorChecks_TemplatePathIsFile(t *testing.T) {
+	viper.Reset()
+	viper.Set(config.KeyLLMProvider, "openai")
+	viper.Set(config.KeyOutputDir, t.TempDir())
+	file := filepath.Join(t.TempDir(), "templates")
+	require.NoError(t, os.WriteFile(file, []byte("x"), 0o600))
+	viper.Set(config.KeyTemplateCustomPath, file)
+
+	check := findCheck(t, runDoctorChecks(), "Template directories")
+	require.Error(t, check.Err)
+	assert.Contains(t, check.Err.Error(), "not a directory")
+}
+
+func TestRunDoctor_ReturnsErrorOnCriticalFailure(t *testing.T) {
+	viper.Reset()
+	viper.Set(config.KeyLLMProvider, "not-a-provider")
+	viper.Set(config.KeyOutputDir, t.TempDir())
+
+	err := runDoctor(doctorCmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "critical problem")
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/quantmind-br/shotgun-cli/internal/app"
+)
+
+// Exit codes returned by the CLI, so scripts can react to each failure mode.
+const (
+	ExitSuccess         = 0
+	ExitFailure         = 1 // Any failure without a more specific code
+	ExitUsage           = 2 // Invalid flags, arguments or configuration
+	ExitSizeLimit       = 3 // Context exceeded the enforced size limit
+	ExitScanFailed      = 4 // Scanning the root failed
+	ExitGenerationError = 5 // Rendering the context failed
+	ExitLLMError        = 6 // The LLM provider could not be used or the request failed
+)
+
+// exitCodeError attaches an exit code to err.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
+// withExitCode marks err to end the process with code. A nil err stays nil.
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &exitCodeError{code: code, err: err}
+}
+
+// usageError marks err as a usage error; it is also cobra's flag error handler.
+func usageError(_ *cobra.Command, err error) error {
+	return withExitCode(ExitUsage, err)
+}
+
+// ExitCode returns the process exit code for an error returned by Execute.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitSuccess
+	}
+
+	var coded *exitCodeError
+	switch {
+	case errors.As(err, &coded):
+		return coded.code
+	case errors.Is(err, app.ErrSizeLimit):
+		return ExitSizeLimit
+	case errors.Is(err, app.ErrScanFailed):
+		return ExitScanFailed
+	case errors.Is(err, app.ErrGenerationFailed):
+		return ExitGenerationError
+	default:
+		return ExitFailure
+	}
+}
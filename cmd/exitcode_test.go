@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/quantmind-br/shotgun-cli/internal/app"
+	"github.com/quantmind-br/shotgun-cli/internal/core/contextgen"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitSuccess},
+		{"generic", errors.New("boom"), ExitFailure},
+		{"usage", withExitCode(ExitUsage, errors.New("bad flag")), ExitUsage},
+		{"size limit", fmt.Errorf("%w: too big", app.ErrSizeLimit), ExitSizeLimit},
+		{"size limit during generation",
+			fmt.Errorf("%w: %w", app.ErrGenerationFailed, contextgen.ErrSizeLimitExceeded), ExitSizeLimit},
+		{"scan", fmt.Errorf("%w: denied", app.ErrScanFailed), ExitScanFailed},
+		{"generation", fmt.Errorf("%w: bad template", app.ErrGenerationFailed), ExitGenerationError},
+		{"llm", withExitCode(ExitLLMError, errors.New("request failed")), ExitLLMError},
+		{"wrapped", fmt.Errorf("command execution failed: %w",
+			fmt.Errorf("context generation failed: %w", app.ErrScanFailed)), ExitScanFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExitCode(tt.err))
+		})
+	}
+}
+
+func TestWithExitCode(t *testing.T) {
+	assert.NoError(t, withExitCode(ExitUsage, nil))
+
+	inner := errors.New("bad flag")
+	err := withExitCode(ExitUsage, inner)
+	assert.EqualError(t, err, "bad flag")
+	assert.ErrorIs(t, err, inner)
+}
+
+func TestUsageErrorForFlags(t *testing.T) {
+	cmd := &cobra.Command{RunE: func(*cobra.Command, []string) error { return nil }}
+	cmd.SetFlagErrorFunc(usageError)
+	cmd.SetArgs([]string{"--bogus"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	assert.Equal(t, ExitUsage, ExitCode(cmd.Execute()))
+}
+
+func TestValidateGenerateFlags(t *testing.T) {
+	newCmd := func(root string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("recent", false, "")
+		cmd.Flags().String("root", root, "")
+		cmd.Flags().String("max-size", "10MB", "")
+		return cmd
+	}
+
+	assert.NoError(t, validateGenerateFlags(newCmd(t.TempDir())))
+	assert.ErrorContains(t, validateGenerateFlags(newCmd("")), "root path cannot be empty")
+	assert.ErrorContains(t, validateGenerateFlags(newCmd(t.TempDir()+"/missing")), "does not exist")
+}
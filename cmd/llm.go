@@ -1,15 +1,23 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/quantmind-br/shotgun-cli/internal/config"
 	"github.com/quantmind-br/shotgun-cli/internal/core/llm"
+	"github.com/quantmind-br/shotgun-cli/internal/core/tokens"
+	platformhttp "github.com/quantmind-br/shotgun-cli/internal/platform/http"
 	"github.com/quantmind-br/shotgun-cli/internal/ui/styles"
 )
 
@@ -50,13 +58,158 @@ var llmListCmd = &cobra.Command{
 	Short: "List supported providers",
 	Long: `List all supported LLM providers and their status.
 
-Shows which providers are available and how to configure them.
+Shows which providers are available and how to configure them. With --windows it
+also prints the context window and recommended tokenizer of each known model
+family, the models --context-window accepts by name.
 
-Example:
-  shotgun-cli llm list`,
+Examples:
+  shotgun-cli llm list
+  shotgun-cli llm list --windows`,
 	RunE: runLLMList,
 }
 
+var llmModelsCmd = &cobra.Command{
+	Use:   "models",
+	Short: "List the model IDs served by the configured endpoint",
+	Long: `Query the configured endpoint for the models it serves and print their IDs, one
+per line, to pick a valid --model or llm.model.
+
+Supported for the OpenAI-compatible provider (OpenAI, OpenRouter, LiteLLM, local
+servers; GET <base-url>/models) and the Gemini API. Unlike 'llm list', which shows
+provider families, the IDs come live from the endpoint.
+
+Examples:
+  shotgun-cli llm models
+  shotgun-cli llm models --base-url http://localhost:4000/v1`,
+	Args: cobra.NoArgs,
+	RunE: runLLMModels,
+}
+
+func runLLMModels(cmd *cobra.Command, args []string) error {
+	cfg := BuildLLMConfig()
+	if baseURL, _ := cmd.Flags().GetString("base-url"); baseURL != "" {
+		if err := config.ValidateValue(config.KeyLLMBaseURL, baseURL); err != nil {
+			return withExitCode(ExitUsage, fmt.Errorf("invalid --base-url: %w", err))
+		}
+		cfg.BaseURL = baseURL
+	}
+
+	provider, err := CreateLLMProvider(cfg)
+	if err != nil {
+		return withExitCode(ExitLLMError, err)
+	}
+	lister, ok := provider.(llm.ModelLister)
+	if !ok {
+		return withExitCode(ExitLLMError,
+			fmt.Errorf("model listing unsupported for the %s provider", provider.Name()))
+	}
+
+	ctx := context.Background()
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(cfg.Timeout)*time.Second)
+		defer cancel()
+	}
+
+	models, err := lister.ListModels(ctx)
+	if errors.Is(err, llm.ErrModelListingUnsupported) {
+		return withExitCode(ExitLLMError, fmt.Errorf("%w at %s; set the model with --model or llm.model", err,
+			displayURL(cfg.BaseURL, cfg.Provider)))
+	}
+	if err != nil {
+		return withExitCode(ExitLLMError, fmt.Errorf("failed to list models: %w", err))
+	}
+
+	for _, model := range models {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), model)
+	}
+	if len(models) == 0 {
+		_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "The endpoint listed no models")
+	}
+
+	return nil
+}
+
+var llmSendCmd = &cobra.Command{
+	Use:   "send",
+	Short: "Send a prompt to the configured provider",
+	Long: `Send a prompt to the configured LLM provider and print the response.
+
+The prompt is taken from --prompt, --prompt-file, or stdin, in that order.
+Use this to try any configured provider without generating a context first.
+With --redact, or context.redact in the config, secrets in the prompt are replaced
+with [REDACTED] before it is sent.
+
+--providers sends the same prompt to several providers at once, each entry a provider
+name optionally followed by :model. The configured llm.provider uses the llm.* settings;
+any other provider reads its API key and endpoint from OPENAI_API_KEY/OPENAI_BASE_URL,
+ANTHROPIC_API_KEY/ANTHROPIC_BASE_URL or GEMINI_API_KEY/GEMINI_BASE_URL. Each response is
+saved as response-<provider>.md, and a table compares duration and token usage. One
+provider failing does not stop the others.
+
+Examples:
+  shotgun-cli llm send --prompt "Reply with OK"
+  shotgun-cli llm send --prompt-file question.md -o answer.md
+  git diff | shotgun-cli llm send --model gpt-4o --timeout 60
+  shotgun-cli llm send --prompt-file question.md --providers openai,anthropic,gemini
+  shotgun-cli llm send --prompt-file shotgun-prompt.md --redact`,
+	Args: cobra.NoArgs,
+	RunE: runLLMSend,
+}
+
+func runLLMSend(cmd *cobra.Command, args []string) error {
+	prompt, err := readLLMPrompt(cmd, os.Stdin)
+	if err != nil {
+		return err
+	}
+	// Before sending to one provider or fanning out to --providers
+	prompt, err = redactSendContent(cmd, prompt)
+	if err != nil {
+		return err
+	}
+
+	opts, err := sendOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	return sendContent(prompt, opts)
+}
+
+// readLLMPrompt returns the prompt from --prompt, --prompt-file or stdin.
+func readLLMPrompt(cmd *cobra.Command, stdin *os.File) (string, error) {
+	prompt, _ := cmd.Flags().GetString("prompt")
+	promptFile, _ := cmd.Flags().GetString("prompt-file")
+	if prompt != "" && promptFile != "" {
+		return "", fmt.Errorf("--prompt cannot be combined with --prompt-file")
+	}
+
+	switch {
+	case promptFile != "":
+		data, err := os.ReadFile(promptFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read prompt file '%s': %w", promptFile, err)
+		}
+		prompt = string(data)
+	case prompt == "":
+		stat, err := stdin.Stat()
+		if err != nil || stat.Mode()&os.ModeCharDevice != 0 {
+			return "", fmt.Errorf("no prompt provided. Use --prompt, --prompt-file or pipe it via stdin")
+		}
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read stdin: %w", err)
+		}
+		prompt = string(data)
+	}
+
+	if strings.TrimSpace(prompt) == "" {
+		return "", fmt.Errorf("no prompt to send (prompt is empty)")
+	}
+
+	return prompt, nil
+}
+
 func runLLMStatus(cmd *cobra.Command, args []string) error {
 	cfg := BuildLLMConfig()
 
@@ -142,7 +295,10 @@ func runLLMDoctor(cmd *cobra.Command, args []string) error {
 		issues = append(issues, "Model not configured")
 	}
 
-	// Check 4: Provider-specific
+	// Check 4: Network settings
+	issues = append(issues, checkLLMNetwork(cfg)...)
+
+	// Check 5: Provider-specific
 	provider, err := CreateLLMProvider(cfg)
 	if err == nil {
 		fmt.Print("Checking provider availability... ")
@@ -194,6 +350,44 @@ func runLLMDoctor(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// checkLLMNetwork prints the proxy and TLS settings in effect for cfg and returns
+// any issues with them.
+func checkLLMNetwork(cfg llm.Config) []string {
+	transportCfg := platformhttp.TransportConfig{
+		Proxy:              cfg.Proxy,
+		CACert:             cfg.CACert,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	fmt.Print("Checking network settings... ")
+	if transportCfg.IsDefault() {
+		fmt.Println("default")
+		return nil
+	}
+	fmt.Println()
+
+	if cfg.Proxy != "" {
+		proxy := cfg.Proxy
+		if proxyURL, err := url.Parse(cfg.Proxy); err == nil {
+			proxy = proxyURL.Redacted()
+		}
+		fmt.Printf("  Proxy: %s\n", proxy)
+	}
+	if cfg.CACert != "" {
+		fmt.Printf("  Extra CA certificates: %s\n", cfg.CACert)
+	}
+	if cfg.InsecureSkipVerify {
+		warning := "TLS certificate verification is DISABLED (llm.insecure-skip-verify)"
+		fmt.Println("  " + styles.WarningStyle.Render(warning))
+	}
+
+	if _, err := platformhttp.NewTransport(transportCfg); err != nil {
+		return []string{fmt.Sprintf("Invalid network settings: %v", err)}
+	}
+
+	return nil
+}
+
 func runLLMList(cmd *cobra.Command, args []string) error {
 	fmt.Println("Supported LLM Providers:")
 	fmt.Println()
@@ -226,10 +420,31 @@ func runLLMList(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 	fmt.Println("For custom endpoints (OpenRouter, Azure, etc.):")
 	fmt.Println("  shotgun-cli config set llm.base-url https://openrouter.ai/api/v1")
+	fmt.Println()
+	fmt.Println("List the models an endpoint serves with:")
+	fmt.Println("  shotgun-cli llm models")
+
+	if windows, _ := cmd.Flags().GetBool("windows"); windows {
+		fmt.Println()
+		printModelWindows(os.Stdout)
+	}
 
 	return nil
 }
 
+// printModelWindows lists the context window and tokenizer of each known model family.
+func printModelWindows(out io.Writer) {
+	_, _ = fmt.Fprintln(out, "Context windows by model family:")
+	_, _ = fmt.Fprintln(out)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "  Model prefix\tWindow\tTokenizer")
+	for _, info := range tokens.KnownModels() {
+		_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\n", info.Prefix, tokens.FormatTokens(info.Window), info.Tokenizer)
+	}
+	_ = w.Flush()
+}
+
 func displayURL(url string, provider llm.ProviderType) string {
 	if url == "" {
 		defaults := llm.DefaultConfigs()
@@ -242,8 +457,29 @@ func displayURL(url string, provider llm.ProviderType) string {
 }
 
 func init() {
+	llmSendCmd.Flags().StringP("prompt", "p", "", "Prompt text to send")
+	llmSendCmd.Flags().String("prompt-file", "", "File containing the prompt to send")
+	llmSendCmd.Flags().StringP("output", "o", "", "Output file for the response (default: print it)")
+	llmSendCmd.Flags().String("output-dir", "", "Directory for response files (default: output.dir config)")
+	llmSendCmd.Flags().StringP("model", "m", "", "Model to use (default: llm.model config)")
+	llmSendCmd.Flags().String("base-url", "", "API endpoint to send to, e.g. a proxy (default: llm.base-url config)")
+	llmSendCmd.Flags().Int("timeout", 0, "Timeout in seconds (default: llm.timeout config)")
+	llmSendCmd.Flags().Bool("raw", false, "Output the raw provider response")
+	llmSendCmd.Flags().Bool("redact", false,
+		"Replace secrets with [REDACTED] before sending (default: context.redact config)")
+	llmSendCmd.Flags().Bool("extract-code", false,
+		"Output only the code of fenced blocks in the response (several blocks go to numbered files)")
+	llmSendCmd.Flags().StringSlice("providers", nil,
+		"Send to several providers at once, e.g. openai,anthropic:claude-opus-4-1, saving response-<provider>.md")
+	llmSendCmd.Flags().Bool("overwrite-response", false,
+		"Save the response as llm-response.md instead of a timestamped name (default: llm.overwrite-response config)")
+
+	llmCmd.AddCommand(llmSendCmd)
 	llmCmd.AddCommand(llmStatusCmd)
 	llmCmd.AddCommand(llmDoctorCmd)
+	llmListCmd.Flags().Bool("windows", false, "Also print the context window and tokenizer of known model families")
 	llmCmd.AddCommand(llmListCmd)
+	llmModelsCmd.Flags().String("base-url", "", "Endpoint to query (default: llm.base-url config)")
+	llmCmd.AddCommand(llmModelsCmd)
 	rootCmd.AddCommand(llmCmd)
 }
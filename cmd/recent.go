@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	recentRootsFileName = "recent-roots.json"
+	// maxRecentRoots caps the recent roots list; the least recently used entries drop off.
+	maxRecentRoots = 10
+)
+
+// recentRoot is a directory that context generate or the wizard last ran against.
+type recentRoot struct {
+	Path     string    `json:"path"`
+	LastUsed time.Time `json:"last_used"`
+}
+
+// loadRecentRoots returns the recorded roots, most recently used first. A missing
+// file yields an empty list.
+func loadRecentRoots(configDir string) ([]recentRoot, error) {
+	data, err := os.ReadFile(filepath.Join(configDir, recentRootsFileName)) //nolint:gosec // path under config dir
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read recent roots: %w", err)
+	}
+
+	var roots []recentRoot
+	if err := json.Unmarshal(data, &roots); err != nil {
+		return nil, fmt.Errorf("failed to parse recent roots: %w", err)
+	}
+
+	return roots, nil
+}
+
+// recordRecentRoot moves root to the front of the recent roots list, dropping any
+// older entry for the same directory and anything beyond maxRecentRoots.
+func recordRecentRoot(configDir, root string, now time.Time) error {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return fmt.Errorf("invalid root path: %w", err)
+	}
+
+	roots, err := loadRecentRoots(configDir)
+	if err != nil {
+		return err
+	}
+
+	updated := []recentRoot{{Path: absRoot, LastUsed: now}}
+	for _, r := range roots {
+		if r.Path != absRoot && len(updated) < maxRecentRoots {
+			updated = append(updated, r)
+		}
+	}
+
+	data, err := json.MarshalIndent(updated, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recent roots: %w", err)
+	}
+
+	if err := os.MkdirAll(configDir, 0o750); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, recentRootsFileName), append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write recent roots: %w", err)
+	}
+
+	return nil
+}
+
+// rememberRoot records root as recently used. Failures are only logged, since the
+// list is a convenience and must never fail a run.
+func rememberRoot(root string) {
+	if err := recordRecentRoot(getConfigDir(), root, time.Now()); err != nil {
+		log.Debug().Err(err).Str("root", root).Msg("Could not record recent root")
+	}
+}
+
+// existingRecentRoots filters out roots whose directory no longer exists.
+func existingRecentRoots(roots []recentRoot) []recentRoot {
+	var existing []recentRoot
+	for _, r := range roots {
+		if info, err := os.Stat(r.Path); err == nil && info.IsDir() {
+			existing = append(existing, r)
+		}
+	}
+
+	return existing
+}
+
+// selectRecentRoot lists the recent roots that still exist and asks for one by number.
+func selectRecentRoot() (string, error) {
+	roots, err := loadRecentRoots(getConfigDir())
+	if err != nil {
+		return "", err
+	}
+
+	return pickRecentRoot(os.Stdin, os.Stdout, existingRecentRoots(roots))
+}
+
+// pickRecentRoot prints roots as a numbered list and reads the chosen number from in.
+func pickRecentRoot(in io.Reader, out io.Writer, roots []recentRoot) (string, error) {
+	if len(roots) == 0 {
+		return "", fmt.Errorf("no recent roots yet; run 'shotgun-cli context generate --root <dir>' first")
+	}
+
+	_, _ = fmt.Fprintln(out, "Recent roots:")
+	for i, r := range roots {
+		_, _ = fmt.Fprintf(out, "  %2d) %s  (%s)\n", i+1, r.Path, r.LastUsed.Local().Format("2006-01-02 15:04"))
+	}
+	_, _ = fmt.Fprintf(out, "Select a root [1-%d]: ", len(roots))
+
+	response, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read user input: %w", err)
+	}
+
+	response = strings.TrimSpace(response)
+	choice, err := strconv.Atoi(response)
+	if err != nil || choice < 1 || choice > len(roots) {
+		return "", fmt.Errorf("invalid selection %q (enter a number from 1 to %d)", response, len(roots))
+	}
+
+	return roots[choice-1].Path, nil
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordRecentRoot(t *testing.T) {
+	configDir := t.TempDir()
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	roots, err := loadRecentRoots(configDir)
+	require.NoError(t, err)
+	assert.Empty(t, roots)
+
+	require.NoError(t, recordRecentRoot(configDir, "/work/a", base))
+	require.NoError(t, recordRecentRoot(configDir, "/work/b", base.Add(time.Minute)))
+	require.NoError(t, recordRecentRoot(configDir, "/work/a", base.Add(2*time.Minute)))
+
+	roots, err = loadRecentRoots(configDir)
+	require.NoError(t, err)
+	require.Len(t, roots, 2, "re-recording a root should not duplicate it")
+	assert.Equal(t, "/work/a", roots[0].Path)
+	assert.True(t, roots[0].LastUsed.Equal(base.Add(2*time.Minute)))
+	assert.Equal(t, "/work/b", roots[1].Path)
+
+	for i := 0; i < maxRecentRoots+5; i++ {
+		require.NoError(t, recordRecentRoot(configDir, fmt.Sprintf("/work/p%d", i), base.Add(time.Hour)))
+	}
+	roots, err = loadRecentRoots(configDir)
+	require.NoError(t, err)
+	assert.Len(t, roots, maxRecentRoots)
+	assert.Equal(t, fmt.Sprintf("/work/p%d", maxRecentRoots+4), roots[0].Path)
+}
+
+func TestLoadRecentRoots_Invalid(t *testing.T) {
+	configDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, recentRootsFileName), []byte("{"), 0o600))
+
+	_, err := loadRecentRoots(configDir)
+	assert.ErrorContains(t, err, "failed to parse recent roots")
+}
+
+func TestExistingRecentRoots(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	require.NoError(t, os.WriteFile(file, []byte("x"), 0o600))
+
+	roots := []recentRoot{{Path: dir}, {Path: filepath.Join(dir, "gone")}, {Path: file}}
+	assert.Equal(t, []recentRoot{{Path: dir}}, existingRecentRoots(roots))
+}
+
+func TestPickRecentRoot(t *testing.T) {
+	roots := []recentRoot{{Path: "/work/a"}, {Path: "/work/b"}}
+
+	var out bytes.Buffer
+	got, err := pickRecentRoot(strings.NewReader("2\n"), &out, roots)
+	require.NoError(t, err)
+	assert.Equal(t, "/work/b", got)
+	assert.Contains(t, out.String(), " 1) /work/a")
+	assert.Contains(t, out.String(), "Select a root [1-2]: ")
+
+	for _, input := range []string{"", "0", "3", "b\n"} {
+		_, err := pickRecentRoot(strings.NewReader(input), &out, roots)
+		assert.ErrorContains(t, err, "invalid selection", "input %q", input)
+	}
+
+	_, err = pickRecentRoot(strings.NewReader("1\n"), &out, nil)
+	assert.ErrorContains(t, err, "no recent roots")
+}
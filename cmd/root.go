@@ -15,8 +15,10 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/quantmind-br/shotgun-cli/internal/config"
+	"github.com/quantmind-br/shotgun-cli/internal/core/contextgen"
 	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
 	"github.com/quantmind-br/shotgun-cli/internal/ui"
+	"github.com/quantmind-br/shotgun-cli/internal/ui/styles"
 	"github.com/quantmind-br/shotgun-cli/internal/utils"
 )
 
@@ -32,7 +34,7 @@ var rootCmd = &cobra.Command{
 	Long: `shotgun-cli is a cross-platform CLI tool that generates LLM-optimized
 codebase contexts with both TUI wizard and headless CLI modes.
 
-When called without arguments, it launches an interactive 5-step wizard.
+When called without arguments, it launches an interactive 6-step wizard.
 When called with arguments, it runs in headless CLI mode.`,
 	Version: version,
 	Run:     runRootCommand,
@@ -48,7 +50,19 @@ func runRootCommand(cmd *cobra.Command, args []string) {
 	// If no subcommands and no flags (except global ones), launch TUI wizard
 	if len(args) == 0 && len(os.Args) == 1 {
 		log.Info().Msg("Launching TUI wizard...")
-		launchTUIWizard()
+		launchTUIWizard("")
+		return
+	}
+
+	// Launch the wizard in a root picked from the recent roots list
+	if recent, _ := cmd.Flags().GetBool("recent"); recent && len(args) == 0 {
+		rootPath, err := selectRecentRoot()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		log.Info().Str("root", rootPath).Msg("Launching TUI wizard...")
+		launchTUIWizard(rootPath)
 		return
 	}
 
@@ -58,12 +72,29 @@ func runRootCommand(cmd *cobra.Command, args []string) {
 	}
 }
 
-func launchTUIWizard() {
-	// Detect current working directory as scan root
-	rootPath, err := os.Getwd()
+// launchTUIWizard runs the wizard against rootPath, or the current working directory
+// when rootPath is empty.
+func launchTUIWizard(rootPath string) {
+	if rootPath == "" {
+		// Detect current working directory as scan root
+		cwd, err := os.Getwd()
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to get current working directory")
+			fmt.Fprintf(os.Stderr, "Error: Could not determine current directory: %v\n", err)
+			os.Exit(1)
+		}
+		rootPath = cwd
+	}
+	rememberRoot(rootPath)
+
+	fileDelimiters, err := fileDelimitersFromConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	redactPatterns, err := redactPatternsFromConfig()
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to get current working directory")
-		fmt.Fprintf(os.Stderr, "Error: Could not determine current directory: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
@@ -73,10 +104,14 @@ func launchTUIWizard() {
 		MaxMemory:            utils.ParseSizeWithDefault(viper.GetString(config.KeyScannerMaxMemory), 500*1024*1024),
 		SkipBinary:           viper.GetBool(config.KeyScannerSkipBinary),
 		IncludeHidden:        viper.GetBool(config.KeyScannerIncludeHidden),
+		IncludeHiddenDirs:    viper.GetBool(config.KeyScannerIncludeHiddenDirs),
+		IncludeHiddenFiles:   viper.GetBool(config.KeyScannerIncludeHiddenFiles),
 		IncludeIgnored:       true,
 		Workers:              viper.GetInt(config.KeyScannerWorkers),
 		RespectGitignore:     viper.GetBool(config.KeyScannerRespectGitignore),
 		RespectShotgunignore: viper.GetBool(config.KeyScannerRespectShotgunignore),
+		RespectGitattributes: viper.GetBool(config.KeyScannerRespectGitattributes),
+		AllowlistMode:        viper.GetBool(config.KeyScannerAllowlistMode),
 	}
 
 	wizardConfig := &ui.WizardConfig{
@@ -87,14 +122,32 @@ func launchTUIWizard() {
 			Model:        viper.GetString(config.KeyLLMModel),
 			Timeout:      viper.GetInt(config.KeyLLMTimeout),
 			SaveResponse: viper.GetBool(config.KeyLLMSaveResponse),
+			SendPreview:  viper.GetBool(config.KeyLLMSendPreview),
+			// Stable response names are opt-in; by default each send gets its own file
+			OverwriteResponse: viper.GetBool(config.KeyLLMOverwriteResponse),
+			// Network settings for the cloud providers
+			Proxy:              viper.GetString(config.KeyLLMProxy),
+			CACert:             viper.GetString(config.KeyLLMCACert),
+			InsecureSkipVerify: viper.GetBool(config.KeyLLMInsecureSkipVerify),
 		},
 		Context: ui.ContextConfig{
 			IncludeTree:    viper.GetBool(config.KeyContextIncludeTree),
 			IncludeSummary: viper.GetBool(config.KeyContextIncludeSummary),
 			MaxSize:        viper.GetString(config.KeyContextMaxSize),
+			StripComments:  viper.GetBool(config.KeyContextStripComments),
+			FoldSiblings:   viper.GetBool(config.KeyContextFoldSiblings),
+			FileDelimiters: fileDelimiters,
+			Redact:         viper.GetBool(config.KeyContextRedact),
+			RedactPatterns: redactPatterns,
+		},
+		Output: ui.OutputConfig{
+			Dir:             viper.GetString(config.KeyOutputDir),
+			ClipboardMode:   viper.GetString(config.KeyOutputClipboardMode),
+			ClipboardVerify: viper.GetBool(config.KeyOutputClipboardVerify),
 		},
 	}
 
+	applyTheme()
 	wizard := ui.NewWizard(rootPath, scanConfig, wizardConfig, nil)
 
 	// Configure Bubble Tea program
@@ -123,15 +176,27 @@ func Execute() error {
 
 func init() {
 	cobra.OnInitialize(initConfig)
+	rootCmd.SetFlagErrorFunc(usageError)
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if _, err := logLevel(); err != nil {
+			return withExitCode(ExitUsage, err)
+		}
+		return nil
+	}
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(
 		&cfgFile, "config", "", "config file (default is ~/.config/shotgun-cli/config.yaml)")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
-	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "quiet output")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false,
+		"quiet output: no summary on stdout and only errors logged (--summary-json still prints its JSON)")
+	rootCmd.PersistentFlags().String("log-level", "",
+		"log level: debug, info, warn, error (default info; overrides --verbose and --quiet for logging)")
+	rootCmd.PersistentFlags().Bool("no-color", false, "disable colors and emoji in output (also set by NO_COLOR)")
 
 	// Local flags
 	rootCmd.Flags().BoolP("version", "", false, "show version information")
+	rootCmd.Flags().Bool("recent", false, "launch the wizard in a root chosen from the recently used roots")
 
 	// Hide completion command from help
 	rootCmd.CompletionOptions.HiddenDefaultCmd = true
@@ -143,11 +208,16 @@ func initConfig() {
 	log.Logger = log.Output(zerolog.ConsoleWriter{
 		Out:        os.Stderr,
 		TimeFormat: "15:04:05",
+		NoColor:    colorDisabled(),
 	})
+	applyColorMode()
 
 	if cfgFile != "" {
 		// Use config file from the flag
 		viper.SetConfigFile(cfgFile)
+	} else if profileFile := activeProfileConfigFile(getConfigDir()); profileFile != "" {
+		// An active profile replaces the base config file
+		viper.SetConfigFile(profileFile)
 	} else {
 		// Search for config in multiple locations
 		home, err := os.UserHomeDir()
@@ -179,6 +249,9 @@ func initConfig() {
 	if rootCmd.PersistentFlags().Lookup("quiet") != nil {
 		_ = viper.BindPFlag(config.KeyQuiet, rootCmd.PersistentFlags().Lookup("quiet"))
 	}
+	if rootCmd.PersistentFlags().Lookup("log-level") != nil {
+		_ = viper.BindPFlag(config.KeyLogLevel, rootCmd.PersistentFlags().Lookup("log-level"))
+	}
 
 	// Read config file
 	if err := viper.ReadInConfig(); err != nil {
@@ -225,18 +298,30 @@ func setConfigDefaults() {
 	viper.SetDefault(config.KeyScannerSkipBinary, true)
 	viper.SetDefault(config.KeyScannerWorkers, 1)
 	viper.SetDefault(config.KeyScannerIncludeHidden, false)
+	viper.SetDefault(config.KeyScannerIncludeHiddenDirs, false)
+	viper.SetDefault(config.KeyScannerIncludeHiddenFiles, false)
 	viper.SetDefault(config.KeyScannerIncludeIgnored, false)
 	viper.SetDefault(config.KeyScannerRespectShotgunignore, true)
+	viper.SetDefault(config.KeyScannerAllowlistMode, false)
+	viper.SetDefault(config.KeyScannerRespectGitattributes, false)
 	viper.SetDefault(config.KeyScannerMaxMemory, "500MB")
 
 	viper.SetDefault(config.KeyContextMaxSize, "10MB")
 	viper.SetDefault(config.KeyContextIncludeTree, true)
 	viper.SetDefault(config.KeyContextIncludeSummary, true)
+	viper.SetDefault(config.KeyContextStripComments, false)
+	viper.SetDefault(config.KeyContextFoldSiblings, false)
+	viper.SetDefault(config.KeyContextFileHeader, contextgen.DefaultFileHeader)
+	viper.SetDefault(config.KeyContextFileFooter, contextgen.DefaultFileFooter)
+	viper.SetDefault(config.KeyContextRedact, false)
 
 	viper.SetDefault(config.KeyTemplateCustomPath, "")
 
 	viper.SetDefault(config.KeyOutputFormat, "markdown")
 	viper.SetDefault(config.KeyOutputClipboard, true)
+	viper.SetDefault(config.KeyOutputClipboardMode, "content")
+	viper.SetDefault(config.KeyOutputClipboardVerify, false)
+	viper.SetDefault(config.KeyOutputDir, "")
 
 	viper.SetDefault(config.KeyLLMProvider, "openai")
 	viper.SetDefault(config.KeyLLMAPIKey, "")
@@ -244,16 +329,74 @@ func setConfigDefaults() {
 	viper.SetDefault(config.KeyLLMModel, "")
 	viper.SetDefault(config.KeyLLMTimeout, 300)
 	viper.SetDefault(config.KeyLLMSaveResponse, true)
+	viper.SetDefault(config.KeyLLMSendPreview, false)
+	viper.SetDefault(config.KeyLLMOverwriteResponse, false)
+	viper.SetDefault(config.KeyLLMProxy, "")
+	viper.SetDefault(config.KeyLLMCACert, "")
+	viper.SetDefault(config.KeyLLMInsecureSkipVerify, false)
+
+	defaultPalette := styles.DefaultPalette()
+	viper.SetDefault(config.KeyThemeColorPrimary, defaultPalette.Primary)
+	viper.SetDefault(config.KeyThemeColorAccent, defaultPalette.Accent)
+	viper.SetDefault(config.KeyThemeColorError, defaultPalette.Error)
+	viper.SetDefault(config.KeyThemeColorWarning, defaultPalette.Warning)
+	viper.SetDefault(config.KeyThemeColorSuccess, defaultPalette.Success)
+	viper.SetDefault(config.KeyThemeColorMuted, defaultPalette.Muted)
+	viper.SetDefault(config.KeyThemeColorText, defaultPalette.Text)
+}
+
+// applyTheme loads the theme.colors palette into the TUI styles. Invalid colors are
+// logged and keep their default.
+func applyTheme() {
+	var palette styles.Palette
+	colors := map[string]*string{
+		config.KeyThemeColorPrimary: &palette.Primary,
+		config.KeyThemeColorAccent:  &palette.Accent,
+		config.KeyThemeColorError:   &palette.Error,
+		config.KeyThemeColorWarning: &palette.Warning,
+		config.KeyThemeColorSuccess: &palette.Success,
+		config.KeyThemeColorMuted:   &palette.Muted,
+		config.KeyThemeColorText:    &palette.Text,
+	}
+	for key, field := range colors {
+		value := viper.GetString(key)
+		if err := config.ValidateValue(key, value); err != nil {
+			log.Warn().Err(err).Str("key", key).Msg("Ignoring invalid theme color")
+			continue
+		}
+		*field = value
+	}
+
+	styles.ApplyPalette(palette)
 }
 
 func updateLoggingLevel() {
-	level := zerolog.InfoLevel
+	level, err := logLevel()
+	if err != nil {
+		// Reported by the root command's PersistentPreRunE
+		level = zerolog.InfoLevel
+	}
+
+	zerolog.SetGlobalLevel(level)
+}
+
+// logLevel returns the zerolog level to use: --log-level when given, else error
+// for --quiet, debug for --verbose and info otherwise.
+func logLevel() (zerolog.Level, error) {
+	switch name := strings.ToLower(viper.GetString(config.KeyLogLevel)); name {
+	case "":
+	case "debug", "info", "warn", "error":
+		level, _ := zerolog.ParseLevel(name)
+		return level, nil
+	default:
+		return zerolog.InfoLevel, fmt.Errorf("invalid --log-level %q (use debug, info, warn or error)", name)
+	}
 
 	if viper.GetBool(config.KeyQuiet) {
-		level = zerolog.ErrorLevel
+		return zerolog.ErrorLevel, nil
 	} else if viper.GetBool(config.KeyVerbose) {
-		level = zerolog.DebugLevel
+		return zerolog.DebugLevel, nil
 	}
 
-	zerolog.SetGlobalLevel(level)
+	return zerolog.InfoLevel, nil
 }
@@ -7,6 +7,7 @@ import (
 	"runtime"
 	"testing"
 
+	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -136,12 +137,18 @@ func TestSetConfigDefaults(t *testing.T) {
 		{"scanner.include-hidden", false},
 		{"scanner.include-ignored", false},
 		{"scanner.respect-shotgunignore", true},
+		{"scanner.allowlist-mode", false},
+		{"scanner.respect-gitattributes", false},
 		{"scanner.max-memory", "500MB"},
 		{"context.max-size", "10MB"},
 		{"context.include-tree", true},
 		{"context.include-summary", true},
+		{"context.file-header", `<file path="{PATH}">`},
+		{"context.file-footer", "</file>"},
+		{"context.redact", false},
 		{"output.format", "markdown"},
 		{"output.clipboard", true},
+		{"output.clipboard-verify", false},
 		{"llm.provider", "openai"},
 		{"llm.timeout", 300},
 	}
@@ -178,6 +185,42 @@ func TestUpdateLoggingLevel(t *testing.T) {
 	}
 }
 
+func TestLogLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		logLevel string
+		quiet    bool
+		verbose  bool
+		want     zerolog.Level
+		wantErr  bool
+	}{
+		{"default", "", false, false, zerolog.InfoLevel, false},
+		{"quiet", "", true, false, zerolog.ErrorLevel, false},
+		{"verbose", "", false, true, zerolog.DebugLevel, false},
+		{"log level", "warn", false, false, zerolog.WarnLevel, false},
+		{"log level overrides quiet", "DEBUG", true, false, zerolog.DebugLevel, false},
+		{"invalid", "trace", false, false, zerolog.InfoLevel, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			t.Cleanup(viper.Reset)
+			viper.Set("log-level", tt.logLevel)
+			viper.Set("quiet", tt.quiet)
+			viper.Set("verbose", tt.verbose)
+
+			got, err := logLevel()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("logLevel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("logLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestGetConfigDir_Windows(t *testing.T) {
 	if runtime.GOOS != "windows" {
 		t.Skip("Windows-specific test")
@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/quantmind-br/shotgun-cli/internal/core/contextgen"
+	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
+)
+
+var scanCmd = &cobra.Command{
+	Use:   "scan",
+	Short: "Report statistics about a directory without generating context",
+	Long: `Scan a directory with the same rules as context generate and report what would
+be included: the file count and size by language, the largest files, and how many
+entries each ignore layer (built-in, gitignore, custom, explicit, not kept) dropped.
+An ignored directory counts once, since its contents are not visited.
+
+Use it to decide on --include and --exclude patterns before generating.
+
+Examples:
+  shotgun-cli scan
+  shotgun-cli scan --root ./src --top 20
+  shotgun-cli scan --exclude "vendor/*" --format json`,
+	Args: cobra.NoArgs,
+	RunE: runScan,
+}
+
+// scanLanguage is the file count and total size of one language in a scan report.
+type scanLanguage struct {
+	Language string `json:"language"`
+	Files    int    `json:"files"`
+	Size     int64  `json:"size"`
+}
+
+// scanFile is a file listed among the largest in a scan report.
+type scanFile struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// scanReport is the output of the scan command.
+type scanReport struct {
+	Root        string         `json:"root"`
+	Files       int            `json:"files"`
+	Directories int            `json:"directories"`
+	TotalSize   int64          `json:"total_size"`
+	Languages   []scanLanguage `json:"languages"`
+	Largest     []scanFile     `json:"largest"`
+	// Generated counts the generated and lock files whose content would be left out
+	Generated int `json:"generated"`
+	// Ignored counts the entries dropped by each ignore layer
+	Ignored      map[string]int64 `json:"ignored"`
+	Inaccessible []string         `json:"inaccessible,omitempty"`
+}
+
+func runScan(cmd *cobra.Command, args []string) error {
+	rootPath, _ := cmd.Flags().GetString("root")
+	include, _ := cmd.Flags().GetStringSlice("include")
+	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+	maxDepth, _ := cmd.Flags().GetInt("max-depth")
+	maxFiles, _ := cmd.Flags().GetInt64("max-files")
+	top, _ := cmd.Flags().GetInt("top")
+	format, _ := cmd.Flags().GetString("format")
+
+	if format != "text" && format != "json" {
+		return withExitCode(ExitUsage, fmt.Errorf("invalid --format %q (expected text or json)", format))
+	}
+	if top < 0 {
+		return withExitCode(ExitUsage, fmt.Errorf("invalid --top: %d (must not be negative)", top))
+	}
+
+	absRoot, err := filepath.Abs(rootPath)
+	if err != nil {
+		return fmt.Errorf("invalid root path: %w", err)
+	}
+
+	scanConfig := buildScannerConfig(GenerateConfig{
+		Include:  include,
+		Exclude:  exclude,
+		MaxDepth: maxDepth,
+		MaxFiles: maxFiles,
+	})
+	// Ignored files must be dropped, not kept and marked, for the per-layer counts
+	scanConfig.IncludeIgnored = false
+
+	fs := scanner.NewFileSystemScanner()
+	tree, err := fs.Scan(absRoot, &scanConfig)
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+
+	report := buildScanReport(absRoot, tree, fs.LastScanStats(), top)
+	if format == "json" {
+		return writeScanJSON(cmd.OutOrStdout(), report)
+	}
+	printScanReport(cmd.OutOrStdout(), report)
+
+	return nil
+}
+
+// buildScanReport summarizes a scanned tree, keeping the top largest files.
+func buildScanReport(root string, tree *scanner.FileNode, stats scanner.ScanStats, top int) scanReport {
+	report := scanReport{
+		Root:         root,
+		Ignored:      stats.IgnoredByReason,
+		Inaccessible: stats.Inaccessible,
+	}
+	if report.Ignored == nil {
+		report.Ignored = map[string]int64{}
+	}
+
+	languages := make(map[string]*scanLanguage)
+	var files []scanFile
+	var walk func(node *scanner.FileNode)
+	walk = func(node *scanner.FileNode) {
+		for _, child := range node.Children {
+			if child.IsDir {
+				report.Directories++
+				walk(child)
+				continue
+			}
+			report.Files++
+			report.TotalSize += child.Size
+			if child.IsGenerated {
+				report.Generated++
+			}
+			files = append(files, scanFile{Path: filepath.ToSlash(child.RelPath), Size: child.Size})
+
+			lang := contextgen.LanguageName(child.RelPath)
+			if languages[lang] == nil {
+				languages[lang] = &scanLanguage{Language: lang}
+			}
+			languages[lang].Files++
+			languages[lang].Size += child.Size
+		}
+	}
+	walk(tree)
+
+	report.Languages = make([]scanLanguage, 0, len(languages))
+	for _, lang := range languages {
+		report.Languages = append(report.Languages, *lang)
+	}
+	sort.Slice(report.Languages, func(i, j int) bool {
+		a, b := report.Languages[i], report.Languages[j]
+		if a.Files != b.Files {
+			return a.Files > b.Files
+		}
+		return a.Language < b.Language
+	})
+
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Size != files[j].Size {
+			return files[i].Size > files[j].Size
+		}
+		return files[i].Path < files[j].Path
+	})
+	if len(files) > top {
+		files = files[:top]
+	}
+	report.Largest = append([]scanFile{}, files...)
+
+	return report
+}
+
+func writeScanJSON(w io.Writer, report scanReport) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to write scan report: %w", err)
+	}
+	return nil
+}
+
+func printScanReport(w io.Writer, report scanReport) {
+	_, _ = fmt.Fprintf(w, "Root: %s\n", report.Root)
+	_, _ = fmt.Fprintf(w, "Files: %d in %d directories (%s)\n",
+		report.Files, report.Directories, scanner.FormatSize(report.TotalSize))
+
+	if report.Generated > 0 {
+		_, _ = fmt.Fprintf(w, "Generated/lock files: %d (content left out unless --include-generated)\n",
+			report.Generated)
+	}
+
+	if len(report.Languages) > 0 {
+		_, _ = fmt.Fprintln(w, "\nBy language:")
+		for _, lang := range report.Languages {
+			_, _ = fmt.Fprintf(w, "  %-16s %6d files  %10s\n", lang.Language, lang.Files, scanner.FormatSize(lang.Size))
+		}
+	}
+
+	if len(report.Largest) > 0 {
+		_, _ = fmt.Fprintln(w, "\nLargest files:")
+		for _, file := range report.Largest {
+			_, _ = fmt.Fprintf(w, "  %10s  %s\n", scanner.FormatSize(file.Size), file.Path)
+		}
+	}
+
+	_, _ = fmt.Fprintln(w, "\nIgnored (an ignored directory counts once):")
+	if len(report.Ignored) == 0 {
+		_, _ = fmt.Fprintln(w, "  nothing")
+	}
+	reasons := make([]string, 0, len(report.Ignored))
+	for reason := range report.Ignored {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+	for _, reason := range reasons {
+		_, _ = fmt.Fprintf(w, "  %-16s %6d\n", reason, report.Ignored[reason])
+	}
+
+	if len(report.Inaccessible) > 0 {
+		_, _ = fmt.Fprintf(w, "\nInaccessible directories (skipped): %d\n", len(report.Inaccessible))
+		for _, dir := range report.Inaccessible {
+			_, _ = fmt.Fprintf(w, "  %s\n", dir)
+		}
+	}
+}
+
+func init() {
+	scanCmd.Flags().StringP("root", "r", ".", "Root directory to scan")
+	scanCmd.Flags().StringSliceP("include", "i", []string{"*"}, "File patterns to include (glob patterns)")
+	scanCmd.Flags().StringSliceP("exclude", "e", []string{}, "File patterns to exclude (glob patterns)")
+	scanCmd.Flags().Int("max-depth", 0, "Directory levels below the root to scan (0 = no limit)")
+	scanCmd.Flags().Int64("max-files", 0,
+		"Fail if the scan finds more non-ignored files than this (0 = use scanner.max-files)")
+	scanCmd.Flags().Int("top", 10, "Number of largest files to list")
+	scanCmd.Flags().String("format", "text", "Output format: text or json")
+
+	rootCmd.AddCommand(scanCmd)
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestRunScan(t *testing.T) {
+	root := t.TempDir()
+	for file, content := range map[string]string{
+		".gitignore":      "*.tmp\n",
+		"main.go":         "package main\n",
+		"pkg/util.go":     strings.Repeat("x", 300),
+		"web/app.ts":      "export {}\n",
+		"cache.tmp":       "cache",
+		"node_modules/x":  "dep",
+		"docs/readme.txt": "docs",
+		"go.sum":          "example.com/mod v1.0.0 h1:abc=\n",
+	} {
+		path := filepath.Join(root, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	viper.Reset()
+	setConfigDefaults()
+	t.Cleanup(viper.Reset)
+
+	run := func(args ...string) string {
+		var out bytes.Buffer
+		scanCmd.SetOut(&out)
+		t.Cleanup(func() { scanCmd.SetOut(nil) })
+		for _, flag := range []string{"format", "top"} {
+			f := scanCmd.Flags().Lookup(flag)
+			_ = f.Value.Set(f.DefValue)
+		}
+		if err := scanCmd.ParseFlags(append([]string{"--root", root}, args...)); err != nil {
+			t.Fatal(err)
+		}
+		if err := runScan(scanCmd, nil); err != nil {
+			t.Fatalf("runScan() error: %v", err)
+		}
+		return out.String()
+	}
+
+	var report scanReport
+	if err := json.Unmarshal([]byte(run("--format", "json", "--top", "1")), &report); err != nil {
+		t.Fatalf("invalid JSON report: %v", err)
+	}
+
+	if report.Files != 5 {
+		t.Errorf("expected 5 files (.gitignore is hidden), got %d", report.Files)
+	}
+	if report.Generated != 1 {
+		t.Errorf("expected go.sum counted as generated, got %d", report.Generated)
+	}
+	if len(report.Languages) == 0 || report.Languages[0].Language != "go" || report.Languages[0].Files != 3 {
+		t.Errorf("expected go first with 3 files (go.sum included), got %+v", report.Languages)
+	}
+	if len(report.Largest) != 1 || report.Largest[0].Path != "pkg/util.go" {
+		t.Errorf("expected pkg/util.go as the single largest file, got %+v", report.Largest)
+	}
+	if report.Ignored["gitignore"] != 1 || report.Ignored["built-in"] != 1 {
+		t.Errorf("expected cache.tmp gitignored and node_modules built-in ignored, got %v", report.Ignored)
+	}
+
+	text := run()
+	for _, want := range []string{
+		"Files: 5 in 3 directories", "Generated/lock files: 1", "By language:", "Largest files:", "gitignore",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in text report, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestRunScan_InvalidFormat(t *testing.T) {
+	defer func() { _ = scanCmd.Flags().Set("format", "text") }()
+	if err := scanCmd.Flags().Set("format", "yaml"); err != nil {
+		t.Fatal(err)
+	}
+
+	err := runScan(scanCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid --format") {
+		t.Errorf("expected invalid --format error, got %v", err)
+	}
+}
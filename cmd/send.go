@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -12,7 +13,10 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/quantmind-br/shotgun-cli/internal/app"
 	"github.com/quantmind-br/shotgun-cli/internal/config"
+	"github.com/quantmind-br/shotgun-cli/internal/core/contextgen"
+	"github.com/quantmind-br/shotgun-cli/internal/core/llm"
 )
 
 var contextSendCmd = &cobra.Command{
@@ -28,7 +32,12 @@ Examples:
   shotgun-cli context send prompt.md -o response.md
   cat prompt.md | shotgun-cli context send
   shotgun-cli context send prompt.md -m gemini-2.0-pro
-  shotgun-cli context send prompt.md --raw`,
+  shotgun-cli context send prompt.md --raw
+  shotgun-cli context send prompt.md -o main.go --extract-code
+  shotgun-cli context send prompt.md --preview
+  shotgun-cli context send prompt.md --cache-context
+  shotgun-cli context send prompt.md --redact
+  shotgun-cli context send prompt.md --providers openai,anthropic,gemini`,
 
 	Args: cobra.MaximumNArgs(1),
 	PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -73,35 +82,154 @@ func runContextSend(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no content to send (file or stdin is empty)")
 	}
 
-	// Get flag overrides
-	model, _ := cmd.Flags().GetString("model")
-	timeout, _ := cmd.Flags().GetInt("timeout")
-	outputFile, _ := cmd.Flags().GetString("output")
-	raw, _ := cmd.Flags().GetBool("raw")
+	content, err := redactSendContent(cmd, content)
+	if err != nil {
+		return err
+	}
+
+	opts, err := sendOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	return sendContent(content, opts)
+}
+
+// redactSendContent replaces the secrets in content with --redact, or context.redact
+// when the flag is not given, so a context generated without redaction is not sent as is.
+func redactSendContent(cmd *cobra.Command, content string) (string, error) {
+	redact := viper.GetBool(config.KeyContextRedact)
+	if cmd.Flags().Changed("redact") {
+		redact, _ = cmd.Flags().GetBool("redact")
+	}
+	if !redact {
+		return content, nil
+	}
+
+	patterns, err := redactPatternsFromConfig()
+	if err != nil {
+		return "", err
+	}
+	redactor, err := contextgen.NewRedactor(patterns)
+	if err != nil {
+		return "", err
+	}
+	content, count := redactor.Redact(content)
+	if count > 0 {
+		log.Info().Int("secrets", count).Msg("Redacted secrets before sending")
+	}
+
+	return content, nil
+}
+
+// sendOptions controls how content is sent to the configured provider and where the
+// response goes.
+type sendOptions struct {
+	Model        string // Overrides llm.model when set
+	BaseURL      string // Overrides llm.base-url when set
+	Timeout      int    // Overrides llm.timeout (seconds) when positive
+	OutputFile   string // Resolved response path; empty prints the response
+	Raw          bool   // Output the raw provider response
+	CacheContext bool   // Cache the file context (Anthropic only)
+	Preview      bool   // Page the content and confirm before sending
+	// ExtractCode writes the code of the response's fenced blocks instead of the whole
+	// response; several blocks go to numbered files placed next to OutputFile or in OutputDir.
+	ExtractCode bool
+	OutputDir   string
+	// Targets, when set, sends to each listed provider at once instead of llm.provider,
+	// saving the responses as response-<provider>.md (see sendToTargets).
+	Targets []sendTarget
+}
+
+// sendOptionsFromFlags reads the flags shared by the send commands. Flags a command
+// does not define keep their zero value.
+func sendOptionsFromFlags(cmd *cobra.Command) (sendOptions, error) {
+	var opts sendOptions
+	opts.Model, _ = cmd.Flags().GetString("model")
+	opts.BaseURL, _ = cmd.Flags().GetString("base-url")
+	if opts.BaseURL != "" {
+		if err := config.ValidateValue(config.KeyLLMBaseURL, opts.BaseURL); err != nil {
+			return sendOptions{}, withExitCode(ExitUsage, fmt.Errorf("invalid --base-url: %w", err))
+		}
+	}
+	opts.Timeout, _ = cmd.Flags().GetInt("timeout")
+	opts.Raw, _ = cmd.Flags().GetBool("raw")
+	opts.CacheContext, _ = cmd.Flags().GetBool("cache-context")
+	opts.ExtractCode, _ = cmd.Flags().GetBool("extract-code")
+	if opts.ExtractCode && opts.Raw {
+		return sendOptions{}, fmt.Errorf("--extract-code cannot be combined with --raw")
+	}
+	opts.OutputDir = outputDirFromFlags(cmd)
+	opts.Preview = viper.GetBool(config.KeyLLMSendPreview)
+	if cmd.Flags().Changed("preview") {
+		opts.Preview, _ = cmd.Flags().GetBool("preview")
+	}
+
+	if providers, _ := cmd.Flags().GetStringSlice("providers"); len(providers) > 0 {
+		return targetSendOptions(cmd, opts, providers)
+	}
 
 	// Check save-response config if no output file specified
-	saveResponse := viper.GetBool(config.KeyLLMSaveResponse)
-	if outputFile == "" && saveResponse {
-		// Auto-generate output filename
-		timestamp := time.Now().Format("20060102-150405")
-		outputFile = fmt.Sprintf("llm-response-%s.md", timestamp)
+	outputFile, _ := cmd.Flags().GetString("output")
+	if outputFile == "" && viper.GetBool(config.KeyLLMSaveResponse) {
+		overwrite := viper.GetBool(config.KeyLLMOverwriteResponse)
+		if cmd.Flags().Changed("overwrite-response") {
+			overwrite, _ = cmd.Flags().GetBool("overwrite-response")
+		}
+		outputFile = app.ResponseFileName("llm-response", overwrite, time.Now())
+	}
+	if outputFile != "" {
+		var err error
+		opts.OutputFile, err = app.ResolveOutputPath(opts.OutputDir, outputFile)
+		if err != nil {
+			return sendOptions{}, err
+		}
+	}
+
+	return opts, nil
+}
+
+// sendContent sends content to the configured LLM provider and prints or saves the response.
+func sendContent(content string, opts sendOptions) error {
+	if len(opts.Targets) > 0 {
+		return sendToTargets(os.Stdout, content, opts)
 	}
 
 	// Build config
-	cfg := BuildLLMConfigWithOverrides(model, timeout)
+	cfg := BuildLLMConfigWithOverrides(opts.Model, opts.Timeout)
+	if opts.BaseURL != "" {
+		cfg.BaseURL = opts.BaseURL
+	}
+	cfg.CacheContext = opts.CacheContext
+	if opts.CacheContext && cfg.Provider != llm.ProviderAnthropic {
+		log.Warn().Str("provider", cfg.Provider.String()).Msg("--cache-context is only supported by the Anthropic provider")
+	}
 
 	// Create provider
 	llmProvider, err := CreateLLMProvider(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create provider: %w", err)
+		return withExitCode(ExitLLMError, fmt.Errorf("failed to create provider: %w", err))
 	}
 
 	if !llmProvider.IsAvailable() {
-		return fmt.Errorf("%s not available. Run 'shotgun-cli llm doctor' for help", llmProvider.Name())
+		return withExitCode(ExitLLMError,
+			fmt.Errorf("%s not available. Run 'shotgun-cli llm doctor' for help", llmProvider.Name()))
 	}
 
 	if err := llmProvider.ValidateConfig(); err != nil {
-		return fmt.Errorf("%s configuration error: %w. Run 'shotgun-cli llm doctor' for help", llmProvider.Name(), err)
+		return withExitCode(ExitLLMError,
+			fmt.Errorf("%s configuration error: %w. Run 'shotgun-cli llm doctor' for help", llmProvider.Name(), err))
+	}
+
+	if opts.Preview {
+		confirmed, err := previewAndConfirm(content, llmProvider.Name())
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Cancelled, nothing was sent.")
+			return nil
+		}
 	}
 
 	// Send
@@ -113,26 +241,47 @@ func runContextSend(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("Sending to %s (%s)...\n", llmProvider.Name(), cfg.Model)
 
+	// Stream the response unless the raw API response is wanted, printing it as it
+	// arrives when it is not saved to a file
 	ctx := context.Background()
-	result, err := llmProvider.Send(ctx, content)
+	var result *llm.Result
+	if opts.Raw {
+		result, err = llmProvider.Send(ctx, content)
+	} else {
+		var onDelta func(string)
+		if opts.OutputFile == "" && !opts.ExtractCode {
+			onDelta = func(delta string) { fmt.Print(delta) }
+		}
+		result, err = llmProvider.SendStream(ctx, content, onDelta)
+	}
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		if hint := llm.ErrorHint(err); hint != "" {
+			return withExitCode(ExitLLMError, fmt.Errorf("request failed: %w. %s", err, hint))
+		}
+		return withExitCode(ExitLLMError, fmt.Errorf("request failed: %w", err))
 	}
 
 	// Get response
 	response := result.Response
-	if raw {
+	if opts.Raw {
 		response = result.RawResponse
 	}
 
 	// Output
-	if outputFile != "" {
-		if err := os.WriteFile(outputFile, []byte(response), 0600); err != nil {
-			return fmt.Errorf("failed to save response to '%s': %w", outputFile, err)
+	switch {
+	case opts.ExtractCode:
+		if err := writeExtractedCode(response, opts); err != nil {
+			return err
 		}
-		fmt.Printf("Response saved to: %s\n", outputFile)
-	} else {
+	case opts.OutputFile != "":
+		if err := os.WriteFile(opts.OutputFile, []byte(response), 0600); err != nil {
+			return fmt.Errorf("failed to save response to '%s': %w", opts.OutputFile, err)
+		}
+		fmt.Printf("Response saved to: %s\n", opts.OutputFile)
+	case opts.Raw:
 		fmt.Println(response)
+	default:
+		fmt.Println()
 	}
 
 	// Show usage if available
@@ -141,12 +290,85 @@ func runContextSend(cmd *cobra.Command, args []string) error {
 			result.Usage.TotalTokens,
 			result.Usage.PromptTokens,
 			result.Usage.CompletionTokens)
+		if result.Usage.CacheReadTokens > 0 || result.Usage.CacheCreationTokens > 0 {
+			fmt.Printf("Cache: %d tokens read, %d tokens written\n",
+				result.Usage.CacheReadTokens,
+				result.Usage.CacheCreationTokens)
+		}
 	}
 	fmt.Printf("Duration: %s\n", formatDuration(result.Duration))
 
 	return nil
 }
 
+// writeExtractedCode writes the code of the fenced blocks in response. A single block
+// is written (or printed) in place of the response; several blocks are written to
+// numbered files named after the output file, e.g. response-1.go and response-2.py.
+// A response without fenced blocks is kept as is.
+func writeExtractedCode(response string, opts sendOptions) error {
+	blocks := llm.ExtractCodeBlocks(response)
+
+	switch len(blocks) {
+	case 0:
+		log.Warn().Msg("No fenced code block in the response, keeping it as is")
+		return writeResponse(response, opts.OutputFile)
+	case 1:
+		log.Info().Str("language", blocks[0].Language).Msg("Extracted code block from the response")
+		return writeResponse(blocks[0].Code, opts.OutputFile)
+	}
+
+	base := opts.OutputFile
+	if base == "" {
+		var err error
+		if base, err = app.ResolveOutputPath(opts.OutputDir, "response"); err != nil {
+			return err
+		}
+	}
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+
+	for i, block := range blocks {
+		path := fmt.Sprintf("%s-%d%s", base, i+1, codeBlockExtension(block.Language))
+		if err := os.WriteFile(path, []byte(block.Code), 0600); err != nil {
+			return fmt.Errorf("failed to save code block to '%s': %w", path, err)
+		}
+		fmt.Printf("Code block %d saved to: %s\n", i+1, path)
+	}
+
+	return nil
+}
+
+// writeResponse saves text to outputFile, or prints it when outputFile is empty.
+func writeResponse(text, outputFile string) error {
+	if outputFile == "" {
+		fmt.Print(text)
+		return nil
+	}
+
+	if err := os.WriteFile(outputFile, []byte(text), 0600); err != nil {
+		return fmt.Errorf("failed to save response to '%s': %w", outputFile, err)
+	}
+	fmt.Printf("Response saved to: %s\n", outputFile)
+
+	return nil
+}
+
+// codeBlockExtension returns the file extension for a fence language such as "go",
+// "py" or "python", falling back to ".txt".
+func codeBlockExtension(language string) string {
+	language = strings.ToLower(language)
+	if language == "" {
+		return ".txt"
+	}
+	if contextgen.LanguageForPath("file."+language) != "" {
+		return "." + language
+	}
+	if exts, ok := contextgen.ExtensionsForLanguage(language); ok {
+		return exts[0]
+	}
+
+	return ".txt"
+}
+
 // formatDuration formats a duration for display.
 func formatDuration(d time.Duration) string {
 	if d < time.Second {
@@ -157,9 +379,24 @@ func formatDuration(d time.Duration) string {
 
 func init() {
 	contextSendCmd.Flags().StringP("output", "o", "", "Output file for Gemini response")
+	contextSendCmd.Flags().String("output-dir", "", "Directory for response files (default: output.dir config)")
 	contextSendCmd.Flags().StringP("model", "m", "", "Gemini model to use (default: from config)")
+	contextSendCmd.Flags().String("base-url", "",
+		"API endpoint to send to, e.g. a proxy (default: llm.base-url config)")
 	contextSendCmd.Flags().Int("timeout", 0, "Timeout in seconds (default: from config)")
 	contextSendCmd.Flags().Bool("raw", false, "Output raw response without processing")
+	contextSendCmd.Flags().Bool("extract-code", false,
+		"Output only the code of fenced blocks in the response (several blocks go to numbered files)")
+	contextSendCmd.Flags().Bool("redact", false,
+		"Replace secrets with [REDACTED] before sending (default: context.redact config)")
+	contextSendCmd.Flags().Bool("cache-context", false,
+		"Cache the file context across requests that only change the task and rules (Anthropic)")
+	contextSendCmd.Flags().Bool("preview", false,
+		"Show the prompt in $PAGER and confirm before sending (default: llm.send-preview config)")
+	contextSendCmd.Flags().StringSlice("providers", nil,
+		"Send to several providers at once, e.g. openai,anthropic:claude-opus-4-1, saving response-<provider>.md")
+	contextSendCmd.Flags().Bool("overwrite-response", false,
+		"Save the response as llm-response.md instead of a timestamped name (default: llm.overwrite-response config)")
 
 	contextCmd.AddCommand(contextSendCmd)
 }
@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/quantmind-br/shotgun-cli/internal/app"
+	"github.com/quantmind-br/shotgun-cli/internal/core/llm"
+)
+
+// providerEnvPrefix names the environment variables, <PREFIX>_API_KEY and <PREFIX>_BASE_URL,
+// that configure a provider other than llm.provider when sending to several at once.
+var providerEnvPrefix = map[llm.ProviderType]string{
+	llm.ProviderOpenAI:    "OPENAI",
+	llm.ProviderAnthropic: "ANTHROPIC",
+	llm.ProviderGemini:    "GEMINI",
+}
+
+// sendTarget is one entry of --providers: a provider and, optionally, the model to use.
+type sendTarget struct {
+	Provider llm.ProviderType
+	Model    string
+}
+
+// targetSendOptions completes opts for --providers, rejecting the flags that only make
+// sense for a single provider.
+func targetSendOptions(cmd *cobra.Command, opts sendOptions, providers []string) (sendOptions, error) {
+	for _, flag := range []string{"output", "model", "base-url", "extract-code"} {
+		if cmd.Flags().Changed(flag) {
+			return sendOptions{}, withExitCode(ExitUsage, fmt.Errorf("--%s cannot be combined with --providers", flag))
+		}
+	}
+
+	targets, err := parseSendTargets(providers)
+	if err != nil {
+		return sendOptions{}, withExitCode(ExitUsage, err)
+	}
+	opts.Targets = targets
+
+	return opts, nil
+}
+
+// parseSendTargets parses --providers values of the form provider or provider:model.
+func parseSendTargets(values []string) ([]sendTarget, error) {
+	var targets []sendTarget
+	seen := make(map[sendTarget]bool)
+	for _, value := range values {
+		name, model, _ := strings.Cut(strings.TrimSpace(value), ":")
+		if name == "" {
+			continue
+		}
+		if !llm.IsValidProvider(name) {
+			return nil, fmt.Errorf("invalid provider %q in --providers (valid: %s)", name, providerNames())
+		}
+		target := sendTarget{Provider: llm.ProviderType(name), Model: model}
+		if seen[target] {
+			return nil, fmt.Errorf("provider %q is listed more than once in --providers", value)
+		}
+		seen[target] = true
+		targets = append(targets, target)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("--providers lists no provider")
+	}
+
+	return targets, nil
+}
+
+func providerNames() string {
+	names := make([]string, 0, len(llm.AllProviders()))
+	for _, p := range llm.AllProviders() {
+		names = append(names, p.String())
+	}
+	return strings.Join(names, ", ")
+}
+
+// targetConfig builds the configuration for one target. The configured llm.provider
+// keeps its llm.* settings; any other provider starts from its defaults and takes its
+// API key and base URL from the environment (e.g. ANTHROPIC_API_KEY, ANTHROPIC_BASE_URL).
+func targetConfig(target sendTarget, opts sendOptions) llm.Config {
+	cfg := BuildLLMConfigWithOverrides("", opts.Timeout)
+	if cfg.Provider != target.Provider {
+		defaults := llm.DefaultConfigs()[target.Provider]
+		prefix := providerEnvPrefix[target.Provider]
+		cfg.Provider = target.Provider
+		cfg.APIKey = os.Getenv(prefix + "_API_KEY")
+		cfg.BaseURL = os.Getenv(prefix + "_BASE_URL")
+		if cfg.BaseURL == "" {
+			cfg.BaseURL = defaults.BaseURL
+		}
+		cfg.Model = defaults.Model
+	}
+	if target.Model != "" {
+		cfg.Model = target.Model
+	}
+	cfg.CacheContext = opts.CacheContext && cfg.Provider == llm.ProviderAnthropic
+
+	return cfg
+}
+
+// targetResult is the outcome of sending to one target.
+type targetResult struct {
+	Provider   llm.ProviderType
+	Model      string
+	OutputFile string
+	Duration   time.Duration
+	Usage      *llm.Usage
+	Err        error
+}
+
+// sendToTargets sends content to every target in opts.Targets at once, writing each
+// response to response-<provider>.md. A failing provider does not stop the others;
+// the comparison table lists every outcome and the error reports how many failed.
+func sendToTargets(w io.Writer, content string, opts sendOptions) error {
+	targets := opts.Targets
+	counts := make(map[llm.ProviderType]int, len(targets))
+	for _, target := range targets {
+		counts[target.Provider]++
+	}
+
+	configs := make([]llm.Config, len(targets))
+	results := make([]targetResult, len(targets))
+	for i, target := range targets {
+		cfg := targetConfig(target, opts)
+		configs[i] = cfg
+		results[i] = targetResult{Provider: target.Provider, Model: cfg.Model}
+		name := "response-" + target.Provider.String()
+		if counts[target.Provider] > 1 {
+			name += "-" + strings.NewReplacer("/", "-", ":", "-", " ", "-").Replace(cfg.Model)
+		}
+		path, err := app.ResolveOutputPath(opts.OutputDir, name+".md")
+		if err != nil {
+			return err
+		}
+		results[i].OutputFile = path
+	}
+
+	if opts.Preview {
+		confirmed, err := previewAndConfirm(content, fmt.Sprintf("%d providers", len(targets)))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			_, _ = fmt.Fprintln(w, "Cancelled, nothing was sent.")
+			return nil
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "Sending to %d providers...\n", len(targets))
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := range targets {
+		wg.Add(1)
+		go func(result *targetResult, cfg llm.Config) {
+			defer wg.Done()
+			sendToTarget(ctx, content, cfg, opts.Raw, result)
+		}(&results[i], configs[i])
+	}
+	wg.Wait()
+
+	failed := printTargetResults(w, results)
+	if failed > 0 {
+		return withExitCode(ExitLLMError, fmt.Errorf("%d of %d providers failed", failed, len(results)))
+	}
+
+	return nil
+}
+
+// sendToTarget creates the provider for cfg, sends content and saves the response,
+// recording the outcome in result.
+func sendToTarget(ctx context.Context, content string, cfg llm.Config, raw bool, result *targetResult) {
+	if cfg.APIKey == "" {
+		result.Err = fmt.Errorf("no API key: set %s_API_KEY, or llm.api-key if it is the configured provider",
+			providerEnvPrefix[cfg.Provider])
+		return
+	}
+	provider, err := CreateLLMProvider(cfg)
+	if err != nil {
+		result.Err = err
+		return
+	}
+	if err := provider.ValidateConfig(); err != nil {
+		result.Err = fmt.Errorf("configuration error: %w", err)
+		return
+	}
+
+	log.Info().Str("provider", provider.Name()).Str("model", cfg.Model).Msg("Sending to LLM")
+	start := time.Now()
+	var res *llm.Result
+	if raw {
+		res, err = provider.Send(ctx, content)
+	} else {
+		res, err = provider.SendStream(ctx, content, nil)
+	}
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Err = fmt.Errorf("request failed: %w", err)
+		return
+	}
+	if res.Duration > 0 {
+		result.Duration = res.Duration
+	}
+	result.Usage = res.Usage
+
+	response := res.Response
+	if raw {
+		response = res.RawResponse
+	}
+	if err := os.WriteFile(result.OutputFile, []byte(response), 0600); err != nil {
+		result.Err = fmt.Errorf("failed to save response to '%s': %w", result.OutputFile, err)
+	}
+}
+
+// printTargetResults writes the comparison table and the errors of failed targets,
+// returning the number that failed.
+func printTargetResults(w io.Writer, results []targetResult) int {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "\nPROVIDER\tMODEL\tSTATUS\tDURATION\tPROMPT\tCOMPLETION\tTOTAL\tRESPONSE")
+	failed := 0
+	for _, result := range results {
+		status, file := "ok", result.OutputFile
+		if result.Err != nil {
+			status, file = "failed", "-"
+			failed++
+		}
+		prompt, completion, total := "-", "-", "-"
+		if result.Usage != nil {
+			prompt = fmt.Sprint(result.Usage.PromptTokens)
+			completion = fmt.Sprint(result.Usage.CompletionTokens)
+			total = fmt.Sprint(result.Usage.TotalTokens)
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", result.Provider, result.Model, status,
+			formatDuration(result.Duration), prompt, completion, total, file)
+	}
+	_ = tw.Flush()
+
+	if failed > 0 {
+		_, _ = fmt.Fprintln(w, "\nFailed:")
+		for _, result := range results {
+			if result.Err != nil {
+				_, _ = fmt.Fprintf(w, "  %s (%s): %v\n", result.Provider, result.Model, result.Err)
+			}
+		}
+	}
+	_, _ = fmt.Fprintf(w, "\n%d of %d providers succeeded\n", len(results)-failed, len(results))
+
+	return failed
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quantmind-br/shotgun-cli/internal/config"
+	"github.com/quantmind-br/shotgun-cli/internal/core/llm"
+)
+
+func TestParseSendTargets(t *testing.T) {
+	targets, err := parseSendTargets([]string{"openai", " anthropic:claude-test", "openai:gpt-b"})
+	require.NoError(t, err)
+	assert.Equal(t, []sendTarget{
+		{Provider: llm.ProviderOpenAI},
+		{Provider: llm.ProviderAnthropic, Model: "claude-test"},
+		{Provider: llm.ProviderOpenAI, Model: "gpt-b"},
+	}, targets)
+
+	_, err = parseSendTargets([]string{"openai", "mistral"})
+	assert.ErrorContains(t, err, `invalid provider "mistral"`)
+
+	_, err = parseSendTargets([]string{"gemini", "gemini"})
+	assert.ErrorContains(t, err, "listed more than once")
+
+	_, err = parseSendTargets([]string{" "})
+	assert.ErrorContains(t, err, "lists no provider")
+}
+
+func TestTargetSendOptions(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("output", "", "")
+	cmd.Flags().String("model", "", "")
+	cmd.Flags().StringSlice("providers", nil, "")
+	require.NoError(t, cmd.Flags().Set("providers", "openai,gemini"))
+
+	opts, err := sendOptionsFromFlags(cmd)
+	require.NoError(t, err)
+	assert.Len(t, opts.Targets, 2)
+	assert.Empty(t, opts.OutputFile)
+
+	require.NoError(t, cmd.Flags().Set("model", "gpt-4o"))
+	_, err = sendOptionsFromFlags(cmd)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--model cannot be combined with --providers")
+	assert.Equal(t, ExitUsage, ExitCode(err))
+}
+
+func TestSendToTargets(t *testing.T) {
+	openai := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Model string `json:"model"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: {\"choices\":[{\"delta\":{\"content\":\"from " + body.Model + "\"}}]}\n\n" +
+			"data: {\"choices\":[],\"usage\":{\"prompt_tokens\":7,\"completion_tokens\":3,\"total_tokens\":10}}\n\n" +
+			"data: [DONE]\n\n"))
+	}))
+	defer openai.Close()
+	anthropic := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":{"type":"overloaded_error","message":"Overloaded"}}`, http.StatusServiceUnavailable)
+	}))
+	defer anthropic.Close()
+
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set(config.KeyLLMProvider, "openai")
+	viper.Set(config.KeyLLMAPIKey, "sk-test")
+	viper.Set(config.KeyLLMBaseURL, openai.URL)
+	viper.Set(config.KeyLLMModel, "gpt-a")
+	t.Setenv("ANTHROPIC_API_KEY", "sk-ant-test")
+	t.Setenv("ANTHROPIC_BASE_URL", anthropic.URL)
+	t.Setenv("GEMINI_API_KEY", "")
+
+	dir := t.TempDir()
+	targets, err := parseSendTargets([]string{"openai", "openai:gpt-b", "anthropic", "gemini"})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	err = sendToTargets(&out, "ping", sendOptions{Targets: targets, OutputDir: dir})
+	require.Error(t, err)
+	assert.Equal(t, ExitLLMError, ExitCode(err))
+	assert.Contains(t, err.Error(), "2 of 4 providers failed")
+
+	responses := map[string]string{"response-openai-gpt-a.md": "from gpt-a", "response-openai-gpt-b.md": "from gpt-b"}
+	for name, want := range responses {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err, name)
+		assert.Equal(t, want, string(data))
+	}
+	assert.NoFileExists(t, filepath.Join(dir, "response-anthropic.md"))
+	assert.NoFileExists(t, filepath.Join(dir, "response-gemini.md"))
+
+	report := out.String()
+	assert.Contains(t, report, "PROVIDER")
+	assert.Regexp(t, `openai\s+gpt-b\s+ok\s+\S+\s+7\s+3\s+10\s+`, report)
+	assert.Regexp(t, `anthropic\s+claude-sonnet-4-20250514\s+failed`, report)
+	assert.Contains(t, report, "gemini (gemini-2.5-flash): no API key: set GEMINI_API_KEY")
+	assert.Contains(t, report, "2 of 4 providers succeeded")
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/quantmind-br/shotgun-cli/internal/core/tokens"
+	"github.com/quantmind-br/shotgun-cli/internal/utils"
+)
+
+// defaultPager is used when $PAGER is not set.
+const defaultPager = "less"
+
+// previewAndConfirm shows content through the pager and asks whether to send it.
+// The answer is read from the terminal, since stdin may hold the piped prompt.
+func previewAndConfirm(content, provider string) (bool, error) {
+	if err := pageContent(content, os.Getenv("PAGER"), os.Stdout); err != nil {
+		return false, err
+	}
+
+	answers := io.Reader(os.Stdin)
+	if stat, err := os.Stdin.Stat(); err != nil || stat.Mode()&os.ModeCharDevice == 0 {
+		tty, err := os.Open("/dev/tty")
+		if err != nil {
+			return false, fmt.Errorf("--preview needs a terminal to confirm: %w", err)
+		}
+		defer func() { _ = tty.Close() }()
+		answers = tty
+	}
+
+	question := fmt.Sprintf("Send %s (~%s tokens) to %s?",
+		utils.FormatBytes(int64(len(content))), tokens.FormatTokens(tokens.Estimate(content)), provider)
+
+	return confirmPrompt(answers, os.Stdout, question)
+}
+
+// pageContent pipes content through pager, which may include arguments (e.g. "less -R").
+// An empty pager means less; when the pager cannot be found, content is written to out.
+func pageContent(content, pager string, out io.Writer) error {
+	args := strings.Fields(pager)
+	if len(args) == 0 {
+		args = []string{defaultPager}
+	}
+
+	if _, err := exec.LookPath(args[0]); err != nil {
+		_, err := io.WriteString(out, content)
+		return err
+	}
+
+	cmd := exec.Command(args[0], args[1:]...) //nolint:gosec // pager chosen by the user via $PAGER
+	cmd.Stdin = strings.NewReader(content)
+	cmd.Stdout = out
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("pager %q failed: %w", strings.Join(args, " "), err)
+	}
+
+	return nil
+}
+
+// confirmPrompt asks a yes/no question, defaulting to no.
+func confirmPrompt(in io.Reader, out io.Writer, question string) (bool, error) {
+	_, _ = fmt.Fprintf(out, "%s (y/N): ", question)
+
+	response, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read user input: %w", err)
+	}
+
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes", nil
+}
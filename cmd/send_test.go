@@ -1,7 +1,11 @@
 package cmd
 
 import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -260,6 +264,40 @@ func TestRunContextSend_Flags(t *testing.T) {
 	})
 }
 
+func TestRunContextSend_BaseURLFlag(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"candidates":[{"content":{"parts":[{"text":"proxied"}]}}]}`))
+	}))
+	defer server.Close()
+
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "prompt.txt")
+	require.NoError(t, os.WriteFile(testFile, []byte("Test content"), 0o600))
+
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set("llm.provider", "gemini")
+	viper.Set("llm.api-key", "test-key")
+	viper.Set("llm.base-url", "https://unreachable.invalid")
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("output", "", "")
+	cmd.Flags().String("base-url", "", "")
+	require.NoError(t, cmd.Flags().Set("output", filepath.Join(tempDir, "response.md")))
+	require.NoError(t, cmd.Flags().Set("base-url", server.URL+"/proxy/v1beta/"))
+
+	require.NoError(t, runContextSend(cmd, []string{testFile}))
+	assert.Equal(t, "/proxy/v1beta/models/gemini-2.5-flash:generateContent", gotPath)
+
+	require.NoError(t, cmd.Flags().Set("base-url", "proxy.local"))
+	err := runContextSend(cmd, []string{testFile})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --base-url")
+}
+
 func TestContextSendCmd_ArgsValidation(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -386,3 +424,78 @@ func TestFormatDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestConfirmPrompt(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		got, err := confirmPrompt(strings.NewReader(tt.input), &out, "Send?")
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, "input %q", tt.input)
+		assert.Equal(t, "Send? (y/N): ", out.String())
+	}
+}
+
+func TestPageContent(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, pageContent("prompt body\n", "cat", &out))
+	assert.Equal(t, "prompt body\n", out.String(), "content should go through the pager")
+
+	out.Reset()
+	require.NoError(t, pageContent("prompt body\n", "no-such-pager-binary --flag", &out))
+	assert.Equal(t, "prompt body\n", out.String(), "content should be printed when the pager is missing")
+}
+
+func TestCodeBlockExtension(t *testing.T) {
+	tests := map[string]string{
+		"go":     ".go",
+		"py":     ".py",
+		"python": ".py",
+		"TS":     ".ts",
+		"":       ".txt",
+		"nosuch": ".txt",
+	}
+	for language, want := range tests {
+		assert.Equal(t, want, codeBlockExtension(language), "language %q", language)
+	}
+}
+
+func TestWriteExtractedCode(t *testing.T) {
+	dir := t.TempDir()
+
+	single := filepath.Join(dir, "main.go")
+	require.NoError(t, writeExtractedCode("Sure:\n```go\npackage main\n```\n", sendOptions{OutputFile: single}))
+	data, err := os.ReadFile(single) //nolint:gosec // test reading controlled file
+	require.NoError(t, err)
+	assert.Equal(t, "package main\n", string(data))
+
+	multi := filepath.Join(dir, "answer.md")
+	response := "```go\npackage a\n```\nand\n```python\nprint(1)\n```\n"
+	require.NoError(t, writeExtractedCode(response, sendOptions{OutputFile: multi}))
+	data, err = os.ReadFile(filepath.Join(dir, "answer-1.go")) //nolint:gosec // test reading controlled file
+	require.NoError(t, err)
+	assert.Equal(t, "package a\n", string(data))
+	data, err = os.ReadFile(filepath.Join(dir, "answer-2.py")) //nolint:gosec // test reading controlled file
+	require.NoError(t, err)
+	assert.Equal(t, "print(1)\n", string(data))
+
+	require.NoError(t, writeExtractedCode(response, sendOptions{OutputDir: dir}))
+	assert.FileExists(t, filepath.Join(dir, "response-1.go"))
+	assert.FileExists(t, filepath.Join(dir, "response-2.py"))
+
+	plain := filepath.Join(dir, "plain.md")
+	require.NoError(t, writeExtractedCode("No code here.", sendOptions{OutputFile: plain}))
+	data, err = os.ReadFile(plain) //nolint:gosec // test reading controlled file
+	require.NoError(t, err)
+	assert.Equal(t, "No code here.", string(data))
+}
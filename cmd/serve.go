@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/quantmind-br/shotgun-cli/internal/app"
+	cfgkeys "github.com/quantmind-br/shotgun-cli/internal/config"
+	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
+	"github.com/quantmind-br/shotgun-cli/internal/utils"
+)
+
+// maxServeRequestBytes bounds the JSON body of a /generate request.
+const maxServeRequestBytes = 1 << 20
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve context generation over a local HTTP API for editor integration",
+	Long: `Serve a small HTTP API on localhost so an editor can request context on demand.
+
+  POST /generate  JSON body {"include", "exclude", "template", "task", "rules"}; returns
+                  {"content", "files", "size", "tokens"} with the generated context
+  GET  /tree      the scanned file tree as JSON; ?include= and ?exclude= filter it
+
+Every request scans the root afresh with the scanner settings of the config, so
+responses reflect the files on disk and concurrent requests do not share state.
+Nothing is written to disk or copied to the clipboard.
+
+The server binds to 127.0.0.1 unless --host says otherwise. While bound to a loopback
+address it only answers requests addressed to localhost, and /generate requires a
+Content-Type of application/json, so web pages cannot query it.
+
+Examples:
+  shotgun-cli serve --root .
+  shotgun-cli serve --port 9000 --max-size 2MB
+  curl -s localhost:7878/generate -H 'Content-Type: application/json' -d '{"include":["*.go"],"task":"Review"}'`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	rootPath, _ := cmd.Flags().GetString("root")
+	host, _ := cmd.Flags().GetString("host")
+	port, _ := cmd.Flags().GetInt("port")
+	if port < 0 || port > 65535 {
+		return withExitCode(ExitUsage, fmt.Errorf("invalid --port: %d (expected 0-65535)", port))
+	}
+
+	base, err := serveBaseConfig(cmd, rootPath)
+	if err != nil {
+		return withExitCode(ExitUsage, err)
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	handler := newServeHandler(base)
+	if isLoopbackHost(host) {
+		handler = loopbackOnly(handler)
+	} else {
+		log.Warn().Str("host", host).Msg("Serving on a non-loopback address; anyone who can reach it can read the root")
+	}
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("🛰️  Serving %s on http://%s (Ctrl+C to stop)\n", base.RootPath, listener.Addr())
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("server failed: %w", err)
+	}
+
+	fmt.Println("Stopped serving.")
+	return nil
+}
+
+// serveBaseConfig returns the generation settings every request starts from: the
+// root and size limit from the flags, and the tree, summary, content and redaction
+// settings from the config.
+func serveBaseConfig(cmd *cobra.Command, rootPath string) (GenerateConfig, error) {
+	absRoot, err := filepath.Abs(rootPath)
+	if err != nil {
+		return GenerateConfig{}, fmt.Errorf("invalid root path '%s': %w", rootPath, err)
+	}
+	if info, err := os.Stat(absRoot); err != nil || !info.IsDir() {
+		return GenerateConfig{}, fmt.Errorf("root path must be an existing directory: %s", absRoot)
+	}
+
+	maxSizeStr := viper.GetString(cfgkeys.KeyContextMaxSize)
+	if cmd.Flags().Changed("max-size") {
+		maxSizeStr, _ = cmd.Flags().GetString("max-size")
+	}
+	maxSize, err := utils.ParseSize(maxSizeStr)
+	if err != nil {
+		return GenerateConfig{}, fmt.Errorf("invalid max-size format '%s': %w", maxSizeStr, err)
+	}
+
+	fileDelimiters, err := fileDelimitersFromConfig()
+	if err != nil {
+		return GenerateConfig{}, err
+	}
+
+	redact := viper.GetBool(cfgkeys.KeyContextRedact)
+	var redactPatterns []string
+	if redact {
+		if redactPatterns, err = redactPatternsFromConfig(); err != nil {
+			return GenerateConfig{}, err
+		}
+	}
+
+	return GenerateConfig{
+		RootPath:       absRoot,
+		Include:        []string{"*"},
+		MaxSize:        maxSize,
+		EnforceLimit:   true,
+		IncludeTree:    viper.GetBool(cfgkeys.KeyContextIncludeTree),
+		IncludeSummary: viper.GetBool(cfgkeys.KeyContextIncludeSummary),
+		StripComments:  viper.GetBool(cfgkeys.KeyContextStripComments),
+		Redact:         redact,
+		RedactPatterns: redactPatterns,
+		FileDelimiters: fileDelimiters,
+		InMemory:       true,
+	}, nil
+}
+
+// serveGenerateRequest is the JSON body of POST /generate.
+type serveGenerateRequest struct {
+	Include  []string `json:"include"`
+	Exclude  []string `json:"exclude"`
+	Template string   `json:"template"`
+	Task     string   `json:"task"`
+	Rules    string   `json:"rules"`
+}
+
+// serveGenerateResponse is the JSON reply to POST /generate.
+type serveGenerateResponse struct {
+	Content string `json:"content"`
+	Files   int    `json:"files"`
+	Size    int64  `json:"size"`
+	Tokens  int64  `json:"tokens"`
+}
+
+// newServeHandler routes the serve API. Each request copies base, so requests
+// share no scanner or generator state.
+func newServeHandler(base GenerateConfig) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /generate", func(w http.ResponseWriter, r *http.Request) {
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+			writeServeError(w, http.StatusUnsupportedMediaType, errors.New("content type must be application/json"))
+			return
+		}
+
+		var req serveGenerateRequest
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxServeRequestBytes))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&req); err != nil {
+			writeServeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+			return
+		}
+
+		cfg := base
+		if len(req.Include) > 0 {
+			cfg.Include = req.Include
+		}
+		cfg.Exclude = req.Exclude
+		cfg.Template = req.Template
+		cfg.Task = req.Task
+		cfg.Rules = req.Rules
+
+		result, err := runContextGeneration(cfg)
+		if err != nil {
+			writeServeError(w, serveErrorStatus(err), err)
+			return
+		}
+		writeServeJSON(w, http.StatusOK, serveGenerateResponse{
+			Content: result.Content,
+			Files:   result.FileCount,
+			Size:    result.ContentSize,
+			Tokens:  result.TokenEstimate,
+		})
+	})
+
+	mux.HandleFunc("GET /tree", func(w http.ResponseWriter, r *http.Request) {
+		cfg := base
+		if include := r.URL.Query()["include"]; len(include) > 0 {
+			cfg.Include = include
+		}
+		cfg.Exclude = r.URL.Query()["exclude"]
+
+		scanConfig := buildScannerConfig(cfg)
+		tree, err := scanner.NewFileSystemScanner().Scan(cfg.RootPath, &scanConfig)
+		if err != nil {
+			writeServeError(w, http.StatusInternalServerError, fmt.Errorf("%w: %w", app.ErrScanFailed, err))
+			return
+		}
+		writeServeJSON(w, http.StatusOK, tree)
+	})
+
+	return mux
+}
+
+// serveErrorStatus maps a generation error to an HTTP status: the size limit is the
+// request's to fix, a failed scan or render the server's, and anything else, such as
+// an unknown template, a bad request.
+func serveErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, app.ErrSizeLimit):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, app.ErrScanFailed), errors.Is(err, app.ErrGenerationFailed):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func writeServeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Debug().Err(err).Msg("Failed to write response")
+	}
+}
+
+func writeServeError(w http.ResponseWriter, status int, err error) {
+	writeServeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// loopbackOnly rejects requests whose Host header names anything but a loopback
+// address, so a page on another site cannot reach the server by DNS rebinding.
+func loopbackOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+		if !isLoopbackHost(host) {
+			writeServeError(w, http.StatusForbidden, fmt.Errorf("host %q is not served", r.Host))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isLoopbackHost reports whether host is localhost or a loopback IP address.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func init() {
+	serveCmd.Flags().StringP("root", "r", ".", "Root directory to serve context for")
+	serveCmd.Flags().String("host", "127.0.0.1", "Address to bind to; anything but loopback exposes the root")
+	serveCmd.Flags().IntP("port", "p", 7878, "Port to listen on (0 = any free port)")
+	serveCmd.Flags().String("max-size", "", "Maximum context size per request (default: context.max-size config)")
+
+	rootCmd.AddCommand(serveCmd)
+}
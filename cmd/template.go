@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,6 +13,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/quantmind-br/shotgun-cli/internal/app"
 	"github.com/quantmind-br/shotgun-cli/internal/config"
 	"github.com/quantmind-br/shotgun-cli/internal/core/template"
 )
@@ -190,6 +192,16 @@ func renderTemplate(templateName string, variables map[string]string, output str
 		return fmt.Errorf("failed to initialize template manager: %w", err)
 	}
 
+	// Git metadata of the current directory, unless given with --var
+	if tmpl, err := manager.GetTemplate(templateName); err == nil {
+		if gitVars := app.GitTemplateVars(context.Background(), ".", tmpl.Content); gitVars != nil {
+			for name, value := range variables {
+				gitVars[name] = value
+			}
+			variables = gitVars
+		}
+	}
+
 	// Pre-validate required template variables
 	requiredVars, err := manager.GetRequiredVariables(templateName)
 	if err != nil {
@@ -381,15 +393,101 @@ Examples:
 	},
 }
 
+var templateValidateCmd = &cobra.Command{
+	Use:   "validate [template-name]",
+	Short: "Check a template for placeholder problems",
+	Long: `Check a template for problems before using it to generate a context.
+
+The command lists the variables the template references, fails on unbalanced
+braces, and warns about placeholders context generation does not fill in and
+about a missing {FILE_STRUCTURE}. Validate an installed template by name, or a
+template file with --file. It exits non-zero on errors (and on warnings with
+--strict), so it can run in a pre-commit hook.
+
+Examples:
+  shotgun-cli template validate makePlan
+  shotgun-cli template validate --file ./templates/review.md
+  shotgun-cli template validate --file ./templates/review.md --strict`,
+
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filePath, _ := cmd.Flags().GetString("file")
+		strict, _ := cmd.Flags().GetBool("strict")
+
+		var label, content string
+		switch {
+		case filePath != "" && len(args) > 0:
+			return fmt.Errorf("give either a template name or --file, not both")
+		case filePath != "":
+			data, err := os.ReadFile(filePath) //nolint:gosec // user-provided path to validate
+			if err != nil {
+				return fmt.Errorf("failed to read template file: %w", err)
+			}
+			label, content = filePath, string(data)
+		case len(args) == 1:
+			manager, err := template.NewManager(template.ManagerConfig{
+				CustomPath: viper.GetString(config.KeyTemplateCustomPath),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to initialize template manager: %w", err)
+			}
+			tmpl, err := manager.GetTemplate(args[0])
+			if err != nil {
+				return fmt.Errorf("template '%s' not found", args[0])
+			}
+			label, content = args[0], tmpl.Content
+		default:
+			return fmt.Errorf("template name or --file is required")
+		}
+
+		return reportTemplateValidation(label, template.Validate(content), strict)
+	},
+}
+
+// reportTemplateValidation prints the result of validating the template called label
+// and returns an error when it has errors, or warnings in strict mode.
+func reportTemplateValidation(label string, result template.ValidationResult, strict bool) error {
+	fmt.Printf("🔍 Template: %s\n", label)
+	if len(result.Variables) > 0 {
+		fmt.Printf("   Variables: %s\n", strings.Join(result.Variables, ", "))
+	} else {
+		fmt.Println("   Variables: none")
+	}
+	for _, msg := range result.Errors {
+		fmt.Printf("❌ %s\n", msg)
+	}
+	for _, msg := range result.Warnings {
+		fmt.Printf("⚠️  %s\n", msg)
+	}
+
+	switch {
+	case !result.Valid():
+		return fmt.Errorf("template '%s' has %d error(s)", label, len(result.Errors))
+	case strict && len(result.Warnings) > 0:
+		return fmt.Errorf("template '%s' has %d warning(s)", label, len(result.Warnings))
+	case len(result.Warnings) > 0:
+		fmt.Printf("✅ Template '%s' is valid, with %d warning(s)\n", label, len(result.Warnings))
+	default:
+		fmt.Printf("✅ Template '%s' is valid\n", label)
+	}
+
+	return nil
+}
+
 func init() {
 	// Template render flags
 	templateRenderCmd.Flags().StringToString("var", nil, "Template variables (key=value pairs)")
 	templateRenderCmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
 
+	// Template validate flags
+	templateValidateCmd.Flags().StringP("file", "f", "", "Validate a template file instead of an installed template")
+	templateValidateCmd.Flags().Bool("strict", false, "Fail on warnings as well as errors")
+
 	// Add subcommands
 	templateCmd.AddCommand(templateListCmd)
 	templateCmd.AddCommand(templateRenderCmd)
 	templateCmd.AddCommand(templateImportCmd)
 	templateCmd.AddCommand(templateExportCmd)
+	templateCmd.AddCommand(templateValidateCmd)
 	rootCmd.AddCommand(templateCmd)
 }
@@ -5,6 +5,10 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/quantmind-br/shotgun-cli/internal/core/template"
 )
 
 func TestRenderTemplateWithVariables(t *testing.T) {
@@ -67,3 +71,53 @@ func TestTemplateRenderCommandErrorsForUnknown(t *testing.T) {
 		t.Fatal("expected error for unknown template")
 	}
 }
+
+func TestReportTemplateValidation(t *testing.T) {
+	valid := template.ValidationResult{Variables: []string{"FILE_STRUCTURE", "TASK"}}
+	output := captureStdout(t, func() {
+		if err := reportTemplateValidation("review", valid, true); err != nil {
+			t.Fatalf("expected valid template to pass: %v", err)
+		}
+	})
+	if !strings.Contains(output, "Variables: FILE_STRUCTURE, TASK") || !strings.Contains(output, "is valid") {
+		t.Fatalf("unexpected output: %s", output)
+	}
+
+	warned := template.ValidationResult{Warnings: []string{"unsupported placeholder {LANGUAGE}"}}
+	_ = captureStdout(t, func() {
+		if err := reportTemplateValidation("review", warned, false); err != nil {
+			t.Fatalf("expected warnings to pass without --strict: %v", err)
+		}
+		if err := reportTemplateValidation("review", warned, true); err == nil {
+			t.Fatal("expected warnings to fail with --strict")
+		}
+	})
+
+	invalid := template.ValidationResult{Errors: []string{"unmatched braces on line 1: {TASK"}}
+	_ = captureStdout(t, func() {
+		if err := reportTemplateValidation("review", invalid, false); err == nil {
+			t.Fatal("expected errors to fail validation")
+		}
+	})
+}
+
+func TestTemplateValidateCommand_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.md")
+	if err := os.WriteFile(path, []byte("{TASK\n{FILE_STRUCTURE}\n"), 0o600); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.Flags().StringP("file", "f", "", "")
+	cmd.Flags().Bool("strict", false, "")
+	_ = cmd.Flags().Set("file", path)
+
+	_ = captureStdout(t, func() {
+		if err := templateValidateCmd.RunE(cmd, nil); err == nil {
+			t.Fatal("expected unbalanced braces to fail validation")
+		}
+		if err := templateValidateCmd.RunE(cmd, []string{"makePlan"}); err == nil {
+			t.Fatal("expected a name and --file together to fail")
+		}
+	})
+}
@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/quantmind-br/shotgun-cli/internal/config"
+	"github.com/quantmind-br/shotgun-cli/internal/core/ignore"
+	"github.com/quantmind-br/shotgun-cli/internal/core/llm"
+	"github.com/quantmind-br/shotgun-cli/internal/core/template"
+	"github.com/quantmind-br/shotgun-cli/internal/ui/styles"
+)
+
+// ValidationCheck is the outcome of a single validate step.
+type ValidationCheck struct {
+	Name   string
+	Detail string
+	Err    error
+	// Optional checks report a failure as a warning that does not fail the run.
+	Optional bool
+	// Hint is the suggested fix shown when the check fails.
+	Hint string
+}
+
+// Passed reports whether the check succeeded.
+func (c ValidationCheck) Passed() bool {
+	return c.Err == nil
+}
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate configuration before a run",
+	Long: `Run a dry-run validation of the configuration without generating context.
+
+Checks that the root path is accessible, all configuration values are valid,
+the template (if given) can be loaded, the LLM provider is known, and the
+ignore files under the root can be read. Exits with an error if any check fails.
+
+Examples:
+  shotgun-cli validate --root .
+  shotgun-cli validate --root ./src --template makePlan`,
+	// A failed check is a report, not a usage error
+	SilenceUsage: true,
+	RunE:         runValidate,
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	rootPath, _ := cmd.Flags().GetString("root")
+	templateName, _ := cmd.Flags().GetString("template")
+
+	checks := runValidationChecks(rootPath, templateName)
+
+	failed := 0
+	for _, check := range checks {
+		if check.Passed() {
+			fmt.Printf("%s %s: %s\n", styles.SuccessStyle.Render("✓"), check.Name, check.Detail)
+			continue
+		}
+		failed++
+		fmt.Printf("%s %s: %v\n", styles.ErrorStyle.Render("✗"), check.Name, check.Err)
+	}
+
+	fmt.Println()
+	if failed > 0 {
+		return fmt.Errorf("validation failed: %d of %d checks failed", failed, len(checks))
+	}
+
+	fmt.Printf("All %d checks passed.\n", len(checks))
+	return nil
+}
+
+// runValidationChecks runs every validation step and returns their results in order.
+// Checks that depend on an accessible root are reported as failed when it is not.
+func runValidationChecks(rootPath, templateName string) []ValidationCheck {
+	absRoot, rootErr := checkRootPath(rootPath)
+
+	checks := []ValidationCheck{
+		{Name: "Root path", Detail: absRoot, Err: rootErr},
+		checkConfigValues(),
+		checkTemplate(templateName),
+		checkLLMProvider(),
+	}
+
+	ignoreCheck := ValidationCheck{Name: "Ignore files"}
+	if rootErr != nil {
+		ignoreCheck.Err = fmt.Errorf("skipped: root path is not accessible")
+	} else {
+		ignoreCheck = checkIgnoreFiles(absRoot)
+	}
+
+	return append(checks, ignoreCheck)
+}
+
+func checkRootPath(rootPath string) (string, error) {
+	if rootPath == "" {
+		return "", fmt.Errorf("root path cannot be empty")
+	}
+
+	absPath, err := filepath.Abs(rootPath)
+	if err != nil {
+		return rootPath, fmt.Errorf("invalid root path '%s': %w", rootPath, err)
+	}
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return absPath, fmt.Errorf("cannot access root path '%s': %w", absPath, err)
+	}
+	if !info.IsDir() {
+		return absPath, fmt.Errorf("root path must be a directory: %s", absPath)
+	}
+	if _, err := os.ReadDir(absPath); err != nil {
+		return absPath, fmt.Errorf("cannot read root path '%s': %w", absPath, err)
+	}
+
+	return absPath, nil
+}
+
+func checkConfigValues() ValidationCheck {
+	check := ValidationCheck{Name: "Configuration values"}
+
+	keys := config.ValidKeys()
+	for _, key := range keys {
+		value := viper.GetString(key)
+		if value == "" {
+			continue
+		}
+		if err := config.ValidateValue(key, value); err != nil {
+			check.Err = fmt.Errorf("%s: %w", key, err)
+			return check
+		}
+	}
+
+	check.Detail = fmt.Sprintf("%d keys valid", len(keys))
+	return check
+}
+
+func checkTemplate(templateName string) ValidationCheck {
+	check := ValidationCheck{Name: "Template"}
+
+	tmplMgr, err := template.NewManager(template.ManagerConfig{
+		CustomPath: viper.GetString(config.KeyTemplateCustomPath),
+	})
+	if err != nil {
+		check.Err = fmt.Errorf("failed to initialize template manager: %w", err)
+		return check
+	}
+
+	if templateName == "" {
+		check.Detail = fmt.Sprintf("%d templates available", len(tmplMgr.GetTemplateNames()))
+		return check
+	}
+
+	if _, err := tmplMgr.GetTemplate(templateName); err != nil {
+		check.Err = fmt.Errorf("failed to load template %q: %w", templateName, err)
+		return check
+	}
+
+	check.Detail = templateName
+	return check
+}
+
+func checkLLMProvider() ValidationCheck {
+	check := ValidationCheck{Name: "LLM provider"}
+
+	provider := viper.GetString(config.KeyLLMProvider)
+	if !llm.IsValidProvider(provider) {
+		check.Err = fmt.Errorf("invalid provider %q", provider)
+		return check
+	}
+
+	check.Detail = provider
+	return check
+}
+
+func checkIgnoreFiles(rootPath string) ValidationCheck {
+	check := ValidationCheck{Name: "Ignore files"}
+
+	engine := ignore.NewIgnoreEngine()
+	if viper.GetBool(config.KeyScannerRespectGitignore) {
+		if err := engine.LoadGitignore(rootPath); err != nil {
+			check.Err = fmt.Errorf("failed to load gitignore rules: %w", err)
+			return check
+		}
+	}
+	if viper.GetBool(config.KeyScannerRespectShotgunignore) {
+		if err := engine.LoadShotgunignore(rootPath); err != nil {
+			check.Err = fmt.Errorf("failed to load shotgunignore rules: %w", err)
+			return check
+		}
+	}
+	if viper.GetBool(config.KeyScannerRespectGitattributes) {
+		if err := engine.LoadGitattributes(rootPath); err != nil {
+			check.Err = fmt.Errorf("failed to load gitattributes rules: %w", err)
+			return check
+		}
+	}
+	if viper.GetBool(config.KeyScannerAllowlistMode) {
+		if err := engine.LoadShotgunkeep(rootPath); err != nil {
+			check.Err = fmt.Errorf("failed to load shotgunkeep rules: %w", err)
+			return check
+		}
+		if engine.HasKeepRules() {
+			check.Detail = "OK (allowlist from .shotgunkeep)"
+			return check
+		}
+	}
+
+	check.Detail = "OK"
+	return check
+}
+
+func init() {
+	validateCmd.Flags().StringP("root", "r", ".", "Root directory to validate")
+	validateCmd.Flags().StringP("template", "t", "", "Template name to check (e.g., makePlan)")
+
+	rootCmd.AddCommand(validateCmd)
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quantmind-br/shotgun-cli/internal/config"
+)
+
+func findCheck(t *testing.T, checks []ValidationCheck, name string) ValidationCheck {
+	t.Helper()
+	for _, check := range checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	t.Fatalf("check %q not found", name)
+	return ValidationCheck{}
+}
+
+func TestRunValidationChecks_AllPass(t *testing.T) {
+	viper.Reset()
+	viper.Set(config.KeyLLMProvider, "anthropic")
+	viper.Set(config.KeyScannerRespectGitignore, true)
+
+	checks := runValidationChecks(t.TempDir(), "")
+
+	require.Len(t, checks, 5)
+	for _, check := range checks {
+		assert.True(t, check.Passed(), "%s: %v", check.Name, check.Err)
+	}
+}
+
+func TestRunValidationChecks_MissingTemplate(t *testing.T) {
+	viper.Reset()
+	viper.Set(config.KeyLLMProvider, "openai")
+
+	checks := runValidationChecks(t.TempDir(), "doesNotExist")
+
+	tmpl := findCheck(t, checks, "Template")
+	require.Error(t, tmpl.Err)
+	assert.Contains(t, tmpl.Err.Error(), "doesNotExist")
+	assert.True(t, findCheck(t, checks, "Root path").Passed())
+}
+
+func TestRunValidationChecks_InaccessibleRoot(t *testing.T) {
+	viper.Reset()
+	viper.Set(config.KeyLLMProvider, "openai")
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	checks := runValidationChecks(missing, "")
+
+	root := findCheck(t, checks, "Root path")
+	require.Error(t, root.Err)
+	assert.Contains(t, root.Err.Error(), "cannot access root path")
+
+	ignoreCheck := findCheck(t, checks, "Ignore files")
+	require.Error(t, ignoreCheck.Err)
+	assert.Contains(t, ignoreCheck.Err.Error(), "skipped")
+}
+
+func TestRunValidationChecks_InvalidProviderAndConfig(t *testing.T) {
+	viper.Reset()
+	viper.Set(config.KeyLLMProvider, "bogus")
+	viper.Set(config.KeyScannerWorkers, 99)
+
+	checks := runValidationChecks(t.TempDir(), "")
+
+	assert.Error(t, findCheck(t, checks, "LLM provider").Err)
+	cfgCheck := findCheck(t, checks, "Configuration values")
+	require.Error(t, cfgCheck.Err)
+	assert.Contains(t, cfgCheck.Err.Error(), config.KeyScannerWorkers)
+}
+
+func TestRunValidate_ReturnsErrorOnFailure(t *testing.T) {
+	viper.Reset()
+	viper.Set(config.KeyLLMProvider, "openai")
+
+	cmd := validateCmd
+	t.Cleanup(func() {
+		_ = cmd.Flags().Set("root", ".")
+	})
+	require.NoError(t, cmd.Flags().Set("root", filepath.Join(t.TempDir(), "missing")))
+
+	err := runValidate(cmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "validation failed")
+}
@@ -2,11 +2,14 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"text/template"
 	"time"
 
+	"github.com/quantmind-br/shotgun-cli/internal/core/contextgen"
 	"github.com/quantmind-br/shotgun-cli/internal/core/llm"
 	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
 )
@@ -45,11 +48,49 @@ type LLMSendConfig struct {
 	Timeout      int
 	SaveResponse bool
 	OutputPath   string
+	// Proxy, CACert and InsecureSkipVerify configure the connection (see llm.Config)
+	Proxy              string
+	CACert             string
+	InsecureSkipVerify bool
+	// OnDelta, when set, streams the response: it is called with each piece of
+	// text as it arrives and takes precedence over the progress callback.
+	OnDelta func(delta string)
 }
 
 // LLMProgressCallback is a function type for receiving progress updates during LLM operations.
 type LLMProgressCallback func(stage string)
 
+// ClipboardMode selects what is copied to the clipboard after generation.
+type ClipboardMode string
+
+const (
+	// ClipboardContent copies the generated context (the default).
+	ClipboardContent ClipboardMode = "content"
+	// ClipboardPath copies the absolute path of the output file.
+	ClipboardPath ClipboardMode = "path"
+	// ClipboardNone copies nothing.
+	ClipboardNone ClipboardMode = "none"
+)
+
+// ClipboardText returns the text to copy for mode, or false when nothing should be
+// copied. Path mode without an output file falls back to the content.
+func ClipboardText(mode ClipboardMode, content, outputPath string) (string, bool) {
+	switch mode {
+	case ClipboardNone:
+		return "", false
+	case ClipboardPath:
+		if outputPath == "" {
+			return content, true
+		}
+		if abs, err := filepath.Abs(outputPath); err == nil {
+			return abs, true
+		}
+		return outputPath, true
+	default:
+		return content, true
+	}
+}
+
 // GenerateConfig holds configuration for the context generation process.
 type GenerateConfig struct {
 	RootPath        string
@@ -60,10 +101,72 @@ type GenerateConfig struct {
 	MaxSize         int64
 	EnforceLimit    bool
 	OutputPath      string
+	OutputDir       string // Directory for relative output paths (empty = current directory)
 	CopyToClipboard bool
+	ClipboardMode   ClipboardMode // What CopyToClipboard copies (empty = content)
+	// VerifyClipboard reads a clipboard copy back and sets GenerateResult.ClipboardMismatch
+	// when the clipboard holds something else, e.g. a copy truncated by the backend.
+	VerifyClipboard bool
 	IncludeTree     bool
 	IncludeSummary  bool
 	SkipBinary      bool
+	StripComments   bool
+	FoldSiblings    bool // Fold many similarly named sibling files to a sample and a count
+	TreeDepth       int  // Collapse tree directories deeper than this to "dir/ (...)" (0 = no limit)
+	// FileDelimiters are the lines written around each file (nil = defaults).
+	FileDelimiters *contextgen.FileDelimiters
+	// Dedup embeds the content of identical files once, referencing it from the
+	// copies; it turns on ScanConfig.ComputeHashes.
+	Dedup bool
+	// ContentMaxSize keeps larger files in the tree but embeds a "[content omitted: ...]"
+	// placeholder instead of their content (0 = no limit).
+	ContentMaxSize int64
+	// PerFileMaxTokens embeds only the head and tail of files estimated over this many
+	// tokens, listing them in GenerateResult.Truncated (0 = no limit).
+	PerFileMaxTokens int
+	// Summarize lists patterns (see scanner.MatchesPattern) of files embedded as a
+	// one-line summary of size and first declaration instead of their content.
+	Summarize []string
+	// Priority decides which files are kept whole when the contents exceed MaxSize;
+	// the rest are listed as omitted. Empty fails generation instead.
+	Priority contextgen.Priority
+	// Order is the sequence of the embedded file blocks (empty = tree order);
+	// CustomOrder lists the relative paths for contextgen.OrderCustom.
+	Order       contextgen.Order
+	CustomOrder []string
+	// RawContext emits only the tree and file contents, skipping the template
+	// and any task/rules framing.
+	RawContext bool
+	// Transforms rewrite each file's content before it is rendered; they run
+	// after redaction and ahead of comment stripping.
+	Transforms []contextgen.ContentTransform
+	// Redact replaces secrets in the embedded files with contextgen.RedactedMarker,
+	// matching contextgen.DefaultRedactPatterns and the RedactPatterns regexes, and
+	// lists the files in GenerateResult.Redacted.
+	Redact         bool
+	RedactPatterns []string
+	// TemplateFuncs are extra functions available to the template, typically
+	// template.Manager.FuncMap().
+	TemplateFuncs template.FuncMap
+	// SkipOutputFile disables writing the output file; the content is copied
+	// to the clipboard instead and generation fails if that copy fails.
+	SkipOutputFile bool
+	// InMemory returns the content in the result only: no output file, manifest or
+	// clipboard copy is written, for callers that hand the content on themselves.
+	InMemory bool
+	// Manifest writes a manifest next to the output file (see ManifestPath), from
+	// which the template can be rendered again without rescanning.
+	Manifest bool
+	// SkipPaths lists slash-separated relative paths left out of the selection, such
+	// as the files a context being appended to already embeds.
+	SkipPaths map[string]bool
+	// ConfirmSelection, when set, is called with the selected files after the scan and
+	// before any is read. It returns slash-separated relative paths to leave out, or an
+	// error to stop generation.
+	ConfirmSelection func(files []*scanner.FileNode) (map[string]bool, error)
+	// Strict fails generation on the first unreadable file. Otherwise such files
+	// are embedded as a placeholder and listed in GenerateResult.Unreadable.
+	Strict bool
 }
 
 // GenerateResult represents the result of a context generation operation.
@@ -74,8 +177,56 @@ type GenerateResult struct {
 	ContentSize       int64
 	TokenEstimate     int64
 	CopiedToClipboard bool
+	// ClipboardMode is the mode used for the clipboard copy.
+	ClipboardMode ClipboardMode
+	// ClipboardSize is the number of bytes copied to the clipboard.
+	ClipboardSize int64
+	// ClipboardMismatch reports that GenerateConfig.VerifyClipboard read back something
+	// other than what was copied. A clipboard that cannot be read back is not a mismatch.
+	ClipboardMismatch bool
+	// CommentBytesSaved is the number of bytes removed by comment stripping.
+	CommentBytesSaved int64
+	// FilesFilteredByTime is the number of files excluded by ScanConfig.ModifiedSince.
+	FilesFilteredByTime int64
+	// IgnoredEntries is the number of files and directories skipped by ignore rules.
+	IgnoredEntries int64
+	// Inaccessible lists the relative paths of directories the scan skipped because they
+	// could not be read, e.g. permission denied.
+	Inaccessible []string
+	// DedupFiles is the number of identical files replaced by a reference.
+	DedupFiles int
+	// DedupBytesSaved is the number of bytes deduplication kept out of the context.
+	DedupBytesSaved int64
+	// DedupTokensSaved is the estimated number of tokens deduplication saved.
+	DedupTokensSaved int64
+	// SummarizedFiles is the number of files embedded as a summary, with Summarize set,
+	// and EmbeddedFiles the number embedded in full alongside them.
+	SummarizedFiles int
+	EmbeddedFiles   int
+	// Truncated lists the files cut to their head and tail by PerFileMaxTokens.
+	Truncated []contextgen.TruncatedFile
+	// SkippedFiles is the number of selected files left out by SkipPaths.
+	SkippedFiles int
+	// Redacted lists the files secrets were redacted from, with Redact set.
+	Redacted []contextgen.RedactedFile
+	// Unreadable lists the selected files embedded as a placeholder because they could not be read.
+	Unreadable []contextgen.UnreadableFile
+	// ManifestPath is the manifest written with Manifest set, if any.
+	ManifestPath string
+	// Tree is the scanned file tree and Selections the paths of it that were included.
+	Tree       *scanner.FileNode
+	Selections map[string]bool
 }
 
+// Errors returned by GenerateWithProgress wrap one of these, so callers can tell
+// with errors.Is which stage failed. A size limit failure during generation also
+// matches ErrGenerationFailed.
+var (
+	ErrScanFailed       = errors.New("scan failed")
+	ErrGenerationFailed = errors.New("generation failed")
+	ErrSizeLimit        = contextgen.ErrSizeLimitExceeded
+)
+
 // ProgressCallback is a function type for receiving detailed progress updates
 // including current/total counts and stage messages.
 type ProgressCallback func(stage string, message string, current, total int64)
@@ -110,3 +261,27 @@ func (c *GenerateConfig) GenerateOutputPath() string {
 	timestamp := time.Now().Format("20060102-150405")
 	return fmt.Sprintf("shotgun-prompt-%s.md", timestamp)
 }
+
+// ResponseFileName returns the file name for a saved LLM response: base followed by a
+// timestamp, e.g. llm-response-20240501-120000.md, so repeated sends keep every
+// response. With overwrite it is just base plus ".md", replaced by each send.
+func ResponseFileName(base string, overwrite bool, now time.Time) string {
+	if overwrite {
+		return base + ".md"
+	}
+	return fmt.Sprintf("%s-%s.md", base, now.Format("20060102-150405"))
+}
+
+// ResolveOutputPath places a relative path inside outputDir, creating the directory
+// if it does not exist. An empty outputDir or an absolute path is returned unchanged.
+func ResolveOutputPath(outputDir, path string) (string, error) {
+	if outputDir == "" || filepath.IsAbs(path) {
+		return path, nil
+	}
+
+	if err := os.MkdirAll(outputDir, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	return filepath.Join(outputDir, path), nil
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -86,6 +87,31 @@ func TestGenerateConfig_GenerateOutputPath_TimestampFormat(t *testing.T) {
 	assert.Regexp(t, `shotgun-prompt-\d{8}-\d{6}\.md`, path)
 }
 
+func TestResponseFileName(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 30, 45, 0, time.UTC)
+
+	assert.Equal(t, "llm-response-20240501-123045.md", ResponseFileName("llm-response", false, now))
+	assert.Equal(t, "llm-response.md", ResponseFileName("llm-response", true, now))
+}
+
+func TestResolveOutputPath(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "prompts", "nested")
+
+	path, err := ResolveOutputPath(dir, "out.md")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "out.md"), path)
+	assert.DirExists(t, dir)
+
+	abs := filepath.Join(t.TempDir(), "abs.md")
+	path, err = ResolveOutputPath(dir, abs)
+	require.NoError(t, err)
+	assert.Equal(t, abs, path)
+
+	path, err = ResolveOutputPath("", "out.md")
+	require.NoError(t, err)
+	assert.Equal(t, "out.md", path)
+}
+
 func TestGenerateResult_Structure(t *testing.T) {
 	result := GenerateResult{
 		Content:           "test content",
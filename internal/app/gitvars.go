@@ -0,0 +1,42 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	"github.com/quantmind-br/shotgun-cli/internal/core/template"
+	"github.com/quantmind-br/shotgun-cli/internal/platform/git"
+)
+
+// GitTemplateVars returns the GIT_BRANCH, GIT_COMMIT, GIT_STATUS and GIT_DIFF_STAT
+// template variables for the repository at root. They are empty outside a repository
+// or without git. It returns nil, without running git, when templateContent uses none
+// of them.
+func GitTemplateVars(ctx context.Context, root, templateContent string) map[string]string {
+	used := false
+	for _, name := range template.GitVars {
+		if strings.Contains(templateContent, "{"+name+"}") {
+			used = true
+			break
+		}
+	}
+	if !used {
+		return nil
+	}
+
+	vars := make(map[string]string, len(template.GitVars))
+	for _, name := range template.GitVars {
+		vars[name] = ""
+	}
+	if !git.IsAvailable() || !git.IsRepository(ctx, root) {
+		return vars
+	}
+
+	// A detached HEAD has no branch and a new repository no commit; those stay empty
+	vars[template.VarGitBranch], _ = git.CurrentBranch(ctx, root)
+	vars[template.VarGitCommit], _ = git.LastCommit(ctx, root)
+	vars[template.VarGitStatus], _ = git.ShortStatus(ctx, root)
+	vars[template.VarGitDiffStat], _ = git.DiffStat(ctx, root)
+
+	return vars
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quantmind-br/shotgun-cli/internal/platform/git"
+)
+
+func TestGitTemplateVars_Unused(t *testing.T) {
+	assert.Nil(t, GitTemplateVars(context.Background(), t.TempDir(), "Task: {TASK}"))
+}
+
+func TestGitTemplateVars_NotRepository(t *testing.T) {
+	vars := GitTemplateVars(context.Background(), t.TempDir(), "Branch: {GIT_BRANCH}")
+
+	assert.Equal(t, map[string]string{
+		"GIT_BRANCH":    "",
+		"GIT_COMMIT":    "",
+		"GIT_STATUS":    "",
+		"GIT_DIFF_STAT": "",
+	}, vars)
+}
+
+func TestGitTemplateVars_Repository(t *testing.T) {
+	if !git.IsAvailable() {
+		t.Skip("git not available in this environment")
+	}
+
+	dir := t.TempDir()
+	runGit := func(args ...string) {
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	runGit("init", "-q", "-b", "feature")
+	runGit("config", "user.email", "test@example.com")
+	runGit("config", "user.name", "test")
+	runGit("config", "commit.gpgsign", "false")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.go"), []byte("package a\n"), 0o600))
+	runGit("add", ".")
+	runGit("commit", "-q", "-m", "Add a")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.go"), []byte("package a\n\nfunc A() {}\n"), 0o600))
+
+	vars := GitTemplateVars(context.Background(), dir, "{GIT_COMMIT}")
+
+	assert.Equal(t, "feature", vars["GIT_BRANCH"])
+	assert.Regexp(t, `^[0-9a-f]+ Add a$`, vars["GIT_COMMIT"])
+	assert.Equal(t, " M a.go", vars["GIT_STATUS"])
+	assert.Contains(t, vars["GIT_DIFF_STAT"], "1 file changed")
+}
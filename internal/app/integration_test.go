@@ -43,6 +43,11 @@ func (m *integrationMockProvider) SendWithProgress(ctx context.Context, content
 	return m.sendResult, m.sendErr
 }
 
+func (m *integrationMockProvider) SendStream(ctx context.Context, content string, onDelta func(delta string)) (*llm.Result, error) {
+	m.callCount++
+	return m.sendResult, m.sendErr
+}
+
 func TestLLMFlow_EndToEnd(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/quantmind-br/shotgun-cli/internal/core/contextgen"
+)
+
+// manifestSuffix replaces the extension of an output file to name its manifest.
+const manifestSuffix = ".manifest.json"
+
+// ManifestPath returns the path of the manifest written next to outputPath, e.g.
+// shotgun-prompt-20240501-120000.manifest.json for shotgun-prompt-20240501-120000.md.
+// A path that already names a manifest is returned unchanged.
+func ManifestPath(outputPath string) string {
+	if strings.HasSuffix(outputPath, manifestSuffix) {
+		return outputPath
+	}
+	return strings.TrimSuffix(outputPath, filepath.Ext(outputPath)) + manifestSuffix
+}
+
+// WriteManifest writes manifest to path as JSON.
+func WriteManifest(path string, manifest *contextgen.Manifest) error {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// ReadManifest reads the manifest of a generated context. path is either the
+// manifest itself or the output file it was written next to.
+func ReadManifest(path string) (*contextgen.Manifest, error) {
+	manifestPath := ManifestPath(path)
+	data, err := os.ReadFile(manifestPath) //nolint:gosec // path is provided by the user
+	if errors.Is(err, os.ErrNotExist) && manifestPath != path {
+		return nil, fmt.Errorf("no manifest found for %s (expected %s; generate it with --manifest)",
+			path, manifestPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var manifest contextgen.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", manifestPath, err)
+	}
+
+	return &manifest, nil
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quantmind-br/shotgun-cli/internal/core/contextgen"
+)
+
+func TestManifestPath(t *testing.T) {
+	assert.Equal(t, "out/prompt.manifest.json", ManifestPath("out/prompt.md"))
+	assert.Equal(t, "prompt.manifest.json", ManifestPath("prompt"))
+	assert.Equal(t, "prompt.manifest.json", ManifestPath("prompt.manifest.json"))
+}
+
+func TestManifestRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "prompt.md")
+	manifest := &contextgen.Manifest{
+		Version:       contextgen.ManifestVersion,
+		Template:      "{TASK}\n{FILE_STRUCTURE}",
+		TemplateVars:  map[string]string{"TASK": "task"},
+		FileStructure: "<file path=\"main.go\">\npackage main\n</file>\n",
+		Files:         []contextgen.FileContent{{RelPath: "main.go", Content: "package main\n"}},
+	}
+	require.NoError(t, WriteManifest(ManifestPath(output), manifest))
+
+	// Either the output or the manifest path finds it
+	for _, path := range []string{output, ManifestPath(output)} {
+		got, err := ReadManifest(path)
+		require.NoError(t, err)
+		assert.Equal(t, manifest.TemplateVars, got.TemplateVars)
+		assert.Equal(t, manifest.FileStructure, got.FileStructure)
+		assert.Equal(t, "main.go", got.Files[0].RelPath)
+	}
+}
+
+func TestReadManifest_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := ReadManifest(filepath.Join(dir, "missing.md"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--manifest")
+
+	bad := filepath.Join(dir, "bad.manifest.json")
+	require.NoError(t, os.WriteFile(bad, []byte("{"), 0o600))
+	_, err = ReadManifest(bad)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid manifest")
+}
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
+)
+
+// SelectionFileName is the name the wizard exports the current selection under.
+const SelectionFileName = "shotgun-selection.json"
+
+// SelectionFile is the JSON form of a file selection, exported from the wizard and
+// read back by `context generate --selection`. Files are slash-separated paths
+// relative to the scanned root.
+type SelectionFile struct {
+	Root  string   `json:"root,omitempty"`
+	Files []string `json:"files"`
+}
+
+// NewSelectionFile describes the selected files of tree, sorted by relative path.
+func NewSelectionFile(tree *scanner.FileNode, selections map[string]bool) SelectionFile {
+	selection := SelectionFile{Files: []string{}}
+	if tree != nil {
+		selection.Root = tree.Path
+	}
+	for _, node := range scanner.SelectedFiles(tree, selections) {
+		selection.Files = append(selection.Files, filepath.ToSlash(node.RelPath))
+	}
+
+	return selection
+}
+
+// WriteSelectionFile writes the selected files of tree to path as JSON and returns
+// how many were written.
+func WriteSelectionFile(path string, tree *scanner.FileNode, selections map[string]bool) (int, error) {
+	selection := NewSelectionFile(tree, selections)
+
+	data, err := json.MarshalIndent(selection, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode selection: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return 0, fmt.Errorf("failed to write selection file: %w", err)
+	}
+
+	return len(selection.Files), nil
+}
+
+// ReadSelectionOrder returns the files of the selection at path in the order it lists
+// them, as slash-separated paths relative to the root, for contextgen.OrderCustom.
+func ReadSelectionOrder(path string) ([]string, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read selection file: %w", err)
+	}
+
+	var selection SelectionFile
+	if err := json.Unmarshal(data, &selection); err != nil {
+		return nil, fmt.Errorf("invalid selection file %s: %w", path, err)
+	}
+
+	order := make([]string, len(selection.Files))
+	for i, rel := range selection.Files {
+		order[i] = filepath.ToSlash(filepath.Clean(filepath.FromSlash(rel)))
+	}
+
+	return order, nil
+}
+
+// ReadSelectionFile reads a selection written by WriteSelectionFile and returns it as
+// selections under rootPath, keyed by absolute path. Every file must be inside
+// rootPath and exist as a regular file.
+func ReadSelectionFile(path, rootPath string) (map[string]bool, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read selection file: %w", err)
+	}
+
+	var selection SelectionFile
+	if err := json.Unmarshal(data, &selection); err != nil {
+		return nil, fmt.Errorf("invalid selection file %s: %w", path, err)
+	}
+	if len(selection.Files) == 0 {
+		return nil, fmt.Errorf("selection file %s lists no files", path)
+	}
+
+	selections := make(map[string]bool, len(selection.Files))
+	var missing []string
+	for _, rel := range selection.Files {
+		local := filepath.FromSlash(rel)
+		if !filepath.IsLocal(local) {
+			return nil, fmt.Errorf("selection file %s: %q is not a path inside the root", path, rel)
+		}
+
+		abs := filepath.Join(rootPath, local)
+		info, err := os.Stat(abs)
+		if err != nil || !info.Mode().IsRegular() {
+			missing = append(missing, rel)
+			continue
+		}
+		selections[abs] = true
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("selection file %s lists %d files not found under %s: %s",
+			path, len(missing), rootPath, strings.Join(missing, ", "))
+	}
+
+	return selections, nil
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
+)
+
+func TestSelectionFileRoundTrip(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "pkg"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "pkg", "util.go"), []byte("package pkg\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "skip.txt"), []byte("not selected\n"), 0o600))
+
+	tree, err := scanner.NewFileSystemScanner().Scan(root, scanner.DefaultScanConfig())
+	require.NoError(t, err)
+
+	selections := scanner.NewSelectAll(tree)
+	delete(selections, filepath.Join(root, "skip.txt"))
+
+	path := filepath.Join(t.TempDir(), SelectionFileName)
+	count, err := WriteSelectionFile(path, tree, selections)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"pkg/util.go"`)
+
+	restored, err := ReadSelectionFile(path, root)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		filepath.Join(root, "main.go"):        true,
+		filepath.Join(root, "pkg", "util.go"): true,
+	}, restored)
+}
+
+func TestReadSelectionFile_Errors(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n"), 0o600))
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"invalid json", `{"files": [`, "invalid selection file"},
+		{"no files", `{"files": []}`, "lists no files"},
+		{"missing file", `{"files": ["main.go", "gone.go"]}`, "1 files not found"},
+		{"escapes root", `{"files": ["../main.go"]}`, "not a path inside the root"},
+		{"absolute path", `{"files": ["/etc/passwd"]}`, "not a path inside the root"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "selection.json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			_, err := ReadSelectionFile(path, root)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestReadSelectionOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), SelectionFileName)
+	require.NoError(t, os.WriteFile(path, []byte(`{"files": ["pkg/util.go", "./main.go", "docs//README.md"]}`), 0o600))
+
+	order, err := ReadSelectionOrder(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pkg/util.go", "main.go", "docs/README.md"}, order)
+
+	_, err = ReadSelectionOrder(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/quantmind-br/shotgun-cli/internal/core/contextgen"
 	"github.com/quantmind-br/shotgun-cli/internal/core/llm"
@@ -19,6 +20,8 @@ type DefaultContextService struct {
 	scanner   scanner.Scanner
 	generator contextgen.ContextGenerator
 	registry  *llm.Registry
+	copyText  func(text string) error
+	verify    func(expected string) (bool, error)
 }
 
 // ServiceOption defines a functional option for configuring the DefaultContextService.
@@ -31,6 +34,8 @@ func NewContextService(opts ...ServiceOption) *DefaultContextService {
 		scanner:   scanner.NewFileSystemScanner(),
 		generator: contextgen.NewDefaultContextGenerator(),
 		registry:  DefaultProviderRegistry,
+		copyText:  clipboard.Copy,
+		verify:    clipboard.Verify,
 	}
 	for _, opt := range opts {
 		opt(svc)
@@ -59,6 +64,21 @@ func WithGenerator(g contextgen.ContextGenerator) ServiceOption {
 	}
 }
 
+// WithClipboard configures the function used to copy text to the clipboard.
+func WithClipboard(copyText func(text string) error) ServiceOption {
+	return func(svc *DefaultContextService) {
+		svc.copyText = copyText
+	}
+}
+
+// WithClipboardVerify configures the function used to read a clipboard copy back
+// when GenerateConfig.VerifyClipboard is set.
+func WithClipboardVerify(verify func(expected string) (bool, error)) ServiceOption {
+	return func(svc *DefaultContextService) {
+		svc.verify = verify
+	}
+}
+
 // Generate generates a codebase context synchronously.
 // It delegates to GenerateWithProgress with a nil callback.
 func (s *DefaultContextService) Generate(ctx context.Context, cfg GenerateConfig) (*GenerateResult, error) {
@@ -72,8 +92,8 @@ func (s *DefaultContextService) Generate(ctx context.Context, cfg GenerateConfig
 // 3. Applies selections (defaulting to all if none provided)
 // 4. Generates context content (reporting progress)
 // 5. Enforces size limits
-// 6. Saves output to file
-// 7. Optionally copies to clipboard
+// 6. Saves output to file (unless SkipOutputFile or InMemory is set)
+// 7. Optionally copies to clipboard (required when SkipOutputFile is set, skipped when InMemory is)
 func (s *DefaultContextService) GenerateWithProgress(
 	ctx context.Context,
 	cfg GenerateConfig,
@@ -93,6 +113,11 @@ func (s *DefaultContextService) GenerateWithProgress(
 	if scanConfig == nil {
 		scanConfig = scanner.DefaultScanConfig()
 	}
+	if cfg.Dedup && !scanConfig.ComputeHashes {
+		hashed := *scanConfig
+		hashed.ComputeHashes = true
+		scanConfig = &hashed
+	}
 
 	report("scanning", "Scanning files...", 0, 0)
 
@@ -118,23 +143,88 @@ func (s *DefaultContextService) GenerateWithProgress(
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("scan failed: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrScanFailed, err)
 	}
 
 	selections := cfg.Selections
 	if selections == nil {
-		selections = scanner.NewSelectAll(tree)
+		if scanConfig.IncludeIgnored {
+			selections = scanner.CollectAllSelections(tree, nil)
+		} else {
+			selections = scanner.NewSelectAll(tree)
+		}
+	}
+	skipped := 0
+	if len(cfg.SkipPaths) > 0 {
+		selections, skipped = skipSelectedPaths(tree, selections, cfg.SkipPaths)
+	}
+	if cfg.ConfirmSelection != nil {
+		exclude, err := cfg.ConfirmSelection(scanner.SelectedFiles(tree, selections))
+		if err != nil {
+			return nil, err
+		}
+		if len(exclude) > 0 {
+			selections, _ = skipSelectedPaths(tree, selections, exclude)
+		}
 	}
 
 	report("generating", "Generating context...", 0, 0)
 
 	genConfig := contextgen.GenerateConfig{
 		MaxTotalSize:   cfg.MaxSize,
+		AllowOverflow:  !cfg.EnforceLimit,
+		ContentMaxSize: cfg.ContentMaxSize,
 		TemplateVars:   cfg.TemplateVars,
 		Template:       cfg.Template,
 		SkipBinary:     cfg.SkipBinary,
 		IncludeTree:    cfg.IncludeTree,
 		IncludeSummary: cfg.IncludeSummary,
+		IncludeIgnored: scanConfig.IncludeIgnored,
+		FoldSiblings:   cfg.FoldSiblings,
+		TreeDepth:      cfg.TreeDepth,
+		FileDelimiters: cfg.FileDelimiters,
+		Raw:            cfg.RawContext,
+		Strict:         cfg.Strict,
+		Unreadable:     contextgen.NewUnreadableReport(),
+		Truncated:      contextgen.NewTruncationReport(),
+		Priority:       cfg.Priority,
+		Order:          cfg.Order,
+		CustomOrder:    cfg.CustomOrder,
+		Funcs:          cfg.TemplateFuncs,
+
+		PerFileMaxTokens: cfg.PerFileMaxTokens,
+	}
+
+	var dedup *contextgen.Deduplicator
+	if cfg.Dedup {
+		dedup = contextgen.NewDeduplicator()
+		genConfig.Dedup = dedup
+	}
+
+	writeOutput := !cfg.SkipOutputFile && !cfg.InMemory
+	if cfg.Manifest && writeOutput {
+		genConfig.Manifest = contextgen.NewManifestRecorder()
+	}
+
+	var summarizer *contextgen.Summarizer
+	if len(cfg.Summarize) > 0 {
+		summarizer = contextgen.NewSummarizer(cfg.Summarize)
+		genConfig.Summarizer = summarizer
+	}
+
+	var redactor *contextgen.Redactor
+	if cfg.Redact {
+		if redactor, err = contextgen.NewRedactor(cfg.RedactPatterns); err != nil {
+			return nil, err
+		}
+		genConfig.Redactor = redactor
+	}
+	genConfig.Transforms = append(genConfig.Transforms, cfg.Transforms...)
+
+	var stripper *tokens.CommentStripper
+	if cfg.StripComments {
+		stripper = tokens.NewCommentStripper()
+		genConfig.Transforms = append(genConfig.Transforms, stripper.Transform)
 	}
 
 	var content string
@@ -147,38 +237,126 @@ func (s *DefaultContextService) GenerateWithProgress(
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("generation failed: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrGenerationFailed, err)
 	}
 
 	contentSize := int64(len(content))
 	if cfg.EnforceLimit && cfg.MaxSize > 0 && contentSize > cfg.MaxSize {
-		return nil, fmt.Errorf("content size (%d) exceeds limit (%d)", contentSize, cfg.MaxSize)
+		return nil, fmt.Errorf("%w: content size (%d) exceeds limit (%d)", ErrSizeLimit, contentSize, cfg.MaxSize)
 	}
 
-	report("saving", "Saving output...", 0, 0)
+	var outputPath string
+	if writeOutput {
+		report("saving", "Saving output...", 0, 0)
+
+		outputPath, err = ResolveOutputPath(cfg.OutputDir, cfg.GenerateOutputPath())
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(outputPath, []byte(content), 0600); err != nil {
+			return nil, fmt.Errorf("failed to save output: %w", err)
+		}
+	}
 
-	outputPath := cfg.GenerateOutputPath()
-	if err := os.WriteFile(outputPath, []byte(content), 0600); err != nil {
-		return nil, fmt.Errorf("failed to save output: %w", err)
+	var manifestPath string
+	if manifest := genConfig.Manifest.Manifest(); manifest != nil {
+		manifestPath = ManifestPath(outputPath)
+		if err := WriteManifest(manifestPath, manifest); err != nil {
+			return nil, err
+		}
 	}
 
-	copied := false
-	if cfg.CopyToClipboard {
-		if err := clipboard.Copy(content); err == nil {
-			copied = true
+	copied, mismatch := false, false
+	var copiedSize int64
+	switch {
+	case cfg.InMemory:
+		// The caller hands the content on itself
+	case cfg.SkipOutputFile:
+		if err := s.copyText(content); err != nil {
+			return nil, fmt.Errorf("failed to copy to clipboard with no output file: %w", err)
+		}
+		copied, copiedSize = true, int64(len(content))
+		mismatch = cfg.VerifyClipboard && s.clipboardMismatch(content)
+	case cfg.CopyToClipboard:
+		if text, ok := ClipboardText(cfg.ClipboardMode, content, outputPath); ok && s.copyText(text) == nil {
+			copied, copiedSize = true, int64(len(text))
+			mismatch = cfg.VerifyClipboard && s.clipboardMismatch(text)
 		}
 	}
 
 	report("complete", "Done", 1, 1)
 
-	return &GenerateResult{
-		Content:           content,
-		OutputPath:        outputPath,
-		FileCount:         tree.CountFiles(),
-		ContentSize:       contentSize,
-		TokenEstimate:     int64(tokens.EstimateFromBytes(contentSize)),
-		CopiedToClipboard: copied,
-	}, nil
+	var bytesSaved int64
+	if stripper != nil {
+		bytesSaved = stripper.BytesSaved()
+	}
+
+	var stats scanner.ScanStats
+	if reporter, ok := s.scanner.(scanner.StatsReporter); ok {
+		stats = reporter.LastScanStats()
+	}
+
+	result := &GenerateResult{
+		Content:             content,
+		OutputPath:          outputPath,
+		FileCount:           tree.CountFiles(),
+		ContentSize:         contentSize,
+		TokenEstimate:       int64(tokens.EstimateFromBytes(contentSize)),
+		CopiedToClipboard:   copied,
+		ClipboardMode:       cfg.ClipboardMode,
+		ClipboardSize:       copiedSize,
+		ClipboardMismatch:   mismatch,
+		CommentBytesSaved:   bytesSaved,
+		FilesFilteredByTime: stats.FilteredByTime,
+		IgnoredEntries:      stats.IgnoredEntries,
+		Inaccessible:        stats.Inaccessible,
+		Unreadable:          genConfig.Unreadable.Files(),
+		Truncated:           genConfig.Truncated.Files(),
+		ManifestPath:        manifestPath,
+		Tree:                tree,
+		Selections:          selections,
+		SkippedFiles:        skipped,
+	}
+	if dedup != nil {
+		result.DedupFiles = dedup.FilesDeduplicated()
+		result.DedupBytesSaved = dedup.BytesSaved()
+		result.DedupTokensSaved = int64(dedup.TokensSaved())
+	}
+	if redactor != nil {
+		result.Redacted = redactor.Files()
+	}
+	if summarizer != nil {
+		result.SummarizedFiles = summarizer.FilesSummarized()
+		result.EmbeddedFiles = summarizer.FilesEmbedded()
+	}
+
+	return result, nil
+}
+
+// skipSelectedPaths returns a copy of selections without the files whose relative
+// path is in skip, and how many it left out.
+func skipSelectedPaths(tree *scanner.FileNode, selections, skip map[string]bool) (map[string]bool, int) {
+	kept := make(map[string]bool, len(selections))
+	for path, selected := range selections {
+		kept[path] = selected
+	}
+
+	skipped := 0
+	for _, node := range scanner.SelectedFiles(tree, selections) {
+		if skip[filepath.ToSlash(node.RelPath)] {
+			delete(kept, node.Path)
+			skipped++
+		}
+	}
+
+	return kept, skipped
+}
+
+// clipboardMismatch reads the clipboard back and reports whether it differs from text.
+// A clipboard that cannot be read back is not reported, since nothing is known about it.
+func (s *DefaultContextService) clipboardMismatch(text string) bool {
+	ok, err := s.verify(text)
+	return err == nil && !ok
 }
 
 // SendToLLM sends content to an LLM provider synchronously.
@@ -201,7 +379,7 @@ func (s *DefaultContextService) SendToLLM(
 // It handles:
 // 1. Provider creation from config
 // 2. Availability and config validation
-// 3. Sending with progress callback
+// 3. Sending with the delta or progress callback
 // 4. Optionally saving the response to a file
 func (s *DefaultContextService) SendToLLMWithProgress(
 	ctx context.Context,
@@ -215,6 +393,10 @@ func (s *DefaultContextService) SendToLLMWithProgress(
 		BaseURL:  cfg.BaseURL,
 		Model:    cfg.Model,
 		Timeout:  cfg.Timeout,
+		// Network settings
+		Proxy:              cfg.Proxy,
+		CACert:             cfg.CACert,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
 	}
 	llmCfg.WithDefaults()
 
@@ -232,9 +414,12 @@ func (s *DefaultContextService) SendToLLMWithProgress(
 	}
 
 	var result *llm.Result
-	if progress != nil {
+	switch {
+	case cfg.OnDelta != nil:
+		result, err = provider.SendStream(ctx, content, cfg.OnDelta)
+	case progress != nil:
 		result, err = provider.SendWithProgress(ctx, content, progress)
-	} else {
+	default:
 		result, err = provider.Send(ctx, content)
 	}
 
@@ -44,6 +44,14 @@ func (m *mockLLMProvider) SendWithProgress(ctx context.Context, content string,
 	return m.sendResult, m.sendErr
 }
 
+func (m *mockLLMProvider) SendStream(ctx context.Context, content string, onDelta func(delta string)) (*llm.Result, error) {
+	m.sendContentSeen = content
+	if m.sendErr == nil && m.sendResult != nil && onDelta != nil {
+		onDelta(m.sendResult.Response)
+	}
+	return m.sendResult, m.sendErr
+}
+
 func newMockRegistry(provider *mockLLMProvider, providerType llm.ProviderType) *llm.Registry {
 	registry := llm.NewRegistry()
 	registry.Register(providerType, func(cfg llm.Config) (llm.Provider, error) {
@@ -8,10 +8,14 @@ const (
 	KeyScannerMaxMemory            = "scanner.max-memory"
 	KeyScannerSkipBinary           = "scanner.skip-binary"
 	KeyScannerIncludeHidden        = "scanner.include-hidden"
+	KeyScannerIncludeHiddenDirs    = "scanner.include-hidden-dirs"
+	KeyScannerIncludeHiddenFiles   = "scanner.include-hidden-files"
 	KeyScannerIncludeIgnored       = "scanner.include-ignored"
 	KeyScannerWorkers              = "scanner.workers"
 	KeyScannerRespectGitignore     = "scanner.respect-gitignore"
 	KeyScannerRespectShotgunignore = "scanner.respect-shotgunignore"
+	KeyScannerAllowlistMode        = "scanner.allowlist-mode"
+	KeyScannerRespectGitattributes = "scanner.respect-gitattributes"
 
 	// LLM
 	KeyLLMProvider     = "llm.provider"
@@ -20,20 +24,46 @@ const (
 	KeyLLMModel        = "llm.model"
 	KeyLLMTimeout      = "llm.timeout"
 	KeyLLMSaveResponse = "llm.save-response"
+	KeyLLMSendPreview  = "llm.send-preview"
+	// KeyLLMOverwriteResponse saves responses under a stable name instead of a timestamped one
+	KeyLLMOverwriteResponse = "llm.overwrite-response"
+	// Network settings for the cloud providers
+	KeyLLMProxy              = "llm.proxy"
+	KeyLLMCACert             = "llm.ca-cert"
+	KeyLLMInsecureSkipVerify = "llm.insecure-skip-verify"
 
 	// Context
 	KeyContextIncludeTree    = "context.include-tree"
 	KeyContextIncludeSummary = "context.include-summary"
 	KeyContextMaxSize        = "context.max-size"
+	KeyContextStripComments  = "context.strip-comments"
+	KeyContextFoldSiblings   = "context.fold-siblings"
+	KeyContextFileHeader     = "context.file-header"
+	KeyContextFileFooter     = "context.file-footer"
+	KeyContextRedact         = "context.redact"
+	KeyContextRedactPatterns = "context.redact-patterns"
 
 	// Template
 	KeyTemplateCustomPath = "template.custom-path"
 
 	// Output
-	KeyOutputFormat    = "output.format"
-	KeyOutputClipboard = "output.clipboard"
+	KeyOutputFormat          = "output.format"
+	KeyOutputClipboard       = "output.clipboard"
+	KeyOutputClipboardMode   = "output.clipboard-mode"
+	KeyOutputClipboardVerify = "output.clipboard-verify"
+	KeyOutputDir             = "output.dir"
+
+	// Theme colors for the TUI
+	KeyThemeColorPrimary = "theme.colors.primary"
+	KeyThemeColorAccent  = "theme.colors.accent"
+	KeyThemeColorError   = "theme.colors.error"
+	KeyThemeColorWarning = "theme.colors.warning"
+	KeyThemeColorSuccess = "theme.colors.success"
+	KeyThemeColorMuted   = "theme.colors.muted"
+	KeyThemeColorText    = "theme.colors.text"
 
 	// Global
-	KeyVerbose = "verbose"
-	KeyQuiet   = "quiet"
+	KeyVerbose  = "verbose"
+	KeyQuiet    = "quiet"
+	KeyLogLevel = "log-level"
 )
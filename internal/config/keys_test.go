@@ -31,7 +31,7 @@ func TestKeyValueFormat(t *testing.T) {
 	keys := getAllKeyValues()
 
 	for name, value := range keys {
-		if value != "verbose" && value != "quiet" && !strings.Contains(value, ".") {
+		if value != KeyVerbose && value != KeyQuiet && value != KeyLogLevel && !strings.Contains(value, ".") {
 			t.Errorf("key %s has value %q which doesn't contain a dot separator", name, value)
 		}
 	}
@@ -55,6 +55,8 @@ func TestScannerKeysExist(t *testing.T) {
 		KeyScannerWorkers,
 		KeyScannerRespectGitignore,
 		KeyScannerRespectShotgunignore,
+		KeyScannerAllowlistMode,
+		KeyScannerRespectGitattributes,
 	}
 
 	for _, key := range expected {
@@ -93,22 +95,48 @@ func getAllKeyValues() map[string]string {
 		"KeyScannerMaxMemory":            KeyScannerMaxMemory,
 		"KeyScannerSkipBinary":           KeyScannerSkipBinary,
 		"KeyScannerIncludeHidden":        KeyScannerIncludeHidden,
+		"KeyScannerIncludeHiddenDirs":    KeyScannerIncludeHiddenDirs,
+		"KeyScannerIncludeHiddenFiles":   KeyScannerIncludeHiddenFiles,
 		"KeyScannerIncludeIgnored":       KeyScannerIncludeIgnored,
 		"KeyScannerWorkers":              KeyScannerWorkers,
 		"KeyScannerRespectGitignore":     KeyScannerRespectGitignore,
 		"KeyScannerRespectShotgunignore": KeyScannerRespectShotgunignore,
+		"KeyScannerAllowlistMode":        KeyScannerAllowlistMode,
+		"KeyScannerRespectGitattributes": KeyScannerRespectGitattributes,
 		"KeyLLMProvider":                 KeyLLMProvider,
 		"KeyLLMAPIKey":                   KeyLLMAPIKey,
 		"KeyLLMBaseURL":                  KeyLLMBaseURL,
 		"KeyLLMModel":                    KeyLLMModel,
 		"KeyLLMTimeout":                  KeyLLMTimeout,
+		"KeyLLMSendPreview":              KeyLLMSendPreview,
+		"KeyLLMOverwriteResponse":        KeyLLMOverwriteResponse,
+		"KeyLLMProxy":                    KeyLLMProxy,
+		"KeyLLMCACert":                   KeyLLMCACert,
+		"KeyLLMInsecureSkipVerify":       KeyLLMInsecureSkipVerify,
 		"KeyContextIncludeTree":          KeyContextIncludeTree,
 		"KeyContextIncludeSummary":       KeyContextIncludeSummary,
 		"KeyContextMaxSize":              KeyContextMaxSize,
+		"KeyContextStripComments":        KeyContextStripComments,
+		"KeyContextFoldSiblings":         KeyContextFoldSiblings,
+		"KeyContextFileHeader":           KeyContextFileHeader,
+		"KeyContextFileFooter":           KeyContextFileFooter,
+		"KeyContextRedact":               KeyContextRedact,
+		"KeyContextRedactPatterns":       KeyContextRedactPatterns,
 		"KeyTemplateCustomPath":          KeyTemplateCustomPath,
 		"KeyOutputFormat":                KeyOutputFormat,
 		"KeyOutputClipboard":             KeyOutputClipboard,
+		"KeyOutputDir":                   KeyOutputDir,
+		"KeyOutputClipboardMode":         KeyOutputClipboardMode,
+		"KeyOutputClipboardVerify":       KeyOutputClipboardVerify,
+		"KeyThemeColorPrimary":           KeyThemeColorPrimary,
+		"KeyThemeColorAccent":            KeyThemeColorAccent,
+		"KeyThemeColorError":             KeyThemeColorError,
+		"KeyThemeColorWarning":           KeyThemeColorWarning,
+		"KeyThemeColorSuccess":           KeyThemeColorSuccess,
+		"KeyThemeColorMuted":             KeyThemeColorMuted,
+		"KeyThemeColorText":              KeyThemeColorText,
 		"KeyVerbose":                     KeyVerbose,
 		"KeyQuiet":                       KeyQuiet,
+		"KeyLogLevel":                    KeyLogLevel,
 	}
 }
@@ -61,6 +61,8 @@ const (
 	CategoryOutput ConfigCategory = "Output"
 	// CategoryLLM groups LLM provider configuration.
 	CategoryLLM ConfigCategory = "LLM Provider"
+	// CategoryTheme groups TUI color configuration.
+	CategoryTheme ConfigCategory = "Theme"
 )
 
 // ConfigMetadata describes a single configuration key.
@@ -126,13 +128,14 @@ func AllCategories() []ConfigCategory {
 		CategoryTemplate,
 		CategoryOutput,
 		CategoryLLM,
+		CategoryTheme,
 	}
 }
 
 // buildAllMetadata constructs the complete metadata list.
 func buildAllMetadata() []ConfigMetadata {
 	return []ConfigMetadata{
-		// Scanner (9 keys)
+		// Scanner (13 keys)
 		{
 			Key:          KeyScannerMaxFiles,
 			Category:     CategoryScanner,
@@ -167,7 +170,21 @@ func buildAllMetadata() []ConfigMetadata {
 			Key:          KeyScannerIncludeHidden,
 			Category:     CategoryScanner,
 			Type:         TypeBool,
-			Description:  "Include hidden files (starting with .)",
+			Description:  "Include hidden files and directories (starting with .)",
+			DefaultValue: false,
+		},
+		{
+			Key:          KeyScannerIncludeHiddenDirs,
+			Category:     CategoryScanner,
+			Type:         TypeBool,
+			Description:  "Traverse hidden directories (e.g., .github/)",
+			DefaultValue: false,
+		},
+		{
+			Key:          KeyScannerIncludeHiddenFiles,
+			Category:     CategoryScanner,
+			Type:         TypeBool,
+			Description:  "Include hidden files (e.g., .env, .editorconfig)",
 			DefaultValue: false,
 		},
 		{
@@ -200,8 +217,22 @@ func buildAllMetadata() []ConfigMetadata {
 			Description:  "Respect .shotgunignore files during scanning",
 			DefaultValue: true,
 		},
+		{
+			Key:          KeyScannerAllowlistMode,
+			Category:     CategoryScanner,
+			Type:         TypeBool,
+			Description:  "Only include files matched by .shotgunkeep files, when present",
+			DefaultValue: false,
+		},
+		{
+			Key:          KeyScannerRespectGitattributes,
+			Category:     CategoryScanner,
+			Type:         TypeBool,
+			Description:  "Skip files .gitattributes marks export-ignore or linguist-generated",
+			DefaultValue: false,
+		},
 
-		// Context (3 keys)
+		// Context (9 keys)
 		{
 			Key:          KeyContextIncludeTree,
 			Category:     CategoryContext,
@@ -213,7 +244,7 @@ func buildAllMetadata() []ConfigMetadata {
 			Key:          KeyContextIncludeSummary,
 			Category:     CategoryContext,
 			Type:         TypeBool,
-			Description:  "Include file summary in generated context",
+			Description:  "Describe directories in the tree using their README",
 			DefaultValue: true,
 		},
 		{
@@ -223,6 +254,48 @@ func buildAllMetadata() []ConfigMetadata {
 			Description:  "Maximum size of generated context",
 			DefaultValue: "10MB",
 		},
+		{
+			Key:          KeyContextStripComments,
+			Category:     CategoryContext,
+			Type:         TypeBool,
+			Description:  "Strip source code comments from embedded files",
+			DefaultValue: false,
+		},
+		{
+			Key:          KeyContextFoldSiblings,
+			Category:     CategoryContext,
+			Type:         TypeBool,
+			Description:  "Fold many similarly named files (e.g. snapshot_001..200) to a sample and a count",
+			DefaultValue: false,
+		},
+		{
+			Key:          KeyContextFileHeader,
+			Category:     CategoryContext,
+			Type:         TypeString,
+			Description:  "Line before each file; {PATH}, {SIZE}, {LANG} and {LINES} are filled in (empty = none)",
+			DefaultValue: `<file path="{PATH}">`,
+		},
+		{
+			Key:          KeyContextFileFooter,
+			Category:     CategoryContext,
+			Type:         TypeString,
+			Description:  "Line after each file; accepts the same placeholders as the header (empty = none)",
+			DefaultValue: "</file>",
+		},
+		{
+			Key:          KeyContextRedact,
+			Category:     CategoryContext,
+			Type:         TypeBool,
+			Description:  "Replace secrets such as API keys, tokens and passwords with [REDACTED] before embedding",
+			DefaultValue: false,
+		},
+		{
+			Key:          KeyContextRedactPatterns,
+			Category:     CategoryContext,
+			Type:         TypeString,
+			Description:  "Extra regular expressions to redact; a list in the config file, or one pattern",
+			DefaultValue: "",
+		},
 
 		// Template (1 key)
 		{
@@ -233,7 +306,7 @@ func buildAllMetadata() []ConfigMetadata {
 			DefaultValue: "",
 		},
 
-		// Output (2 keys)
+		// Output (5 keys)
 		{
 			Key:          KeyOutputFormat,
 			Category:     CategoryOutput,
@@ -249,8 +322,30 @@ func buildAllMetadata() []ConfigMetadata {
 			Description:  "Copy generated context to clipboard",
 			DefaultValue: true,
 		},
+		{
+			Key:          KeyOutputClipboardMode,
+			Category:     CategoryOutput,
+			Type:         TypeEnum,
+			Description:  "What to copy to the clipboard: the content, the output file path, or nothing",
+			DefaultValue: "content",
+			EnumOptions:  []string{"content", "path", "none"},
+		},
+		{
+			Key:          KeyOutputClipboardVerify,
+			Category:     CategoryOutput,
+			Type:         TypeBool,
+			Description:  "Read the clipboard back after copying and warn when it holds less than was copied",
+			DefaultValue: false,
+		},
+		{
+			Key:          KeyOutputDir,
+			Category:     CategoryOutput,
+			Type:         TypePath,
+			Description:  "Directory for generated prompt and response files (empty = current behavior)",
+			DefaultValue: "",
+		},
 
-		// LLM Provider (6 keys)
+		// LLM Provider (7 keys)
 		{
 			Key:          KeyLLMProvider,
 			Category:     CategoryLLM,
@@ -296,5 +391,91 @@ func buildAllMetadata() []ConfigMetadata {
 			Description:  "Save LLM response to file",
 			DefaultValue: false,
 		},
+		{
+			Key:          KeyLLMSendPreview,
+			Category:     CategoryLLM,
+			Type:         TypeBool,
+			Description:  "Preview the prompt and confirm before sending it to the LLM",
+			DefaultValue: false,
+		},
+		{
+			Key:          KeyLLMOverwriteResponse,
+			Category:     CategoryLLM,
+			Type:         TypeBool,
+			Description:  "Save responses under a stable name that repeated sends overwrite",
+			DefaultValue: false,
+		},
+		{
+			Key:          KeyLLMProxy,
+			Category:     CategoryLLM,
+			Type:         TypeURL,
+			Description:  "Proxy URL for API requests (http, https or socks5; empty uses HTTP(S)_PROXY)",
+			DefaultValue: "",
+		},
+		{
+			Key:          KeyLLMCACert,
+			Category:     CategoryLLM,
+			Type:         TypePath,
+			Description:  "PEM bundle of extra CA certificates to trust, e.g. for a TLS-inspecting proxy",
+			DefaultValue: "",
+		},
+		{
+			Key:          KeyLLMInsecureSkipVerify,
+			Category:     CategoryLLM,
+			Type:         TypeBool,
+			Description:  "DANGEROUS: skip TLS certificate verification (lab use only)",
+			DefaultValue: false,
+		},
+
+		// Theme (7 keys): hex (#88C0D0) or ANSI 256 color numbers (33)
+		{
+			Key:          KeyThemeColorPrimary,
+			Category:     CategoryTheme,
+			Type:         TypeString,
+			Description:  "Color of titles, focused borders and the cursor",
+			DefaultValue: "#88C0D0",
+		},
+		{
+			Key:          KeyThemeColorAccent,
+			Category:     CategoryTheme,
+			Type:         TypeString,
+			Description:  "Color of progress bars and statistics",
+			DefaultValue: "#A3BE8C",
+		},
+		{
+			Key:          KeyThemeColorError,
+			Category:     CategoryTheme,
+			Type:         TypeString,
+			Description:  "Color of error messages",
+			DefaultValue: "#BF616A",
+		},
+		{
+			Key:          KeyThemeColorWarning,
+			Category:     CategoryTheme,
+			Type:         TypeString,
+			Description:  "Color of warnings and partially selected directories",
+			DefaultValue: "#EBCB8B",
+		},
+		{
+			Key:          KeyThemeColorSuccess,
+			Category:     CategoryTheme,
+			Type:         TypeString,
+			Description:  "Color of success messages and selected files",
+			DefaultValue: "#A3BE8C",
+		},
+		{
+			Key:          KeyThemeColorMuted,
+			Category:     CategoryTheme,
+			Type:         TypeString,
+			Description:  "Color of help text, separators and unselected files",
+			DefaultValue: "#7B88A1",
+		},
+		{
+			Key:          KeyThemeColorText,
+			Category:     CategoryTheme,
+			Type:         TypeString,
+			Description:  "Color of the main text",
+			DefaultValue: "#D8DEE9",
+		},
 	}
 }
@@ -38,7 +38,7 @@ func TestAllConfigMetadata_ReturnsAllKeys(t *testing.T) {
 	metadata := AllConfigMetadata()
 
 	assert.NotEmpty(t, metadata)
-	assert.Len(t, metadata, 21, "should have 21 configuration keys")
+	assert.Len(t, metadata, 46, "should have 46 configuration keys")
 }
 
 func TestAllConfigMetadata_MatchesValidKeys(t *testing.T) {
@@ -190,11 +190,14 @@ func TestGetByCategory_ReturnsCorrectKeys(t *testing.T) {
 		expectedCount int
 		expectedKeys  []string
 	}{
-		{CategoryScanner, 9, []string{KeyScannerMaxFiles, KeyScannerWorkers}},
-		{CategoryContext, 3, []string{KeyContextIncludeTree, KeyContextMaxSize}},
+		{CategoryScanner, 13, []string{KeyScannerMaxFiles, KeyScannerWorkers}},
+		{CategoryContext, 9, []string{KeyContextIncludeTree, KeyContextMaxSize, KeyContextRedact}},
 		{CategoryTemplate, 1, []string{KeyTemplateCustomPath}},
-		{CategoryOutput, 2, []string{KeyOutputFormat, KeyOutputClipboard}},
-		{CategoryLLM, 6, []string{KeyLLMProvider, KeyLLMAPIKey}},
+		{CategoryOutput, 5, []string{
+			KeyOutputFormat, KeyOutputClipboard, KeyOutputClipboardMode, KeyOutputClipboardVerify, KeyOutputDir,
+		}},
+		{CategoryLLM, 11, []string{KeyLLMProvider, KeyLLMAPIKey}},
+		{CategoryTheme, 7, []string{KeyThemeColorPrimary, KeyThemeColorText}},
 	}
 
 	for _, tt := range tests {
@@ -228,12 +231,13 @@ func TestAllCategories_ReturnsAllCategories(t *testing.T) {
 
 	categories := AllCategories()
 
-	assert.Len(t, categories, 5)
+	assert.Len(t, categories, 6)
 	assert.Equal(t, CategoryScanner, categories[0])
 	assert.Equal(t, CategoryContext, categories[1])
 	assert.Equal(t, CategoryTemplate, categories[2])
 	assert.Equal(t, CategoryOutput, categories[3])
 	assert.Equal(t, CategoryLLM, categories[4])
+	assert.Equal(t, CategoryTheme, categories[5])
 }
 
 func TestAllCategories_CoversAllMetadata(t *testing.T) {
@@ -266,18 +270,39 @@ func TestMetadataDefaults_MatchRootDefaults(t *testing.T) {
 		KeyScannerWorkers:              1,
 		KeyScannerRespectGitignore:     true,
 		KeyScannerRespectShotgunignore: true,
+		KeyScannerAllowlistMode:        false,
+		KeyScannerRespectGitattributes: false,
 		KeyContextIncludeTree:          true,
 		KeyContextIncludeSummary:       true,
 		KeyContextMaxSize:              "10MB",
+		KeyContextFileHeader:           `<file path="{PATH}">`,
+		KeyContextFileFooter:           "</file>",
+		KeyContextRedact:               false,
+		KeyContextRedactPatterns:       "",
 		KeyTemplateCustomPath:          "",
 		KeyOutputFormat:                "markdown",
 		KeyOutputClipboard:             true,
+		KeyOutputDir:                   "",
+		KeyOutputClipboardMode:         "content",
+		KeyOutputClipboardVerify:       false,
 		KeyLLMProvider:                 "gemini",
 		KeyLLMAPIKey:                   "",
 		KeyLLMBaseURL:                  "",
 		KeyLLMModel:                    "",
 		KeyLLMTimeout:                  300,
 		KeyLLMSaveResponse:             false,
+		KeyLLMSendPreview:              false,
+		KeyLLMOverwriteResponse:        false,
+		KeyLLMProxy:                    "",
+		KeyLLMCACert:                   "",
+		KeyLLMInsecureSkipVerify:       false,
+		KeyThemeColorPrimary:           "#88C0D0",
+		KeyThemeColorAccent:            "#A3BE8C",
+		KeyThemeColorError:             "#BF616A",
+		KeyThemeColorWarning:           "#EBCB8B",
+		KeyThemeColorSuccess:           "#A3BE8C",
+		KeyThemeColorMuted:             "#7B88A1",
+		KeyThemeColorText:              "#D8DEE9",
 	}
 
 	for key, expectedDefault := range expectedDefaults {
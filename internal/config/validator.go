@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/quantmind-br/shotgun-cli/internal/core/contextgen"
+	platformhttp "github.com/quantmind-br/shotgun-cli/internal/platform/http"
 	"github.com/quantmind-br/shotgun-cli/internal/utils"
 )
 
@@ -20,18 +23,31 @@ func ValidKeys() []string {
 		KeyScannerSkipBinary,
 		KeyScannerWorkers,
 		KeyScannerIncludeHidden,
+		KeyScannerIncludeHiddenDirs,
+		KeyScannerIncludeHiddenFiles,
 		KeyScannerIncludeIgnored,
 		KeyScannerRespectShotgunignore,
+		KeyScannerAllowlistMode,
+		KeyScannerRespectGitattributes,
 		KeyScannerMaxMemory,
 		// Context keys
 		KeyContextMaxSize,
 		KeyContextIncludeTree,
 		KeyContextIncludeSummary,
+		KeyContextStripComments,
+		KeyContextFoldSiblings,
+		KeyContextFileHeader,
+		KeyContextFileFooter,
+		KeyContextRedact,
+		KeyContextRedactPatterns,
 		// Template keys
 		KeyTemplateCustomPath,
 		// Output keys
 		KeyOutputFormat,
 		KeyOutputClipboard,
+		KeyOutputDir,
+		KeyOutputClipboardMode,
+		KeyOutputClipboardVerify,
 		// LLM Provider keys
 		KeyLLMProvider,
 		KeyLLMAPIKey,
@@ -40,6 +56,19 @@ func ValidKeys() []string {
 		KeyLLMTimeout,
 		// LLM save response key
 		KeyLLMSaveResponse,
+		KeyLLMSendPreview,
+		KeyLLMOverwriteResponse,
+		KeyLLMProxy,
+		KeyLLMCACert,
+		KeyLLMInsecureSkipVerify,
+		// Theme keys
+		KeyThemeColorPrimary,
+		KeyThemeColorAccent,
+		KeyThemeColorError,
+		KeyThemeColorWarning,
+		KeyThemeColorSuccess,
+		KeyThemeColorMuted,
+		KeyThemeColorText,
 	}
 }
 
@@ -61,15 +90,24 @@ func ValidateValue(key, value string) error {
 	case KeyScannerMaxFileSize, KeyContextMaxSize, KeyScannerMaxMemory:
 		return validateSizeFormat(value)
 	case KeyScannerRespectGitignore, KeyScannerSkipBinary,
-		KeyScannerIncludeHidden, KeyScannerIncludeIgnored, KeyScannerRespectShotgunignore,
-		KeyContextIncludeTree, KeyContextIncludeSummary, KeyOutputClipboard,
-		KeyLLMSaveResponse:
+		KeyScannerIncludeHidden, KeyScannerIncludeHiddenDirs, KeyScannerIncludeHiddenFiles,
+		KeyScannerIncludeIgnored, KeyScannerRespectShotgunignore, KeyScannerAllowlistMode,
+		KeyScannerRespectGitattributes,
+		KeyContextIncludeTree, KeyContextIncludeSummary, KeyContextStripComments, KeyContextFoldSiblings,
+		KeyContextRedact, KeyOutputClipboard, KeyOutputClipboardVerify, KeyLLMSaveResponse, KeyLLMSendPreview,
+		KeyLLMOverwriteResponse, KeyLLMInsecureSkipVerify:
 		return validateBooleanValue(value)
 	case KeyScannerWorkers:
 		return validateWorkers(value)
+	case KeyContextFileHeader, KeyContextFileFooter:
+		return contextgen.ValidateFileDelimiter(value)
+	case KeyContextRedactPatterns:
+		return contextgen.ValidateRedactPattern(value)
 	case KeyOutputFormat:
 		return validateOutputFormat(value)
-	case KeyTemplateCustomPath:
+	case KeyOutputClipboardMode:
+		return validateClipboardMode(value)
+	case KeyTemplateCustomPath, KeyOutputDir:
 		return validatePath(value)
 	case KeyLLMTimeout:
 		return validateTimeout(value)
@@ -81,6 +119,13 @@ func ValidateValue(key, value string) error {
 		return validateURL(value)
 	case KeyLLMModel:
 		return nil // Model can be any string, validation is provider-specific
+	case KeyLLMProxy:
+		return validateProxy(value)
+	case KeyLLMCACert:
+		return validateCACert(value)
+	case KeyThemeColorPrimary, KeyThemeColorAccent, KeyThemeColorError, KeyThemeColorWarning,
+		KeyThemeColorSuccess, KeyThemeColorMuted, KeyThemeColorText:
+		return validateColor(value)
 	}
 
 	return nil
@@ -97,9 +142,12 @@ func ConvertValue(key, value string) (interface{}, error) {
 		return intVal, nil
 
 	case KeyScannerRespectGitignore, KeyScannerSkipBinary,
-		KeyScannerIncludeHidden, KeyScannerIncludeIgnored, KeyScannerRespectShotgunignore,
-		KeyContextIncludeTree, KeyContextIncludeSummary, KeyOutputClipboard,
-		KeyLLMSaveResponse:
+		KeyScannerIncludeHidden, KeyScannerIncludeHiddenDirs, KeyScannerIncludeHiddenFiles,
+		KeyScannerIncludeIgnored, KeyScannerRespectShotgunignore, KeyScannerAllowlistMode,
+		KeyScannerRespectGitattributes,
+		KeyContextIncludeTree, KeyContextIncludeSummary, KeyContextStripComments, KeyContextFoldSiblings,
+		KeyContextRedact, KeyOutputClipboard, KeyOutputClipboardVerify, KeyLLMSaveResponse, KeyLLMSendPreview,
+		KeyLLMOverwriteResponse, KeyLLMInsecureSkipVerify:
 		return strings.ToLower(value) == "true", nil
 
 	default:
@@ -168,6 +216,14 @@ func validateOutputFormat(value string) error {
 	return nil
 }
 
+// validateClipboardMode validates output.clipboard-mode values.
+func validateClipboardMode(value string) error {
+	if value != "content" && value != "path" && value != "none" {
+		return fmt.Errorf("expected 'content', 'path' or 'none', got '%s'", value)
+	}
+	return nil
+}
+
 // validatePath validates file/directory path configuration values.
 func validatePath(value string) error {
 	if value == "" {
@@ -220,6 +276,30 @@ func validateLLMProvider(value string) error {
 	return fmt.Errorf("expected one of: %s", strings.Join(validProviders, ", "))
 }
 
+// validateProxy validates the proxy URL used for LLM requests.
+func validateProxy(value string) error {
+	if value == "" {
+		return nil
+	}
+	_, err := platformhttp.ParseProxyURL(value)
+	return err
+}
+
+// validateCACert checks that the CA bundle for LLM requests is an existing file.
+func validateCACert(value string) error {
+	if value == "" {
+		return nil
+	}
+	info, err := os.Stat(value)
+	if err != nil {
+		return fmt.Errorf("cannot access CA certificate: %w", err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("CA certificate path is a directory: %s", value)
+	}
+	return nil
+}
+
 // validateURL validates URL configuration values.
 func validateURL(value string) error {
 	if value == "" {
@@ -231,3 +311,24 @@ func validateURL(value string) error {
 	}
 	return nil
 }
+
+// validateColor validates a theme color: a hex value (#RGB or #RRGGBB) or an ANSI
+// 256 color number. Empty keeps the default color.
+func validateColor(value string) error {
+	if value == "" {
+		return nil
+	}
+	if hex, ok := strings.CutPrefix(value, "#"); ok {
+		if len(hex) != 3 && len(hex) != 6 {
+			return fmt.Errorf("hex color must be #RGB or #RRGGBB, got '%s'", value)
+		}
+		if _, err := strconv.ParseUint(hex, 16, 32); err != nil {
+			return fmt.Errorf("invalid hex color '%s'", value)
+		}
+		return nil
+	}
+	if n, err := strconv.Atoi(value); err != nil || n < 0 || n > 255 {
+		return fmt.Errorf("expected a hex color like #88C0D0 or an ANSI color number 0-255, got '%s'", value)
+	}
+	return nil
+}
@@ -203,6 +203,61 @@ func TestValidateValue_OutputFormat(t *testing.T) {
 	}
 }
 
+func TestValidateValue_ClipboardMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{"content", false},
+		{"path", false},
+		{"none", false},
+		{"file", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateValue(KeyOutputClipboardMode, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateValue(output.clipboard-mode, %q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateValue_ThemeColor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{"#88C0D0", false},
+		{"#fff", false},
+		{"33", false},
+		{"255", false},
+		{"", false},
+		{"#88C0D", true},
+		{"#GGGGGG", true},
+		{"256", true},
+		{"-1", true},
+		{"red", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateValue(KeyThemeColorPrimary, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateValue(theme.colors.primary, %q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateValue_Timeout(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,73 @@
+package contextgen
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// delimiterPattern returns a pattern matching a whole line written from delimiter,
+// capturing {PATH} when capturePath is set. Other placeholders match any text.
+func delimiterPattern(delimiter string, capturePath bool) *regexp.Regexp {
+	var pattern strings.Builder
+	pattern.WriteString(`(?m)^`)
+	last := 0
+	for _, loc := range placeholderRe.FindAllStringIndex(delimiter, -1) {
+		pattern.WriteString(regexp.QuoteMeta(delimiter[last:loc[0]]))
+		if delimiter[loc[0]:loc[1]] == "{PATH}" && capturePath {
+			pattern.WriteString(`(.+?)`)
+		} else {
+			pattern.WriteString(`.*?`)
+		}
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(delimiter[last:]))
+	pattern.WriteString(`\r?$`)
+
+	return regexp.MustCompile(pattern.String())
+}
+
+// EmbeddedPaths returns the relative paths of the files embedded in content, found
+// by their header lines, in the order they appear. The header must contain {PATH}.
+func EmbeddedPaths(content string, delimiters FileDelimiters) ([]string, error) {
+	if !strings.Contains(delimiters.Header, "{PATH}") {
+		return nil, fmt.Errorf("cannot find embedded files: the file header %q has no {PATH}", delimiters.Header)
+	}
+
+	var paths []string
+	for _, match := range delimiterPattern(delimiters.Header, true).FindAllStringSubmatch(content, -1) {
+		paths = append(paths, match[1])
+	}
+
+	return paths, nil
+}
+
+// AppendFileBlocks inserts blocks, as rendered for the same delimiters, after the last
+// file embedded in content, so template text that follows the files stays last.
+// Without a footer line to find the end of that file, blocks go at the end.
+func AppendFileBlocks(content, blocks string, delimiters FileDelimiters) string {
+	if blocks == "" {
+		return content
+	}
+
+	at := len(content)
+	if delimiters.Header != "" && delimiters.Footer != "" {
+		if headers := delimiterPattern(delimiters.Header, false).FindAllStringIndex(content, -1); len(headers) > 0 {
+			lastHeader := headers[len(headers)-1][1]
+			footer := delimiterPattern(delimiters.Footer, false).FindStringIndex(content[lastHeader:])
+			if footer != nil {
+				at = lastHeader + footer[1]
+				if at < len(content) && content[at] == '\n' {
+					at++
+				}
+			}
+		}
+	}
+
+	head := content[:at]
+	if head != "" && !strings.HasSuffix(head, "\n") {
+		head += "\n"
+	}
+
+	return head + blocks + content[at:]
+}
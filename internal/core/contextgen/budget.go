@@ -0,0 +1,133 @@
+package contextgen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Priority selects which files are kept whole when the collected file contents do
+// not fit in GenerateConfig.MaxTotalSize. Files are ranked by the strategy and kept
+// in rank order until the first one that does not fit; that file and every file
+// ranked after it are omitted and listed at the end of the file structure.
+type Priority string
+
+const (
+	// PriorityNone fails generation when the contents exceed the limit.
+	PriorityNone Priority = ""
+	// PrioritySmallestFirst keeps as many files as possible, smallest first.
+	PrioritySmallestFirst Priority = "smallest-first"
+	// PriorityPathOrder keeps files in tree order.
+	PriorityPathOrder Priority = "path-order"
+	// PriorityRecentlyModified keeps the most recently modified files first.
+	PriorityRecentlyModified Priority = "recently-modified"
+	// PriorityImportance keeps the files that score highest on importanceScores first:
+	// entrypoints, READMEs and docs, and files other selected files import.
+	PriorityImportance Priority = "importance"
+)
+
+// Priorities lists the strategies accepted by ParsePriority.
+var Priorities = []Priority{PrioritySmallestFirst, PriorityPathOrder, PriorityRecentlyModified, PriorityImportance}
+
+// ParsePriority validates a strategy name; an empty name means PriorityNone.
+func ParsePriority(name string) (Priority, error) {
+	if name == "" {
+		return PriorityNone, nil
+	}
+
+	names := make([]string, len(Priorities))
+	for i, p := range Priorities {
+		if string(p) == name {
+			return p, nil
+		}
+		names[i] = string(p)
+	}
+
+	return PriorityNone, fmt.Errorf("unknown priority %q (expected: %s)", name, strings.Join(names, ", "))
+}
+
+// OmittedFile is a file left out of the context to stay within the size limit.
+type OmittedFile struct {
+	RelPath string `json:"relPath"`
+	Size    int64  `json:"size"`
+}
+
+// rankFiles returns the indexes of files in the order priority keeps them.
+// Ties keep tree order, so the ranking is deterministic.
+func rankFiles(files []FileContent, priority Priority) []int {
+	order := make([]int, len(files))
+	for i := range order {
+		order[i] = i
+	}
+
+	switch priority {
+	case PrioritySmallestFirst:
+		sort.SliceStable(order, func(a, b int) bool {
+			return files[order[a]].Size < files[order[b]].Size
+		})
+	case PriorityRecentlyModified:
+		sort.SliceStable(order, func(a, b int) bool {
+			return files[order[a]].modTime.After(files[order[b]].modTime)
+		})
+	case PriorityImportance:
+		scores := importanceScores(files)
+		sort.SliceStable(order, func(a, b int) bool {
+			return scores[order[a]] > scores[order[b]]
+		})
+	}
+
+	return order
+}
+
+// contentFitCount returns how many files, taken in rank order, fit in budget bytes
+// by their contents alone; it bounds how many can fit in the rendered context.
+func contentFitCount(files []FileContent, order []int, budget int64) int {
+	var used int64
+	for n, i := range order {
+		if used+files[i].Size > budget {
+			return n
+		}
+		used += files[i].Size
+	}
+
+	return len(order)
+}
+
+// keepRanked keeps the first n files in rank order. Kept files stay in tree order;
+// the others are returned as omitted, in rank order.
+func keepRanked(files []FileContent, order []int, n int) ([]FileContent, []OmittedFile) {
+	keep := make([]bool, len(files))
+	for _, i := range order[:n] {
+		keep[i] = true
+	}
+
+	kept := make([]FileContent, 0, n)
+	for i, file := range files {
+		if keep[i] {
+			kept = append(kept, file)
+		}
+	}
+
+	omitted := make([]OmittedFile, 0, len(order)-n)
+	for _, i := range order[n:] {
+		omitted = append(omitted, OmittedFile{RelPath: files[i].RelPath, Size: files[i].Size})
+	}
+
+	return kept, omitted
+}
+
+// renderOmittedFiles lists files left out by the size limit in an XML-like block.
+func renderOmittedFiles(omitted []OmittedFile, priority Priority) string {
+	if len(omitted) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("<omitted_files reason=\"size limit\" priority=\"%s\">\n", priority))
+	for _, file := range omitted {
+		builder.WriteString(fmt.Sprintf("%s (%s)\n", file.RelPath, formatFileSize(file.Size)))
+	}
+	builder.WriteString("</omitted_files>\n")
+
+	return builder.String()
+}
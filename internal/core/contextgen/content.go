@@ -1,15 +1,19 @@
 package contextgen
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
+	"github.com/quantmind-br/shotgun-cli/internal/core/tokens"
 )
 
 const (
@@ -24,23 +28,42 @@ type FileContent struct {
 	Language string `json:"language"`
 	Content  string `json:"content"`
 	Size     int64  `json:"size"`
+	Tokens   int    `json:"tokens"`             // Estimated token count of Content
+	FoldNote string `json:"foldNote,omitempty"` // Describes similar sibling files folded after this one
+	// DuplicateOf is the file whose identical content this one references instead of embedding
+	DuplicateOf string `json:"duplicateOf,omitempty"`
+
+	modTime time.Time // Only set for PriorityRecentlyModified
 }
 
 func collectFileContents(
-	root *scanner.FileNode, selections map[string]bool, config GenerateConfig,
+	ctx context.Context, root *scanner.FileNode, selections map[string]bool, config GenerateConfig,
 ) ([]FileContent, error) {
 	var files []FileContent
 	var totalSize int64
 	fileCount := 0
 
-	err := walkSelectedNodes(root, func(node *scanner.FileNode) error {
-		if node.IsDir || node.IsIgnored() {
-			return nil
+	isCandidate := func(node *scanner.FileNode) bool {
+		if node.IsDir || node.IsSymlink || (node.IsIgnored() && !config.IncludeIgnored) {
+			return false
 		}
 
 		// Check selection against the map
 		// If selections map is nil, we assume all non-ignored files are selected
-		if selections != nil && !selections[node.Path] {
+		return selections == nil || selections[node.Path]
+	}
+
+	var omitted map[*scanner.FileNode]bool
+	var foldNotes map[*scanner.FileNode]string
+	if config.FoldSiblings {
+		omitted, foldNotes = collectSiblingFolds(root, isCandidate)
+	}
+
+	err := walkSelectedNodes(root, func(node *scanner.FileNode) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !isCandidate(node) || omitted[node] {
 			return nil
 		}
 
@@ -52,32 +75,57 @@ func collectFileContents(
 			return nil
 		}
 
-		// First peek at the file header to check if it's binary before reading full content
-		if config.SkipBinary {
-			header, err := peekFileHeader(node.Path)
-			if err != nil {
-				return fmt.Errorf("failed to peek file header %s: %w", node.Path, err)
-			}
-			if !isTextFile(string(header)) {
-				return nil // Skip binary file without reading full content
-			}
-		}
-
-		content, err := readFileContent(node.Path)
+		relPath, err := filepath.Rel(root.Path, node.Path)
 		if err != nil {
-			return fmt.Errorf("failed to read file %s: %w", node.Path, err)
+			relPath = node.Path
 		}
 
-		if totalSize+int64(len(content)) > config.MaxTotalSize {
-			return fmt.Errorf(
-				"cumulative content size exceeds total size limit: %d + %d > %d",
-				totalSize, len(content), config.MaxTotalSize,
-			)
+		// Files over ContentMaxSize stay in the context, but their content is not read;
+		// neither is that of generated files, unless they are to be summarized
+		oversized := config.ContentMaxSize > 0 && node.Size > config.ContentMaxSize
+		generated := node.IsGenerated && !config.Summarizer.matches(relPath)
+		raw, binary, readErr := readCandidate(node, config.SkipBinary, !oversized && !generated)
+		if binary {
+			return nil
+		}
+		if readErr != nil && config.Strict {
+			return readErr
 		}
 
-		relPath, err := filepath.Rel(root.Path, node.Path)
-		if err != nil {
-			relPath = node.Path
+		// Unreadable files are embedded as a placeholder and reported instead of failing
+		content := raw
+		if config.Redactor != nil && readErr == nil && !oversized && !generated {
+			content = config.Redactor.Transform(relPath, raw)
+		}
+		summarized := false
+		switch {
+		case readErr != nil:
+			reason := unreadableReason(readErr)
+			if config.Unreadable != nil {
+				config.Unreadable.add(relPath, reason)
+			}
+			content = unreadablePlaceholder(reason)
+		case oversized:
+			content = omittedContentPlaceholder(node.Size, config.ContentMaxSize)
+		case generated:
+			content = generatedContentPlaceholder(node.Size)
+		case config.Summarizer.matches(relPath):
+			content = config.Summarizer.summarize(content, node.Size)
+			summarized = true
+		default:
+			for _, transform := range config.Transforms {
+				content = transform(relPath, content)
+			}
+			if config.PerFileMaxTokens > 0 {
+				if fullTokens := tokens.Estimate(content); fullTokens > config.PerFileMaxTokens {
+					var omitted string
+					content, omitted = truncateHeadTail(content, config.PerFileMaxTokens)
+					if config.Truncated != nil {
+						config.Truncated.add(TruncatedFile{RelPath: relPath, Tokens: fullTokens, Omitted: omitted})
+					}
+				}
+			}
+			config.Summarizer.embed()
 		}
 
 		fileContent := FileContent{
@@ -86,6 +134,25 @@ func collectFileContents(
 			Language: detectLanguage(node.Name),
 			Content:  content,
 			Size:     int64(len(content)),
+			Tokens:   tokens.Estimate(content),
+			FoldNote: foldNotes[node],
+		}
+		if config.Dedup != nil && readErr == nil && !oversized && !generated && !summarized {
+			config.Dedup.dedupe(node, raw, &fileContent)
+		}
+
+		// With a priority the generator trims the files to the limit instead
+		overflows := totalSize+fileContent.Size > config.MaxTotalSize
+		if config.Priority == PriorityNone && !config.AllowOverflow && overflows {
+			return &sizeLimitError{msg: fmt.Sprintf(
+				"cumulative content size exceeds total size limit: %d + %d > %d",
+				totalSize, fileContent.Size, config.MaxTotalSize,
+			)}
+		}
+		if config.Priority == PriorityRecentlyModified {
+			if info, err := os.Stat(node.Path); err == nil {
+				fileContent.modTime = info.ModTime()
+			}
 		}
 
 		files = append(files, fileContent)
@@ -118,6 +185,41 @@ func walkSelectedNodes(node *scanner.FileNode, fn func(*scanner.FileNode) error)
 	return nil
 }
 
+// readCandidate reads the content of node when readContent is set. With skipBinary
+// it first peeks at the header and reports binary files without reading them in full.
+func readCandidate(node *scanner.FileNode, skipBinary, readContent bool) (content string, binary bool, err error) {
+	if skipBinary {
+		header, err := peekFileHeader(node.Path)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to peek file header %s: %w", node.Path, err)
+		}
+		if !isTextFile(string(header)) {
+			return "", true, nil
+		}
+	}
+	if !readContent {
+		return "", false, nil
+	}
+
+	content, err = readFileContent(node.Path)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read file %s: %w", node.Path, err)
+	}
+
+	return content, false, nil
+}
+
+// omittedContentPlaceholder is the content embedded for a file over GenerateConfig.ContentMaxSize.
+func omittedContentPlaceholder(size, limit int64) string {
+	return fmt.Sprintf("[content omitted: %s > %s limit]\n", formatFileSize(size), formatFileSize(limit))
+}
+
+// generatedContentPlaceholder is the content embedded for a generated or lock file.
+func generatedContentPlaceholder(size int64) string {
+	return fmt.Sprintf("[content omitted: generated or lock file, %s; embed with --include-generated]\n",
+		formatFileSize(size))
+}
+
 func peekFileHeader(path string) ([]byte, error) {
 	file, err := os.Open(path) //nolint:gosec // path is validated by caller
 	if err != nil {
@@ -150,6 +252,12 @@ func readFileContent(path string) (string, error) {
 	return string(content), nil
 }
 
+// IsText reports whether header, the first bytes of a file, looks like text rather
+// than binary data.
+func IsText(header []byte) bool {
+	return isTextFile(string(header))
+}
+
 func isTextFile(content string) bool {
 	if len(content) == 0 {
 		return true
@@ -184,6 +292,34 @@ func detectLanguage(filename string) string {
 	return detectLanguageByExtension(ext)
 }
 
+// fenceLanguageOverrides lists extensions whose Markdown fence tag is more specific
+// than the language reported for the file.
+var fenceLanguageOverrides = map[string]string{
+	".tsx": "tsx",
+	".jsx": "jsx",
+}
+
+// LanguageForPath returns the Markdown code fence language for a file path, such as
+// "go" for main.go or "tsx" for App.tsx, and "" when the file type is unknown.
+func LanguageForPath(path string) string {
+	if lang, ok := fenceLanguageOverrides[strings.ToLower(filepath.Ext(path))]; ok {
+		return lang
+	}
+
+	lang := detectLanguage(filepath.Base(path))
+	if lang == "text" {
+		return ""
+	}
+
+	return lang
+}
+
+// LanguageName returns the language of a file path as named by --lang, such as
+// "typescript" for App.tsx, or "text" when the file type is unknown.
+func LanguageName(path string) string {
+	return detectLanguage(filepath.Base(path))
+}
+
 func detectLanguageByBasename(base string) string {
 	switch base {
 	case "dockerfile":
@@ -267,6 +403,62 @@ var extensionToLanguage = map[string]string{
 	".dockerfile": langDockerfile,
 }
 
+// LanguageNames returns the sorted language names accepted by ExtensionsForLanguage.
+func LanguageNames() []string {
+	seen := make(map[string]bool)
+	names := make([]string, 0, len(extensionToLanguage))
+	for _, lang := range extensionToLanguage {
+		if !seen[lang] {
+			seen[lang] = true
+			names = append(names, lang)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// testFilePatterns are the names common conventions give test files and test
+// directories (a trailing '/' marks a directory).
+var testFilePatterns = []string{
+	// Go
+	"*_test.go", "testdata/",
+	// JavaScript and TypeScript
+	"*.test.js", "*.spec.js", "*.test.jsx", "*.spec.jsx", "*.test.mjs", "*.spec.mjs", "*.test.cjs", "*.spec.cjs",
+	"*.test.ts", "*.spec.ts", "*.test.tsx", "*.spec.tsx", "__tests__/",
+	// Python
+	"test_*.py", "*_test.py", "conftest.py",
+	// Rust: integration tests and test modules kept in their own file
+	"tests/", "tests.rs",
+}
+
+// TestFilePatterns returns the patterns that match test files across Go, JS/TS,
+// Python and Rust. They work both as gitignore-style excludes and as
+// ScanConfig.RequirePatterns.
+func TestFilePatterns() []string {
+	return append([]string(nil), testFilePatterns...)
+}
+
+// ExtensionsForLanguage returns the sorted file extensions (such as ".ts" and ".tsx")
+// detected as the named language, or false if the name is unknown. Names are
+// case-insensitive.
+func ExtensionsForLanguage(name string) ([]string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	var exts []string
+	for ext, lang := range extensionToLanguage {
+		if lang == name {
+			exts = append(exts, ext)
+		}
+	}
+	if len(exts) == 0 {
+		return nil, false
+	}
+	sort.Strings(exts)
+
+	return exts, true
+}
+
 func detectLanguageByExtension(ext string) string {
 	if lang, ok := extensionToLanguage[ext]; ok {
 		return lang
@@ -287,18 +479,28 @@ func shouldSkipFile(node *scanner.FileNode, config GenerateConfig) bool {
 	return false
 }
 
-// renderFileContentBlocks renders file contents in XML-like format
-func renderFileContentBlocks(files []FileContent) string {
+// renderFileContentBlocks renders file contents between the delimiter lines,
+// in XML-like format by default
+func renderFileContentBlocks(files []FileContent, delimiters FileDelimiters) string {
 	var builder strings.Builder
 
 	for _, file := range files {
-		builder.WriteString(fmt.Sprintf("<file path=\"%s\">\n", file.RelPath))
+		if delimiters.Header != "" {
+			builder.WriteString(expandFileDelimiter(delimiters.Header, file))
+			builder.WriteString("\n")
+		}
 		builder.WriteString(file.Content)
 		// Ensure content ends with newline before closing tag
 		if len(file.Content) > 0 && !strings.HasSuffix(file.Content, "\n") {
 			builder.WriteString("\n")
 		}
-		builder.WriteString("</file>\n")
+		if delimiters.Footer != "" {
+			builder.WriteString(expandFileDelimiter(delimiters.Footer, file))
+			builder.WriteString("\n")
+		}
+		if file.FoldNote != "" {
+			builder.WriteString(fmt.Sprintf("<!-- %s -->\n", file.FoldNote))
+		}
 	}
 
 	return builder.String()
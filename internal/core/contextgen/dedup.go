@@ -0,0 +1,72 @@
+package contextgen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
+	"github.com/quantmind-br/shotgun-cli/internal/core/tokens"
+)
+
+// Deduplicator embeds identical files once. Later copies are replaced by a line
+// naming the first one, and the bytes and tokens this saves are tallied.
+type Deduplicator struct {
+	seen        map[string]FileContent
+	files       int
+	bytesSaved  int64
+	tokensSaved int
+}
+
+// NewDeduplicator returns a Deduplicator for a single generation.
+func NewDeduplicator() *Deduplicator {
+	return &Deduplicator{seen: make(map[string]FileContent)}
+}
+
+// dedupe replaces file's content with a reference when an identical file was
+// collected before it and the reference is shorter. Files are matched by node.Hash, falling back to hashing
+// raw, and must also be identical after the content transforms.
+func (d *Deduplicator) dedupe(node *scanner.FileNode, raw string, file *FileContent) {
+	key := node.Hash
+	if key == "" {
+		sum := sha256.Sum256([]byte(raw))
+		key = hex.EncodeToString(sum[:])
+	}
+
+	first, ok := d.seen[key]
+	if !ok || first.Content != file.Content {
+		if !ok {
+			d.seen[key] = *file
+		}
+		return
+	}
+
+	// Files shorter than the reference are cheaper to embed again
+	reference := "// identical to " + first.RelPath + "\n"
+	if len(reference) >= len(file.Content) {
+		return
+	}
+
+	d.files++
+	d.bytesSaved += file.Size - int64(len(reference))
+	d.tokensSaved += file.Tokens - tokens.Estimate(reference)
+
+	file.DuplicateOf = first.RelPath
+	file.Content = reference
+	file.Size = int64(len(reference))
+	file.Tokens = tokens.Estimate(reference)
+}
+
+// FilesDeduplicated returns the number of files replaced by a reference.
+func (d *Deduplicator) FilesDeduplicated() int {
+	return d.files
+}
+
+// BytesSaved returns the number of content bytes not embedded.
+func (d *Deduplicator) BytesSaved() int64 {
+	return d.bytesSaved
+}
+
+// TokensSaved returns the estimated number of tokens not embedded.
+func (d *Deduplicator) TokensSaved() int {
+	return d.tokensSaved
+}
@@ -0,0 +1,98 @@
+package contextgen
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// DefaultFileHeader is the line written before each embedded file.
+	DefaultFileHeader = `<file path="{PATH}">`
+	// DefaultFileFooter is the line written after each embedded file.
+	DefaultFileFooter = `</file>`
+)
+
+// delimiterPlaceholders lists the placeholders expanded in file headers and footers.
+var delimiterPlaceholders = []string{"{PATH}", "{SIZE}", "{LANG}", "{LINES}"}
+
+// placeholderRe matches an uppercase placeholder name after "{", with its closing
+// brace when present. Other braces are literal text.
+var placeholderRe = regexp.MustCompile(`\{([A-Z_]+)(\}?)`)
+
+// FileDelimiters are the lines written around each embedded file. Either may be
+// empty to omit that line. Placeholders are expanded per file: {PATH} is the path
+// relative to the root, {SIZE} the content size (e.g. 1.2KB), {LANG} the detected
+// language and {LINES} the number of lines.
+type FileDelimiters struct {
+	Header string `json:"header"`
+	Footer string `json:"footer"`
+}
+
+// DefaultFileDelimiters returns the delimiters used when none are configured.
+func DefaultFileDelimiters() FileDelimiters {
+	return FileDelimiters{Header: DefaultFileHeader, Footer: DefaultFileFooter}
+}
+
+// Validate reports malformed or unknown placeholders in the header or footer.
+func (d FileDelimiters) Validate() error {
+	if err := ValidateFileDelimiter(d.Header); err != nil {
+		return fmt.Errorf("invalid file header: %w", err)
+	}
+	if err := ValidateFileDelimiter(d.Footer); err != nil {
+		return fmt.Errorf("invalid file footer: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateFileDelimiter checks that every placeholder in a file header or footer
+// is closed and supported. An empty delimiter is valid.
+func ValidateFileDelimiter(delimiter string) error {
+	for _, match := range placeholderRe.FindAllStringSubmatch(delimiter, -1) {
+		if match[2] == "" {
+			return fmt.Errorf("unclosed placeholder %q", match[0])
+		}
+		if !isDelimiterPlaceholder(match[0]) {
+			return fmt.Errorf("unknown placeholder %s (supported: %s)",
+				match[0], strings.Join(delimiterPlaceholders, ", "))
+		}
+	}
+
+	return nil
+}
+
+func isDelimiterPlaceholder(token string) bool {
+	for _, placeholder := range delimiterPlaceholders {
+		if token == placeholder {
+			return true
+		}
+	}
+
+	return false
+}
+
+// expandFileDelimiter returns delimiter with the placeholders filled in for file.
+func expandFileDelimiter(delimiter string, file FileContent) string {
+	if !strings.Contains(delimiter, "{") {
+		return delimiter
+	}
+
+	return strings.NewReplacer(
+		"{PATH}", file.RelPath,
+		"{SIZE}", formatFileSize(file.Size),
+		"{LANG}", file.Language,
+		"{LINES}", fmt.Sprintf("%d", countLines(file.Content)),
+	).Replace(delimiter)
+}
+
+// countLines returns the number of lines in content, counting a final line
+// without a trailing newline.
+func countLines(content string) int {
+	lines := strings.Count(content, "\n")
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		lines++
+	}
+
+	return lines
+}
@@ -63,7 +63,7 @@ func TestFileStructureWithContentBlocks(t *testing.T) {
 	}
 
 	// Test file content blocks rendering
-	contentBlocks := renderFileContentBlocks(files)
+	contentBlocks := renderFileContentBlocks(files, DefaultFileDelimiters())
 
 	// Verify content blocks format
 	if !strings.Contains(contentBlocks, `<file path="main.go">`) {
@@ -84,7 +84,7 @@ func TestFileStructureWithContentBlocks(t *testing.T) {
 
 	// Test complete file structure (tree + content blocks)
 	generator := NewDefaultContextGenerator()
-	completeStructure := generator.buildCompleteFileStructure(tree, files)
+	completeStructure := generator.buildCompleteFileStructure(tree, files, DefaultFileDelimiters())
 
 	// Verify complete structure has both parts
 	if !strings.Contains(completeStructure, "project/") {
@@ -172,7 +172,7 @@ func TestRenderFileContentBlocks(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := renderFileContentBlocks(tt.files)
+			result := renderFileContentBlocks(tt.files, DefaultFileDelimiters())
 
 			for _, expected := range tt.expected {
 				if !strings.Contains(result, expected) {
@@ -0,0 +1,103 @@
+package contextgen
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
+)
+
+const (
+	// foldMinSiblings is the smallest group of similarly named files that gets folded.
+	foldMinSiblings = 10
+	// foldSampleSize is the number of files kept from a folded group.
+	foldSampleSize = 3
+)
+
+var digitRunRe = regexp.MustCompile(`[0-9]+`)
+
+// siblingPattern returns the name with every run of digits replaced by "*",
+// or an empty string for names without digits, which are never folded.
+func siblingPattern(name string) string {
+	if !digitRunRe.MatchString(name) {
+		return ""
+	}
+
+	return digitRunRe.ReplaceAllString(name, "*")
+}
+
+// foldSiblings groups files among siblings whose names differ only in their digits
+// (e.g. snapshot_001.txt .. snapshot_200.txt). Groups of at least foldMinSiblings
+// files keep the first foldSampleSize by name; the rest are returned as omitted.
+// notes maps the last kept file of each folded group to a note describing it.
+func foldSiblings(
+	siblings []*scanner.FileNode,
+) (omitted map[*scanner.FileNode]bool, notes map[*scanner.FileNode]string) {
+	groups := make(map[string][]*scanner.FileNode)
+	for _, node := range siblings {
+		if node.IsDir {
+			continue
+		}
+		if pattern := siblingPattern(node.Name); pattern != "" {
+			groups[pattern] = append(groups[pattern], node)
+		}
+	}
+
+	for pattern, group := range groups {
+		if len(group) < foldMinSiblings {
+			continue
+		}
+
+		sort.Slice(group, func(i, j int) bool { return group[i].Name < group[j].Name })
+
+		if omitted == nil {
+			omitted = make(map[*scanner.FileNode]bool)
+			notes = make(map[*scanner.FileNode]string)
+		}
+		for _, node := range group[foldSampleSize:] {
+			omitted[node] = true
+		}
+		notes[group[foldSampleSize-1]] = foldNote(len(group)-foldSampleSize, pattern)
+	}
+
+	return omitted, notes
+}
+
+func foldNote(count int, pattern string) string {
+	return fmt.Sprintf("… %d more files matching %s", count, pattern)
+}
+
+// collectSiblingFolds applies foldSiblings to every directory under root, considering
+// only the children accepted by include.
+func collectSiblingFolds(
+	root *scanner.FileNode, include func(*scanner.FileNode) bool,
+) (omitted map[*scanner.FileNode]bool, notes map[*scanner.FileNode]string) {
+	omitted = make(map[*scanner.FileNode]bool)
+	notes = make(map[*scanner.FileNode]string)
+
+	_ = walkSelectedNodes(root, func(node *scanner.FileNode) error {
+		if !node.IsDir {
+			return nil
+		}
+
+		var siblings []*scanner.FileNode
+		for _, child := range node.Children {
+			if include(child) {
+				siblings = append(siblings, child)
+			}
+		}
+
+		dirOmitted, dirNotes := foldSiblings(siblings)
+		for n := range dirOmitted {
+			omitted[n] = true
+		}
+		for n, note := range dirNotes {
+			notes[n] = note
+		}
+
+		return nil
+	})
+
+	return omitted, notes
+}
@@ -1,8 +1,11 @@
 package contextgen
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
@@ -13,6 +16,24 @@ const (
 	DefaultMaxFiles = 1000
 )
 
+// ErrSizeLimitExceeded matches, through errors.Is, the errors returned when the
+// context would exceed GenerateConfig.MaxTotalSize.
+var ErrSizeLimitExceeded = errors.New("size limit exceeded")
+
+// sizeLimitError keeps the detailed message of a size limit failure while matching
+// ErrSizeLimitExceeded.
+type sizeLimitError struct {
+	msg string
+}
+
+func (e *sizeLimitError) Error() string {
+	return e.msg
+}
+
+func (e *sizeLimitError) Is(target error) bool {
+	return target == ErrSizeLimitExceeded
+}
+
 // GenProgress represents structured progress information
 type GenProgress struct {
 	Stage   string `json:"stage"`
@@ -31,15 +52,50 @@ type ContextGenerator interface {
 	) (string, error)
 }
 
+// CancelableGenerator is implemented by generators that can stop collecting file
+// contents when a context is cancelled.
+type CancelableGenerator interface {
+	GenerateWithContext(
+		ctx context.Context, root *scanner.FileNode, selections map[string]bool, config GenerateConfig,
+		progress func(GenProgress),
+	) (string, error)
+}
+
+// ContentTransform rewrites the content of a file before it is embedded in the context.
+// It receives the file path relative to the scan root.
+type ContentTransform func(relPath, content string) string
+
 type GenerateConfig struct {
-	MaxFileSize    int64             `json:"maxFileSize"`  // Maximum size for individual files
-	MaxTotalSize   int64             `json:"maxTotalSize"` // Maximum total size of all content
-	MaxFiles       int               `json:"maxFiles"`
-	SkipBinary     bool              `json:"skipBinary"`
-	TemplateVars   map[string]string `json:"templateVars"`
-	Template       string            `json:"template,omitempty"`
-	IncludeTree    bool              `json:"includeTree"`    // Include directory tree in output
-	IncludeSummary bool              `json:"includeSummary"` // Include file summaries in output
+	MaxFileSize    int64              `json:"maxFileSize"`    // Maximum size for individual files
+	MaxTotalSize   int64              `json:"maxTotalSize"`   // Maximum total size of all content
+	ContentMaxSize int64              `json:"contentMaxSize"` // Embed a placeholder for larger files (0 = no limit)
+	MaxFiles       int                `json:"maxFiles"`
+	SkipBinary     bool               `json:"skipBinary"`
+	TemplateVars   map[string]string  `json:"templateVars"`
+	Template       string             `json:"template,omitempty"`
+	IncludeTree    bool               `json:"includeTree"`    // Include directory tree in output
+	IncludeSummary bool               `json:"includeSummary"` // Describe directories in the tree from their README
+	IncludeIgnored bool               `json:"includeIgnored"` // Include ignored files in tree and content
+	FoldSiblings   bool               `json:"foldSiblings"`   // Fold many similarly named files to a sample
+	TreeDepth      int                `json:"treeDepth"`      // Collapse tree directories below this depth (0 = all)
+	Raw            bool               `json:"raw"`            // Emit only the tree and file contents, no template
+	Priority       Priority           `json:"priority"`       // Which files to keep when contents exceed MaxTotalSize
+	Order          Order              `json:"order"`          // Sequence of the file blocks ("" = tree order)
+	CustomOrder    []string           `json:"customOrder"`    // Relative paths in OrderCustom order
+	Dedup          *Deduplicator      `json:"-"`              // Embeds identical files once (nil = disabled)
+	Unreadable     *UnreadableReport  `json:"-"`              // Collects files that could not be read (nil = not kept)
+	Summarizer     *Summarizer        `json:"-"`              // Embeds matching files as a summary (nil = none)
+	Redactor       *Redactor          `json:"-"`              // Redacts secrets ahead of Transforms and summaries
+	Truncated      *TruncationReport  `json:"-"`              // Collects files cut by PerFileMaxTokens (nil = not kept)
+	Manifest       *ManifestRecorder  `json:"-"`              // Records what was rendered (nil = not kept)
+	Strict         bool               `json:"strict"`         // Fail on an unreadable file instead of a placeholder
+	FileDelimiters *FileDelimiters    `json:"fileDelimiters"` // Lines around each file (nil = defaults)
+	Transforms     []ContentTransform `json:"-"`              // Applied to each file's content in order
+	AllowOverflow  bool               `json:"allowOverflow"`  // Render past MaxTotalSize instead of failing
+	Funcs          template.FuncMap   `json:"-"`              // Extra template functions, overriding built-ins
+
+	// PerFileMaxTokens cuts files estimated over this many tokens to their head and tail (0 = no limit)
+	PerFileMaxTokens int `json:"perFileMaxTokens"`
 }
 
 type ContextData struct {
@@ -47,6 +103,7 @@ type ContextData struct {
 	Rules         string         `json:"rules"`
 	FileStructure string         `json:"fileStructure"`
 	Files         []FileContent  `json:"files"`
+	Omitted       []OmittedFile  `json:"omitted,omitempty"` // Files left out to fit MaxTotalSize
 	CurrentDate   string         `json:"currentDate"`
 	Config        GenerateConfig `json:"config"`
 }
@@ -85,6 +142,15 @@ func (g *DefaultContextGenerator) GenerateWithProgress(
 
 func (g *DefaultContextGenerator) GenerateWithProgressEx(
 	root *scanner.FileNode, selections map[string]bool, config GenerateConfig, progress func(GenProgress),
+) (string, error) {
+	return g.GenerateWithContext(context.Background(), root, selections, config, progress)
+}
+
+// GenerateWithContext is GenerateWithProgressEx that stops with ctx.Err() when ctx is
+// cancelled while file contents are being collected.
+func (g *DefaultContextGenerator) GenerateWithContext(
+	ctx context.Context, root *scanner.FileNode, selections map[string]bool, config GenerateConfig,
+	progress func(GenProgress),
 ) (string, error) {
 	if err := g.validateConfig(&config); err != nil {
 		return "", fmt.Errorf("invalid config: %w", err)
@@ -97,8 +163,17 @@ func (g *DefaultContextGenerator) GenerateWithProgressEx(
 			progress(GenProgress{Stage: "tree_generation", Message: "Generating file structure..."})
 		}
 
+		renderer := g.treeRenderer
+		if config.IncludeIgnored || config.IncludeSummary || config.FoldSiblings || config.TreeDepth > 0 {
+			renderer = NewTreeRenderer().
+				WithShowIgnored(config.IncludeIgnored).
+				WithDirDescriptions(config.IncludeSummary).
+				WithFoldSiblings(config.FoldSiblings).
+				WithCollapseDepth(config.TreeDepth)
+		}
+
 		var err error
-		fileStructure, err = g.treeRenderer.RenderTree(root)
+		fileStructure, err = renderer.RenderTree(root)
 		if err != nil {
 			return "", fmt.Errorf("failed to render tree: %w", err)
 		}
@@ -108,20 +183,112 @@ func (g *DefaultContextGenerator) GenerateWithProgressEx(
 		progress(GenProgress{Stage: "content_collection", Message: "Collecting file contents..."})
 	}
 
-	files, err := g.collectFileContents(root, selections, config)
+	files, err := g.collectFileContents(ctx, root, selections, config)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+			return "", ctxErr
+		}
 		return "", fmt.Errorf("failed to collect file contents: %w", err)
 	}
 
+	var result string
+	if config.Priority == PriorityNone {
+		result, err = g.render(fileStructure, files, nil, config, progress)
+	} else {
+		result, err = g.renderWithinBudget(fileStructure, files, config, progress)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return g.finish(result, config, progress)
+}
+
+// renderWithinBudget renders the context with as many files as fit in MaxTotalSize,
+// taken in config.Priority order. The rendered size grows with every file kept, so
+// the count is found by binary search, bounded by the files whose contents alone fit.
+func (g *DefaultContextGenerator) renderWithinBudget(
+	fileStructure string, files []FileContent, config GenerateConfig, progress func(GenProgress),
+) (string, error) {
+	if progress != nil && !config.Raw {
+		progress(GenProgress{Stage: "template_rendering", Message: "Rendering template..."})
+	}
+
+	order := rankFiles(files, config.Priority)
+	renderN := func(n int) (string, []OmittedFile, error) {
+		kept, omitted := keepRanked(files, order, n)
+		result, err := g.render(fileStructure, kept, omitted, config, nil)
+		return result, omitted, err
+	}
+
+	low, high := 0, contentFitCount(files, order, config.MaxTotalSize)
+	for low < high {
+		mid := (low + high + 1) / 2
+		result, _, err := renderN(mid)
+		if err != nil {
+			return "", err
+		}
+		if int64(len(result)) <= config.MaxTotalSize {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+
+	result, omitted, err := renderN(low)
+	if err != nil {
+		return "", err
+	}
+	if progress != nil && len(omitted) > 0 {
+		progress(GenProgress{
+			Stage:   "content_collection",
+			Message: fmt.Sprintf("Omitted %d files to fit the size limit", len(omitted)),
+		})
+	}
+
+	return result, nil
+}
+
+// render combines the tree and file contents and, unless config.Raw is set, renders
+// them through the template.
+func (g *DefaultContextGenerator) render(
+	fileStructure string, files []FileContent, omitted []OmittedFile, config GenerateConfig,
+	progress func(GenProgress),
+) (string, error) {
+	files = orderFiles(files, config.Order, config.CustomOrder)
+
 	// Combine tree structure with file content blocks (only if tree is included)
 	var fileStructureComplete string
 	if config.IncludeTree {
-		fileStructureComplete = g.buildCompleteFileStructure(fileStructure, files)
+		fileStructureComplete = g.buildCompleteFileStructure(fileStructure, files, *config.FileDelimiters)
 	} else {
 		// Without tree, just include file content blocks
-		fileStructureComplete = renderFileContentBlocks(files)
+		fileStructureComplete = renderFileContentBlocks(files, *config.FileDelimiters)
+	}
+	fileStructureComplete += renderOmittedFiles(omitted, config.Priority)
+
+	config.Manifest.record(Manifest{
+		Version:       ManifestVersion,
+		Template:      config.Template,
+		TemplateVars:  config.TemplateVars,
+		FileStructure: fileStructureComplete,
+		Files:         files,
+		Omitted:       omitted,
+		Raw:           config.Raw,
+	})
+
+	if config.Raw {
+		return fileStructureComplete, nil
 	}
 
+	return g.renderTemplate(fileStructureComplete, files, omitted, config, progress)
+}
+
+// renderTemplate renders the template of config over the combined file structure.
+func (g *DefaultContextGenerator) renderTemplate(
+	fileStructureComplete string, files []FileContent, omitted []OmittedFile, config GenerateConfig,
+	progress func(GenProgress),
+) (string, error) {
 	if progress != nil {
 		progress(GenProgress{Stage: "template_rendering", Message: "Rendering template..."})
 	}
@@ -131,28 +298,41 @@ func (g *DefaultContextGenerator) GenerateWithProgressEx(
 		Rules:         config.TemplateVars["RULES"],
 		FileStructure: fileStructureComplete,
 		Files:         files,
+		Omitted:       omitted,
 		CurrentDate:   time.Now().Format("2006-01-02 15:04:05"),
 		Config:        config,
 	}
 
-	template := config.Template
-	if template == "" {
-		template = g.templateRenderer.getDefaultTemplate()
+	tmpl := config.Template
+	if tmpl == "" {
+		tmpl = g.templateRenderer.getDefaultTemplate()
 	}
 
 	// Convert {VARIABLE} syntax to {{.Variable}} syntax for Go templates
-	template = convertTemplateVariables(template)
+	tmpl = convertTemplateVariables(tmpl, config.TemplateVars)
+
+	renderer := g.templateRenderer
+	if len(config.Funcs) > 0 {
+		renderer = g.templateRenderer.WithFuncs(config.Funcs)
+	}
 
-	result, err := g.templateRenderer.RenderTemplate(template, contextData)
+	result, err := renderer.RenderTemplate(tmpl, contextData)
 	if err != nil {
 		return "", fmt.Errorf("failed to render template: %w", err)
 	}
 
-	if int64(len(result)) > config.MaxTotalSize {
-		return "", fmt.Errorf(
+	return result, nil
+}
+
+// finish enforces the total size limit on the generated context and reports completion.
+func (g *DefaultContextGenerator) finish(
+	result string, config GenerateConfig, progress func(GenProgress),
+) (string, error) {
+	if !config.AllowOverflow && int64(len(result)) > config.MaxTotalSize {
+		return "", &sizeLimitError{msg: fmt.Sprintf(
 			"generated context exceeds total size limit: %d bytes > %d bytes",
 			len(result), config.MaxTotalSize,
-		)
+		)}
 	}
 
 	if progress != nil {
@@ -162,7 +342,6 @@ func (g *DefaultContextGenerator) GenerateWithProgressEx(
 	return result, nil
 }
 
-//nolint:unparam // error return reserved for future validation logic
 func (g *DefaultContextGenerator) validateConfig(config *GenerateConfig) error {
 	// Set defaults if fields are not set
 	if config.MaxFileSize == 0 {
@@ -177,19 +356,32 @@ func (g *DefaultContextGenerator) validateConfig(config *GenerateConfig) error {
 	if config.TemplateVars == nil {
 		config.TemplateVars = make(map[string]string)
 	}
+	if config.FileDelimiters == nil {
+		defaults := DefaultFileDelimiters()
+		config.FileDelimiters = &defaults
+	} else if err := config.FileDelimiters.Validate(); err != nil {
+		return err
+	}
+	if config.Order != "" {
+		if _, err := ParseOrder(string(config.Order)); err != nil {
+			return err
+		}
+	}
 	// Note: IncludeTree and IncludeSummary default to false (zero value)
 	// They must be explicitly set to true when desired
 	return nil
 }
 
 func (g *DefaultContextGenerator) collectFileContents(
-	root *scanner.FileNode, selections map[string]bool, config GenerateConfig,
+	ctx context.Context, root *scanner.FileNode, selections map[string]bool, config GenerateConfig,
 ) ([]FileContent, error) {
-	return collectFileContents(root, selections, config)
+	return collectFileContents(ctx, root, selections, config)
 }
 
 // buildCompleteFileStructure combines ASCII tree with file content blocks
-func (g *DefaultContextGenerator) buildCompleteFileStructure(tree string, files []FileContent) string {
+func (g *DefaultContextGenerator) buildCompleteFileStructure(
+	tree string, files []FileContent, delimiters FileDelimiters,
+) string {
 	var builder strings.Builder
 
 	// First part: ASCII tree structure
@@ -200,14 +392,15 @@ func (g *DefaultContextGenerator) buildCompleteFileStructure(tree string, files
 		builder.WriteString("\n")
 
 		// Second part: File content blocks in XML-like format
-		builder.WriteString(renderFileContentBlocks(files))
+		builder.WriteString(renderFileContentBlocks(files, delimiters))
 	}
 
 	return builder.String()
 }
 
-// convertTemplateVariables converts {VARIABLE} syntax to {{.Variable}} syntax for Go templates
-func convertTemplateVariables(template string) string {
+// convertTemplateVariables converts {VARIABLE} syntax to {{.Variable}} syntax for Go templates.
+// Other variables in vars, such as --var values and GIT_BRANCH, are read from the config.
+func convertTemplateVariables(template string, vars map[string]string) string {
 	// Map of variable conversions from {UPPERCASE} to {{.TitleCase}}
 	conversions := map[string]string{
 		"{TASK}":           "{{.Task}}",
@@ -216,6 +409,13 @@ func convertTemplateVariables(template string) string {
 		"{CURRENT_DATE}":   "{{.CurrentDate}}",
 	}
 
+	for name := range vars {
+		placeholder := "{" + name + "}"
+		if _, ok := conversions[placeholder]; !ok {
+			conversions[placeholder] = fmt.Sprintf("{{index .Config.TemplateVars %q}}", name)
+		}
+	}
+
 	result := template
 	for old, new := range conversions {
 		result = strings.ReplaceAll(result, old, new)
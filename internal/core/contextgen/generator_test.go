@@ -1,6 +1,7 @@
 package contextgen
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
+	"github.com/quantmind-br/shotgun-cli/internal/core/tokens"
 )
 
 // Compile-time contract checks
@@ -78,6 +80,7 @@ type fileSpec struct {
 	selected      bool
 	gitIgnored    bool
 	customIgnored bool
+	generated     bool
 }
 
 func buildTestTree(tb testing.TB, specs []fileSpec) (*scanner.FileNode, map[string]bool, func()) {
@@ -120,6 +123,7 @@ func buildTestTree(tb testing.TB, specs []fileSpec) (*scanner.FileNode, map[stri
 			IsDir:           spec.isDir,
 			IsGitignored:    spec.gitIgnored,
 			IsCustomIgnored: spec.customIgnored,
+			IsGenerated:     spec.generated,
 		}
 
 		if spec.selected {
@@ -306,6 +310,25 @@ func TestDefaultContextGenerator_GenerateScenarios(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:  "other template variables substituted",
+			specs: []fileSpec{{relPath: "src/main.go", content: "package main", selected: true}},
+			config: GenerateConfig{
+				Template:     "{TASK} on {GIT_BRANCH} by {AUTHOR}, {UNKNOWN} kept",
+				MaxTotalSize: 1 << 20,
+				MaxFileSize:  1 << 20,
+				MaxFiles:     5,
+				TemplateVars: map[string]string{"TASK": "Review", "GIT_BRANCH": "main", "AUTHOR": "{{.Task}}"},
+			},
+			verify: func(t *testing.T, output string, err error) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if output != "Review on main by {{.Task}}, {UNKNOWN} kept" {
+					t.Fatalf("unexpected substitution: %s", output)
+				}
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -422,7 +445,7 @@ func TestDefaultContextGenerator_ErrorPropagation(t *testing.T) {
 	_ = os.Remove(filepath.Join(root.Path, "missing.txt"))
 
 	gen := NewDefaultContextGenerator()
-	cfg := GenerateConfig{TemplateVars: map[string]string{"TASK": "x"}}
+	cfg := GenerateConfig{TemplateVars: map[string]string{"TASK": "x"}, Strict: true}
 
 	_, err := gen.Generate(root, selections, cfg)
 	if err == nil {
@@ -433,6 +456,35 @@ func TestDefaultContextGenerator_ErrorPropagation(t *testing.T) {
 	}
 }
 
+func TestDefaultContextGenerator_UnreadableFilePlaceholder(t *testing.T) {
+	t.Parallel()
+
+	specs := []fileSpec{
+		{relPath: "ok.txt", content: "readable", selected: true},
+		{relPath: "missing.txt", content: "temp", selected: true},
+	}
+	root, selections, cleanup := buildTestTree(t, specs)
+	defer cleanup()
+	_ = os.Remove(filepath.Join(root.Path, "missing.txt"))
+
+	report := NewUnreadableReport()
+	out, err := NewDefaultContextGenerator().Generate(root, selections, GenerateConfig{Raw: true, Unreadable: report})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if !strings.Contains(out, "readable") {
+		t.Errorf("expected readable file to be embedded, got:\n%s", out)
+	}
+	if !strings.Contains(out, "[unreadable: no such file or directory]") {
+		t.Errorf("expected placeholder for the unreadable file, got:\n%s", out)
+	}
+	files := report.Files()
+	if len(files) != 1 || files[0].RelPath != "missing.txt" || files[0].Reason != "no such file or directory" {
+		t.Errorf("unexpected report: %+v", files)
+	}
+}
+
 func TestDefaultContextGenerator_IgnoresFlaggedFilesInTree(t *testing.T) {
 	t.Parallel()
 	specs := []fileSpec{
@@ -483,6 +535,414 @@ func TestDefaultContextGenerator_TemplateValidation(t *testing.T) {
 	}
 }
 
+func TestDefaultContextGenerator_AppliesTransforms(t *testing.T) {
+	t.Parallel()
+
+	specs := []fileSpec{
+		{relPath: "main.go", content: "package main // entry point\n", selected: true},
+	}
+	root, selections, cleanup := buildTestTree(t, specs)
+	defer cleanup()
+
+	var seen []string
+	cfg := GenerateConfig{
+		Template: "{{.FileStructure}}",
+		Transforms: []ContentTransform{
+			func(relPath, content string) string {
+				seen = append(seen, relPath)
+				return strings.ReplaceAll(content, " // entry point", "")
+			},
+			func(_, content string) string {
+				return strings.ToUpper(content)
+			},
+		},
+	}
+
+	out, err := NewDefaultContextGenerator().Generate(root, selections, cfg)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if len(seen) != 1 || seen[0] != "main.go" {
+		t.Fatalf("expected transform to receive relative path, got %v", seen)
+	}
+	if !strings.Contains(out, "PACKAGE MAIN\n</file>") {
+		t.Fatalf("expected transformed content in output, got %s", out)
+	}
+}
+
+func TestDefaultContextGenerator_RawContext(t *testing.T) {
+	t.Parallel()
+
+	specs := []fileSpec{
+		{relPath: "main.go", content: "package main\n", selected: true},
+		{relPath: "pkg/util.go", content: "package pkg\n", selected: true},
+	}
+	root, selections, cleanup := buildTestTree(t, specs)
+	defer cleanup()
+
+	cfg := GenerateConfig{
+		Raw:          true,
+		IncludeTree:  true,
+		TemplateVars: map[string]string{"TASK": "do something", "RULES": "be careful"},
+	}
+
+	out, err := NewDefaultContextGenerator().Generate(root, selections, cfg)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, framing := range []string{"TASK", "Task", "RULES", "Rules", "do something", "# Project Context", "## File"} {
+		if strings.Contains(out, framing) {
+			t.Errorf("raw context should not contain %q, got:\n%s", framing, out)
+		}
+	}
+	for _, want := range []string{"util.go", `<file path="main.go">`, `<file path="pkg/util.go">`, "package pkg"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("raw context missing %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestDefaultContextGenerator_FoldSiblings(t *testing.T) {
+	t.Parallel()
+
+	specs := []fileSpec{{relPath: "main.go", content: "package main\n", selected: true}}
+	for i := 1; i <= 50; i++ {
+		specs = append(specs, fileSpec{
+			relPath:  fmt.Sprintf("fixtures/snapshot_%03d.txt", i),
+			content:  fmt.Sprintf("snapshot %d\n", i),
+			selected: true,
+		})
+	}
+	root, selections, cleanup := buildTestTree(t, specs)
+	defer cleanup()
+
+	cfg := GenerateConfig{Raw: true, IncludeTree: true, FoldSiblings: true}
+	out, err := NewDefaultContextGenerator().Generate(root, selections, cfg)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, want := range []string{
+		`<file path="fixtures/snapshot_001.txt">`,
+		`<file path="fixtures/snapshot_003.txt">`,
+		`<file path="main.go">`,
+		"<!-- … 47 more files matching snapshot_*.txt -->",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("folded context missing %q, got:\n%s", want, out)
+		}
+	}
+	if got := strings.Count(out, "└── … 47 more files matching snapshot_*.txt"); got != 1 {
+		t.Errorf("expected one fold note in the tree, found %d:\n%s", got, out)
+	}
+	for _, unwanted := range []string{"snapshot_004.txt", "snapshot_050.txt"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("folded context should not contain %q", unwanted)
+		}
+	}
+
+	cfg.FoldSiblings = false
+	out, err = NewDefaultContextGenerator().Generate(root, selections, cfg)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !strings.Contains(out, `<file path="fixtures/snapshot_050.txt">`) || strings.Contains(out, "more files matching") {
+		t.Errorf("siblings should not be folded when disabled")
+	}
+}
+
+func TestDefaultContextGenerator_CustomTemplateFuncs(t *testing.T) {
+	t.Parallel()
+
+	specs := []fileSpec{{relPath: "pkg/util.go", content: "package pkg\n", selected: true}}
+	root, selections, cleanup := buildTestTree(t, specs)
+	defer cleanup()
+
+	cfg := GenerateConfig{
+		Template: "{{range .Files}}[{{basename .RelPath}}] [{{upper .Language}}]{{end}}",
+		Funcs: map[string]interface{}{
+			"basename": filepath.Base,
+			"upper":    func(s string) string { return "custom-" + s },
+		},
+	}
+
+	out, err := NewDefaultContextGenerator().Generate(root, selections, cfg)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if out != "[util.go] [custom-go]" {
+		t.Errorf("expected custom funcs to be callable and override built-ins, got %q", out)
+	}
+}
+
+func TestDefaultContextGenerator_DefaultTemplateFenceLanguage(t *testing.T) {
+	t.Parallel()
+
+	specs := []fileSpec{
+		{relPath: "main.go", content: "package main\n", selected: true},
+		{relPath: "web/App.tsx", content: "export const App = () => null\n", selected: true},
+		{relPath: "notes.xyz", content: "plain notes\n", selected: true},
+	}
+	root, selections, cleanup := buildTestTree(t, specs)
+	defer cleanup()
+
+	cfg := GenerateConfig{TemplateVars: map[string]string{"TASK": "Review"}}
+	out, err := NewDefaultContextGenerator().Generate(root, selections, cfg)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, want := range []string{"```go\npackage main", "```tsx\nexport const App", "```\nplain notes"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestDefaultContextGenerator_Priority(t *testing.T) {
+	t.Parallel()
+
+	specs := []fileSpec{
+		{relPath: "a_large.go", content: strings.Repeat("a", 600), selected: true},
+		{relPath: "b_small.go", content: strings.Repeat("b", 100), selected: true},
+		{relPath: "c_medium.go", content: strings.Repeat("c", 300), selected: true},
+	}
+	root, selections, cleanup := buildTestTree(t, specs)
+	defer cleanup()
+
+	// Make b_small.go the oldest file and a_large.go the newest
+	now := time.Now()
+	for i, name := range []string{"b_small.go", "c_medium.go", "a_large.go"} {
+		modTime := now.Add(time.Duration(i-3) * time.Hour)
+		if err := os.Chtimes(filepath.Join(root.Path, name), modTime, modTime); err != nil {
+			t.Fatalf("chtimes: %v", err)
+		}
+	}
+
+	tests := []struct {
+		priority Priority
+		kept     []string
+		omitted  []string
+	}{
+		{PrioritySmallestFirst, []string{"b_small.go", "c_medium.go"}, []string{"a_large.go (600B)"}},
+		{PriorityPathOrder, []string{"a_large.go"}, []string{"b_small.go (100B)", "c_medium.go (300B)"}},
+		{PriorityRecentlyModified, []string{"a_large.go"}, []string{"c_medium.go (300B)", "b_small.go (100B)"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.priority), func(t *testing.T) {
+			cfg := GenerateConfig{Raw: true, MaxTotalSize: 800, Priority: tt.priority}
+			out, err := NewDefaultContextGenerator().Generate(root, selections, cfg)
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			if len(out) > 800 {
+				t.Errorf("expected output within 800 bytes, got %d", len(out))
+			}
+
+			for _, name := range tt.kept {
+				if !strings.Contains(out, `<file path="`+name+`">`) {
+					t.Errorf("expected %s to be kept, got:\n%s", name, out)
+				}
+			}
+			wantOmitted := "<omitted_files reason=\"size limit\" priority=\"" + string(tt.priority) + "\">\n" +
+				strings.Join(tt.omitted, "\n") + "\n</omitted_files>\n"
+			if !strings.HasSuffix(out, wantOmitted) {
+				t.Errorf("expected omitted list %q, got:\n%s", wantOmitted, out)
+			}
+		})
+	}
+
+	t.Run("budget accounts for framing", func(t *testing.T) {
+		cfg := GenerateConfig{Raw: true, MaxTotalSize: 450, Priority: PrioritySmallestFirst}
+		out, err := NewDefaultContextGenerator().Generate(root, selections, cfg)
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if len(out) > 450 {
+			t.Errorf("expected output within 450 bytes, got %d", len(out))
+		}
+		if !strings.Contains(out, `<file path="b_small.go">`) || strings.Contains(out, `<file path="c_medium.go">`) {
+			t.Errorf("expected only b_small.go to be kept, got:\n%s", out)
+		}
+	})
+
+	t.Run("no priority fails", func(t *testing.T) {
+		_, err := NewDefaultContextGenerator().Generate(root, selections, GenerateConfig{Raw: true, MaxTotalSize: 800})
+		if err == nil {
+			t.Error("expected size limit error without a priority")
+		}
+	})
+
+	t.Run("allow overflow renders everything", func(t *testing.T) {
+		cfg := GenerateConfig{Raw: true, MaxTotalSize: 800, AllowOverflow: true}
+		out, err := NewDefaultContextGenerator().Generate(root, selections, cfg)
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if len(out) <= 800 || strings.Contains(out, "<omitted_files") {
+			t.Errorf("expected all files past the 800 byte limit, got %d bytes:\n%s", len(out), out)
+		}
+	})
+}
+
+func TestRankFiles_Importance(t *testing.T) {
+	t.Parallel()
+
+	files := []FileContent{
+		{RelPath: "internal/util/strings.go", Content: "package util\n"},
+		{RelPath: "internal/api/api.go", Content: "package api\n\nimport \"example.com/app/internal/util\"\n"},
+		{RelPath: "internal/api/api_test.go", Content: "package api\n\nimport \"testing\"\n"},
+		{RelPath: "cmd/app/main.go", Content: "package main\n\nimport (\n\t\"fmt\"\n\n" +
+			"\t\"example.com/app/internal/api\"\n\t\"example.com/app/internal/util\"\n)\n"},
+		{RelPath: "web/lib/format.ts", Content: "export const format = 1\n"},
+		{RelPath: "web/index.ts", Content: "import { format } from './lib/format'\n" +
+			"const x = require(\"./lib/format.ts\")\n"},
+		{RelPath: "web/page.ts", Content: "import { format } from \"./lib/format.js\"\n"},
+		{RelPath: "py/pkg/models.py", Content: "x = 1\n"},
+		{RelPath: "py/pkg/views.py", Content: "from .models import x\n"},
+		{RelPath: "py/tool.py", Content: "import pkg.models\n"},
+		{RelPath: "README.md", Content: "# App\n"},
+		{RelPath: "notes.txt", Content: "notes\n"},
+	}
+
+	wantScores := map[string]int{
+		"internal/util/strings.go": 4, // imported by api.go and main.go
+		"internal/api/api.go":      2,
+		"internal/api/api_test.go": -2,
+		"cmd/app/main.go":          8,
+		"web/lib/format.ts":        4, // index.ts and page.ts, each counted once
+		"web/index.ts":             8,
+		"web/page.ts":              0,
+		"py/pkg/models.py":         4, // relative and absolute imports
+		"py/pkg/views.py":          0,
+		"py/tool.py":               0,
+		"README.md":                6,
+		"notes.txt":                0,
+	}
+	for i, score := range importanceScores(files) {
+		if want := wantScores[files[i].RelPath]; score != want {
+			t.Errorf("importance of %s = %d, want %d", files[i].RelPath, score, want)
+		}
+	}
+
+	var ranked []string
+	for _, i := range rankFiles(files, PriorityImportance) {
+		ranked = append(ranked, files[i].RelPath)
+	}
+	want := []string{
+		"cmd/app/main.go", "web/index.ts", "README.md", "internal/util/strings.go", "web/lib/format.ts",
+		"py/pkg/models.py", "internal/api/api.go", "web/page.ts", "py/pkg/views.py", "py/tool.py", "notes.txt",
+		"internal/api/api_test.go",
+	}
+	if strings.Join(ranked, ",") != strings.Join(want, ",") {
+		t.Errorf("rankFiles(importance) = %v, want %v", ranked, want)
+	}
+}
+
+func TestParsePriority(t *testing.T) {
+	for _, p := range Priorities {
+		got, err := ParsePriority(string(p))
+		if err != nil || got != p {
+			t.Errorf("ParsePriority(%q) = %q, %v", p, got, err)
+		}
+	}
+	if got, err := ParsePriority(""); err != nil || got != PriorityNone {
+		t.Errorf("ParsePriority(\"\") = %q, %v", got, err)
+	}
+	if _, err := ParsePriority("largest-first"); err == nil {
+		t.Error("expected error for unknown priority")
+	}
+}
+
+func TestDefaultContextGenerator_Order(t *testing.T) {
+	t.Parallel()
+
+	specs := []fileSpec{
+		{relPath: "a.go", content: strings.Repeat("a", 30), selected: true},
+		{relPath: "b.go", content: strings.Repeat("b", 10), selected: true},
+		{relPath: "c.go", content: strings.Repeat("c", 30), selected: true},
+		{relPath: "d.go", content: strings.Repeat("d", 20), selected: true},
+	}
+	root, selections, cleanup := buildTestTree(t, specs)
+	defer cleanup()
+
+	tests := []struct {
+		order  Order
+		custom []string
+		want   []string
+	}{
+		{OrderTree, nil, []string{"a.go", "b.go", "c.go", "d.go"}},
+		{OrderPath, nil, []string{"a.go", "b.go", "c.go", "d.go"}},
+		// Equal sizes keep tree order in both directions
+		{OrderSizeAsc, nil, []string{"b.go", "d.go", "a.go", "c.go"}},
+		{OrderSizeDesc, nil, []string{"a.go", "c.go", "d.go", "b.go"}},
+		// Files the custom order does not list follow it in tree order
+		{OrderCustom, []string{"d.go", "a.go"}, []string{"d.go", "a.go", "b.go", "c.go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			cfg := GenerateConfig{Raw: true, Order: tt.order, CustomOrder: tt.custom}
+			for run := 0; run < 2; run++ {
+				out, err := NewDefaultContextGenerator().Generate(root, selections, cfg)
+				if err != nil {
+					t.Fatalf("Generate failed: %v", err)
+				}
+
+				var got []string
+				for _, line := range strings.Split(out, "\n") {
+					if name, ok := strings.CutPrefix(line, `<file path="`); ok {
+						got = append(got, strings.TrimSuffix(name, `">`))
+					}
+				}
+				if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+					t.Errorf("run %d: expected files in order %v, got %v", run, tt.want, got)
+				}
+			}
+		})
+	}
+
+	t.Run("invalid order", func(t *testing.T) {
+		_, err := NewDefaultContextGenerator().Generate(root, selections, GenerateConfig{Order: "random"})
+		if err == nil || !strings.Contains(err.Error(), `unknown order "random"`) {
+			t.Errorf("expected unknown order error, got %v", err)
+		}
+	})
+}
+
+func TestOrderFiles_PathDiffersFromTree(t *testing.T) {
+	// Tree order lists a directory's files before its subdirectories
+	files := []FileContent{{RelPath: "z.go"}, {RelPath: "a/b.go"}, {RelPath: "m.go"}}
+
+	got := orderFiles(files, OrderPath, nil)
+	if got[0].RelPath != "a/b.go" || got[1].RelPath != "m.go" || got[2].RelPath != "z.go" {
+		t.Errorf("expected path order, got %v", got)
+	}
+	if files[0].RelPath != "z.go" {
+		t.Error("expected orderFiles to leave its input unchanged")
+	}
+	if got := orderFiles(files, OrderTree, nil); got[0].RelPath != "z.go" {
+		t.Errorf("expected tree order unchanged, got %v", got)
+	}
+}
+
+func TestParseOrder(t *testing.T) {
+	for _, o := range Orders {
+		got, err := ParseOrder(string(o))
+		if err != nil || got != o {
+			t.Errorf("ParseOrder(%q) = %q, %v", o, got, err)
+		}
+	}
+	if got, err := ParseOrder(""); err != nil || got != OrderTree {
+		t.Errorf("ParseOrder(\"\") = %q, %v", got, err)
+	}
+	if _, err := ParseOrder("random"); err == nil {
+		t.Error("expected error for unknown order")
+	}
+}
+
 func BenchmarkDefaultContextGenerator(b *testing.B) {
 	specs := make([]fileSpec, 0, 50)
 	for i := 0; i < 50; i++ {
@@ -508,3 +968,409 @@ func BenchmarkDefaultContextGenerator(b *testing.B) {
 		}
 	}
 }
+
+func TestDefaultContextGenerator_Dedup(t *testing.T) {
+	t.Parallel()
+
+	shared := strings.Repeat("package fixture\n", 20)
+	specs := []fileSpec{
+		{relPath: "src/foo.go", content: shared, selected: true},
+		{relPath: "third_party/foo.go", content: shared, selected: true},
+		{relPath: "other.go", content: "package other\n", selected: true},
+	}
+	root, selections, cleanup := buildTestTree(t, specs)
+	defer cleanup()
+
+	dedup := NewDeduplicator()
+	out, err := NewDefaultContextGenerator().Generate(root, selections, GenerateConfig{Raw: true, Dedup: dedup})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if got := strings.Count(out, shared); got != 1 {
+		t.Errorf("expected the shared content once, got %d times:\n%s", got, out)
+	}
+	if !strings.Contains(out, "<file path=\"third_party/foo.go\">\n// identical to src/foo.go\n</file>") {
+		t.Errorf("expected third_party/foo.go to reference src/foo.go, got:\n%s", out)
+	}
+	if dedup.FilesDeduplicated() != 1 {
+		t.Errorf("FilesDeduplicated() = %d, want 1", dedup.FilesDeduplicated())
+	}
+	if want := int64(len(shared) - len("// identical to src/foo.go\n")); dedup.BytesSaved() != want {
+		t.Errorf("BytesSaved() = %d, want %d", dedup.BytesSaved(), want)
+	}
+	if dedup.TokensSaved() <= 0 {
+		t.Errorf("TokensSaved() = %d, want > 0", dedup.TokensSaved())
+	}
+}
+
+func TestDefaultContextGenerator_DedupComparesTransformedContent(t *testing.T) {
+	t.Parallel()
+
+	specs := []fileSpec{
+		{relPath: "a.go", content: "package x\n", selected: true},
+		{relPath: "b.go", content: "package x\n", selected: true},
+	}
+	root, selections, cleanup := buildTestTree(t, specs)
+	defer cleanup()
+
+	// A path-dependent transform makes otherwise identical files differ
+	tagPath := func(relPath, content string) string { return "// " + relPath + "\n" + content }
+	dedup := NewDeduplicator()
+	cfg := GenerateConfig{Raw: true, Dedup: dedup, Transforms: []ContentTransform{tagPath}}
+	out, err := NewDefaultContextGenerator().Generate(root, selections, cfg)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if strings.Contains(out, "identical to") || dedup.FilesDeduplicated() != 0 {
+		t.Errorf("expected no deduplication, got:\n%s", out)
+	}
+}
+
+func TestDefaultContextGenerator_DedupKeepsSmallFiles(t *testing.T) {
+	t.Parallel()
+
+	specs := []fileSpec{
+		{relPath: "a/doc.go", content: "package a\n", selected: true},
+		{relPath: "b/doc.go", content: "package a\n", selected: true},
+	}
+	root, selections, cleanup := buildTestTree(t, specs)
+	defer cleanup()
+
+	dedup := NewDeduplicator()
+	out, err := NewDefaultContextGenerator().Generate(root, selections, GenerateConfig{Raw: true, Dedup: dedup})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if got := strings.Count(out, "package a\n"); got != 2 {
+		t.Errorf("expected files shorter than a reference to be embedded twice, got %d:\n%s", got, out)
+	}
+	if dedup.FilesDeduplicated() != 0 {
+		t.Errorf("FilesDeduplicated() = %d, want 0", dedup.FilesDeduplicated())
+	}
+}
+
+func TestDefaultContextGenerator_FileDelimiters(t *testing.T) {
+	t.Parallel()
+
+	specs := []fileSpec{
+		{relPath: "main.go", content: "package main\n\nfunc main() {}\n", selected: true},
+	}
+	root, selections, cleanup := buildTestTree(t, specs)
+	defer cleanup()
+
+	tests := []struct {
+		name       string
+		delimiters FileDelimiters
+		want       string
+		notWant    string
+	}{
+		{
+			name: "placeholders",
+			delimiters: FileDelimiters{
+				Header: "===== {PATH} ({LANG}, {LINES} lines, {SIZE}) =====",
+				Footer: "===== end =====",
+			},
+			want:    "===== main.go (go, 3 lines, 29B) =====\npackage main\n\nfunc main() {}\n===== end =====",
+			notWant: "<file path=",
+		},
+		{
+			name:       "empty header",
+			delimiters: FileDelimiters{Header: "", Footer: "---"},
+			want:       "package main\n\nfunc main() {}\n---",
+			notWant:    "<file path=",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delimiters := tt.delimiters
+			out, err := NewDefaultContextGenerator().Generate(root, selections,
+				GenerateConfig{Raw: true, FileDelimiters: &delimiters})
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("expected output to contain %q, got:\n%s", tt.want, out)
+			}
+			if strings.Contains(out, tt.notWant) {
+				t.Errorf("expected output not to contain %q, got:\n%s", tt.notWant, out)
+			}
+		})
+	}
+}
+
+func TestDefaultContextGenerator_InvalidFileDelimiters(t *testing.T) {
+	t.Parallel()
+
+	root, selections, cleanup := buildTestTree(t, []fileSpec{{relPath: "a.txt", content: "a", selected: true}})
+	defer cleanup()
+
+	_, err := NewDefaultContextGenerator().Generate(root, selections,
+		GenerateConfig{FileDelimiters: &FileDelimiters{Header: "{PATH"}})
+	if err == nil || !strings.Contains(err.Error(), "invalid file header") {
+		t.Errorf("expected invalid file header error, got %v", err)
+	}
+}
+
+func TestValidateFileDelimiter(t *testing.T) {
+	t.Parallel()
+
+	valid := []string{"", DefaultFileHeader, DefaultFileFooter, "===== FILE: {PATH} =====", `{ "a": 1 }`}
+	for _, delimiter := range valid {
+		if err := ValidateFileDelimiter(delimiter); err != nil {
+			t.Errorf("ValidateFileDelimiter(%q) = %v, want nil", delimiter, err)
+		}
+	}
+
+	invalid := map[string]string{
+		"{PATH":        "unclosed placeholder",
+		"-- {FOO} --":  "unknown placeholder {FOO}",
+		"{PATH} {SIZ}": "unknown placeholder {SIZ}",
+	}
+	for delimiter, want := range invalid {
+		err := ValidateFileDelimiter(delimiter)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ValidateFileDelimiter(%q) = %v, want error containing %q", delimiter, err, want)
+		}
+	}
+}
+
+func TestDefaultContextGenerator_ContentMaxSize(t *testing.T) {
+	t.Parallel()
+
+	specs := []fileSpec{
+		{relPath: "small.txt", content: "small", selected: true},
+		{relPath: "large.txt", content: strings.Repeat("x", 2048), selected: true},
+	}
+	root, selections, cleanup := buildTestTree(t, specs)
+	defer cleanup()
+
+	out, err := NewDefaultContextGenerator().Generate(root, selections,
+		GenerateConfig{Raw: true, IncludeTree: true, ContentMaxSize: 1024})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if !strings.Contains(out, "small") {
+		t.Errorf("expected small file content, got:\n%s", out)
+	}
+	if strings.Contains(out, strings.Repeat("x", 2048)) {
+		t.Errorf("expected large file content to be omitted, got:\n%s", out)
+	}
+	if !strings.Contains(out, "[content omitted: 2.0KB > 1.0KB limit]") {
+		t.Errorf("expected omitted content placeholder, got:\n%s", out)
+	}
+	if !strings.Contains(out, "large.txt [2.0KB]") {
+		t.Errorf("expected large file to stay in the tree, got:\n%s", out)
+	}
+}
+
+func TestDefaultContextGenerator_GeneratedFiles(t *testing.T) {
+	t.Parallel()
+
+	specs := []fileSpec{
+		{relPath: "go.sum", content: "example.com/mod v1.0.0 h1:abc=\n", selected: true, generated: true},
+		{relPath: "api/user.pb.go", content: "package api\n\ntype User struct{}\n", selected: true, generated: true},
+		{relPath: "main.go", content: "package main\n", selected: true},
+	}
+	root, selections, cleanup := buildTestTree(t, specs)
+	defer cleanup()
+
+	out, err := NewDefaultContextGenerator().Generate(root, selections, GenerateConfig{
+		Raw:         true,
+		IncludeTree: true,
+		Summarizer:  NewSummarizer([]string{"*.pb.go"}),
+	})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if strings.Contains(out, "h1:abc=") {
+		t.Errorf("expected go.sum content to be omitted, got:\n%s", out)
+	}
+	if !strings.Contains(out, "[content omitted: generated or lock file, 31B; embed with --include-generated]") {
+		t.Errorf("expected generated file placeholder, got:\n%s", out)
+	}
+	if !strings.Contains(out, "go.sum (generated)") {
+		t.Errorf("expected go.sum to stay in the tree with a marker, got:\n%s", out)
+	}
+	if !strings.Contains(out, "[summarized:") {
+		t.Errorf("expected a summarize pattern to take precedence over the placeholder, got:\n%s", out)
+	}
+	if !strings.Contains(out, "package main") {
+		t.Errorf("expected main.go content, got:\n%s", out)
+	}
+}
+
+func TestDefaultContextGenerator_Summarize(t *testing.T) {
+	t.Parallel()
+
+	generated := "// Code generated by protoc-gen-go. DO NOT EDIT.\n\n" +
+		"package userpb\n\ntype User struct {\n\tName string\n}\n"
+	specs := []fileSpec{
+		{relPath: "api/gen/user.pb.go", content: generated, selected: true},
+		{relPath: "api/gen/notes.txt", content: "\n\nfirst line\nsecond line\n", selected: true},
+		{relPath: "main.go", content: "package main\n", selected: true},
+	}
+	root, selections, cleanup := buildTestTree(t, specs)
+	defer cleanup()
+
+	summarizer := NewSummarizer([]string{"api/gen/"})
+	cfg := GenerateConfig{Raw: true, Summarizer: summarizer}
+	out, err := NewDefaultContextGenerator().Generate(root, selections, cfg)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	wantSummary := fmt.Sprintf(
+		"<file path=\"api/gen/user.pb.go\">\n[summarized: %s, 7 lines] type User struct {\n</file>",
+		formatFileSize(int64(len(generated))))
+	if !strings.Contains(out, wantSummary) {
+		t.Errorf("expected the declaration summary %q, got:\n%s", wantSummary, out)
+	}
+	if !strings.Contains(out, "lines] first line\n") {
+		t.Errorf("expected the first non-blank line as summary, got:\n%s", out)
+	}
+	if strings.Contains(out, "Name string") || !strings.Contains(out, "package main\n") {
+		t.Errorf("expected only main.go embedded in full, got:\n%s", out)
+	}
+	if summarizer.FilesSummarized() != 2 || summarizer.FilesEmbedded() != 1 {
+		t.Errorf("FilesSummarized()=%d FilesEmbedded()=%d, want 2 and 1",
+			summarizer.FilesSummarized(), summarizer.FilesEmbedded())
+	}
+}
+
+func TestDefaultContextGenerator_SummarizeRedacts(t *testing.T) {
+	t.Parallel()
+
+	specs := []fileSpec{
+		{relPath: "app.env", content: "AWS_SECRET=wJalrXUtnFEMIK7MDENGbPxRfiCY\nDEBUG=true\n", selected: true},
+	}
+	root, selections, cleanup := buildTestTree(t, specs)
+	defer cleanup()
+
+	redactor, err := NewRedactor(nil)
+	if err != nil {
+		t.Fatalf("NewRedactor failed: %v", err)
+	}
+	cfg := GenerateConfig{Raw: true, Summarizer: NewSummarizer([]string{"*.env"}), Redactor: redactor}
+	out, err := NewDefaultContextGenerator().Generate(root, selections, cfg)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if strings.Contains(out, "wJalrXUtnFEMIK7MDENGbPxRfiCY") || !strings.Contains(out, "lines] AWS_SECRET=[REDACTED]") {
+		t.Errorf("expected the summary line redacted, got:\n%s", out)
+	}
+	if files := redactor.Files(); len(files) != 1 || files[0] != (RedactedFile{RelPath: "app.env", Count: 1}) {
+		t.Errorf("Files() = %+v, want app.env with 1 secret", files)
+	}
+}
+
+func TestDefaultContextGenerator_PerFileMaxTokens(t *testing.T) {
+	t.Parallel()
+
+	var long strings.Builder
+	for i := 1; i <= 100; i++ {
+		fmt.Fprintf(&long, "line %03d\n", i)
+	}
+	minified := strings.Repeat("x", 400)
+	specs := []fileSpec{
+		{relPath: "long.txt", content: long.String(), selected: true},
+		{relPath: "bundle.min.js", content: minified, selected: true},
+		{relPath: "small.txt", content: "small\n", selected: true},
+	}
+	root, selections, cleanup := buildTestTree(t, specs)
+	defer cleanup()
+
+	report := NewTruncationReport()
+	cfg := GenerateConfig{Raw: true, PerFileMaxTokens: 20, Truncated: report}
+	out, err := NewDefaultContextGenerator().Generate(root, selections, cfg)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	// 20 tokens keep 40 bytes at each end: four 9-byte lines
+	want := "line 001\nline 002\nline 003\nline 004\n[... 92 lines omitted ...]\n" +
+		"line 097\nline 098\nline 099\nline 100\n"
+	if !strings.Contains(out, want) {
+		t.Errorf("expected head and tail around the marker %q, got:\n%s", want, out)
+	}
+	wantBytes := strings.Repeat("x", 40) + "\n[... 320 bytes omitted ...]\n" + strings.Repeat("x", 40)
+	if !strings.Contains(out, wantBytes) {
+		t.Errorf("expected a single long line cut by bytes, got:\n%s", out)
+	}
+	if !strings.Contains(out, "small\n") {
+		t.Errorf("expected small file embedded in full, got:\n%s", out)
+	}
+
+	files := report.Files()
+	if len(files) != 2 {
+		t.Fatalf("expected 2 truncated files, got %+v", files)
+	}
+	for _, file := range files {
+		switch file.RelPath {
+		case "long.txt":
+			if file.Omitted != "92 lines" || file.Tokens != tokens.Estimate(long.String()) {
+				t.Errorf("unexpected report for long.txt: %+v", file)
+			}
+		case "bundle.min.js":
+			if file.Omitted != "320 bytes" || file.Tokens != 100 {
+				t.Errorf("unexpected report for bundle.min.js: %+v", file)
+			}
+		default:
+			t.Errorf("unexpected truncated file %+v", file)
+		}
+	}
+}
+
+func TestDefaultContextGenerator_RenderManifest(t *testing.T) {
+	t.Parallel()
+
+	specs := []fileSpec{
+		{relPath: "main.go", content: "package main\n", selected: true},
+	}
+	root, selections, cleanup := buildTestTree(t, specs)
+	defer cleanup()
+
+	tmpl := "Task: {TASK}\n{FILE_STRUCTURE}"
+	recorder := NewManifestRecorder()
+	gen := NewDefaultContextGenerator()
+	out, err := gen.Generate(root, selections, GenerateConfig{
+		Template:     tmpl,
+		TemplateVars: map[string]string{"TASK": "old task"},
+		Manifest:     recorder,
+	})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	manifest := recorder.Manifest()
+	if manifest == nil || len(manifest.Files) != 1 || manifest.Template != tmpl {
+		t.Fatalf("expected a manifest of 1 file and the template, got %+v", manifest)
+	}
+
+	again, err := gen.RenderManifest(manifest, GenerateConfig{
+		Template:     manifest.Template,
+		TemplateVars: map[string]string{"TASK": "new task"},
+	})
+	if err != nil {
+		t.Fatalf("RenderManifest failed: %v", err)
+	}
+	if want := strings.Replace(out, "old task", "new task", 1); again != want {
+		t.Errorf("expected only the task to change, got:\n%s\nwant:\n%s", again, want)
+	}
+
+	manifest.Raw = true
+	if _, err := gen.RenderManifest(manifest, GenerateConfig{}); err == nil {
+		t.Error("expected an error for a manifest of a raw context")
+	}
+	manifest.Raw = false
+	manifest.Version = ManifestVersion + 1
+	if _, err := gen.RenderManifest(manifest, GenerateConfig{}); err == nil {
+		t.Error("expected an error for an unsupported manifest version")
+	}
+}
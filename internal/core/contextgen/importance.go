@@ -0,0 +1,190 @@
+package contextgen
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Weights of the importance heuristics. A file gains importanceRefWeight for every
+// other selected file that imports it.
+const (
+	importanceEntrypointWeight = 8
+	importanceReadmeWeight     = 6
+	importanceManifestWeight   = 4
+	importanceDocWeight        = 3
+	importanceTestWeight       = -2
+	importanceRefWeight        = 2
+)
+
+var (
+	// Entrypoints by base name without extension
+	importanceEntrypoints = map[string]bool{
+		"main": true, "index": true, "app": true, "server": true, "__main__": true, "cli": true,
+	}
+	importanceManifests = map[string]bool{
+		"go.mod": true, "package.json": true, "cargo.toml": true, "pyproject.toml": true,
+		"setup.py": true, "pom.xml": true, "build.gradle": true, "gemfile": true, "makefile": true,
+		"dockerfile": true,
+	}
+
+	goImportBlockRe  = regexp.MustCompile(`(?s)\bimport\s*\((.*?)\)`)
+	goImportLineRe   = regexp.MustCompile(`(?m)^\s*import\s+(?:[\w.]+\s+)?"([^"]+)"`)
+	quotedRe         = regexp.MustCompile(`"([^"]+)"`)
+	jsImportRe       = regexp.MustCompile(`(?:\bfrom\s+|\brequire\(\s*|\bimport\(\s*|\bimport\s+)["']([^"']+)["']`)
+	pythonImportRe   = regexp.MustCompile(`(?m)^\s*(?:from\s+(\.*[\w.]*)\s+import|import\s+([\w.]+))`)
+	importanceJSExts = []string{".ts", ".tsx", ".js", ".jsx", ".mjs", ".cjs", ".vue", ".svelte"}
+)
+
+// importanceScores returns a best-effort importance score for each file: entrypoints,
+// READMEs, docs and build manifests by name, and how many other selected files import
+// each one, found by scanning Go, JavaScript/TypeScript and Python import statements.
+// It only reads the contents already collected, and the same files score the same.
+func importanceScores(files []FileContent) []int {
+	scores := make([]int, len(files))
+	for i, file := range files {
+		scores[i] = nameImportance(filepath.ToSlash(file.RelPath))
+	}
+
+	index := newImportIndex(files)
+	for i, file := range files {
+		importers := map[int]bool{}
+		for _, target := range index.resolve(file) {
+			if target != i {
+				importers[target] = true
+			}
+		}
+		for target := range importers {
+			scores[target] += importanceRefWeight
+		}
+	}
+
+	return scores
+}
+
+// nameImportance scores a file by its slash-separated relative path alone.
+func nameImportance(relPath string) int {
+	base := strings.ToLower(path.Base(relPath))
+	ext := path.Ext(base)
+	stem := strings.TrimSuffix(base, ext)
+
+	switch {
+	case isTestFile(base):
+		return importanceTestWeight
+	case stem == "readme":
+		return importanceReadmeWeight
+	case importanceManifests[base]:
+		return importanceManifestWeight
+	case importanceEntrypoints[stem] && ext != "" && ext != ".md":
+		return importanceEntrypointWeight
+	case ext == ".md" || ext == ".rst" || strings.HasPrefix(strings.ToLower(relPath), "docs/"):
+		return importanceDocWeight
+	default:
+		return 0
+	}
+}
+
+func isTestFile(base string) bool {
+	return strings.HasSuffix(base, "_test.go") || strings.HasPrefix(base, "test_") ||
+		strings.Contains(base, ".test.") || strings.Contains(base, ".spec.")
+}
+
+// importIndex maps the names files can be imported by to their indexes.
+type importIndex struct {
+	byDir  map[string][]int // Go package directory
+	byStem map[string][]int // Path without extension, and the directory of an index file
+}
+
+func newImportIndex(files []FileContent) importIndex {
+	index := importIndex{byDir: map[string][]int{}, byStem: map[string][]int{}}
+	for i, file := range files {
+		relPath := filepath.ToSlash(file.RelPath)
+		if strings.HasSuffix(relPath, ".go") && !strings.HasSuffix(relPath, "_test.go") {
+			dir := path.Dir(relPath)
+			index.byDir[dir] = append(index.byDir[dir], i)
+		}
+		stem := strings.TrimSuffix(relPath, path.Ext(relPath))
+		index.byStem[stem] = append(index.byStem[stem], i)
+		if base := path.Base(stem); base == "index" || base == "__init__" {
+			index.byStem[path.Dir(stem)] = append(index.byStem[path.Dir(stem)], i)
+		}
+	}
+	return index
+}
+
+// resolve returns the indexes of the files that file imports. A Go import path
+// matches the package directory it ends with; relative JavaScript imports and Python
+// modules match a path without its extension.
+func (x importIndex) resolve(file FileContent) []int {
+	relPath := filepath.ToSlash(file.RelPath)
+	dir := path.Dir(relPath)
+	var targets []int
+
+	switch ext := path.Ext(relPath); {
+	case ext == ".go":
+		for _, spec := range goImports(file.Content) {
+			// github.com/org/repo/internal/api imports the package in internal/api
+			targets = append(targets, matchKeys(x.byDir, func(key string) bool {
+				return spec == key || strings.HasSuffix(spec, "/"+key)
+			})...)
+		}
+	case ext == ".py":
+		for _, match := range pythonImportRe.FindAllStringSubmatch(file.Content, -1) {
+			module := match[1] + match[2]
+			trimmed := strings.TrimLeft(module, ".")
+			modulePath := strings.ReplaceAll(trimmed, ".", "/")
+			if dots := len(module) - len(trimmed); dots > 0 {
+				base := dir
+				for i := 1; i < dots; i++ {
+					base = path.Dir(base)
+				}
+				targets = append(targets, x.byStem[path.Join(base, modulePath)]...)
+				continue
+			}
+			// A top-level module may live under a source directory such as src/
+			targets = append(targets, matchKeys(x.byStem, func(key string) bool {
+				return key == modulePath || strings.HasSuffix(key, "/"+modulePath)
+			})...)
+		}
+	case slices.Contains(importanceJSExts, ext):
+		for _, match := range jsImportRe.FindAllStringSubmatch(file.Content, -1) {
+			if spec := match[1]; strings.HasPrefix(spec, ".") {
+				// "./api" and "./api.js" both name api.ts; "./config.service" names config.service.ts
+				resolved := path.Join(dir, spec)
+				targets = append(targets, x.byStem[resolved]...)
+				targets = append(targets, x.byStem[strings.TrimSuffix(resolved, path.Ext(resolved))]...)
+			}
+		}
+	}
+
+	return targets
+}
+
+// matchKeys returns the files under the keys match accepts, in index order.
+func matchKeys(keys map[string][]int, match func(key string) bool) []int {
+	var matches []int
+	for key, indexes := range keys {
+		if match(key) {
+			matches = append(matches, indexes...)
+		}
+	}
+	sort.Ints(matches)
+	return matches
+}
+
+// goImports returns the import paths of a Go source file.
+func goImports(content string) []string {
+	var specs []string
+	for _, block := range goImportBlockRe.FindAllStringSubmatch(content, -1) {
+		for _, match := range quotedRe.FindAllStringSubmatch(block[1], -1) {
+			specs = append(specs, match[1])
+		}
+	}
+	for _, match := range goImportLineRe.FindAllStringSubmatch(content, -1) {
+		specs = append(specs, match[1])
+	}
+	return specs
+}
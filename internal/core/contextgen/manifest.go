@@ -0,0 +1,69 @@
+package contextgen
+
+import "fmt"
+
+// ManifestVersion is the version of the Manifest format written by this build.
+const ManifestVersion = 1
+
+// Manifest records the embedded files of a generation and the template they were
+// rendered with, so the template can be rendered again with new variables, such as a
+// new task, without rescanning.
+type Manifest struct {
+	Version int `json:"version"`
+	// Template is the template content; empty means the built-in default
+	Template     string            `json:"template"`
+	TemplateVars map[string]string `json:"templateVars"`
+	// FileStructure is the tree and file content blocks given to the template
+	FileStructure string        `json:"fileStructure"`
+	Files         []FileContent `json:"files"`
+	Omitted       []OmittedFile `json:"omitted,omitempty"`
+	Raw           bool          `json:"raw"`
+}
+
+// ManifestRecorder captures the Manifest of a single generation.
+type ManifestRecorder struct {
+	manifest *Manifest
+}
+
+// NewManifestRecorder returns an empty ManifestRecorder for a single generation.
+func NewManifestRecorder() *ManifestRecorder {
+	return &ManifestRecorder{}
+}
+
+// Manifest returns the recorded manifest, or nil when nothing was rendered.
+func (r *ManifestRecorder) Manifest() *Manifest {
+	if r == nil {
+		return nil
+	}
+	return r.manifest
+}
+
+// record keeps the last render, the one that produced the output.
+func (r *ManifestRecorder) record(manifest Manifest) {
+	if r == nil {
+		return
+	}
+	r.manifest = &manifest
+}
+
+// RenderManifest renders config.Template over the files recorded in manifest, with
+// config.TemplateVars; the files are not read again. The result is held to
+// config.MaxTotalSize like a generation.
+func (g *DefaultContextGenerator) RenderManifest(manifest *Manifest, config GenerateConfig) (string, error) {
+	if manifest.Version != ManifestVersion {
+		return "", fmt.Errorf("unsupported manifest version %d (expected %d)", manifest.Version, ManifestVersion)
+	}
+	if manifest.Raw {
+		return "", fmt.Errorf("the context was generated without a template, so there is nothing to render")
+	}
+	if err := g.validateConfig(&config); err != nil {
+		return "", fmt.Errorf("invalid config: %w", err)
+	}
+
+	result, err := g.renderTemplate(manifest.FileStructure, manifest.Files, manifest.Omitted, config, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return g.finish(result, config, nil)
+}
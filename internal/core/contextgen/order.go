@@ -0,0 +1,88 @@
+package contextgen
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Order selects the sequence of the embedded file blocks. It never changes which
+// files are included, only the order they appear in.
+type Order string
+
+const (
+	// OrderTree embeds files in tree order, as the scan walked them.
+	OrderTree Order = "tree"
+	// OrderPath embeds files sorted by relative path.
+	OrderPath Order = "path"
+	// OrderSizeAsc embeds the smallest files first.
+	OrderSizeAsc Order = "size-asc"
+	// OrderSizeDesc embeds the largest files first.
+	OrderSizeDesc Order = "size-desc"
+	// OrderCustom embeds files in the order of GenerateConfig.CustomOrder.
+	OrderCustom Order = "custom"
+)
+
+// Orders lists the orders accepted by ParseOrder.
+var Orders = []Order{OrderTree, OrderPath, OrderSizeAsc, OrderSizeDesc, OrderCustom}
+
+// ParseOrder validates an order name; an empty name means OrderTree.
+func ParseOrder(name string) (Order, error) {
+	if name == "" {
+		return OrderTree, nil
+	}
+
+	names := make([]string, len(Orders))
+	for i, o := range Orders {
+		if string(o) == name {
+			return o, nil
+		}
+		names[i] = string(o)
+	}
+
+	return OrderTree, fmt.Errorf("unknown order %q (expected: %s)", name, strings.Join(names, ", "))
+}
+
+// orderFiles returns files in the given order. custom lists slash-separated relative
+// paths for OrderCustom; files it does not list follow the listed ones. Ties keep tree
+// order, so the result is deterministic.
+func orderFiles(files []FileContent, order Order, custom []string) []FileContent {
+	if order == "" || order == OrderTree || len(files) < 2 {
+		return files
+	}
+
+	ordered := append([]FileContent(nil), files...)
+	switch order {
+	case OrderPath:
+		sort.SliceStable(ordered, func(a, b int) bool {
+			return filepath.ToSlash(ordered[a].RelPath) < filepath.ToSlash(ordered[b].RelPath)
+		})
+	case OrderSizeAsc:
+		sort.SliceStable(ordered, func(a, b int) bool {
+			return ordered[a].Size < ordered[b].Size
+		})
+	case OrderSizeDesc:
+		sort.SliceStable(ordered, func(a, b int) bool {
+			return ordered[a].Size > ordered[b].Size
+		})
+	case OrderCustom:
+		rank := make(map[string]int, len(custom))
+		for i, relPath := range custom {
+			if _, ok := rank[relPath]; !ok {
+				rank[relPath] = i
+			}
+		}
+		position := func(file FileContent) int {
+			if i, ok := rank[filepath.ToSlash(file.RelPath)]; ok {
+				return i
+			}
+			return len(custom)
+		}
+		sort.SliceStable(ordered, func(a, b int) bool {
+			return position(ordered[a]) < position(ordered[b])
+		})
+	}
+
+	return ordered
+}
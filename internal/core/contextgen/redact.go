@@ -0,0 +1,143 @@
+package contextgen
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RedactedMarker replaces each secret a Redactor finds.
+const RedactedMarker = "[REDACTED]"
+
+// DefaultRedactPatterns match common secrets: cloud and API keys, tokens, private
+// keys and password assignments. A pattern with a capture group redacts only the
+// first group, so `password = "hunter2"` keeps its name.
+var DefaultRedactPatterns = []string{
+	// AWS access key IDs and secret access keys
+	`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,
+	`(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})`,
+	// OpenAI, Anthropic and similar "sk-" API keys
+	`\bsk-[A-Za-z0-9_-]{20,}`,
+	// GitHub and Slack tokens
+	`\bgh[pousr]_[A-Za-z0-9]{36,}\b`,
+	`\bxox[abprs]-[A-Za-z0-9-]{10,}`,
+	// JWT-like strings: three base64url segments, the first a JSON header
+	`\beyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`,
+	// PEM private key blocks
+	`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`,
+	// Quoted password, secret and API key assignments in code and config
+	`(?i)\b(?:password|passwd|pwd|secret|api_?key|access_?token)["']?\s*[:=]\s*["']([^"'\s]{4,})["']`,
+	// .env style assignments such as DB_PASSWORD=value
+	`(?m)^\s*(?:export\s+)?[A-Z0-9_]*(?:PASSWORD|PASSWD|SECRET|TOKEN|API_KEY)[A-Z0-9_]*\s*=\s*["']?([^\s"'#]+)`,
+}
+
+// RedactedFile counts the secrets redacted from one file.
+type RedactedFile struct {
+	RelPath string `json:"relPath"`
+	Count   int    `json:"count"`
+}
+
+// Redactor is a content transform that replaces secrets with RedactedMarker and
+// records how many it replaced in each file.
+type Redactor struct {
+	patterns []*regexp.Regexp
+	files    []RedactedFile
+}
+
+// NewRedactor returns a Redactor for a single generation that matches
+// DefaultRedactPatterns and the extra regular expressions.
+func NewRedactor(extra []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, pattern := range append(append([]string(nil), DefaultRedactPatterns...), extra...) {
+		re, err := compileRedactPattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// ValidateRedactPattern checks that pattern is a regular expression a Redactor accepts.
+func ValidateRedactPattern(pattern string) error {
+	_, err := compileRedactPattern(pattern)
+	return err
+}
+
+func compileRedactPattern(pattern string) (*regexp.Regexp, error) {
+	if strings.TrimSpace(pattern) == "" {
+		return nil, fmt.Errorf("empty redaction pattern")
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+	}
+	return re, nil
+}
+
+// Transform redacts the secrets in content and records their count for relPath.
+func (r *Redactor) Transform(relPath, content string) string {
+	redacted, count := r.Redact(content)
+	if count > 0 {
+		r.files = append(r.files, RedactedFile{RelPath: relPath, Count: count})
+	}
+	return redacted
+}
+
+// Redact replaces the secrets in content and returns how many it replaced, without
+// recording them.
+func (r *Redactor) Redact(content string) (string, int) {
+	total := 0
+	for _, re := range r.patterns {
+		var count int
+		content, count = redactMatches(re, content)
+		total += count
+	}
+	return content, total
+}
+
+// redactMatches replaces each match of re, or its first capture group when it has
+// one, with RedactedMarker and returns how many it replaced. A match spanning lines
+// keeps its line breaks after the marker, so the lines that follow keep their numbers
+// for transforms working on line ranges.
+func redactMatches(re *regexp.Regexp, content string) (string, int) {
+	matches := re.FindAllStringSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		return content, 0
+	}
+
+	var builder strings.Builder
+	last, count := 0, 0
+	for _, match := range matches {
+		start, end := match[0], match[1]
+		if re.NumSubexp() > 0 {
+			start, end = match[2], match[3]
+		}
+		// Skip unmatched groups, overlaps and secrets an earlier pattern already redacted
+		if start < 0 || start < last || content[start:end] == RedactedMarker {
+			continue
+		}
+		builder.WriteString(content[last:start])
+		builder.WriteString(RedactedMarker)
+		builder.WriteString(strings.Repeat("\n", strings.Count(content[start:end], "\n")))
+		last = end
+		count++
+	}
+	builder.WriteString(content[last:])
+
+	return builder.String(), count
+}
+
+// Files returns the files with redactions, in the order they were met.
+func (r *Redactor) Files() []RedactedFile {
+	return r.files
+}
+
+// Total returns the number of secrets redacted across all files.
+func (r *Redactor) Total() int {
+	total := 0
+	for _, file := range r.files {
+		total += file.Count
+	}
+	return total
+}
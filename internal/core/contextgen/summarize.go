@@ -0,0 +1,99 @@
+package contextgen
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
+)
+
+// summaryLineMax caps the length of the line quoted in a file summary.
+const summaryLineMax = 120
+
+// declarationPattern matches a top-level declaration line in common languages.
+var declarationPattern = regexp.MustCompile(
+	`^(export\s+)?(default\s+)?(pub(\([a-z]+\))?\s+)?(async\s+)?(abstract\s+)?` +
+		`(func|type|class|def|fn|struct|enum|interface|trait|impl|message|service|function|module)\b`)
+
+// Summarizer embeds files matching its patterns as a one-line summary instead of
+// their content, and counts the files it summarized and those embedded in full.
+type Summarizer struct {
+	patterns   []string
+	summarized int
+	embedded   int
+}
+
+// NewSummarizer returns a Summarizer for a single generation. Patterns are matched
+// with scanner.MatchesPattern, so "gen/" covers a whole directory.
+func NewSummarizer(patterns []string) *Summarizer {
+	return &Summarizer{patterns: patterns}
+}
+
+// matches reports whether relPath should be summarized. It is safe on a nil Summarizer.
+func (s *Summarizer) matches(relPath string) bool {
+	if s == nil {
+		return false
+	}
+	for _, pattern := range s.patterns {
+		if scanner.MatchesPattern(pattern, relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// summarize returns the summary embedded for a file of size bytes with content raw:
+// its size, line count and first top-level declaration, or first non-blank line.
+func (s *Summarizer) summarize(raw string, size int64) string {
+	s.summarized++
+
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	lineCount := len(lines)
+	if strings.HasSuffix(raw, "\n") {
+		lineCount--
+	}
+
+	var first, declaration string
+	for _, line := range lines {
+		if first == "" && strings.TrimSpace(line) != "" {
+			first = strings.TrimSpace(line)
+		}
+		if declarationPattern.MatchString(line) {
+			declaration = strings.TrimSpace(line)
+			break
+		}
+	}
+	quoted := declaration
+	if quoted == "" {
+		quoted = first
+	}
+	if utf8.RuneCountInString(quoted) > summaryLineMax {
+		quoted = string([]rune(quoted)[:summaryLineMax]) + "..."
+	}
+
+	summary := fmt.Sprintf("[summarized: %s, %d lines]", formatFileSize(size), lineCount)
+	if quoted != "" {
+		summary += " " + quoted
+	}
+
+	return summary + "\n"
+}
+
+// embed counts a file embedded in full. It is safe on a nil Summarizer.
+func (s *Summarizer) embed() {
+	if s != nil {
+		s.embedded++
+	}
+}
+
+// FilesSummarized returns the number of files embedded as a summary.
+func (s *Summarizer) FilesSummarized() int {
+	return s.summarized
+}
+
+// FilesEmbedded returns the number of files embedded with their full content.
+func (s *Summarizer) FilesEmbedded() int {
+	return s.embedded
+}
@@ -23,6 +23,20 @@ func NewTemplateRenderer() *TemplateRenderer {
 	}
 }
 
+// WithFuncs returns a renderer whose function map also contains funcs; entries
+// in funcs replace built-in functions of the same name.
+func (tr *TemplateRenderer) WithFuncs(funcs template.FuncMap) *TemplateRenderer {
+	merged := make(template.FuncMap, len(tr.funcs)+len(funcs))
+	for name, fn := range tr.funcs {
+		merged[name] = fn
+	}
+	for name, fn := range funcs {
+		merged[name] = fn
+	}
+
+	return &TemplateRenderer{funcs: merged, requiredVars: tr.requiredVars}
+}
+
 func (tr *TemplateRenderer) RenderTemplate(templateContent string, data ContextData) (string, error) {
 	// Validate required variables for default template
 	if templateContent == tr.getDefaultTemplate() {
@@ -59,6 +73,7 @@ func getTemplateFunctions() template.FuncMap {
 		},
 		"formatSize": formatFileSize,
 		"detectLang": detectLanguage,
+		"fenceLang":  LanguageForPath,
 		"now": func() string {
 			return time.Now().Format("2006-01-02 15:04:05")
 		},
@@ -97,7 +112,7 @@ func (tr *TemplateRenderer) getDefaultTemplate() string {
 {{range .Files}}
 ### {{.RelPath}}{{if .Language}} ({{.Language}}){{end}}
 
-` + "```" + `{{if .Language}}{{.Language}}{{end}}
+` + "```" + `{{fenceLang .RelPath}}
 {{.Content}}
 ` + "```" + `
 {{end}}
@@ -1,16 +1,39 @@
 package contextgen
 
 import (
+	"bufio"
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 
 	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
 )
 
+// maxDescriptionLength caps README-derived directory descriptions in the tree.
+const maxDescriptionLength = 80
+
+// symlinkMarker follows symbolic links the scan did not follow.
+const symlinkMarker = " (symlink)"
+
+// depthLimitMarker follows directories whose contents the scan skipped because of ScanConfig.MaxDepth.
+const depthLimitMarker = " (depth limit reached)"
+
+// accessErrorMarker follows directories whose contents the scan could not read.
+const accessErrorMarker = " (not readable)"
+
+// generatedMarker follows generated and lock files, whose content is left out.
+const generatedMarker = " (generated)"
+
+// collapsedMarker follows directories whose contents the renderer collapsed because of WithCollapseDepth.
+const collapsedMarker = " (...)"
+
 type TreeRenderer struct {
-	showIgnored bool
-	maxDepth    int
+	showIgnored     bool
+	maxDepth        int
+	dirDescriptions bool
+	foldSiblings    bool
+	collapseDepth   int
 }
 
 func NewTreeRenderer() *TreeRenderer {
@@ -32,6 +55,28 @@ func (tr *TreeRenderer) WithMaxDepth(depth int) *TreeRenderer {
 	return tr
 }
 
+// WithCollapseDepth renders directories at the given depth below the root as
+// "dir/ (...)" without their contents (0 = no limit).
+func (tr *TreeRenderer) WithCollapseDepth(depth int) *TreeRenderer {
+	tr.collapseDepth = depth
+
+	return tr
+}
+
+// WithDirDescriptions annotates directories with the first line of their README.
+func (tr *TreeRenderer) WithDirDescriptions(show bool) *TreeRenderer {
+	tr.dirDescriptions = show
+
+	return tr
+}
+
+// WithFoldSiblings collapses large groups of similarly named files to a sample and a count.
+func (tr *TreeRenderer) WithFoldSiblings(fold bool) *TreeRenderer {
+	tr.foldSiblings = fold
+
+	return tr
+}
+
 func (tr *TreeRenderer) RenderTree(root *scanner.FileNode) (string, error) {
 	if root == nil {
 		return "", fmt.Errorf("root node is nil")
@@ -50,14 +95,22 @@ func (tr *TreeRenderer) renderNode(
 		return
 	}
 
-	line := tr.formatNodeLine(node, prefix, isLast)
+	collapsed := tr.isCollapsed(node, depth)
+	line := tr.formatNodeLine(node, prefix, isLast, collapsed)
 	result.WriteString(line)
 
-	if node.IsDir && len(node.Children) > 0 {
+	if node.IsDir && len(node.Children) > 0 && !collapsed {
 		tr.renderChildren(node, prefix, isLast, depth, result)
 	}
 }
 
+// isCollapsed reports whether node is a directory at the collapse depth with
+// visible contents to hide.
+func (tr *TreeRenderer) isCollapsed(node *scanner.FileNode, depth int) bool {
+	return tr.collapseDepth > 0 && depth >= tr.collapseDepth && node.IsDir &&
+		len(tr.getVisibleChildren(node)) > 0
+}
+
 func (tr *TreeRenderer) shouldSkipNode(node *scanner.FileNode, depth int) bool {
 	if tr.maxDepth >= 0 && depth > tr.maxDepth {
 		return true
@@ -66,11 +119,8 @@ func (tr *TreeRenderer) shouldSkipNode(node *scanner.FileNode, depth int) bool {
 	return !tr.showIgnored && node.IsIgnored()
 }
 
-func (tr *TreeRenderer) formatNodeLine(node *scanner.FileNode, prefix string, isLast bool) string {
-	connector := "├── "
-	if isLast {
-		connector = "└── "
-	}
+func (tr *TreeRenderer) formatNodeLine(node *scanner.FileNode, prefix string, isLast, collapsed bool) string {
+	connector := connectorFor(isLast)
 
 	name := node.Name
 	if node.IsDir {
@@ -78,9 +128,96 @@ func (tr *TreeRenderer) formatNodeLine(node *scanner.FileNode, prefix string, is
 	}
 
 	ignoreIndicator := tr.getIgnoreIndicator(node)
+	if node.IsSymlink {
+		ignoreIndicator += symlinkMarker
+	}
+	if node.DepthLimited {
+		ignoreIndicator += depthLimitMarker
+	}
+	if node.AccessError {
+		ignoreIndicator += accessErrorMarker
+	}
+	if node.IsGenerated {
+		ignoreIndicator += generatedMarker
+	}
+	if collapsed {
+		ignoreIndicator += collapsedMarker
+	}
 	sizeInfo := tr.getSizeInfo(node)
+	description := tr.getDescription(node)
 
-	return fmt.Sprintf("%s%s%s%s%s\n", prefix, connector, name, ignoreIndicator, sizeInfo)
+	return fmt.Sprintf("%s%s%s%s%s%s\n", prefix, connector, name, ignoreIndicator, sizeInfo, description)
+}
+
+func connectorFor(isLast bool) string {
+	if isLast {
+		return "└── "
+	}
+
+	return "├── "
+}
+
+func (tr *TreeRenderer) getDescription(node *scanner.FileNode) string {
+	if !tr.dirDescriptions || !node.IsDir {
+		return ""
+	}
+
+	if desc := readmeDescription(node); desc != "" {
+		return " — " + desc
+	}
+
+	return ""
+}
+
+// readmeDescription returns the first non-empty line of a directory's README,
+// without Markdown heading markers, or an empty string when there is none.
+func readmeDescription(dir *scanner.FileNode) string {
+	for _, child := range dir.Children {
+		if child.IsDir || !isReadme(child.Name) {
+			continue
+		}
+
+		file, err := os.Open(child.Path)
+		if err != nil {
+			continue
+		}
+
+		line := firstTextLine(file)
+		_ = file.Close()
+
+		if line != "" {
+			return line
+		}
+	}
+
+	return ""
+}
+
+func isReadme(name string) bool {
+	base := strings.ToLower(name)
+	if i := strings.IndexByte(base, '.'); i >= 0 {
+		base = base[:i]
+	}
+
+	return base == "readme"
+}
+
+func firstTextLine(file *os.File) string {
+	lines := bufio.NewScanner(file)
+	for lines.Scan() {
+		line := strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(lines.Text()), "#"))
+		if line == "" {
+			continue
+		}
+
+		if runes := []rune(line); len(runes) > maxDescriptionLength {
+			line = string(runes[:maxDescriptionLength-1]) + "…"
+		}
+
+		return line
+	}
+
+	return ""
 }
 
 func (tr *TreeRenderer) getIgnoreIndicator(node *scanner.FileNode) string {
@@ -115,9 +252,37 @@ func (tr *TreeRenderer) renderChildren(
 	children := tr.getVisibleChildren(node)
 	tr.sortChildren(children)
 
-	for i, child := range children {
-		isChildLast := i == len(children)-1
-		tr.renderNode(child, childPrefix, isChildLast, depth+1, result)
+	var omitted map[*scanner.FileNode]bool
+	var notes map[*scanner.FileNode]string
+	if tr.foldSiblings {
+		omitted, notes = foldSiblings(children)
+	}
+
+	// Each entry is either a child node or, for a folded group, its note line
+	type entry struct {
+		node *scanner.FileNode
+		note string
+	}
+	entries := make([]entry, 0, len(children))
+	for _, child := range children {
+		if omitted[child] {
+			continue
+		}
+		entries = append(entries, entry{node: child})
+		if note, ok := notes[child]; ok {
+			entries = append(entries, entry{note: note})
+		}
+	}
+
+	for i, e := range entries {
+		isChildLast := i == len(entries)-1
+		if e.node == nil {
+			if tr.maxDepth < 0 || depth+1 <= tr.maxDepth {
+				result.WriteString(childPrefix + connectorFor(isChildLast) + e.note + "\n")
+			}
+			continue
+		}
+		tr.renderNode(e.node, childPrefix, isChildLast, depth+1, result)
 	}
 }
 
@@ -1,6 +1,9 @@
 package contextgen
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -297,3 +300,121 @@ func TestShouldSkipNode(t *testing.T) {
 		assert.False(t, renderer.shouldSkipNode(node, 0))
 	})
 }
+
+func TestRenderTreeDirDescriptionsFromReadme(t *testing.T) {
+	dir := t.TempDir()
+	readmePath := filepath.Join(dir, "docs", "README.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(readmePath), 0o750))
+	require.NoError(t, os.WriteFile(readmePath, []byte("\n# Developer documentation\n\nMore text.\n"), 0o600))
+
+	root := createTestFileNode("project", dir, true, 0,
+		createTestFileNode("docs", filepath.Join(dir, "docs"), true, 0,
+			createTestFileNode("README.md", readmePath, false, 40),
+		),
+		createTestFileNode("src", filepath.Join(dir, "src"), true, 0,
+			createTestFileNode("main.go", filepath.Join(dir, "src", "main.go"), false, 10),
+		),
+	)
+
+	out, err := NewTreeRenderer().WithDirDescriptions(true).RenderTree(root)
+	require.NoError(t, err)
+	assert.Contains(t, out, "docs/ — Developer documentation\n")
+	assert.Contains(t, out, "src/\n", "directories without a README get no description")
+
+	plain, err := NewTreeRenderer().RenderTree(root)
+	require.NoError(t, err)
+	assert.NotContains(t, plain, "Developer documentation")
+}
+
+func TestRenderTreeFoldSiblings(t *testing.T) {
+	var children []*scanner.FileNode
+	for i := 50; i >= 1; i-- {
+		name := fmt.Sprintf("snapshot_%03d.txt", i)
+		children = append(children, createTestFileNode(name, "/root/fixtures/"+name, false, 0))
+	}
+	for _, name := range []string{"a1.txt", "a2.txt", "zz.txt"} {
+		children = append(children, createTestFileNode(name, "/root/fixtures/"+name, false, 0))
+	}
+	root := createTestFileNode("root", "/root", true, 0,
+		createTestFileNode("fixtures", "/root/fixtures", true, 0, children...))
+
+	out, err := NewTreeRenderer().WithFoldSiblings(true).RenderTree(root)
+	require.NoError(t, err)
+
+	expected := `└── root/
+    └── fixtures/
+        ├── a1.txt
+        ├── a2.txt
+        ├── snapshot_001.txt
+        ├── snapshot_002.txt
+        ├── snapshot_003.txt
+        ├── … 47 more files matching snapshot_*.txt
+        └── zz.txt
+`
+	assert.Equal(t, expected, out)
+
+	unfolded, err := NewTreeRenderer().RenderTree(root)
+	require.NoError(t, err)
+	assert.Contains(t, unfolded, "snapshot_050.txt")
+	assert.NotContains(t, unfolded, "more files matching")
+}
+
+func TestRenderTreeDepthLimitMarker(t *testing.T) {
+	limited := createTestFileNode("node_modules", "/root/node_modules", true, 0)
+	limited.DepthLimited = true
+	root := createTestFileNode("root", "/root", true, 0,
+		limited, createTestFileNode("main.go", "/root/main.go", false, 0))
+
+	out, err := NewTreeRenderer().RenderTree(root)
+	require.NoError(t, err)
+
+	expected := `└── root/
+    ├── node_modules/ (depth limit reached)
+    └── main.go
+`
+	assert.Equal(t, expected, out)
+}
+
+func TestRenderTreeAccessErrorMarker(t *testing.T) {
+	secret := createTestFileNode("secret", "/root/secret", true, 0)
+	secret.AccessError = true
+	root := createTestFileNode("root", "/root", true, 0, secret)
+
+	out, err := NewTreeRenderer().RenderTree(root)
+	require.NoError(t, err)
+	assert.Contains(t, out, "secret/ (not readable)")
+}
+
+func TestRenderTreeCollapseDepth(t *testing.T) {
+	deepFile := createTestFileNode("deep.go", "/root/src/pkg/deep.go", false, 0)
+	pkgDir := createTestFileNode("pkg", "/root/src/pkg", true, 0, deepFile)
+	emptyDir := createTestFileNode("empty", "/root/src/empty", true, 0)
+	srcFile := createTestFileNode("main.go", "/root/src/main.go", false, 0)
+	srcDir := createTestFileNode("src", "/root/src", true, 0, pkgDir, emptyDir, srcFile)
+	root := createTestFileNode("root", "/root", true, 0, srcDir)
+
+	out, err := NewTreeRenderer().WithCollapseDepth(2).RenderTree(root)
+	require.NoError(t, err)
+
+	expected := `└── root/
+    └── src/
+        ├── empty/
+        ├── pkg/ (...)
+        └── main.go
+`
+	assert.Equal(t, expected, out)
+
+	out, err = NewTreeRenderer().WithCollapseDepth(1).RenderTree(root)
+	require.NoError(t, err)
+	assert.Equal(t, "└── root/\n    └── src/ (...)\n", out)
+}
+
+func TestRenderTreeSymlinkMarker(t *testing.T) {
+	link := createTestFileNode("vendor-link", "/root/vendor-link", false, 0)
+	link.IsSymlink = true
+	root := createTestFileNode("root", "/root", true, 0, link)
+
+	out, err := NewTreeRenderer().RenderTree(root)
+	require.NoError(t, err)
+	assert.Contains(t, out, "└── vendor-link (symlink)\n")
+}
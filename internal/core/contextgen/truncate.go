@@ -0,0 +1,96 @@
+package contextgen
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/quantmind-br/shotgun-cli/internal/core/tokens"
+)
+
+// TruncatedFile is a file embedded as its first and last lines because its estimated
+// tokens exceeded GenerateConfig.PerFileMaxTokens.
+type TruncatedFile struct {
+	RelPath string `json:"relPath"`
+	// Tokens is the estimated token count of the full content
+	Tokens int `json:"tokens"`
+	// Omitted describes what the marker replaced, e.g. "120 lines"
+	Omitted string `json:"omitted"`
+}
+
+// TruncationReport collects the files a generation cut to their head and tail.
+type TruncationReport struct {
+	files []TruncatedFile
+}
+
+// NewTruncationReport returns an empty TruncationReport for a single generation.
+func NewTruncationReport() *TruncationReport {
+	return &TruncationReport{}
+}
+
+// Files returns the truncated files in the order they were met.
+func (r *TruncationReport) Files() []TruncatedFile {
+	return r.files
+}
+
+func (r *TruncationReport) add(file TruncatedFile) {
+	r.files = append(r.files, file)
+}
+
+// truncateHeadTail keeps the first and last lines of content within about maxTokens,
+// with a "[... N lines omitted ...]" marker between them, and describes what was
+// omitted. Content whose first or last line alone is over half the budget, such as
+// a minified bundle, is cut by bytes instead.
+func truncateHeadTail(content string, maxTokens int) (string, string) {
+	half := maxTokens * tokens.BytesPerToken / 2
+
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	head, headSize := 0, 0
+	for head < len(lines) && headSize+len(lines[head]) <= half {
+		headSize += len(lines[head])
+		head++
+	}
+	tail, tailSize := 0, 0
+	for tail < len(lines)-head && tailSize+len(lines[len(lines)-1-tail]) <= half {
+		tailSize += len(lines[len(lines)-1-tail])
+		tail++
+	}
+
+	if head == 0 || tail == 0 {
+		return truncateBytes(content, half)
+	}
+
+	omitted := fmt.Sprintf("%d lines", len(lines)-head-tail)
+	var builder strings.Builder
+	for _, line := range lines[:head] {
+		builder.WriteString(line)
+	}
+	if !strings.HasSuffix(lines[head-1], "\n") {
+		builder.WriteString("\n")
+	}
+	builder.WriteString("[... " + omitted + " omitted ...]\n")
+	for _, line := range lines[len(lines)-tail:] {
+		builder.WriteString(line)
+	}
+
+	return builder.String(), omitted
+}
+
+// truncateBytes keeps the first and last half bytes of content, cut at rune boundaries.
+func truncateBytes(content string, half int) (string, string) {
+	start := half
+	for start > 0 && !utf8.RuneStart(content[start]) {
+		start--
+	}
+	end := len(content) - half
+	for end < len(content) && !utf8.RuneStart(content[end]) {
+		end++
+	}
+
+	omitted := fmt.Sprintf("%d bytes", end-start)
+	return content[:start] + "\n[... " + omitted + " omitted ...]\n" + content[end:], omitted
+}
@@ -0,0 +1,48 @@
+package contextgen
+
+import (
+	"errors"
+	"io/fs"
+)
+
+// UnreadableFile is a selected file whose content could not be read. It is
+// embedded as a placeholder naming the reason instead.
+type UnreadableFile struct {
+	RelPath string `json:"relPath"`
+	Reason  string `json:"reason"`
+}
+
+// UnreadableReport collects the files a generation could not read, so a single
+// bad file is reported rather than failing the whole context.
+type UnreadableReport struct {
+	files []UnreadableFile
+}
+
+// NewUnreadableReport returns an empty UnreadableReport for a single generation.
+func NewUnreadableReport() *UnreadableReport {
+	return &UnreadableReport{}
+}
+
+// Files returns the unreadable files in the order they were met.
+func (r *UnreadableReport) Files() []UnreadableFile {
+	return r.files
+}
+
+func (r *UnreadableReport) add(relPath, reason string) {
+	r.files = append(r.files, UnreadableFile{RelPath: relPath, Reason: reason})
+}
+
+// unreadablePlaceholder is the content embedded for a file that could not be read.
+func unreadablePlaceholder(reason string) string {
+	return "[unreadable: " + reason + "]\n"
+}
+
+// unreadableReason describes err without the path, which the file header already shows.
+func unreadableReason(err error) string {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return pathErr.Err.Error()
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,137 @@
+package diff
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+)
+
+// omittedMarker separates the kept regions of a reduced Go file.
+const omittedMarker = "// ..."
+
+// ExtractChangedFunctions reduces Go source to the declarations touched by the changed lines.
+// The result keeps the file header (comments, build tags and package clause) and imports
+// for context, followed by every top-level declaration, with its doc comment, that overlaps
+// a changed range. Unchanged regions are replaced by an omission marker.
+// It returns false when the source cannot be parsed, so callers can fall back to the whole file.
+func ExtractChangedFunctions(content string, changed []LineRange) (string, bool) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", content, parser.ParseComments)
+	if err != nil {
+		return "", false
+	}
+
+	line := func(pos token.Pos) int { return fset.Position(pos).Line }
+
+	kept := []LineRange{{Start: 1, End: line(file.Name.End())}}
+	for _, decl := range file.Decls {
+		start, end := line(decl.Pos()), line(decl.End())
+		if doc := declDoc(decl); doc != nil {
+			start = line(doc.Pos())
+		}
+
+		if isImportDecl(decl) || overlapsAny(changed, start, end) {
+			kept = append(kept, LineRange{Start: start, End: end})
+		}
+	}
+
+	return renderKept(strings.Split(content, "\n"), kept), true
+}
+
+// ChangedFunctionFilter trims Go files to the declarations changed in a diff.
+// Non-Go files and files without recorded changes pass through untouched.
+type ChangedFunctionFilter struct {
+	changed map[string][]LineRange
+}
+
+// NewChangedFunctionFilter creates a filter from per-file changed line ranges,
+// keyed by slash-separated paths relative to the context root.
+func NewChangedFunctionFilter(changed map[string][]LineRange) *ChangedFunctionFilter {
+	return &ChangedFunctionFilter{changed: changed}
+}
+
+// Transform has the signature of a contextgen content transform.
+func (f *ChangedFunctionFilter) Transform(relPath, content string) string {
+	if filepath.Ext(relPath) != ".go" {
+		return content
+	}
+
+	ranges := f.changed[filepath.ToSlash(relPath)]
+	if len(ranges) == 0 {
+		return content
+	}
+
+	reduced, ok := ExtractChangedFunctions(content, ranges)
+	if !ok {
+		return content
+	}
+
+	return reduced
+}
+
+func declDoc(decl ast.Decl) *ast.CommentGroup {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		return d.Doc
+	case *ast.GenDecl:
+		return d.Doc
+	}
+
+	return nil
+}
+
+func isImportDecl(decl ast.Decl) bool {
+	gen, ok := decl.(*ast.GenDecl)
+	return ok && gen.Tok == token.IMPORT
+}
+
+func overlapsAny(ranges []LineRange, start, end int) bool {
+	for _, r := range ranges {
+		if r.Overlaps(start, end) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// renderKept joins the kept line ranges (in ascending order), replacing gaps that
+// contain code with an omission marker.
+func renderKept(lines []string, kept []LineRange) string {
+	var b strings.Builder
+	last := 0
+
+	for _, r := range kept {
+		switch {
+		case hasCode(lines, last, r.Start-1):
+			b.WriteString("\n" + omittedMarker + "\n\n")
+		case last > 0:
+			b.WriteString("\n")
+		}
+
+		for i := r.Start; i <= r.End && i <= len(lines); i++ {
+			b.WriteString(lines[i-1])
+			b.WriteString("\n")
+		}
+		last = r.End
+	}
+
+	if hasCode(lines, last, len(lines)) {
+		b.WriteString("\n" + omittedMarker + "\n")
+	}
+
+	return b.String()
+}
+
+// hasCode reports whether any of lines[from:to] (0-indexed, half-open) is non-blank.
+func hasCode(lines []string, from, to int) bool {
+	for i := from; i < to && i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) != "" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,60 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleGoFile = `// Package sample is a fixture.
+package sample
+
+import "fmt"
+
+// Unchanged is never touched.
+func Unchanged() {
+	fmt.Println("unchanged")
+}
+
+// Changed has an edited body.
+func Changed() int {
+	return 42
+}
+
+var trailing = 1
+`
+
+func TestExtractChangedFunctions(t *testing.T) {
+	// Line 13 is "return 42" inside Changed
+	got, ok := ExtractChangedFunctions(sampleGoFile, []LineRange{{Start: 13, End: 13}})
+	require.True(t, ok)
+
+	assert.Contains(t, got, "package sample")
+	assert.Contains(t, got, `import "fmt"`)
+	assert.Contains(t, got, "// Changed has an edited body.")
+	assert.Contains(t, got, "return 42")
+	assert.NotContains(t, got, "func Unchanged")
+	assert.NotContains(t, got, "var trailing")
+	assert.Contains(t, got, omittedMarker)
+}
+
+func TestExtractChangedFunctions_InvalidSource(t *testing.T) {
+	_, ok := ExtractChangedFunctions("not go at all {", []LineRange{{Start: 1, End: 1}})
+	assert.False(t, ok)
+}
+
+func TestChangedFunctionFilter_Transform(t *testing.T) {
+	filter := NewChangedFunctionFilter(map[string][]LineRange{
+		"pkg/sample.go": {{Start: 7, End: 8}},
+		"README.md":     {{Start: 1, End: 1}},
+	})
+
+	reduced := filter.Transform("pkg/sample.go", sampleGoFile)
+	assert.Contains(t, reduced, "func Unchanged")
+	assert.NotContains(t, reduced, "func Changed")
+
+	// Non-Go files and Go files without changes are passed through whole
+	assert.Equal(t, "# Title\n", filter.Transform("README.md", "# Title\n"))
+	assert.Equal(t, sampleGoFile, filter.Transform("other.go", sampleGoFile))
+}
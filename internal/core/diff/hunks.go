@@ -0,0 +1,86 @@
+package diff
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// LineRange is an inclusive, 1-indexed range of lines in the new version of a file.
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// Contains reports whether line falls inside the range.
+func (r LineRange) Contains(line int) bool {
+	return line >= r.Start && line <= r.End
+}
+
+// Overlaps reports whether the range intersects the inclusive range [start, end].
+func (r LineRange) Overlaps(start, end int) bool {
+	return r.Start <= end && start <= r.End
+}
+
+var hunkHeaderRe = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// ParseChangedLines extracts, per file, the line ranges touched in a unified diff.
+// Keys are the new-side paths, unquoted, with forward slashes and no "b/" prefix.
+// Deleted files are omitted. A pure deletion hunk is reported as the single line
+// where the removed lines used to be, so the enclosing code is still considered changed.
+func ParseChangedLines(unifiedDiff string) map[string][]LineRange {
+	changed := make(map[string][]LineRange)
+	current := ""
+
+	for _, line := range strings.Split(unifiedDiff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			current = parseNewPath(strings.TrimPrefix(line, "+++ "))
+		case strings.HasPrefix(line, "@@ ") && current != "":
+			if r, ok := parseHunkHeader(line); ok {
+				changed[current] = append(changed[current], r)
+			}
+		}
+	}
+
+	return changed
+}
+
+// parseNewPath returns the path of a "+++" line. Git quotes paths with special or
+// non-ASCII characters as C-style strings, e.g. "b/\303\247a.go".
+func parseNewPath(path string) string {
+	path = strings.TrimSpace(path)
+	if path == "/dev/null" {
+		return ""
+	}
+	if strings.HasPrefix(path, `"`) {
+		if unquoted, err := strconv.Unquote(path); err == nil {
+			path = unquoted
+		}
+	}
+
+	return strings.TrimPrefix(path, "b/")
+}
+
+func parseHunkHeader(line string) (LineRange, bool) {
+	m := hunkHeaderRe.FindStringSubmatch(line)
+	if m == nil {
+		return LineRange{}, false
+	}
+
+	start, _ := strconv.Atoi(m[1])
+	count := 1
+	if m[2] != "" {
+		count, _ = strconv.Atoi(m[2])
+	}
+
+	if count == 0 {
+		// Pure deletion: git reports the line before the removed block
+		if start < 1 {
+			start = 1
+		}
+		return LineRange{Start: start, End: start}, true
+	}
+
+	return LineRange{Start: start, End: start + count - 1}, true
+}
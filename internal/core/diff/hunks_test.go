@@ -0,0 +1,71 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChangedLines(t *testing.T) {
+	unified := `diff --git a/pkg/a.go b/pkg/a.go
+index 1111111..2222222 100644
+--- a/pkg/a.go
++++ b/pkg/a.go
+@@ -3 +3 @@ func A() {
+-	return 1
++	return 2
+@@ -10,0 +11,3 @@ func B() {
++	x := 1
++	y := 2
++	_ = x + y
+@@ -20,2 +23,0 @@ func C() {
+-	old()
+-	older()
+diff --git a/gone.go b/gone.go
+deleted file mode 100644
+--- a/gone.go
++++ /dev/null
+@@ -1,3 +0,0 @@
+-package gone
+diff --git a/new.go b/new.go
+new file mode 100644
+--- /dev/null
++++ b/new.go
+@@ -0,0 +1,2 @@
++package new
++
+`
+
+	changed := ParseChangedLines(unified)
+
+	assert.Equal(t, map[string][]LineRange{
+		"pkg/a.go": {{Start: 3, End: 3}, {Start: 11, End: 13}, {Start: 23, End: 23}},
+		"new.go":   {{Start: 1, End: 2}},
+	}, changed)
+}
+
+func TestParseChangedLines_QuotedPath(t *testing.T) {
+	unified := `diff --git "a/\303\247\303\243o.go" "b/\303\247\303\243o.go"
+--- "a/\303\247\303\243o.go"
++++ "b/\303\247\303\243o.go"
+@@ -2 +2 @@
+-var x = 1
++var x = 2
+`
+
+	assert.Equal(t, map[string][]LineRange{"ção.go": {{Start: 2, End: 2}}}, ParseChangedLines(unified))
+}
+
+func TestLineRange(t *testing.T) {
+	r := LineRange{Start: 5, End: 8}
+
+	assert.True(t, r.Contains(5))
+	assert.True(t, r.Contains(8))
+	assert.False(t, r.Contains(9))
+
+	assert.True(t, r.Overlaps(1, 5))
+	assert.True(t, r.Overlaps(8, 12))
+	assert.True(t, r.Overlaps(6, 7))
+	assert.False(t, r.Overlaps(1, 4))
+	assert.False(t, r.Overlaps(9, 12))
+}
@@ -28,6 +28,14 @@ const (
 	IgnoreReasonCustom
 	// IgnoreReasonExplicit indicates the path was explicitly excluded
 	IgnoreReasonExplicit
+	// IgnoreReasonNotKept indicates the file is not matched by .shotgunkeep rules
+	IgnoreReasonNotKept
+	// IgnoreReasonGenerated indicates a generated or lock file, such as go.sum or
+	// *.pb.go; callers typically keep it in the tree and leave out its content
+	IgnoreReasonGenerated
+	// IgnoreReasonGitattributes indicates a path marked export-ignore or linguist-generated
+	// in .gitattributes
+	IgnoreReasonGitattributes
 )
 
 // String returns the string representation of the ignore reason
@@ -43,6 +51,12 @@ func (r IgnoreReason) String() string {
 		return "custom"
 	case IgnoreReasonExplicit:
 		return "explicit"
+	case IgnoreReasonNotKept:
+		return "not kept"
+	case IgnoreReasonGenerated:
+		return "generated"
+	case IgnoreReasonGitattributes:
+		return "gitattributes"
 	default:
 		return "unknown"
 	}
@@ -76,6 +90,60 @@ type IgnoreEngine interface {
 
 	// LoadShotgunignore loads .shotgunignore rules from the specified directory
 	LoadShotgunignore(rootDir string) error
+
+	// LoadShotgunkeep loads .shotgunkeep allowlist rules from the specified directory
+	LoadShotgunkeep(rootDir string) error
+
+	// HasKeepRules returns true if .shotgunkeep rules restrict the files considered
+	HasKeepRules() bool
+
+	// LoadGitattributes loads the export-ignore and linguist-generated paths of the
+	// .gitattributes files in the specified directory
+	LoadGitattributes(rootDir string) error
+
+	// SetIgnoreGenerated turns the generated and lock file layer on or off
+	SetIgnoreGenerated(enabled bool)
+
+	// ExplainIgnore is ShouldIgnore also returning the pattern that decided
+	ExplainIgnore(relPath string) (bool, IgnoreReason, string)
+}
+
+// generatedPatterns match lock files and generated sources, which are large and
+// rarely useful to an LLM.
+var generatedPatterns = []string{
+	// Lock files
+	"go.sum",
+	"package-lock.json",
+	"npm-shrinkwrap.json",
+	"yarn.lock",
+	"pnpm-lock.yaml",
+	"bun.lockb",
+	"Cargo.lock",
+	"Gemfile.lock",
+	"poetry.lock",
+	"Pipfile.lock",
+	"uv.lock",
+	"composer.lock",
+	"mix.lock",
+	"pubspec.lock",
+	"Podfile.lock",
+	"flake.lock",
+
+	// Generated sources
+	"*.pb.go",
+	"*.pb.gw.go",
+	"*_pb2.py",
+	"*_pb2_grpc.py",
+	"*.pb.cc",
+	"*.pb.h",
+	"*_generated.go",
+	"*.gen.go",
+	"zz_generated*.go",
+
+	// Minified bundles and source maps
+	"*.min.js",
+	"*.min.css",
+	"*.map",
 }
 
 // LayeredIgnoreEngine implements the IgnoreEngine interface with layered rule support
@@ -85,6 +153,15 @@ type LayeredIgnoreEngine struct {
 	customMatcher    *gitignore.GitIgnore
 	explicitExcludes *gitignore.GitIgnore
 	explicitIncludes *gitignore.GitIgnore
+	keepMatcher      *gitignore.GitIgnore
+	generatedMatcher *gitignore.GitIgnore
+
+	// gitattributesMatcher matches the paths .gitattributes marks export-ignore or
+	// linguist-generated; nil until LoadGitattributes finds any
+	gitattributesMatcher *gitignore.GitIgnore
+
+	// customNegations matches the paths re-included by negated custom patterns (!foo)
+	customNegations *gitignore.GitIgnore
 
 	// Store patterns for accumulation across calls
 	customPatterns          []string
@@ -92,7 +169,8 @@ type LayeredIgnoreEngine struct {
 	explicitIncludePatterns []string
 }
 
-// NewIgnoreEngine creates a new layered ignore engine with built-in patterns
+// NewIgnoreEngine creates a new layered ignore engine with built-in patterns and the
+// generated and lock file layer enabled
 func NewIgnoreEngine() *LayeredIgnoreEngine {
 	engine := &LayeredIgnoreEngine{}
 
@@ -182,43 +260,94 @@ func NewIgnoreEngine() *LayeredIgnoreEngine {
 	engine.customMatcher = gitignore.CompileIgnoreLines()
 	engine.explicitExcludes = gitignore.CompileIgnoreLines()
 	engine.explicitIncludes = gitignore.CompileIgnoreLines()
+	engine.customNegations = gitignore.CompileIgnoreLines()
+	engine.SetIgnoreGenerated(true)
 
 	return engine
 }
 
 // ShouldIgnore checks if a path should be ignored using layered rules
-// Priority: explicit excludes → explicit includes → built-in → .gitignore → custom
+// Priority: explicit excludes → explicit includes → .shotgunkeep → built-in → .gitignore → custom
+// → .gitattributes → generated. A negated custom pattern (!go.sum in .shotgunignore) keeps a
+// file out of the .gitattributes and generated layers.
+// A trailing slash marks relPath as a directory, so directory-only patterns (foo/)
+// match the directory itself and not just its contents.
 func (e *LayeredIgnoreEngine) ShouldIgnore(relPath string) (bool, IgnoreReason) {
-	// Normalize path separators for consistent matching
-	normalizedPath := filepath.ToSlash(relPath)
+	ignored, reason, _ := e.ExplainIgnore(relPath)
+
+	return ignored, reason
+}
+
+// ExplainIgnore is ShouldIgnore also returning the pattern that decided, as compiled
+// from its ignore file: the ignoring pattern, or the explicit include or negation that
+// kept the path. It is "" when no pattern matched or the path is not kept.
+func (e *LayeredIgnoreEngine) ExplainIgnore(relPath string) (bool, IgnoreReason, string) {
+	normalizedPath := normalizePath(relPath)
 
 	// 1. Check explicit excludes (highest priority)
-	if e.explicitExcludes.MatchesPath(normalizedPath) {
-		return true, IgnoreReasonExplicit
+	if matched, pattern := e.explicitExcludes.MatchesPathHow(normalizedPath); matched {
+		return true, IgnoreReasonExplicit, pattern.Line
 	}
 
 	// 2. Check explicit includes (overrides all other rules)
-	if e.explicitIncludes.MatchesPath(normalizedPath) {
-		return false, IgnoreReasonNone
+	if matched, pattern := e.explicitIncludes.MatchesPathHow(normalizedPath); matched {
+		return false, IgnoreReasonNone, pattern.Line
 	}
 
-	// 3. Check built-in patterns
-	if e.builtInMatcher.MatchesPath(normalizedPath) {
-		return true, IgnoreReasonBuiltIn
+	// 3. Check the allowlist: files it does not match are never candidates.
+	// Directories are left to the other layers, since kept files may lie below them.
+	if e.keepMatcher != nil && !strings.HasSuffix(normalizedPath, "/") && !e.keepMatcher.MatchesPath(normalizedPath) {
+		return true, IgnoreReasonNotKept, ""
 	}
 
-	// 4. Check .gitignore patterns
-	if e.gitignoreMatcher.MatchesPath(normalizedPath) {
-		return true, IgnoreReasonGitignore
+	// 4. Check built-in patterns
+	if matched, pattern := e.builtInMatcher.MatchesPathHow(normalizedPath); matched {
+		return true, IgnoreReasonBuiltIn, pattern.Line
 	}
 
-	// 5. Check custom patterns (lowest priority)
-	if e.customMatcher.MatchesPath(normalizedPath) {
-		return true, IgnoreReasonCustom
+	// 5. Check .gitignore patterns
+	if matched, pattern := e.gitignoreMatcher.MatchesPathHow(normalizedPath); matched {
+		return true, IgnoreReasonGitignore, pattern.Line
+	}
+
+	// 6. Check custom patterns (lowest priority)
+	if matched, pattern := e.customMatcher.MatchesPathHow(normalizedPath); matched {
+		return true, IgnoreReasonCustom, pattern.Line
+	}
+
+	// 7. Check .gitattributes export-ignore and linguist-generated paths, then generated
+	// and lock files, unless a custom negation re-includes them
+	layers := []struct {
+		matcher *gitignore.GitIgnore
+		reason  IgnoreReason
+	}{
+		{e.gitattributesMatcher, IgnoreReasonGitattributes},
+		{e.generatedMatcher, IgnoreReasonGenerated},
+	}
+	for _, layer := range layers {
+		if layer.matcher == nil {
+			continue
+		}
+		if matched, pattern := layer.matcher.MatchesPathHow(normalizedPath); matched {
+			if negated, negation := e.customNegations.MatchesPathHow(normalizedPath); negated {
+				return false, IgnoreReasonNone, "!" + negation.Line
+			}
+			return true, layer.reason, pattern.Line
+		}
 	}
 
 	// Path is not ignored
-	return false, IgnoreReasonNone
+	return false, IgnoreReasonNone, ""
+}
+
+// skipUnreadableDir lets the ignore file walks skip a directory below rootDir that
+// cannot be read, as the scanner does, instead of failing the whole scan.
+func skipUnreadableDir(rootDir, path string, info os.FileInfo, err error) error {
+	if info != nil && info.IsDir() && path != rootDir {
+		return filepath.SkipDir
+	}
+
+	return err
 }
 
 // LoadGitignore loads .gitignore rules from the specified directory
@@ -228,7 +357,7 @@ func (e *LayeredIgnoreEngine) LoadGitignore(rootDir string) error {
 
 	err := filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
-			return err
+			return skipUnreadableDir(rootDir, path, info, err)
 		}
 
 		if !info.IsDir() && info.Name() == ".gitignore" {
@@ -273,18 +402,7 @@ func (e *LayeredIgnoreEngine) LoadGitignore(rootDir string) error {
 				continue // Skip empty lines and comments
 			}
 
-			// If this is a nested .gitignore (not root), prefix patterns with relative path
-			if relDir != "." && relDir != "" {
-				// Adjust pattern for nested location
-				if strings.HasPrefix(line, "!") {
-					// Negation pattern - prefix after the !
-					line = "!" + filepath.Join(relDir, line[1:])
-				} else {
-					line = filepath.Join(relDir, line)
-				}
-			}
-
-			allPatterns = append(allPatterns, line)
+			allPatterns = append(allPatterns, scopePattern(relDir, line))
 		}
 	}
 
@@ -303,10 +421,8 @@ func (e *LayeredIgnoreEngine) AddCustomRule(pattern string) error {
 	// Add pattern to accumulated list
 	e.customPatterns = append(e.customPatterns, pattern)
 
-	// Recompile matcher with all accumulated patterns
-	if len(e.customPatterns) > 0 {
-		e.customMatcher = gitignore.CompileIgnoreLines(e.customPatterns...)
-	}
+	// Recompile matchers with all accumulated patterns
+	e.compileCustom()
 
 	return nil
 }
@@ -333,12 +449,26 @@ func (e *LayeredIgnoreEngine) AddCustomRules(patterns []string) error {
 	// Accumulate patterns with existing customPatterns
 	e.customPatterns = append(e.customPatterns, validPatterns...)
 
-	// Recompile matcher with all accumulated patterns
-	e.customMatcher = gitignore.CompileIgnoreLines(e.customPatterns...)
+	// Recompile matchers with all accumulated patterns
+	e.compileCustom()
 
 	return nil
 }
 
+// compileCustom compiles the accumulated custom patterns, along with a matcher for
+// the paths their negations re-include.
+func (e *LayeredIgnoreEngine) compileCustom() {
+	e.customMatcher = gitignore.CompileIgnoreLines(e.customPatterns...)
+
+	var negations []string
+	for _, pattern := range e.customPatterns {
+		if strings.HasPrefix(pattern, "!") {
+			negations = append(negations, pattern[1:])
+		}
+	}
+	e.customNegations = gitignore.CompileIgnoreLines(negations...)
+}
+
 // AddExplicitExclude adds a pattern that should always be excluded
 func (e *LayeredIgnoreEngine) AddExplicitExclude(pattern string) error {
 	if pattern == "" {
@@ -375,14 +505,14 @@ func (e *LayeredIgnoreEngine) AddExplicitInclude(pattern string) error {
 
 // IsGitignored returns true if the path would be ignored by .gitignore rules specifically.
 func (e *LayeredIgnoreEngine) IsGitignored(relPath string) bool {
-	normalizedPath := filepath.ToSlash(relPath)
+	normalizedPath := normalizePath(relPath)
 
 	return e.gitignoreMatcher.MatchesPath(normalizedPath)
 }
 
 // IsCustomIgnored returns true if the path would be ignored by custom rules specifically.
 func (e *LayeredIgnoreEngine) IsCustomIgnored(relPath string) bool {
-	normalizedPath := filepath.ToSlash(relPath)
+	normalizedPath := normalizePath(relPath)
 
 	return e.customMatcher.MatchesPath(normalizedPath)
 }
@@ -394,7 +524,7 @@ func (e *LayeredIgnoreEngine) LoadShotgunignore(rootDir string) error {
 
 	err := filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
-			return err
+			return skipUnreadableDir(rootDir, path, info, err)
 		}
 
 		if !info.IsDir() && info.Name() == ".shotgunignore" {
@@ -437,18 +567,7 @@ func (e *LayeredIgnoreEngine) LoadShotgunignore(rootDir string) error {
 				continue // Skip empty lines and comments
 			}
 
-			// If this is a nested .shotgunignore (not root), prefix patterns with relative path
-			if relDir != "." && relDir != "" {
-				// Adjust pattern for nested location
-				if strings.HasPrefix(line, "!") {
-					// Negation pattern - prefix after the !
-					line = "!" + filepath.Join(relDir, line[1:])
-				} else {
-					line = filepath.Join(relDir, line)
-				}
-			}
-
-			allPatterns = append(allPatterns, line)
+			allPatterns = append(allPatterns, scopePattern(relDir, line))
 		}
 	}
 
@@ -459,3 +578,176 @@ func (e *LayeredIgnoreEngine) LoadShotgunignore(rootDir string) error {
 
 	return nil
 }
+
+// LoadShotgunkeep loads .shotgunkeep rules from the specified directory. When any are
+// found, only files they match are candidates for inclusion; the other layers still
+// apply to those files. Without .shotgunkeep files the engine is left unchanged.
+func (e *LayeredIgnoreEngine) LoadShotgunkeep(rootDir string) error {
+	var allPatterns []string
+
+	err := filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return skipUnreadableDir(rootDir, path, info, err)
+		}
+		if info.IsDir() || info.Name() != ".shotgunkeep" {
+			return nil
+		}
+
+		content, err := os.ReadFile(path) //nolint:gosec // path comes from controlled directory walk
+		if err != nil {
+			return nil //nolint:nilerr // skip files we can't read
+		}
+		relDir, err := filepath.Rel(rootDir, filepath.Dir(path))
+		if err != nil {
+			return nil //nolint:nilerr // skip files outside the root
+		}
+
+		for _, line := range strings.Split(string(content), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue // Skip empty lines and comments
+			}
+
+			allPatterns = append(allPatterns, scopePattern(relDir, line))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to walk directory for shotgunkeep files: %w", err)
+	}
+
+	if len(allPatterns) > 0 {
+		e.keepMatcher = gitignore.CompileIgnoreLines(allPatterns...)
+	}
+
+	return nil
+}
+
+// HasKeepRules returns true if .shotgunkeep rules restrict the files considered.
+func (e *LayeredIgnoreEngine) HasKeepRules() bool {
+	return e.keepMatcher != nil
+}
+
+// LoadGitattributes loads the .gitattributes files in the specified directory tree and
+// ignores the paths they mark export-ignore or linguist-generated (set or =true). Later
+// lines unsetting the attribute (-export-ignore, linguist-generated=false) keep a path, as
+// in git. Without such attributes the engine is left unchanged.
+func (e *LayeredIgnoreEngine) LoadGitattributes(rootDir string) error {
+	var allPatterns []string
+
+	err := filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return skipUnreadableDir(rootDir, path, info, err)
+		}
+		if info.IsDir() || info.Name() != ".gitattributes" {
+			return nil
+		}
+
+		content, err := os.ReadFile(path) //nolint:gosec // path comes from controlled directory walk
+		if err != nil {
+			return nil //nolint:nilerr // skip files we can't read
+		}
+		relDir, err := filepath.Rel(rootDir, filepath.Dir(path))
+		if err != nil {
+			return nil //nolint:nilerr // skip files outside the root
+		}
+
+		for _, line := range strings.Split(string(content), "\n") {
+			if pattern, ok := gitattributesPattern(line); ok {
+				allPatterns = append(allPatterns, scopePattern(relDir, pattern))
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to walk directory for gitattributes files: %w", err)
+	}
+
+	if len(allPatterns) > 0 {
+		e.gitattributesMatcher = gitignore.CompileIgnoreLines(allPatterns...)
+	}
+
+	return nil
+}
+
+// gitattributesPattern turns a .gitattributes line into an ignore pattern: the line's
+// pattern when it sets export-ignore or linguist-generated, or its negation when it
+// unsets them. Lines about other attributes, comments and macros give false.
+func gitattributesPattern(line string) (string, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], "[attr]") {
+		return "", false
+	}
+
+	pattern, result := fields[0], ""
+	for _, attr := range fields[1:] {
+		switch attr {
+		case "export-ignore", "linguist-generated", "linguist-generated=true":
+			result = pattern
+		case "-export-ignore", "!export-ignore", "-linguist-generated", "!linguist-generated",
+			"linguist-generated=false":
+			result = "!" + pattern
+		}
+	}
+
+	return result, result != ""
+}
+
+// SetIgnoreGenerated turns the generated and lock file layer on or off. It is on for
+// a new engine.
+func (e *LayeredIgnoreEngine) SetIgnoreGenerated(enabled bool) {
+	if !enabled {
+		e.generatedMatcher = nil
+		return
+	}
+
+	e.generatedMatcher = gitignore.CompileIgnoreLines(generatedPatterns...)
+}
+
+// normalizePath converts relPath to the root-relative, slash-separated form the
+// matchers expect, so anchored patterns (/foo) are always evaluated from the root.
+// A trailing slash is preserved because it marks a directory.
+func normalizePath(relPath string) string {
+	normalized := filepath.ToSlash(relPath)
+	for strings.HasPrefix(normalized, "./") {
+		normalized = normalized[2:]
+	}
+
+	return strings.TrimLeft(normalized, "/")
+}
+
+// scopePattern rewrites a pattern read from an ignore file in relDir (relative to the
+// scan root) so it keeps git semantics once compiled into a single root-level matcher.
+// Patterns with a slash before their last character are anchored to the ignore file's
+// directory; patterns without one match at any depth below it.
+func scopePattern(relDir, pattern string) string {
+	negated := strings.HasPrefix(pattern, "!")
+	if negated {
+		pattern = pattern[1:]
+	}
+
+	base := ""
+	if relDir != "." && relDir != "" {
+		base = "/" + filepath.ToSlash(relDir)
+	}
+
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	switch {
+	case base == "" && strings.HasPrefix(pattern, "**/"):
+		// Already matches at any depth from the root
+	case anchored:
+		pattern = base + "/" + strings.TrimPrefix(pattern, "/")
+	case base != "":
+		pattern = base + "/**/" + pattern
+	}
+
+	if negated {
+		return "!" + pattern
+	}
+
+	return pattern
+}
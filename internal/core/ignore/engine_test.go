@@ -16,6 +16,8 @@ func TestIgnoreReason_String(t *testing.T) {
 		{IgnoreReasonGitignore, "gitignore"},
 		{IgnoreReasonCustom, "custom"},
 		{IgnoreReasonExplicit, "explicit"},
+		{IgnoreReasonNotKept, "not kept"},
+		{IgnoreReasonGitattributes, "gitattributes"},
 		{IgnoreReason(999), "unknown"},
 	}
 
@@ -642,3 +644,335 @@ func BenchmarkLayeredIgnoreEngine_NewIgnoreEngine(b *testing.B) {
 		NewIgnoreEngine()
 	}
 }
+
+func TestLayeredIgnoreEngine_AnchoredPatterns(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		ignored bool
+	}{
+		{"/build", "build", true},
+		{"/build", "build/", true},
+		{"/build", "build/out.txt", true},
+		{"/build", "src/build/out.txt", false},
+		{"/build", "src/build/", false},
+		{"build/", "build/", true},
+		{"build/", "build/out.txt", true},
+		{"build/", "src/build/", true},
+		{"build/", "src/build/out.txt", true},
+		{"build/", "build", false}, // a file named build is not a directory
+		{"**/build", "build", true},
+		{"**/build", "src/build", true},
+		{"**/build", "a/b/build/out.txt", true},
+		{"src/build", "src/build/out.txt", true},
+		{"src/build", "lib/src/build/out.txt", false}, // a middle slash anchors the pattern
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, ".shotgunignore"), []byte(tt.pattern+"\n"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			engine := NewIgnoreEngine()
+			if err := engine.LoadShotgunignore(dir); err != nil {
+				t.Fatal(err)
+			}
+
+			// IsCustomIgnored skips the built-in layer, which already ignores build/
+			if got := engine.IsCustomIgnored(tt.path); got != tt.ignored {
+				t.Errorf("pattern %q: IsCustomIgnored(%q) = %v, want %v", tt.pattern, tt.path, got, tt.ignored)
+			}
+		})
+	}
+}
+
+func TestLayeredIgnoreEngine_NestedShotgunignoreAnchoring(t *testing.T) {
+	dir := t.TempDir()
+	nested := filepath.Join(dir, "pkg")
+	if err := os.MkdirAll(nested, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	content := "/gen\ncache/\n*.tmp\n!keep.tmp\n"
+	if err := os.WriteFile(filepath.Join(nested, ".shotgunignore"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := NewIgnoreEngine()
+	if err := engine.LoadShotgunignore(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path    string
+		ignored bool
+	}{
+		{"pkg/gen/", true},
+		{"pkg/gen/code.go", true},
+		{"pkg/sub/gen/code.go", false}, // anchored to pkg/
+		{"gen/code.go", false},
+		{"other/pkg/gen/code.go", false},
+		{"pkg/cache/", true},
+		{"pkg/sub/cache/data", true}, // unanchored: any depth below pkg/
+		{"cache/data", false},
+		{"pkg/a.tmp", true},
+		{"pkg/sub/b.tmp", true},
+		{"pkg/sub/keep.tmp", false},
+		{"a.tmp", false},
+	}
+
+	for _, tt := range tests {
+		if got := engine.IsCustomIgnored(tt.path); got != tt.ignored {
+			t.Errorf("IsCustomIgnored(%q) = %v, want %v", tt.path, got, tt.ignored)
+		}
+	}
+}
+
+func TestLayeredIgnoreEngine_ShouldIgnoreNormalizesRootPrefix(t *testing.T) {
+	engine := NewIgnoreEngine()
+	if err := engine.AddCustomRule("/docs"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{"docs/a.md", "./docs/a.md", "/docs/a.md"} {
+		if ignored, _ := engine.ShouldIgnore(path); !ignored {
+			t.Errorf("ShouldIgnore(%q) = false, want true", path)
+		}
+	}
+	if ignored, _ := engine.ShouldIgnore("src/docs/a.md"); ignored {
+		t.Error("ShouldIgnore(\"src/docs/a.md\") = true, want false")
+	}
+}
+
+func TestLayeredIgnoreEngine_LoadShotgunkeep(t *testing.T) {
+	t.Run("missing shotgunkeep file", func(t *testing.T) {
+		engine := NewIgnoreEngine()
+		if err := engine.LoadShotgunkeep(t.TempDir()); err != nil {
+			t.Fatalf("LoadShotgunkeep() with missing file should not error, got %v", err)
+		}
+
+		if engine.HasKeepRules() {
+			t.Error("HasKeepRules() = true without a .shotgunkeep file")
+		}
+		if ignored, _ := engine.ShouldIgnore("main.go"); ignored {
+			t.Error("Should not ignore files when no .shotgunkeep exists")
+		}
+	})
+
+	t.Run("only kept files are candidates", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.MkdirAll(filepath.Join(dir, "pkg"), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, ".shotgunkeep"), []byte("# Keep\n*.go\ndocs/\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "pkg", ".shotgunkeep"), []byte("/schema.sql\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		engine := NewIgnoreEngine()
+		if err := engine.AddCustomRule("*_test.go"); err != nil {
+			t.Fatal(err)
+		}
+		if err := engine.AddExplicitInclude("Makefile"); err != nil {
+			t.Fatal(err)
+		}
+		if err := engine.AddExplicitExclude("docs/draft.md"); err != nil {
+			t.Fatal(err)
+		}
+		if err := engine.LoadShotgunkeep(dir); err != nil {
+			t.Fatal(err)
+		}
+		if !engine.HasKeepRules() {
+			t.Fatal("HasKeepRules() = false after loading .shotgunkeep")
+		}
+
+		tests := []struct {
+			path    string
+			ignored bool
+			reason  IgnoreReason
+		}{
+			{"main.go", false, IgnoreReasonNone},
+			{"pkg/util.go", false, IgnoreReasonNone},
+			{"docs/guide.md", false, IgnoreReasonNone},
+			{"pkg/schema.sql", false, IgnoreReasonNone},
+			{"README.md", true, IgnoreReasonNotKept}, // not ignored by any other rule
+			{"schema.sql", true, IgnoreReasonNotKept},
+			{"pkg/sub/schema.sql", true, IgnoreReasonNotKept},
+			{"pkg/", false, IgnoreReasonNone}, // directories stay traversable
+			{"main_test.go", true, IgnoreReasonCustom},
+			{"Makefile", false, IgnoreReasonNone},
+			{"docs/draft.md", true, IgnoreReasonExplicit},
+		}
+
+		for _, tt := range tests {
+			ignored, reason := engine.ShouldIgnore(tt.path)
+			if ignored != tt.ignored || reason != tt.reason {
+				t.Errorf("ShouldIgnore(%q) = (%v, %v), want (%v, %v)", tt.path, ignored, reason, tt.ignored, tt.reason)
+			}
+		}
+	})
+}
+
+func TestLayeredIgnoreEngine_GeneratedFiles(t *testing.T) {
+	engine := NewIgnoreEngine()
+	if err := engine.AddCustomRules([]string{"*.lock", "!Cargo.lock"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.AddExplicitInclude("api/*.pb.go"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path    string
+		ignored bool
+		reason  IgnoreReason
+	}{
+		{"go.sum", true, IgnoreReasonGenerated},
+		{"web/package-lock.json", true, IgnoreReasonGenerated},
+		{"proto/user.pb.go", true, IgnoreReasonGenerated},
+		{"static/app.min.js", true, IgnoreReasonGenerated},
+		{"yarn.lock", true, IgnoreReasonCustom}, // a custom rule drops it entirely
+		{"Cargo.lock", false, IgnoreReasonNone}, // re-included by a custom negation
+		{"api/user.pb.go", false, IgnoreReasonNone},
+		{"main.go", false, IgnoreReasonNone},
+		{"go.mod", false, IgnoreReasonNone},
+	}
+
+	for _, tt := range tests {
+		ignored, reason := engine.ShouldIgnore(tt.path)
+		if ignored != tt.ignored || reason != tt.reason {
+			t.Errorf("ShouldIgnore(%q) = (%v, %v), want (%v, %v)", tt.path, ignored, reason, tt.ignored, tt.reason)
+		}
+	}
+
+	engine.SetIgnoreGenerated(false)
+	if ignored, _ := engine.ShouldIgnore("go.sum"); ignored {
+		t.Error("ShouldIgnore(go.sum) = true with the generated layer off")
+	}
+	if IgnoreReasonGenerated.String() != "generated" {
+		t.Errorf("IgnoreReasonGenerated.String() = %q", IgnoreReasonGenerated.String())
+	}
+}
+
+func TestLayeredIgnoreEngine_LoadGitattributes(t *testing.T) {
+	t.Run("missing gitattributes file", func(t *testing.T) {
+		engine := NewIgnoreEngine()
+		if err := engine.LoadGitattributes(t.TempDir()); err != nil {
+			t.Fatalf("LoadGitattributes() with missing file should not error, got %v", err)
+		}
+		if ignored, _ := engine.ShouldIgnore("release/notes.txt"); ignored {
+			t.Error("Should not ignore files when no .gitattributes exists")
+		}
+	})
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "api"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	rootAttributes := "# Release archive\n" +
+		"[attr]binary -diff -merge -text\n" +
+		"*.csv binary\n" +
+		"*.sh text eol=lf\n" +
+		"/release/** export-ignore\n" +
+		"testdata/** export-ignore\n" +
+		"testdata/golden.txt -export-ignore\n" +
+		"*.gen.go linguist-generated=true\n" +
+		"docs/*.html linguist-generated\n" +
+		"docs/index.html linguist-generated=false\n"
+	if err := os.WriteFile(filepath.Join(dir, ".gitattributes"), []byte(rootAttributes), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "api", ".gitattributes"), []byte("/schema.go linguist-generated\n"),
+		0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := NewIgnoreEngine()
+	if err := engine.AddCustomRule("!keep.gen.go"); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.LoadGitattributes(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path    string
+		ignored bool
+		reason  IgnoreReason
+	}{
+		{"release/notes.txt", true, IgnoreReasonGitattributes},
+		{"testdata/input.json", true, IgnoreReasonGitattributes},
+		{"testdata/golden.txt", false, IgnoreReasonNone}, // export-ignore unset by a later line
+		{"models.gen.go", true, IgnoreReasonGitattributes},
+		{"pkg/models.gen.go", true, IgnoreReasonGitattributes},
+		{"keep.gen.go", false, IgnoreReasonNone}, // re-included by a custom negation
+		{"docs/api.html", true, IgnoreReasonGitattributes},
+		{"docs/index.html", false, IgnoreReasonNone},
+		{"api/schema.go", true, IgnoreReasonGitattributes},
+		{"schema.go", false, IgnoreReasonNone}, // scoped to the api directory
+		{"fixtures.csv", false, IgnoreReasonNone},
+		{"build.sh", false, IgnoreReasonNone},
+		{"main.go", false, IgnoreReasonNone},
+		{"src/release/notes.txt", false, IgnoreReasonNone},
+	}
+
+	for _, tt := range tests {
+		ignored, reason := engine.ShouldIgnore(tt.path)
+		if ignored != tt.ignored || reason != tt.reason {
+			t.Errorf("ShouldIgnore(%q) = (%v, %v), want (%v, %v)", tt.path, ignored, reason, tt.ignored, tt.reason)
+		}
+	}
+}
+
+// TestLayeredIgnoreEngine_Precedence pins the layer order documented on ShouldIgnore
+// and in the README: each case is matched by several layers and the first one wins.
+func TestLayeredIgnoreEngine_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*.log\nsecret.txt\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := NewIgnoreEngine()
+	if err := engine.LoadGitignore(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.AddCustomRules([]string{"secret.txt", "notes.txt", "*.md", "!go.sum"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.AddExplicitInclude("keep.log"); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.AddExplicitExclude("keep.log"); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.AddExplicitInclude("README.md"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path    string
+		ignored bool
+		reason  IgnoreReason
+		pattern string
+	}{
+		{"keep.log", true, IgnoreReasonExplicit, "keep.log"},      // explicit exclude beats explicit include
+		{"README.md", false, IgnoreReasonNone, "README.md"},       // explicit include beats custom
+		{"app.log", true, IgnoreReasonBuiltIn, "*.log"},           // built-in beats .gitignore
+		{"secret.txt", true, IgnoreReasonGitignore, "secret.txt"}, // .gitignore beats custom
+		{"notes.txt", true, IgnoreReasonCustom, "notes.txt"},
+		{"go.sum", false, IgnoreReasonNone, "!go.sum"}, // a custom negation beats generated
+		{"yarn.lock", true, IgnoreReasonGenerated, "yarn.lock"},
+		{"main.go", false, IgnoreReasonNone, ""},
+	}
+
+	for _, tt := range tests {
+		ignored, reason, pattern := engine.ExplainIgnore(tt.path)
+		if ignored != tt.ignored || reason != tt.reason || pattern != tt.pattern {
+			t.Errorf("ExplainIgnore(%q) = (%v, %v, %q), want (%v, %v, %q)",
+				tt.path, ignored, reason, pattern, tt.ignored, tt.reason, tt.pattern)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package llm
+
+import (
+	"strings"
+)
+
+// CodeBlock is a fenced code block found in a response.
+type CodeBlock struct {
+	Language string // First word of the fence info string, e.g. "go" (empty = none)
+	Code     string // Content between the fences, ending in a newline unless empty
+}
+
+// ExtractCodeBlocks returns the fenced (``` or ~~~) code blocks in text, in order.
+// A block that is never closed is ignored.
+func ExtractCodeBlocks(text string) []CodeBlock {
+	var blocks []CodeBlock
+
+	var (
+		fence   string
+		current *CodeBlock
+		lines   []string
+	)
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if current == nil {
+			if marker := openingFence(trimmed); marker != "" {
+				fence = marker
+				info := strings.Fields(strings.TrimPrefix(trimmed, marker))
+				current = &CodeBlock{}
+				if len(info) > 0 {
+					current.Language = info[0]
+				}
+				lines = nil
+			}
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			if len(lines) > 0 {
+				current.Code = strings.Join(lines, "\n") + "\n"
+			}
+			blocks = append(blocks, *current)
+			current = nil
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return blocks
+}
+
+// openingFence returns the run of backticks or tildes that opens a code block on
+// line, or an empty string when line does not open one.
+func openingFence(line string) string {
+	for _, ch := range []string{"`", "~"} {
+		n := len(line) - len(strings.TrimLeft(line, ch))
+		if n >= 3 {
+			return line[:n]
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,59 @@
+package llm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractCodeBlocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []CodeBlock
+	}{
+		{
+			name:     "single block",
+			text:     "Here you go:\n\n```go\npackage main\n\nfunc main() {}\n```\n",
+			expected: []CodeBlock{{Language: "go", Code: "package main\n\nfunc main() {}\n"}},
+		},
+		{
+			name: "multiple blocks",
+			text: "```go\na := 1\n```\ntext\n~~~python title=x.py\nb = 2\n~~~\n```\nplain\n```",
+			expected: []CodeBlock{
+				{Language: "go", Code: "a := 1\n"},
+				{Language: "python", Code: "b = 2\n"},
+				{Code: "plain\n"},
+			},
+		},
+		{
+			name:     "longer fence keeps inner fences",
+			text:     "````markdown\n```go\nx\n```\n````\n",
+			expected: []CodeBlock{{Language: "markdown", Code: "```go\nx\n```\n"}},
+		},
+		{
+			name:     "crlf line endings",
+			text:     "```sh\r\necho hi\r\n```\r\n",
+			expected: []CodeBlock{{Language: "sh", Code: "echo hi\n"}},
+		},
+		{
+			name:     "empty block",
+			text:     "```\n```",
+			expected: []CodeBlock{{}},
+		},
+		{
+			name: "unclosed block",
+			text: "```go\npackage main\n",
+		},
+		{
+			name: "no blocks",
+			text: "Just prose.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExtractCodeBlocks(tt.text))
+		})
+	}
+}
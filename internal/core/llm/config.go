@@ -19,6 +19,14 @@ type Config struct {
 	// Optional configurations.
 	MaxTokens   int     // Max tokens in response
 	Temperature float64 // Temperature (0.0 - 2.0)
+
+	// CacheContext asks providers that support prompt caching to cache the file context.
+	CacheContext bool
+
+	// Network configurations (empty = direct, with HTTP(S)_PROXY from the environment).
+	Proxy              string // Proxy URL for every request
+	CACert             string // Path to a PEM bundle of extra trusted CA certificates
+	InsecureSkipVerify bool   // Skip TLS certificate verification (dangerous, lab use only)
 }
 
 // DefaultConfigs returns default configurations per provider.
@@ -0,0 +1,116 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Kinds of failed request, matched with errors.Is against the errors providers return.
+var (
+	ErrRateLimited = errors.New("rate limited")
+	ErrAuth        = errors.New("authentication failed")
+	ErrTimeout     = errors.New("request timed out")
+	ErrQuota       = errors.New("quota exceeded")
+	ErrServerError = errors.New("server error")
+)
+
+// APIError is a failed provider request. It matches its Kind and its cause with
+// errors.Is and errors.As, and prints the provider's message as before.
+type APIError struct {
+	Kind       error  // One of ErrRateLimited, ErrAuth, ErrTimeout, ErrQuota or ErrServerError; nil if none fits
+	Provider   string // Provider display name
+	StatusCode int    // HTTP status, 0 when no response was received
+	Message    string // Message from the provider or the transport
+	Err        error  // Underlying cause
+}
+
+// Error returns the provider's message with its HTTP status.
+func (e *APIError) Error() string {
+	if e.StatusCode == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("API error [%d]: %s", e.StatusCode, e.Message)
+}
+
+// Unwrap returns the kind and the cause, so errors.Is(err, ErrAuth) holds for an
+// authentication failure.
+func (e *APIError) Unwrap() []error {
+	var errs []error
+	if e.Kind != nil {
+		errs = append(errs, e.Kind)
+	}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
+
+// NewAPIError returns the error for a response with the given status and message,
+// classified by ClassifyStatus.
+func NewAPIError(provider string, statusCode int, message string, cause error) *APIError {
+	return &APIError{
+		Kind:       ClassifyStatus(statusCode, message),
+		Provider:   provider,
+		StatusCode: statusCode,
+		Message:    message,
+		Err:        cause,
+	}
+}
+
+// ClassifyStatus returns the kind of failure an HTTP status stands for, or nil. A 429
+// is a quota error rather than a rate limit when message mentions quota, billing or
+// credit, since waiting does not help.
+func ClassifyStatus(statusCode int, message string) error {
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ErrAuth
+	case statusCode == http.StatusPaymentRequired:
+		return ErrQuota
+	case statusCode == http.StatusTooManyRequests:
+		lower := strings.ToLower(message)
+		for _, word := range []string{"quota", "billing", "credit"} {
+			if strings.Contains(lower, word) {
+				return ErrQuota
+			}
+		}
+		return ErrRateLimited
+	case statusCode == http.StatusRequestTimeout || statusCode == http.StatusGatewayTimeout:
+		return ErrTimeout
+	case statusCode >= http.StatusInternalServerError:
+		return ErrServerError
+	default:
+		return nil
+	}
+}
+
+// IsTimeout reports whether err is a deadline or network timeout, for requests that
+// got no response.
+func IsTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// ErrorHint returns a suggestion for recovering from err, or "" when it has none.
+func ErrorHint(err error) string {
+	switch {
+	case errors.Is(err, ErrAuth):
+		return "Check the API key with 'shotgun-cli config set llm.api-key YOUR_KEY' and run 'shotgun-cli llm doctor'"
+	case errors.Is(err, ErrQuota):
+		return "The account is out of quota or credit; check its plan and billing with the provider"
+	case errors.Is(err, ErrRateLimited):
+		return "The provider is rate limiting requests; wait a moment and try again"
+	case errors.Is(err, ErrTimeout):
+		return "The request timed out; raise llm.timeout or send less context"
+	case errors.Is(err, ErrServerError):
+		return "The provider had a server error; try again later"
+	default:
+		return ""
+	}
+}
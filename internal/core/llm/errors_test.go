@@ -0,0 +1,74 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyStatus(t *testing.T) {
+	tests := []struct {
+		status  int
+		message string
+		want    error
+	}{
+		{401, "Invalid API key", ErrAuth},
+		{403, "Forbidden", ErrAuth},
+		{402, "Payment required", ErrQuota},
+		{429, "Rate limit reached", ErrRateLimited},
+		{429, "You exceeded your current quota", ErrQuota},
+		{429, "Your credit balance is too low", ErrQuota},
+		{408, "Request timeout", ErrTimeout},
+		{504, "Gateway timeout", ErrTimeout},
+		{500, "Internal error", ErrServerError},
+		{529, "Overloaded", ErrServerError},
+		{400, "Bad request", nil},
+		{404, "Not found", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d %s", tt.status, tt.message), func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyStatus(tt.status, tt.message))
+		})
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	cause := errors.New("HTTP 401: {}")
+	err := fmt.Errorf("request failed: %w", NewAPIError("OpenAI", 401, "Invalid API key", cause))
+
+	assert.EqualError(t, err, "request failed: API error [401]: Invalid API key")
+	assert.ErrorIs(t, err, ErrAuth)
+	assert.ErrorIs(t, err, cause)
+	assert.NotErrorIs(t, err, ErrRateLimited)
+
+	var apiErr *APIError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "OpenAI", apiErr.Provider)
+	assert.Equal(t, 401, apiErr.StatusCode)
+
+	timeout := &APIError{Kind: ErrTimeout, Message: "request failed: deadline", Err: context.DeadlineExceeded}
+	assert.EqualError(t, timeout, "request failed: deadline")
+	assert.ErrorIs(t, timeout, ErrTimeout)
+	assert.ErrorIs(t, timeout, context.DeadlineExceeded)
+
+	unclassified := NewAPIError("OpenAI", 400, "Bad request", nil)
+	assert.Nil(t, unclassified.Unwrap())
+}
+
+func TestIsTimeout(t *testing.T) {
+	assert.True(t, IsTimeout(fmt.Errorf("request failed: %w", context.DeadlineExceeded)))
+	assert.False(t, IsTimeout(context.Canceled))
+	assert.False(t, IsTimeout(errors.New("connection refused")))
+}
+
+func TestErrorHint(t *testing.T) {
+	assert.Contains(t, ErrorHint(NewAPIError("OpenAI", 401, "bad key", nil)), "llm.api-key")
+	assert.Contains(t, ErrorHint(NewAPIError("OpenAI", 429, "slow down", nil)), "try again")
+	assert.Contains(t, ErrorHint(NewAPIError("OpenAI", 429, "insufficient_quota", nil)), "quota")
+	assert.Contains(t, ErrorHint(&APIError{Kind: ErrTimeout}), "llm.timeout")
+	assert.Empty(t, ErrorHint(errors.New("other")))
+}
@@ -3,9 +3,59 @@ package llm
 
 import (
 	"context"
+	"errors"
+	"regexp"
+	"strings"
 	"time"
 )
 
+// SystemDelimiter marks the end of the system portion of a prompt. Templates put it
+// on its own line after the instructions that should be sent as a system message.
+const SystemDelimiter = "{END_SYSTEM}"
+
+// Request is a prompt split by role.
+type Request struct {
+	System  string // Instructions sent as a system message (empty = none)
+	Context string // Stable file context sent ahead of User, see WithFileContext (empty = none)
+	User    string // Content sent as the user message
+}
+
+// ParseRequest splits content at the first SystemDelimiter. Text before it becomes
+// the system portion; without a delimiter the whole content is the user message.
+func ParseRequest(content string) Request {
+	system, user, found := strings.Cut(content, SystemDelimiter)
+	if !found {
+		return Request{User: content}
+	}
+
+	return Request{
+		System: strings.TrimSpace(system),
+		User:   strings.TrimLeft(user, "\r\n"),
+	}
+}
+
+var (
+	fileBlockStartRe = regexp.MustCompile(`(?m)^<file path="`)
+	fileBlockEndRe   = regexp.MustCompile(`(?m)^</file>$`)
+)
+
+// WithFileContext moves the file blocks of the user message, from the first
+// <file path="..."> line to the last </file> line, into Context. Providers send the
+// context first so it can be cached across requests that only change the task and
+// rules. The request is returned unchanged when the user message has no file blocks.
+func (r Request) WithFileContext() Request {
+	start := fileBlockStartRe.FindStringIndex(r.User)
+	ends := fileBlockEndRe.FindAllStringIndex(r.User, -1)
+	if start == nil || len(ends) == 0 || ends[len(ends)-1][1] <= start[0] {
+		return r
+	}
+	end := ends[len(ends)-1][1]
+
+	r.Context = r.User[start[0]:end]
+	r.User = strings.TrimRight(r.User[:start[0]], "\n") + "\n" + strings.TrimLeft(r.User[end:], "\n")
+	return r
+}
+
 // Result represents the result of an LLM call.
 type Result struct {
 	Response    string        // Processed/cleaned response
@@ -21,6 +71,10 @@ type Usage struct {
 	PromptTokens     int // Tokens in the prompt
 	CompletionTokens int // Tokens in the response
 	TotalTokens      int // Total tokens
+
+	// Prompt caching (providers that report it)
+	CacheCreationTokens int // Prompt tokens written to the cache
+	CacheReadTokens     int // Prompt tokens read from the cache
 }
 
 // Provider defines the common interface for LLM providers.
@@ -31,6 +85,11 @@ type Provider interface {
 	// SendWithProgress sends with progress callback (for TUI).
 	SendWithProgress(ctx context.Context, content string, progress func(stage string)) (*Result, error)
 
+	// SendStream sends a prompt, calling onDelta with each piece of the response text
+	// as it arrives, and returns the complete result. Providers that cannot stream
+	// deliver the whole response in a single call.
+	SendStream(ctx context.Context, content string, onDelta func(delta string)) (*Result, error)
+
 	// Name returns the provider name (e.g., "OpenAI", "Anthropic", "Gemini").
 	Name() string
 
@@ -44,6 +103,16 @@ type Provider interface {
 	ValidateConfig() error
 }
 
+// ModelLister is implemented by providers that can list the models their endpoint serves.
+type ModelLister interface {
+	// ListModels returns the model IDs accepted as a model setting, sorted.
+	ListModels(ctx context.Context) ([]string, error)
+}
+
+// ErrModelListingUnsupported is returned, wrapped, by ListModels when the endpoint
+// does not implement model listing, as some OpenAI-compatible gateways do not.
+var ErrModelListingUnsupported = errors.New("model listing unsupported for this endpoint")
+
 // ProviderType identifies the provider type.
 type ProviderType string
 
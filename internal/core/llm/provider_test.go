@@ -40,3 +40,38 @@ func TestProviderTypeString(t *testing.T) {
 	assert.Equal(t, "anthropic", ProviderAnthropic.String())
 	assert.Equal(t, "gemini", ProviderGemini.String())
 }
+
+func TestParseRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Request
+	}{
+		{"no delimiter", "just a prompt", Request{User: "just a prompt"}},
+		{"system and user", "Be terse.\n{END_SYSTEM}\nExplain this.", Request{System: "Be terse.", User: "Explain this."}},
+		{"only first delimiter splits", "A\n{END_SYSTEM}\nB {END_SYSTEM} C", Request{System: "A", User: "B {END_SYSTEM} C"}},
+		{"empty system", "{END_SYSTEM}\nprompt", Request{User: "prompt"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseRequest(tt.content))
+		})
+	}
+}
+
+func TestRequestWithFileContext(t *testing.T) {
+	user := "## Task\nFix it.\n\n## Files\n<file path=\"a.go\">\npackage a\n</file>\n" +
+		"<file path=\"b.go\">\npackage b\n</file>\n\n## Rules\nBe brief.\n"
+
+	got := Request{System: "sys", User: user}.WithFileContext()
+	assert.Equal(t, Request{
+		System:  "sys",
+		Context: "<file path=\"a.go\">\npackage a\n</file>\n<file path=\"b.go\">\npackage b\n</file>",
+		User:    "## Task\nFix it.\n\n## Files\n## Rules\nBe brief.\n",
+	}, got)
+
+	// Indented examples, as in template instructions, are not file blocks
+	plain := Request{User: "Use blocks like:\n    <file path=\"x\">\n    </file>\n"}
+	assert.Equal(t, plain, plain.WithFileContext())
+}
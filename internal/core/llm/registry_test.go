@@ -21,6 +21,10 @@ func (m *mockProvider) SendWithProgress(ctx context.Context, content string, pro
 	return m.Send(ctx, content)
 }
 
+func (m *mockProvider) SendStream(ctx context.Context, content string, onDelta func(delta string)) (*Result, error) {
+	return m.Send(ctx, content)
+}
+
 func (m *mockProvider) Name() string         { return m.name }
 func (m *mockProvider) IsAvailable() bool    { return true }
 func (m *mockProvider) IsConfigured() bool   { return true }
@@ -0,0 +1,236 @@
+package scanner
+
+import (
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/quantmind-br/shotgun-cli/internal/core/ignore"
+)
+
+// ExplanationStep is one check the scan applied to a path and its outcome.
+type ExplanationStep struct {
+	Check  string `json:"check"`
+	Result string `json:"result"`
+}
+
+// Explanation is the decision chain behind the scan including or excluding a path.
+type Explanation struct {
+	RelPath  string            `json:"path"`
+	Included bool              `json:"included"`
+	Verdict  string            `json:"verdict"`
+	Steps    []ExplanationStep `json:"steps"`
+}
+
+// Explainer is implemented by scanners that can explain their decisions, such as
+// FileSystemScanner. Both methods use the ignore rules loaded by the most recent scan,
+// so call them after scanning rootPath with the same config.
+type Explainer interface {
+	Explain(rootPath, relPath string, config *ScanConfig) (*Explanation, error)
+	ExplainExcluded(rootPath string, config *ScanConfig) ([]Explanation, error)
+}
+
+// Explain traces how the scan decides on relPath, relative to rootPath: the directories
+// above it in turn, then the include patterns, ignore layers and filters for the path
+// itself, in the order the scan applies them.
+func (fs *FileSystemScanner) Explain(rootPath, relPath string, config *ScanConfig) (*Explanation, error) {
+	if config == nil {
+		config = DefaultScanConfig()
+	}
+	if filepath.IsAbs(relPath) {
+		rel, err := filepath.Rel(rootPath, relPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path %s: %w", relPath, err)
+		}
+		relPath = rel
+	}
+	relPath = filepath.ToSlash(filepath.Clean(relPath))
+	if relPath == "." || relPath == ".." || strings.HasPrefix(relPath, "../") {
+		return nil, fmt.Errorf("path %s is not below the root %s", relPath, rootPath)
+	}
+
+	explanation := &Explanation{RelPath: relPath}
+	parts := strings.Split(relPath, "/")
+	for i := range parts {
+		entryRel := strings.Join(parts[:i+1], "/")
+		info, err := os.Lstat(filepath.Join(rootPath, filepath.FromSlash(entryRel)))
+		if err != nil {
+			explanation.Verdict = "excluded: not found under the root"
+			return explanation, nil //nolint:nilerr // a missing path is a verdict, not a failure
+		}
+
+		decision := fs.decide(entryRel, iofs.FileInfoToDirEntry(info), config)
+		if i == len(parts)-1 {
+			explanation.Steps = append(explanation.Steps, decision.steps...)
+			explanation.Included = decision.included
+			explanation.Verdict = decision.verdict
+			break
+		}
+
+		if !decision.included || !decision.descend {
+			for _, step := range decision.steps {
+				step.Check = entryRel + "/: " + step.Check
+				explanation.Steps = append(explanation.Steps, step)
+			}
+			explanation.Verdict = fmt.Sprintf("excluded with directory %s/: %s", entryRel, decision.reason)
+			return explanation, nil
+		}
+		explanation.Steps = append(explanation.Steps, ExplanationStep{Check: entryRel + "/", Result: "scanned"})
+	}
+
+	return explanation, nil
+}
+
+// ExplainExcluded lists the entries under rootPath the scan leaves out, with why. An
+// excluded directory is listed once, since its contents are not visited.
+func (fs *FileSystemScanner) ExplainExcluded(rootPath string, config *ScanConfig) ([]Explanation, error) {
+	if config == nil {
+		config = DefaultScanConfig()
+	}
+
+	var excluded []Explanation
+	err := walkTree(rootPath, config, func(path string, d os.DirEntry, err error) error {
+		relPath, relErr := filepath.Rel(rootPath, path)
+		if err != nil || relErr != nil || relPath == "." {
+			return nil //nolint:nilerr // unreadable entries are reported by the scan itself
+		}
+
+		relPath = filepath.ToSlash(relPath)
+		decision := fs.decide(relPath, d, config)
+		if !decision.included {
+			excluded = append(excluded, Explanation{RelPath: relPath, Verdict: decision.verdict, Steps: decision.steps})
+			return fs.skipIfDirectory(d)
+		}
+		if d.IsDir() && !decision.descend {
+			return filepath.SkipDir
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory: %w", err)
+	}
+
+	return excluded, nil
+}
+
+// decision is the outcome of the scan's checks for a single entry.
+type decision struct {
+	steps    []ExplanationStep
+	included bool
+	verdict  string
+	// reason is the outcome of the check that excluded the entry, or that stopped
+	// the scan below a directory
+	reason string
+	// descend is false for a directory whose contents are not scanned
+	descend bool
+}
+
+func (d *decision) step(check, result string) {
+	d.steps = append(d.steps, ExplanationStep{Check: check, Result: result})
+}
+
+func (d *decision) exclude(check, result string) decision {
+	d.step(check, result)
+	d.verdict = "excluded: " + result
+	d.reason = result
+	return *d
+}
+
+// decide mirrors the checks walkAndBuild applies to an entry, recording each one.
+func (fs *FileSystemScanner) decide(relPath string, d os.DirEntry, config *ScanConfig) decision {
+	var result decision
+	isDir := d.IsDir()
+
+	if !isDir {
+		if len(config.IncludePatterns) > 0 {
+			pattern, ok := matchingIncludePattern(relPath, config.IncludePatterns)
+			if !ok {
+				return result.exclude("include patterns", "no include pattern matches")
+			}
+			result.step("include patterns", fmt.Sprintf("matched %q", pattern))
+		}
+		if len(config.IncludeExtensions) > 0 {
+			if !matchesIncludeExtensions(relPath, false, config) {
+				return result.exclude("extensions", "extension not in "+strings.Join(config.IncludeExtensions, ", "))
+			}
+			result.step("extensions", "matched "+filepath.Ext(relPath))
+		}
+		if len(config.RequirePatterns) > 0 {
+			if !matchesRequirePatterns(relPath, false, config) {
+				return result.exclude("required patterns", "no required pattern matches")
+			}
+			result.step("required patterns", "matched")
+		}
+	}
+
+	// Generated files are left to the generated check below
+	ignored, reason, pattern := fs.ignoreEngine.ExplainIgnore(ignorePath(relPath, isDir))
+	generated := reason == ignore.IgnoreReasonGenerated
+	switch {
+	case ignored && !generated && !config.IncludeIgnored:
+		return result.exclude("ignore rules", "ignored by "+describeRule(reason, pattern))
+	case ignored && !generated:
+		result.step("ignore rules", "ignored by "+describeRule(reason, pattern)+", kept by include ignored")
+	default:
+		if pattern != "" && !generated {
+			result.step("ignore rules", fmt.Sprintf("kept by %q", pattern))
+		} else {
+			result.step("ignore rules", "no rule matched")
+		}
+		if fs.isHiddenFile(relPath, isDir, config) {
+			return result.exclude("hidden", "hidden entry (include hidden files to keep it)")
+		}
+	}
+
+	if _, tooLarge := fs.getFileSize(d, config); tooLarge {
+		return result.exclude("size", fmt.Sprintf("larger than the %s file size limit", FormatSize(config.MaxFileSize)))
+	}
+	if fs.isOutsideTimeWindow(d, config) {
+		return result.exclude("modified since", "modified before "+config.ModifiedSince.Format("2006-01-02 15:04"))
+	}
+
+	result.included = true
+	result.descend = isDir
+	result.verdict = "included"
+	switch {
+	case generated && fs.isGenerated(relPath, isDir, config):
+		result.step("generated", fmt.Sprintf("matched %q, content left out", pattern))
+		result.verdict = "included in the tree, content left out as a generated file"
+	case generated:
+		result.step("generated", fmt.Sprintf("matched %q, embedded because an include pattern names it", pattern))
+	case isDir && fs.atDepthLimit(relPath, d, config):
+		result.reason = fmt.Sprintf("at the depth limit of %d, contents not scanned", config.MaxDepth)
+		result.step("max depth", result.reason)
+		result.descend = false
+	}
+
+	return result
+}
+
+// matchingIncludePattern returns the first include pattern matching relPath or its
+// file name, as matchesIncludePatterns does.
+func matchingIncludePattern(relPath string, patterns []string) (string, bool) {
+	fileName := filepath.Base(relPath)
+	for _, pattern := range patterns {
+		if matched, _ := filepath.Match(pattern, relPath); matched {
+			return pattern, true
+		}
+		if matched, _ := filepath.Match(pattern, fileName); matched {
+			return pattern, true
+		}
+	}
+
+	return "", false
+}
+
+// describeRule names the ignore layer that matched, with its pattern when known.
+func describeRule(reason ignore.IgnoreReason, pattern string) string {
+	if pattern == "" {
+		return reason.String() + " rule"
+	}
+
+	return fmt.Sprintf("%s rule %q", reason.String(), pattern)
+}
@@ -0,0 +1,91 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplain(t *testing.T) {
+	tempDir := t.TempDir()
+	for path, content := range map[string]string{
+		"main.go":               "package main",
+		"go.sum":                "example.com/mod v1.0.0 h1:abc=",
+		"cache.tmp":             "cache",
+		"notes.txt":             "notes",
+		"node_modules/dep/x.js": "dep",
+		"pkg/util.go":           "package pkg",
+		".gitignore":            "*.tmp\n",
+	} {
+		fullPath := filepath.Join(tempDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o750))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0o600))
+	}
+
+	fs := NewFileSystemScanner()
+	config := DefaultScanConfig()
+	config.RespectGitignore = true
+	config.IncludePatterns = []string{"*.go", "*.tmp", "*.js", "go.sum"}
+	_, err := fs.Scan(tempDir, config)
+	require.NoError(t, err)
+
+	tests := []struct {
+		path     string
+		included bool
+		verdict  string
+	}{
+		{"main.go", true, "included"},
+		{"pkg/util.go", true, "included"},
+		{"go.sum", true, "included"}, // named by an include pattern
+		{"notes.txt", false, "excluded: no include pattern matches"},
+		{"cache.tmp", false, `excluded: ignored by gitignore rule "*.tmp"`},
+		{"node_modules/dep/x.js", false,
+			`excluded with directory node_modules/: ignored by built-in rule "node_modules/"`},
+		{".gitignore", false, "excluded: no include pattern matches"},
+		{"missing.go", false, "excluded: not found under the root"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			explanation, err := fs.Explain(tempDir, tt.path, config)
+			require.NoError(t, err)
+			assert.Equal(t, tt.included, explanation.Included)
+			assert.Equal(t, tt.verdict, explanation.Verdict)
+		})
+	}
+
+	explanation, err := fs.Explain(tempDir, "pkg/util.go", config)
+	require.NoError(t, err)
+	assert.Equal(t, []ExplanationStep{
+		{Check: "pkg/", Result: "scanned"},
+		{Check: "include patterns", Result: `matched "*.go"`},
+		{Check: "ignore rules", Result: "no rule matched"},
+	}, explanation.Steps)
+
+	_, err = fs.Explain(tempDir, "../outside.go", config)
+	assert.Error(t, err)
+
+	t.Run("excluded entries", func(t *testing.T) {
+		excluded, err := fs.ExplainExcluded(tempDir, config)
+		require.NoError(t, err)
+
+		var paths []string
+		for _, entry := range excluded {
+			paths = append(paths, entry.RelPath)
+		}
+		assert.Equal(t, []string{".gitignore", "cache.tmp", "node_modules", "notes.txt"}, paths)
+	})
+
+	t.Run("explanations match the scan", func(t *testing.T) {
+		root, err := fs.Scan(tempDir, config)
+		require.NoError(t, err)
+
+		var scanned []string
+		for _, file := range SelectedFiles(root, NewSelectAll(root)) {
+			scanned = append(scanned, filepath.ToSlash(file.RelPath))
+		}
+		assert.Equal(t, []string{"go.sum", "main.go", "pkg/util.go"}, scanned)
+	})
+}
@@ -1,7 +1,12 @@
 package scanner
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -14,6 +19,7 @@ import (
 // FileSystemScanner implements the Scanner interface for local file systems
 type FileSystemScanner struct {
 	ignoreEngine ignore.IgnoreEngine
+	stats        ScanStats
 }
 
 // NewFileSystemScanner creates a new file system scanner
@@ -45,38 +51,16 @@ func (fs *FileSystemScanner) Scan(rootPath string, config *ScanConfig) (*FileNod
 func (fs *FileSystemScanner) ScanWithProgress(
 	rootPath string, config *ScanConfig, progress chan<- Progress,
 ) (*FileNode, error) {
-	if config == nil {
-		config = DefaultScanConfig()
-	}
+	return fs.ScanWithContext(context.Background(), rootPath, config, progress)
+}
 
-	// Validate rootPath exists and is a directory
-	info, err := os.Stat(rootPath)
+// ScanWithContext is ScanWithProgress stopping with ctx.Err() once ctx is cancelled.
+func (fs *FileSystemScanner) ScanWithContext(
+	ctx context.Context, rootPath string, config *ScanConfig, progress chan<- Progress,
+) (*FileNode, error) {
+	config, err := fs.prepareScan(rootPath, config)
 	if err != nil {
-		return nil, fmt.Errorf("invalid root path: %w", err)
-	}
-	if !info.IsDir() {
-		return nil, fmt.Errorf("root path is not a directory: %s", rootPath)
-	}
-
-	// Load .gitignore rules if configured (default: true)
-	if config.RespectGitignore {
-		if err := fs.ignoreEngine.LoadGitignore(rootPath); err != nil {
-			return nil, fmt.Errorf("failed to load gitignore rules: %w", err)
-		}
-	}
-
-	// Load .shotgunignore rules if configured (default: true)
-	if config.RespectShotgunignore {
-		if err := fs.ignoreEngine.LoadShotgunignore(rootPath); err != nil {
-			return nil, fmt.Errorf("failed to load shotgunignore rules: %w", err)
-		}
-	}
-
-	// Add custom patterns from config
-	if len(config.IgnorePatterns) > 0 {
-		if err := fs.ignoreEngine.AddCustomRules(config.IgnorePatterns); err != nil {
-			return nil, fmt.Errorf("failed to add custom ignore patterns: %w", err)
-		}
+		return nil, err
 	}
 
 	// Send initial progress in streaming mode (total unknown)
@@ -93,9 +77,15 @@ func (fs *FileSystemScanner) ScanWithProgress(
 	// Single pass: build the file tree (streaming mode with total = -1)
 	// We pass -1 as total to indicate streaming mode where the final count is unknown.
 	// This signals consumers (like the UI) to display an indeterminate progress state (e.g. spinner).
-	root, actualCount, err := fs.walkAndBuild(rootPath, config, progress, -1)
+	root, actualCount, err := fs.walkAndBuild(ctx, rootPath, config, progress, -1)
 	if err != nil {
-		return nil, fmt.Errorf("failed to scan directory: %w", err)
+		return nil, scanError(ctx, err)
+	}
+
+	// Drop directories left empty by the time window or the allowlist so only
+	// matching files remain
+	if !config.ModifiedSince.IsZero() || fs.ignoreEngine.HasKeepRules() {
+		fs.pruneEmptyDirs(root)
 	}
 
 	// Sort children for consistent ordering
@@ -114,20 +104,96 @@ func (fs *FileSystemScanner) ScanWithProgress(
 	return root, nil
 }
 
+// scanError returns the error a scan reports for a failed walk: a MaxFilesError or the
+// error of a cancelled ctx as is, anything else wrapped.
+func scanError(ctx context.Context, err error) error {
+	var maxFilesErr *MaxFilesError
+	if errors.As(err, &maxFilesErr) {
+		return maxFilesErr
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+		return ctxErr
+	}
+
+	return fmt.Errorf("failed to scan directory: %w", err)
+}
+
+// prepareScan resets the scan stats, checks that rootPath is a directory and loads the
+// ignore rules. It returns config, or the default config when config is nil.
+func (fs *FileSystemScanner) prepareScan(rootPath string, config *ScanConfig) (*ScanConfig, error) {
+	if config == nil {
+		config = DefaultScanConfig()
+	}
+	fs.stats = ScanStats{}
+
+	// Validate rootPath exists and is a directory
+	info, err := os.Stat(rootPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid root path: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("root path is not a directory: %s", rootPath)
+	}
+
+	if err := fs.loadIgnoreRules(rootPath, config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// loadIgnoreRules adds the ignore files under rootPath and the configured patterns to the engine.
+func (fs *FileSystemScanner) loadIgnoreRules(rootPath string, config *ScanConfig) error {
+	fs.ignoreEngine.SetIgnoreGenerated(!config.IncludeGenerated)
+
+	// Load .gitignore rules if configured (default: true)
+	if config.RespectGitignore {
+		if err := fs.ignoreEngine.LoadGitignore(rootPath); err != nil {
+			return fmt.Errorf("failed to load gitignore rules: %w", err)
+		}
+	}
+
+	// Load .shotgunignore rules if configured (default: true)
+	if config.RespectShotgunignore {
+		if err := fs.ignoreEngine.LoadShotgunignore(rootPath); err != nil {
+			return fmt.Errorf("failed to load shotgunignore rules: %w", err)
+		}
+	}
+
+	// Load .gitattributes export-ignore and linguist-generated paths if configured (default: false)
+	if config.RespectGitattributes {
+		if err := fs.ignoreEngine.LoadGitattributes(rootPath); err != nil {
+			return fmt.Errorf("failed to load gitattributes rules: %w", err)
+		}
+	}
+
+	// Load .shotgunkeep allowlist rules if configured (default: false)
+	if config.AllowlistMode {
+		if err := fs.ignoreEngine.LoadShotgunkeep(rootPath); err != nil {
+			return fmt.Errorf("failed to load shotgunkeep rules: %w", err)
+		}
+	}
+
+	// Add custom patterns from config
+	if len(config.IgnorePatterns) > 0 {
+		if err := fs.ignoreEngine.AddCustomRules(config.IgnorePatterns); err != nil {
+			return fmt.Errorf("failed to add custom ignore patterns: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // countItems performs a quick count of all items to be processed
 func (fs *FileSystemScanner) countItems(rootPath string, config *ScanConfig) (int64, error) {
 	var count int64
 	var fileCount int64
 
-	err := filepath.WalkDir(rootPath, func(path string, d os.DirEntry, err error) error {
+	err := walkTree(rootPath, config, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
 			return fs.handleCountError(d)
 		}
 
-		if fs.shouldStopCounting(config, d, fileCount) {
-			return filepath.SkipDir
-		}
-
 		relPath, err := filepath.Rel(rootPath, path)
 		if err != nil || relPath == "." {
 			return nil //nolint:nilerr // intentional: continue walking on relative path error
@@ -137,14 +203,21 @@ func (fs *FileSystemScanner) countItems(rootPath string, config *ScanConfig) (in
 			return fs.skipIfDirectory(d)
 		}
 
-		if fs.shouldSkipLargeFile(d, config) {
+		if fs.shouldSkipLargeFile(d, config) || fs.isOutsideTimeWindow(d, config) {
 			return nil
 		}
 
-		count++
-		if !d.IsDir() {
+		if fs.countsTowardMaxFiles(relPath, d, config) {
+			if fs.exceedsMaxFiles(config, fileCount) {
+				return &MaxFilesError{Limit: config.MaxFiles}
+			}
 			fileCount++
 		}
+		count++
+
+		if fs.atDepthLimit(relPath, d, config) {
+			return filepath.SkipDir
+		}
 
 		return nil
 	})
@@ -164,10 +237,6 @@ func (fs *FileSystemScanner) handleCountError(d os.DirEntry) error {
 	return nil
 }
 
-func (fs *FileSystemScanner) shouldStopCounting(config *ScanConfig, d os.DirEntry, fileCount int64) bool {
-	return config.MaxFiles > 0 && !d.IsDir() && fileCount >= config.MaxFiles
-}
-
 func (fs *FileSystemScanner) skipIfDirectory(d os.DirEntry) error {
 	if d.IsDir() {
 		return filepath.SkipDir
@@ -189,10 +258,9 @@ func (fs *FileSystemScanner) shouldSkipLargeFile(d os.DirEntry, config *ScanConf
 
 // walkAndBuild builds the file tree with progress reporting
 func (fs *FileSystemScanner) walkAndBuild(
-	rootPath string, config *ScanConfig, progress chan<- Progress, total int64,
+	ctx context.Context, rootPath string, config *ScanConfig, progress chan<- Progress, total int64,
 ) (*FileNode, int64, error) {
 	var current int64
-	var fileCount int64
 
 	// Create root node
 	root := &FileNode{
@@ -208,22 +276,58 @@ func (fs *FileSystemScanner) walkAndBuild(
 	dirNodes := make(map[string]*FileNode)
 	dirNodes[normRel(".")] = root
 
-	err := filepath.WalkDir(rootPath, func(path string, d os.DirEntry, err error) error {
+	err := fs.walkNodes(ctx, rootPath, config,
+		func(node *FileNode) error {
+			fs.addNodeToTree(node, node.RelPath, dirNodes)
+
+			current++
+
+			// Progress Throttling:
+			// reportProgress enforces a throttle (only sending updates every 100 items).
+			// This "magic number" is crucial to prevent channel flooding and UI performance degradation
+			// when scanning large directories with thousands of files.
+			fs.reportProgress(progress, current, total, node.RelPath)
+
+			return nil
+		},
+		func(relPath string, d os.DirEntry) error {
+			return fs.handleWalkError(relPath, d, dirNodes)
+		},
+	)
+
+	if err != nil {
+		return root, current, fmt.Errorf("failed to walk directory: %w", err)
+	}
+
+	return root, current, nil
+}
+
+// walkNodes walks rootPath, applying the ignore rules and the filters of config, and
+// passes a node without parent or children to visit for each entry that passes them,
+// parents before their contents. Walk errors go to onError, whose result the walk
+// returns. It is the traversal shared by the tree scan and ScanStream.
+func (fs *FileSystemScanner) walkNodes(
+	ctx context.Context, rootPath string, config *ScanConfig,
+	visit func(node *FileNode) error, onError func(relPath string, d os.DirEntry) error,
+) error {
+	var fileCount int64
+
+	return walkTree(rootPath, config, func(path string, d os.DirEntry, err error) error {
 		// Error Suppression Strategy:
 		// If we encounter an error accessing a path (e.g. permission denied), we generally don't want
 		// to abort the entire scan. handleWalkError implements this policy:
-		// - For directories: Skip the directory (filepath.SkipDir) to avoid getting stuck or crashing.
+		// - For directories: Skip the directory (filepath.SkipDir), mark its node AccessError
+		//   and record it in the scan stats so callers can report it.
 		// - For files: Suppress the error and continue, effectively ignoring the problematic file.
-		if err != nil {
-			return fs.handleWalkError(d)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
 		}
 
-		if fs.shouldStopWalking(config, d, fileCount) {
-			return filepath.SkipDir
+		relPath, relErr := filepath.Rel(rootPath, path)
+		if err != nil {
+			return onError(relPath, d)
 		}
-
-		relPath, err := filepath.Rel(rootPath, path)
-		if err != nil || relPath == "." {
+		if relErr != nil || relPath == "." {
 			return nil //nolint:nilerr // intentional: continue walking on relative path error
 		}
 
@@ -233,6 +337,9 @@ func (fs *FileSystemScanner) walkAndBuild(
 		// Critical optimization: If a directory is ignored, we skip it entirely (filepath.SkipDir),
 		// preventing traversal of massive ignored folders like node_modules or .git.
 		if fs.shouldIgnore(relPath, d.IsDir(), config) {
+			if ignored, reason := fs.ignoredBy(relPath, d.IsDir()); ignored {
+				fs.stats.countIgnored(reason)
+			}
 			return fs.skipIfDirectory(d)
 		}
 
@@ -241,44 +348,81 @@ func (fs *FileSystemScanner) walkAndBuild(
 			return nil
 		}
 
-		node := fs.createFileNode(path, relPath, d, size, config)
-		fs.addNodeToTree(node, relPath, dirNodes)
+		if fs.isOutsideTimeWindow(d, config) {
+			fs.stats.FilteredByTime++
+			return nil
+		}
 
-		current++
-		if !d.IsDir() {
+		if fs.countsTowardMaxFiles(relPath, d, config) {
+			if fs.exceedsMaxFiles(config, fileCount) {
+				return &MaxFilesError{Limit: config.MaxFiles}
+			}
 			fileCount++
 		}
 
-		// Progress Throttling:
-		// reportProgress enforces a throttle (only sending updates every 100 items).
-		// This "magic number" is crucial to prevent channel flooding and UI performance degradation
-		// when scanning large directories with thousands of files.
-		fs.reportProgress(progress, current, total, relPath)
+		// Depth Limiting:
+		// A directory at MaxDepth stays in the tree, marked so renderers can show why it
+		// is empty, but nothing below it is visited.
+		node := fs.createFileNode(path, relPath, d, size, config)
+		node.DepthLimited = fs.atDepthLimit(relPath, d, config)
+		if err := visit(node); err != nil {
+			return err
+		}
+		if node.DepthLimited {
+			return filepath.SkipDir
+		}
 
 		return nil
 	})
+}
 
-	if err != nil {
-		return root, current, fmt.Errorf("failed to walk directory: %w", err)
+// handleWalkError skips a directory that could not be read, marking its node and
+// recording it in the scan stats. Errors on files are suppressed.
+func (fs *FileSystemScanner) handleWalkError(relPath string, d os.DirEntry, dirNodes map[string]*FileNode) error {
+	if d == nil || !d.IsDir() {
+		return nil
 	}
 
-	return root, current, nil
+	if node, ok := dirNodes[normRel(relPath)]; ok {
+		node.AccessError = true
+		fs.stats.Inaccessible = append(fs.stats.Inaccessible, filepath.ToSlash(relPath))
+	}
+
+	return filepath.SkipDir
 }
 
-func (fs *FileSystemScanner) handleWalkError(d os.DirEntry) error {
-	if d != nil && d.IsDir() {
-		return filepath.SkipDir
+// countsTowardMaxFiles reports whether an entry that passed the filters is a file
+// that counts toward config.MaxFiles. Ignored files kept by IncludeIgnored do not.
+func (fs *FileSystemScanner) countsTowardMaxFiles(relPath string, d os.DirEntry, config *ScanConfig) bool {
+	if d.IsDir() || config.MaxFiles <= 0 {
+		return false
+	}
+	if !config.IncludeIgnored {
+		return true
 	}
+	isGitignored, isCustomIgnored := fs.getIgnoreStatus(relPath, false, config)
 
-	return nil
+	return !isGitignored && !isCustomIgnored
+}
+
+// exceedsMaxFiles reports whether counting one more file would go past config.MaxFiles.
+func (fs *FileSystemScanner) exceedsMaxFiles(config *ScanConfig, fileCount int64) bool {
+	return config.MaxFiles > 0 && fileCount >= config.MaxFiles
 }
 
-func (fs *FileSystemScanner) shouldStopWalking(config *ScanConfig, d os.DirEntry, fileCount int64) bool {
-	return config.MaxFiles > 0 && !d.IsDir() && fileCount >= config.MaxFiles
+// atDepthLimit reports whether relPath is a directory at config.MaxDepth, whose
+// contents must not be traversed.
+func (fs *FileSystemScanner) atDepthLimit(relPath string, d os.DirEntry, config *ScanConfig) bool {
+	return config.MaxDepth > 0 && d.IsDir() && pathDepth(relPath) >= config.MaxDepth
+}
+
+// pathDepth returns the number of components in relPath, so root entries have depth 1.
+func pathDepth(relPath string) int {
+	return strings.Count(normRel(relPath), "/") + 1
 }
 
 func (fs *FileSystemScanner) getFileSize(d os.DirEntry, config *ScanConfig) (int64, bool) {
-	if d.IsDir() {
+	if d.IsDir() || isSymlink(d) {
 		return 0, false
 	}
 	if info, err := d.Info(); err == nil {
@@ -293,11 +437,61 @@ func (fs *FileSystemScanner) getFileSize(d os.DirEntry, config *ScanConfig) (int
 	return 0, false
 }
 
+// isOutsideTimeWindow reports whether a file was last modified before config.ModifiedSince.
+func (fs *FileSystemScanner) isOutsideTimeWindow(d os.DirEntry, config *ScanConfig) bool {
+	if d.IsDir() || config.ModifiedSince.IsZero() {
+		return false
+	}
+	info, err := d.Info()
+	if err != nil {
+		return false
+	}
+
+	return info.ModTime().Before(config.ModifiedSince)
+}
+
+// pruneEmptyDirs removes directories that contain no files and reports whether node is now empty.
+// Depth-limited and unreadable directories are kept, since their contents were never examined.
+func (fs *FileSystemScanner) pruneEmptyDirs(node *FileNode) bool {
+	if !node.IsDir || node.DepthLimited || node.AccessError {
+		return false
+	}
+
+	kept := node.Children[:0]
+	for _, child := range node.Children {
+		if !fs.pruneEmptyDirs(child) {
+			kept = append(kept, child)
+		}
+	}
+	node.Children = kept
+
+	return len(node.Children) == 0
+}
+
+// countIgnored records an entry skipped by the ignore engine for reason.
+func (s *ScanStats) countIgnored(reason ignore.IgnoreReason) {
+	s.IgnoredEntries++
+	if s.IgnoredByReason == nil {
+		s.IgnoredByReason = make(map[string]int64)
+	}
+	s.IgnoredByReason[reason.String()]++
+}
+
+// LastScanStats returns statistics collected during the most recent scan.
+func (fs *FileSystemScanner) LastScanStats() ScanStats {
+	return fs.stats
+}
+
 func (fs *FileSystemScanner) createFileNode(
 	path, relPath string, d os.DirEntry, size int64, config *ScanConfig,
 ) *FileNode {
 	isGitignored, isCustomIgnored := fs.getIgnoreStatus(relPath, d.IsDir(), config)
 
+	var hash string
+	if config.ComputeHashes && !d.IsDir() && !isSymlink(d) {
+		hash = hashFile(path)
+	}
+
 	return &FileNode{
 		Name:            d.Name(),
 		Path:            path,
@@ -306,11 +500,30 @@ func (fs *FileSystemScanner) createFileNode(
 		Children:        make([]*FileNode, 0),
 		IsGitignored:    isGitignored,
 		IsCustomIgnored: isCustomIgnored,
+		IsGenerated:     fs.isGenerated(relPath, d.IsDir(), config),
+		IsSymlink:       isSymlink(d),
 		Size:            size,
+		Hash:            hash,
 		Expanded:        false,
 	}
 }
 
+// hashFile returns the hex-encoded SHA-256 of the file at path, or "" if it cannot be read.
+func hashFile(path string) string {
+	file, err := os.Open(path) //nolint:gosec // path comes from controlled directory walk
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = file.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 func (fs *FileSystemScanner) addNodeToTree(node *FileNode, relPath string, dirNodes map[string]*FileNode) {
 	parentNode := fs.findParentNode(relPath, dirNodes)
 	if parentNode != nil {
@@ -415,64 +628,140 @@ func (fs *FileSystemScanner) matchesIncludePatterns(relPath string, isDir bool,
 	return false
 }
 
+// matchesIncludeExtensions checks if a file has one of the included extensions (if any)
+func matchesIncludeExtensions(relPath string, isDir bool, config *ScanConfig) bool {
+	if len(config.IncludeExtensions) == 0 || isDir {
+		return true
+	}
+
+	ext := filepath.Ext(relPath)
+	for _, included := range config.IncludeExtensions {
+		if strings.EqualFold(ext, included) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesRequirePatterns checks if a file matches one of the required patterns (if any)
+func matchesRequirePatterns(relPath string, isDir bool, config *ScanConfig) bool {
+	if len(config.RequirePatterns) == 0 || isDir {
+		return true
+	}
+
+	for _, pattern := range config.RequirePatterns {
+		if MatchesPattern(pattern, relPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // shouldIgnore checks if a path should be ignored based on all rules
 func (fs *FileSystemScanner) shouldIgnore(relPath string, isDir bool, config *ScanConfig) bool {
 	// First check if file matches include patterns (if any)
-	if !fs.matchesIncludePatterns(relPath, isDir, config) {
+	if !fs.matchesIncludePatterns(relPath, isDir, config) || !matchesIncludeExtensions(relPath, isDir, config) ||
+		!matchesRequirePatterns(relPath, isDir, config) {
 		return true
 	}
 
 	// Use the ignore engine - it properly handles explicit includes/excludes
-	ignored, _ := fs.ignoreEngine.ShouldIgnore(relPath)
+	ignored, _ := fs.ignoredBy(relPath, isDir)
 	if ignored {
 		return !fs.shouldIncludeIgnored(config)
 	}
-	// If not ignored by engine, check hidden file exclusion
-	if !config.IncludeHidden {
-		baseName := filepath.Base(relPath)
-		if strings.HasPrefix(baseName, ".") && baseName != "." && baseName != ".." {
-			return true
-		}
-	}
-
-	return false
+	// If not ignored by engine, check hidden file/directory exclusion
+	return fs.isHiddenFile(relPath, isDir, config)
 }
 
 // getIgnoreStatus returns the ignore status for both gitignore and custom rules
-func (fs *FileSystemScanner) getIgnoreStatus(relPath string, _ bool, config *ScanConfig) (bool, bool) {
-	return fs.getIgnoreStatusWithEngine(relPath, config)
+func (fs *FileSystemScanner) getIgnoreStatus(relPath string, isDir bool, config *ScanConfig) (bool, bool) {
+	return fs.getIgnoreStatusWithEngine(relPath, isDir, config)
 }
 
-func (fs *FileSystemScanner) getIgnoreStatusWithEngine(relPath string, config *ScanConfig) (bool, bool) {
-	ignored, reason := fs.ignoreEngine.ShouldIgnore(relPath)
+func (fs *FileSystemScanner) getIgnoreStatusWithEngine(relPath string, isDir bool, config *ScanConfig) (bool, bool) {
+	enginePath := ignorePath(relPath, isDir)
+	ignored, reason := fs.ignoredBy(relPath, isDir)
 
 	if ignored {
 		return fs.classifyIgnoreReason(reason)
 	}
 
-	if fs.isHiddenFile(relPath, config) {
+	if fs.isHiddenFile(relPath, isDir, config) {
 		return false, true
 	}
 
-	isGitignored := fs.ignoreEngine.IsGitignored(relPath)
-	isCustomIgnored := fs.ignoreEngine.IsCustomIgnored(relPath) || (ignored && reason != ignore.IgnoreReasonGitignore)
+	isGitignored := fs.ignoreEngine.IsGitignored(enginePath)
+	isCustomIgnored := fs.ignoreEngine.IsCustomIgnored(enginePath) || (ignored && reason != ignore.IgnoreReasonGitignore)
 
 	return isGitignored, isCustomIgnored
 }
 
+// ignoredBy consults the ignore engine for relPath. Generated and lock files are
+// reported as not ignored: they stay in the tree, marked IsGenerated by isGenerated.
+func (fs *FileSystemScanner) ignoredBy(relPath string, isDir bool) (bool, ignore.IgnoreReason) {
+	ignored, reason := fs.ignoreEngine.ShouldIgnore(ignorePath(relPath, isDir))
+	if reason == ignore.IgnoreReasonGenerated {
+		return false, ignore.IgnoreReasonNone
+	}
+
+	return ignored, reason
+}
+
+// isGenerated reports whether relPath is a generated or lock file whose content should
+// be left out. A file named by an include pattern other than "*" is embedded anyway.
+func (fs *FileSystemScanner) isGenerated(relPath string, isDir bool, config *ScanConfig) bool {
+	if isDir {
+		return false
+	}
+	if _, reason := fs.ignoreEngine.ShouldIgnore(ignorePath(relPath, false)); reason != ignore.IgnoreReasonGenerated {
+		return false
+	}
+
+	fileName := filepath.Base(relPath)
+	for _, pattern := range config.IncludePatterns {
+		if pattern == "*" {
+			continue
+		}
+		if matched, _ := filepath.Match(pattern, relPath); matched {
+			return false
+		}
+		if matched, _ := filepath.Match(pattern, fileName); matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ignorePath returns relPath in the form the ignore engine expects, with a trailing
+// slash for directories so directory-only patterns (build/) match the directory itself.
+func ignorePath(relPath string, isDir bool) string {
+	slashed := filepath.ToSlash(relPath)
+	if isDir && !strings.HasSuffix(slashed, "/") {
+		return slashed + "/"
+	}
+
+	return slashed
+}
+
 func (fs *FileSystemScanner) classifyIgnoreReason(reason ignore.IgnoreReason) (bool, bool) {
 	switch reason {
 	case ignore.IgnoreReasonGitignore:
 		return true, false
-	case ignore.IgnoreReasonBuiltIn, ignore.IgnoreReasonCustom, ignore.IgnoreReasonExplicit:
+	case ignore.IgnoreReasonBuiltIn, ignore.IgnoreReasonCustom, ignore.IgnoreReasonExplicit,
+		ignore.IgnoreReasonNotKept, ignore.IgnoreReasonGitattributes:
 		return false, true
 	}
 
 	return false, false
 }
 
-func (fs *FileSystemScanner) isHiddenFile(relPath string, config *ScanConfig) bool {
-	if config.IncludeHidden {
+// isHiddenFile reports whether relPath is a hidden entry that the config excludes.
+func (fs *FileSystemScanner) isHiddenFile(relPath string, isDir bool, config *ScanConfig) bool {
+	if config.IncludesHidden(isDir) {
 		return false
 	}
 
@@ -1,5 +1,12 @@
 package scanner
 
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
 // CollectSelections recursively collects all non-ignored file paths into a selection map.
 func CollectSelections(node *FileNode, selections map[string]bool) map[string]bool {
 	if node == nil {
@@ -26,3 +33,63 @@ func CollectSelections(node *FileNode, selections map[string]bool) map[string]bo
 func NewSelectAll(root *FileNode) map[string]bool {
 	return CollectSelections(root, make(map[string]bool))
 }
+
+// CollectAllSelections recursively collects every file path into a selection map,
+// including ignored ones. Use it when ignored files were deliberately scanned.
+func CollectAllSelections(node *FileNode, selections map[string]bool) map[string]bool {
+	if node == nil {
+		return selections
+	}
+	if selections == nil {
+		selections = make(map[string]bool)
+	}
+
+	selections[node.Path] = true
+
+	if node.IsDir {
+		for _, child := range node.Children {
+			CollectAllSelections(child, selections)
+		}
+	}
+
+	return selections
+}
+
+// SelectedFiles returns the files of tree whose path is selected, sorted by relative path.
+func SelectedFiles(tree *FileNode, selections map[string]bool) []*FileNode {
+	var files []*FileNode
+
+	var walk func(node *FileNode)
+	walk = func(node *FileNode) {
+		if node == nil {
+			return
+		}
+		if !node.IsDir && selections[node.Path] {
+			files = append(files, node)
+		}
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(tree)
+
+	sort.Slice(files, func(i, j int) bool { return files[i].RelPath < files[j].RelPath })
+
+	return files
+}
+
+// MatchesPattern reports whether the file at relPath matches a glob pattern, tried
+// against both the relative path and the file name. A pattern ending in '/' matches
+// every file below a directory of that path, at any depth, e.g. "tests/" or "api/gen/".
+func MatchesPattern(pattern, relPath string) bool {
+	slashPath := filepath.ToSlash(relPath)
+	if dir, ok := strings.CutSuffix(pattern, "/"); ok {
+		return strings.Contains("/"+path.Dir(slashPath)+"/", "/"+dir+"/")
+	}
+
+	if matched, _ := path.Match(pattern, slashPath); matched {
+		return true
+	}
+	matched, _ := path.Match(pattern, path.Base(slashPath))
+	return matched
+}
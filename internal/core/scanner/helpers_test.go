@@ -281,3 +281,45 @@ func TestNewSelectAll_EmptyDirectory(t *testing.T) {
 	assert.True(t, selections["/empty"])
 	assert.Len(t, selections, 1)
 }
+
+func TestCollectAllSelections_IncludesIgnored(t *testing.T) {
+	root := &FileNode{
+		Name:  "root",
+		Path:  "/root",
+		IsDir: true,
+		Children: []*FileNode{
+			{Name: "kept.go", Path: "/root/kept.go"},
+			{Name: "ignored.js", Path: "/root/ignored.js", IsGitignored: true},
+			{Name: "custom.log", Path: "/root/custom.log", IsCustomIgnored: true},
+		},
+	}
+
+	selections := CollectAllSelections(root, nil)
+
+	assert.True(t, selections["/root/kept.go"])
+	assert.True(t, selections["/root/ignored.js"])
+	assert.True(t, selections["/root/custom.log"])
+	assert.Nil(t, CollectAllSelections(nil, nil))
+}
+
+func TestMatchesPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		relPath string
+		want    bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "pkg/util.go", true},
+		{"pkg/*.go", "pkg/util.go", true},
+		{"pkg/*.go", "other/pkg/util.go", false},
+		{"tests/", "crate/tests/it.rs", true},
+		{"tests/", "tests/deep/it.rs", true},
+		{"tests/", "tests.rs", false},
+		{"api/gen/", "api/gen/v1/user.pb.go", true},
+		{"api/gen/", "api/user.go", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, MatchesPattern(tt.pattern, tt.relPath), "%s vs %s", tt.pattern, tt.relPath)
+	}
+}
@@ -0,0 +1,29 @@
+package scanner
+
+// PathFilter answers, for single paths, whether a scan with the same configuration
+// would leave them out. It lets callers such as file watchers skip changes that
+// cannot affect the scan result.
+type PathFilter struct {
+	fs     *FileSystemScanner
+	config *ScanConfig
+}
+
+// NewPathFilter loads the ignore rules that a scan of rootPath with config would apply.
+func NewPathFilter(rootPath string, config *ScanConfig) (*PathFilter, error) {
+	fs := NewFileSystemScanner()
+	if err := fs.loadIgnoreRules(rootPath, config); err != nil {
+		return nil, err
+	}
+
+	return &PathFilter{fs: fs, config: config}, nil
+}
+
+// Excluded reports whether relPath (relative to the root) is ignored, hidden,
+// outside the include patterns or below the depth limit.
+func (f *PathFilter) Excluded(relPath string, isDir bool) bool {
+	if f.config.MaxDepth > 0 && pathDepth(relPath) > f.config.MaxDepth {
+		return true
+	}
+
+	return f.fs.shouldIgnore(relPath, isDir, f.config)
+}
@@ -1,6 +1,7 @@
 package scanner
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -37,12 +38,28 @@ type FileNode struct {
 	// IsCustomIgnored indicates if this file is ignored by custom rules
 	IsCustomIgnored bool `json:"is_custom_ignored"`
 
+	// IsGenerated indicates a generated or lock file (go.sum, *.pb.go) that stays in the
+	// tree but whose content is left out, unless ScanConfig.IncludeGenerated is set
+	IsGenerated bool `json:"is_generated"`
+
 	// Size is the file size in bytes (0 for directories)
 	Size int64 `json:"size"`
 
 	// Expanded indicates if this directory node is expanded in the TUI
 	Expanded bool `json:"expanded"`
 
+	// IsSymlink indicates a symbolic link that was not followed; it is a leaf node
+	IsSymlink bool `json:"is_symlink"`
+
+	// DepthLimited indicates a directory at ScanConfig.MaxDepth whose contents were not scanned
+	DepthLimited bool `json:"depth_limited"`
+
+	// AccessError indicates a directory whose contents could not be read, e.g. permission denied
+	AccessError bool `json:"access_error"`
+
+	// Hash is the hex-encoded SHA-256 of the file content, set only with ScanConfig.ComputeHashes
+	Hash string `json:"hash,omitempty"`
+
 	// Parent reference for tree navigation (not serialized)
 	Parent *FileNode `json:"-"`
 }
@@ -66,23 +83,46 @@ type Progress struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// MaxFilesError reports a scan stopped because it found more than ScanConfig.MaxFiles files.
+type MaxFilesError struct {
+	Limit int64
+}
+
+func (e *MaxFilesError) Error() string {
+	return fmt.Sprintf(
+		"scan hit max-files limit of %d; narrow with --include/--exclude or raise scanner.max-files", e.Limit)
+}
+
 // ScanConfig contains configuration options for file scanning
 type ScanConfig struct {
 	// MaxFileSize limits the size of files to include (in bytes, 0 = no limit)
 	MaxFileSize int64 `json:"max_file_size"`
 
-	// MaxFiles limits the total number of files to scan (0 = no limit)
+	// MaxFiles limits the total number of files to scan (0 = no limit). Only files that
+	// are not ignored count toward it, and a scan that would exceed it fails with a
+	// *MaxFilesError rather than returning a partial tree.
 	MaxFiles int64 `json:"max_files"`
 
+	// MaxDepth limits how many directory levels below the root are scanned (0 = no limit).
+	// Directories at the limit are kept as empty nodes marked DepthLimited.
+	MaxDepth int `json:"max_depth"`
+
 	// MaxMemory limits memory usage during scanning (in bytes, 0 = no limit)
 	MaxMemory int64 `json:"max_memory"`
 
 	// SkipBinary indicates whether to skip binary files
 	SkipBinary bool `json:"skip_binary"`
 
-	// IncludeHidden indicates whether to include hidden files and directories
+	// IncludeHidden indicates whether to include hidden files and directories.
+	// It is a convenience that implies both IncludeHiddenDirs and IncludeHiddenFiles.
 	IncludeHidden bool `json:"include_hidden"`
 
+	// IncludeHiddenDirs indicates whether to traverse hidden directories (e.g. .github/)
+	IncludeHiddenDirs bool `json:"include_hidden_dirs"`
+
+	// IncludeHiddenFiles indicates whether to include hidden files (e.g. .env, .editorconfig)
+	IncludeHiddenFiles bool `json:"include_hidden_files"`
+
 	// Workers specifies the number of concurrent workers for scanning
 	Workers int `json:"workers"`
 
@@ -97,15 +137,88 @@ type ScanConfig struct {
 	// Uses glob-style patterns like "*.go", "*.js", etc.
 	IncludePatterns []string `json:"include_patterns,omitempty"`
 
+	// IncludeExtensions restricts files to these extensions (like ".go"), compared
+	// case-insensitively. It applies in addition to IncludePatterns.
+	IncludeExtensions []string `json:"include_extensions,omitempty"`
+
+	// RequirePatterns restricts files to those matching one of these glob patterns,
+	// in addition to IncludePatterns and IncludeExtensions. Patterns are matched
+	// with MatchesPattern, so one ending in '/' matches the files below a directory.
+	RequirePatterns []string `json:"require_patterns,omitempty"`
+
 	// IncludeIgnored indicates whether to include ignored files in the tree
 	// When true, ignored files are included but marked with IsGitignored/IsCustomIgnored flags
 	IncludeIgnored bool `json:"include_ignored"`
 
+	// IncludeGenerated embeds generated and lock files like any other file instead of
+	// marking them IsGenerated
+	IncludeGenerated bool `json:"include_generated"`
+
 	// RespectGitignore indicates whether to load and respect .gitignore rules
 	RespectGitignore bool `json:"respect_gitignore"`
 
 	// RespectShotgunignore indicates whether to load and respect .shotgunignore rules
 	RespectShotgunignore bool `json:"respect_shotgunignore"`
+
+	// RespectGitattributes ignores the paths .gitattributes marks export-ignore or
+	// linguist-generated
+	RespectGitattributes bool `json:"respect_gitattributes"`
+
+	// AllowlistMode loads .shotgunkeep rules; when any are found, only files they
+	// match are candidates for inclusion and directories left empty are dropped.
+	AllowlistMode bool `json:"allowlist_mode"`
+
+	// FollowSymlinks resolves symbolic links instead of recording them as leaf nodes.
+	// Links to directories are traversed, with cycles broken by tracking real paths.
+	FollowSymlinks bool `json:"follow_symlinks"`
+
+	// AllowExternalSymlinks lets followed links point outside the root.
+	// Without it, such links are skipped.
+	AllowExternalSymlinks bool `json:"allow_external_symlinks"`
+
+	// ComputeHashes fills FileNode.Hash for every file, reading each file in full.
+	ComputeHashes bool `json:"compute_hashes"`
+
+	// ModifiedSince excludes files last modified before this time (zero = no filter).
+	// Directories are kept only if they contain at least one qualifying file.
+	ModifiedSince time.Time `json:"modified_since,omitempty"`
+}
+
+// ScanStats holds counters collected during the most recent scan.
+type ScanStats struct {
+	// FilteredByTime is the number of files excluded by ScanConfig.ModifiedSince
+	FilteredByTime int64 `json:"filtered_by_time"`
+
+	// IgnoredEntries is the number of files and directories skipped by ignore rules.
+	// An ignored directory counts once; its contents are not traversed.
+	IgnoredEntries int64 `json:"ignored_entries"`
+
+	// IgnoredByReason splits IgnoredEntries by the ignore.IgnoreReason that matched,
+	// keyed by its String form (e.g. "gitignore", "built-in").
+	IgnoredByReason map[string]int64 `json:"ignored_by_reason,omitempty"`
+
+	// Inaccessible lists the relative paths of directories whose contents could not be
+	// read. The scan skips them and keeps them in the tree as empty nodes marked AccessError.
+	Inaccessible []string `json:"inaccessible,omitempty"`
+}
+
+// CancelableScanner is implemented by scanners whose scan can be stopped through a
+// context, such as FileSystemScanner.
+type CancelableScanner interface {
+	ScanWithContext(
+		ctx context.Context, rootPath string, config *ScanConfig, progress chan<- Progress,
+	) (*FileNode, error)
+}
+
+// StreamingScanner is implemented by scanners that can emit files as they find them
+// instead of building the whole tree, such as FileSystemScanner.
+type StreamingScanner interface {
+	ScanStream(ctx context.Context, rootPath string, config *ScanConfig) (<-chan *FileNode, <-chan error)
+}
+
+// StatsReporter is implemented by scanners that expose statistics about their last scan.
+type StatsReporter interface {
+	LastScanStats() ScanStats
 }
 
 // DefaultScanConfig returns a default scanning configuration
@@ -122,6 +235,18 @@ func DefaultScanConfig() *ScanConfig {
 	}
 }
 
+// IncludesHidden reports whether hidden entries of the given kind should be included.
+func (c *ScanConfig) IncludesHidden(isDir bool) bool {
+	if c.IncludeHidden {
+		return true
+	}
+	if isDir {
+		return c.IncludeHiddenDirs
+	}
+
+	return c.IncludeHiddenFiles
+}
+
 // IsIgnored returns true if the file node is ignored by any rule
 func (f *FileNode) IsIgnored() bool {
 	return f.IsGitignored || f.IsCustomIgnored
@@ -1,13 +1,21 @@
 package scanner
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/quantmind-br/shotgun-cli/internal/core/ignore"
 )
 
@@ -624,7 +632,7 @@ func TestHiddenFileConsistencyWithIgnoreEngine(t *testing.T) {
 		}
 
 		// Build tree
-		root, buildCount, err := scanner.walkAndBuild(tempDir, config, nil, count)
+		root, buildCount, err := scanner.walkAndBuild(context.Background(), tempDir, config, nil, count)
 		if err != nil {
 			t.Fatalf("walkAndBuild failed: %v", err)
 		}
@@ -670,7 +678,7 @@ func TestHiddenFileConsistencyWithIgnoreEngine(t *testing.T) {
 		}
 
 		// Build tree
-		root, buildCount, err := scanner.walkAndBuild(tempDir, config, nil, count)
+		root, buildCount, err := scanner.walkAndBuild(context.Background(), tempDir, config, nil, count)
 		if err != nil {
 			t.Fatalf("walkAndBuild failed: %v", err)
 		}
@@ -733,7 +741,7 @@ func TestHiddenFileConsistencyWithIgnoreEngine(t *testing.T) {
 		}
 
 		// Build tree
-		root, buildCount, err := scanner.walkAndBuild(tempDir, config, nil, count)
+		root, buildCount, err := scanner.walkAndBuild(context.Background(), tempDir, config, nil, count)
 		if err != nil {
 			t.Fatalf("walkAndBuild failed: %v", err)
 		}
@@ -764,9 +772,395 @@ func TestHiddenFileConsistencyWithIgnoreEngine(t *testing.T) {
 	})
 }
 
+func TestHiddenDirsAndFilesCombinations(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, file := range []string{
+		"normal.txt",
+		".hidden.txt",
+		".hiddendir/file.txt",
+		".hiddendir/.nested.txt",
+		"subdir/.hidden_in_subdir.txt",
+	} {
+		fullPath := filepath.Join(tempDir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o750))
+		require.NoError(t, os.WriteFile(fullPath, []byte("content"), 0o600))
+	}
+
+	all := []string{
+		".hidden.txt",
+		".hiddendir/.nested.txt",
+		".hiddendir/file.txt",
+		"normal.txt",
+		"subdir/.hidden_in_subdir.txt",
+	}
+
+	tests := []struct {
+		name     string
+		dirs     bool
+		files    bool
+		hidden   bool
+		expected []string
+	}{
+		{"neither", false, false, false, []string{"normal.txt"}},
+		{"dirs only", true, false, false, []string{".hiddendir/file.txt", "normal.txt"}},
+		{"files only", false, true, false, []string{".hidden.txt", "normal.txt", "subdir/.hidden_in_subdir.txt"}},
+		{"dirs and files", true, true, false, all},
+		{"include hidden implies both", false, false, true, all},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := NewFileSystemScanner()
+			config := DefaultScanConfig()
+			config.IncludeHiddenDirs = tt.dirs
+			config.IncludeHiddenFiles = tt.files
+			config.IncludeHidden = tt.hidden
+
+			count, err := fs.countItems(tempDir, config)
+			require.NoError(t, err)
+
+			root, buildCount, err := fs.walkAndBuild(context.Background(), tempDir, config, nil, count)
+			require.NoError(t, err)
+			assert.Equal(t, count, buildCount, "count and build passes should match")
+
+			var files []string
+			var collect func(*FileNode)
+			collect = func(node *FileNode) {
+				for _, child := range node.Children {
+					if child.IsDir {
+						collect(child)
+					} else {
+						files = append(files, filepath.ToSlash(child.RelPath))
+					}
+				}
+			}
+			collect(root)
+			sort.Strings(files)
+
+			assert.Equal(t, tt.expected, files)
+		})
+	}
+}
+
+func TestModifiedSinceFilter(t *testing.T) {
+	tempDir := t.TempDir()
+	old := time.Now().Add(-30 * 24 * time.Hour)
+	for file, mtime := range map[string]time.Time{
+		"recent.go":        time.Now(),
+		"old.go":           old,
+		"stale/legacy.go":  old,
+		"active/new.go":    time.Now(),
+		"active/old.txt":   old,
+		"active/deep/x.go": old,
+	} {
+		fullPath := filepath.Join(tempDir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o750))
+		require.NoError(t, os.WriteFile(fullPath, []byte("content"), 0o600))
+		require.NoError(t, os.Chtimes(fullPath, mtime, mtime))
+	}
+
+	t.Run("excludes old files and empty directories", func(t *testing.T) {
+		fs := NewFileSystemScanner()
+		config := DefaultScanConfig()
+		config.ModifiedSince = time.Now().Add(-7 * 24 * time.Hour)
+
+		root, err := fs.Scan(tempDir, config)
+		require.NoError(t, err)
+
+		var paths []string
+		var collect func(*FileNode)
+		collect = func(node *FileNode) {
+			for _, child := range node.Children {
+				paths = append(paths, filepath.ToSlash(child.RelPath))
+				collect(child)
+			}
+		}
+		collect(root)
+
+		assert.ElementsMatch(t, []string{"active", "active/new.go", "recent.go"}, paths)
+		assert.Equal(t, int64(4), fs.LastScanStats().FilteredByTime)
+	})
+
+	t.Run("combines with include patterns", func(t *testing.T) {
+		fs := NewFileSystemScanner()
+		config := DefaultScanConfig()
+		config.ModifiedSince = time.Now().Add(-7 * 24 * time.Hour)
+		config.IncludePatterns = []string{"*.txt"}
+
+		root, err := fs.Scan(tempDir, config)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, root.CountFiles())
+		assert.Empty(t, root.Children)
+		assert.Equal(t, int64(1), fs.LastScanStats().FilteredByTime)
+	})
+
+	t.Run("zero value disables filter", func(t *testing.T) {
+		fs := NewFileSystemScanner()
+
+		root, err := fs.Scan(tempDir, DefaultScanConfig())
+		require.NoError(t, err)
+
+		assert.Equal(t, 6, root.CountFiles())
+		assert.Zero(t, fs.LastScanStats().FilteredByTime)
+	})
+}
+
+func TestIncludeExtensions(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, file := range []string{"main.go", "web/app.ts", "web/App.TSX", "web/style.css", "docs/guide.md"} {
+		fullPath := filepath.Join(tempDir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o750))
+		require.NoError(t, os.WriteFile(fullPath, []byte("x"), 0o600))
+	}
+
+	scanFiles := func(config *ScanConfig) []string {
+		root, err := NewFileSystemScanner().Scan(tempDir, config)
+		require.NoError(t, err)
+
+		var files []string
+		var walk func(*FileNode)
+		walk = func(node *FileNode) {
+			for _, child := range node.Children {
+				if !child.IsDir {
+					files = append(files, filepath.ToSlash(child.RelPath))
+				}
+				walk(child)
+			}
+		}
+		walk(root)
+		return files
+	}
+
+	t.Run("keeps only matching extensions", func(t *testing.T) {
+		config := DefaultScanConfig()
+		config.IncludeExtensions = []string{".go", ".ts", ".tsx"}
+
+		assert.ElementsMatch(t, []string{"main.go", "web/app.ts", "web/App.TSX"}, scanFiles(config))
+	})
+
+	t.Run("combines with include patterns", func(t *testing.T) {
+		config := DefaultScanConfig()
+		config.IncludeExtensions = []string{".go", ".ts", ".tsx"}
+		config.IncludePatterns = []string{"web/*"}
+
+		assert.ElementsMatch(t, []string{"web/app.ts", "web/App.TSX"}, scanFiles(config))
+	})
+}
+
+func TestRequirePatterns(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, file := range []string{"main.go", "main_test.go", "web/app.spec.ts", "web/app.ts", "crate/tests/it.rs"} {
+		fullPath := filepath.Join(tempDir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o750))
+		require.NoError(t, os.WriteFile(fullPath, []byte("x"), 0o600))
+	}
+
+	config := DefaultScanConfig()
+	config.RequirePatterns = []string{"*_test.go", "*.spec.ts", "tests/"}
+	root, err := NewFileSystemScanner().Scan(tempDir, config)
+	require.NoError(t, err)
+
+	var files []string
+	var walk func(*FileNode)
+	walk = func(node *FileNode) {
+		for _, child := range node.Children {
+			if !child.IsDir {
+				files = append(files, filepath.ToSlash(child.RelPath))
+			}
+			walk(child)
+		}
+	}
+	walk(root)
+
+	assert.ElementsMatch(t, []string{"main_test.go", "web/app.spec.ts", "crate/tests/it.rs"}, files)
+}
+
+func TestMaxDepth(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, file := range []string{"main.go", "pkg/util.go", "pkg/internal/deep.go", "pkg/internal/x/deeper.go"} {
+		fullPath := filepath.Join(tempDir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o750))
+		require.NoError(t, os.WriteFile(fullPath, []byte("package x"), 0o600))
+	}
+
+	collect := func(root *FileNode) (paths, limited []string) {
+		var walk func(*FileNode)
+		walk = func(node *FileNode) {
+			for _, child := range node.Children {
+				paths = append(paths, filepath.ToSlash(child.RelPath))
+				if child.DepthLimited {
+					limited = append(limited, filepath.ToSlash(child.RelPath))
+				}
+				walk(child)
+			}
+		}
+		walk(root)
+		return paths, limited
+	}
+
+	t.Run("stops at the limit and marks pruned directories", func(t *testing.T) {
+		fs := NewFileSystemScanner()
+		config := DefaultScanConfig()
+		config.MaxDepth = 2
+
+		root, err := fs.Scan(tempDir, config)
+		require.NoError(t, err)
+
+		paths, limited := collect(root)
+		assert.ElementsMatch(t, []string{"main.go", "pkg", "pkg/util.go", "pkg/internal"}, paths)
+		assert.Equal(t, []string{"pkg/internal"}, limited)
+
+		count, err := fs.countItems(tempDir, config)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(paths)), count)
+	})
+
+	t.Run("zero value disables the limit", func(t *testing.T) {
+		root, err := NewFileSystemScanner().Scan(tempDir, DefaultScanConfig())
+		require.NoError(t, err)
+
+		_, limited := collect(root)
+		assert.Empty(t, limited)
+		assert.Equal(t, 4, root.CountFiles())
+	})
+
+	t.Run("path filter excludes paths below the limit", func(t *testing.T) {
+		config := DefaultScanConfig()
+		config.MaxDepth = 2
+
+		filter, err := NewPathFilter(tempDir, config)
+		require.NoError(t, err)
+
+		assert.False(t, filter.Excluded("pkg/internal", true))
+		assert.True(t, filter.Excluded("pkg/internal/deep.go", false))
+	})
+}
+
+func TestSymlinks(t *testing.T) {
+	outside := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "secret.go"), []byte("package x"), 0o600))
+
+	tempDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "src", "pkg"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "src", "pkg", "util.go"), []byte("package pkg"), 0o600))
+	for link, target := range map[string]string{
+		"linked":       filepath.Join(tempDir, "src"), // directory inside the root
+		"src/pkg/loop": filepath.Join(tempDir, "src"), // cycle back to an ancestor
+		"main.go":      filepath.Join(tempDir, "src", "pkg", "util.go"),
+		"external":     outside,
+	} {
+		if err := os.Symlink(target, filepath.Join(tempDir, link)); err != nil {
+			t.Skipf("symlinks not supported: %v", err)
+		}
+	}
+
+	scan := func(t *testing.T, config *ScanConfig) map[string]*FileNode {
+		t.Helper()
+		fs := NewFileSystemScanner()
+		root, err := fs.Scan(tempDir, config)
+		require.NoError(t, err)
+
+		nodes := make(map[string]*FileNode)
+		var walk func(*FileNode)
+		walk = func(node *FileNode) {
+			for _, child := range node.Children {
+				nodes[filepath.ToSlash(child.RelPath)] = child
+				walk(child)
+			}
+		}
+		walk(root)
+
+		count, err := fs.countItems(tempDir, config)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(nodes)), count, "countItems should agree with the built tree")
+		return nodes
+	}
+
+	t.Run("not followed by default", func(t *testing.T) {
+		nodes := scan(t, DefaultScanConfig())
+
+		for _, link := range []string{"linked", "src/pkg/loop", "main.go", "external"} {
+			require.Contains(t, nodes, link)
+			assert.True(t, nodes[link].IsSymlink, link)
+			assert.False(t, nodes[link].IsDir, link)
+			assert.Empty(t, nodes[link].Children, link)
+		}
+		assert.NotContains(t, nodes, "linked/pkg")
+	})
+
+	t.Run("followed without cycles or external targets", func(t *testing.T) {
+		config := DefaultScanConfig()
+		config.FollowSymlinks = true
+		nodes := scan(t, config)
+
+		require.Contains(t, nodes, "main.go")
+		assert.False(t, nodes["main.go"].IsSymlink)
+		assert.Equal(t, int64(len("package pkg")), nodes["main.go"].Size)
+
+		require.Contains(t, nodes, "linked/pkg/util.go")
+		require.Contains(t, nodes, "src/pkg/util.go")
+		assert.NotContains(t, nodes, "external")
+
+		// The links back to src are reached after src was entered, so they stay leaves
+		for _, loop := range []string{"linked/pkg/loop", "src/pkg/loop"} {
+			require.Contains(t, nodes, loop)
+			assert.True(t, nodes[loop].IsSymlink, loop)
+		}
+		for path := range nodes {
+			assert.NotContains(t, path, "loop/", "cycle should not be traversed")
+		}
+	})
+
+	t.Run("external targets when allowed", func(t *testing.T) {
+		config := DefaultScanConfig()
+		config.FollowSymlinks = true
+		config.AllowExternalSymlinks = true
+		nodes := scan(t, config)
+
+		require.Contains(t, nodes, "external/secret.go")
+		assert.True(t, nodes["external"].IsDir)
+	})
+}
+
+func TestScanStatsIgnoredEntries(t *testing.T) {
+	tempDir := t.TempDir()
+	for file, content := range map[string]string{
+		".gitignore":    "*.tmp\n",
+		"sub/cache.tmp": "cache",
+		"debug.log":     "log",
+		"main.go":       "package main",
+	} {
+		fullPath := filepath.Join(tempDir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o750))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0o600))
+	}
+
+	fs := NewFileSystemScanner()
+	root, err := fs.Scan(tempDir, DefaultScanConfig())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, root.CountFiles())
+	assert.Equal(t, int64(2), fs.LastScanStats().IgnoredEntries, "gitignored cache.tmp and built-in ignored debug.log")
+	assert.Equal(t, map[string]int64{"gitignore": 1, "built-in": 1}, fs.LastScanStats().IgnoredByReason)
+}
+
+func TestScanWithContext_Cancelled(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "main.go"), []byte("package main"), 0o600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	root, err := NewFileSystemScanner().ScanWithContext(ctx, tempDir, DefaultScanConfig(), nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, root)
+}
+
 func TestScannerInterface(t *testing.T) {
 	// Verify that FileSystemScanner implements the Scanner interface
 	var _ Scanner = (*FileSystemScanner)(nil)
+	var _ CancelableScanner = (*FileSystemScanner)(nil)
 }
 
 //nolint:gocyclo // comprehensive sorting test with detailed verification
@@ -850,6 +1244,214 @@ func TestTreeSorting(t *testing.T) {
 	}
 }
 
+func TestComputeHashes(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "main.go"), []byte("package main\n"), 0o600))
+
+	findFile := func(root *FileNode) *FileNode {
+		for _, child := range root.Children {
+			if child.Name == "main.go" {
+				return child
+			}
+		}
+		t.Fatal("main.go not found in scan")
+		return nil
+	}
+
+	root, err := NewFileSystemScanner().Scan(tempDir, DefaultScanConfig())
+	require.NoError(t, err)
+	assert.Empty(t, findFile(root).Hash)
+	assert.Empty(t, root.Hash)
+
+	config := DefaultScanConfig()
+	config.ComputeHashes = true
+	root, err = NewFileSystemScanner().Scan(tempDir, config)
+	require.NoError(t, err)
+
+	sum := sha256.Sum256([]byte("package main\n"))
+	assert.Equal(t, hex.EncodeToString(sum[:]), findFile(root).Hash)
+}
+
+func TestShotgunkeepAllowlistMode(t *testing.T) {
+	tempDir := t.TempDir()
+	for path, content := range map[string]string{
+		"main.go":          "package main",
+		"README.md":        "# Readme",
+		"lib/utils.go":     "package lib",
+		"assets/style.css": "body {}",
+		".shotgunkeep":     "*.go\n",
+	} {
+		fullPath := filepath.Join(tempDir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	collectPaths := func(root *FileNode) []string {
+		var paths []string
+		var walk func(*FileNode)
+		walk = func(node *FileNode) {
+			if node != root {
+				paths = append(paths, node.RelPath)
+			}
+			for _, child := range node.Children {
+				walk(child)
+			}
+		}
+		walk(root)
+		sort.Strings(paths)
+
+		return paths
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		root, err := NewFileSystemScanner().Scan(tempDir, DefaultScanConfig())
+		if err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+
+		assert.Equal(t, []string{"README.md", "assets", "assets/style.css", "lib", "lib/utils.go", "main.go"},
+			collectPaths(root))
+	})
+
+	t.Run("excludes files not kept and empty directories", func(t *testing.T) {
+		config := DefaultScanConfig()
+		config.AllowlistMode = true
+
+		root, err := NewFileSystemScanner().Scan(tempDir, config)
+		if err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+
+		// README.md is matched by no ignore rule, but .shotgunkeep does not keep it
+		assert.Equal(t, []string{"lib", "lib/utils.go", "main.go"}, collectPaths(root))
+	})
+}
+
+func TestGeneratedFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	for path, content := range map[string]string{
+		"main.go":           "package main",
+		"go.sum":            "example.com/mod v1.0.0 h1:abc=",
+		"package-lock.json": "{}",
+		"api/user.pb.go":    "package api",
+		".shotgunignore":    "!package-lock.json\n",
+	} {
+		fullPath := filepath.Join(tempDir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	generatedPaths := func(t *testing.T, config *ScanConfig) []string {
+		t.Helper()
+		root, err := NewFileSystemScanner().Scan(tempDir, config)
+		if err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+
+		var paths []string
+		var walk func(*FileNode)
+		walk = func(node *FileNode) {
+			if node.IsGenerated {
+				paths = append(paths, filepath.ToSlash(node.RelPath))
+			}
+			for _, child := range node.Children {
+				walk(child)
+			}
+		}
+		walk(root)
+		sort.Strings(paths)
+
+		// Generated files stay selectable in the tree
+		assert.Len(t, SelectedFiles(root, NewSelectAll(root)), 4)
+		return paths
+	}
+
+	t.Run("marked by default", func(t *testing.T) {
+		// package-lock.json is re-included by the .shotgunignore negation
+		assert.Equal(t, []string{"api/user.pb.go", "go.sum"}, generatedPaths(t, DefaultScanConfig()))
+	})
+
+	t.Run("include pattern forces them in", func(t *testing.T) {
+		config := DefaultScanConfig()
+		config.IncludePatterns = []string{"*", "*.pb.go"}
+		assert.Equal(t, []string{"go.sum"}, generatedPaths(t, config))
+	})
+
+	t.Run("include generated", func(t *testing.T) {
+		config := DefaultScanConfig()
+		config.IncludeGenerated = true
+		assert.Empty(t, generatedPaths(t, config))
+	})
+}
+
+func TestGitattributes(t *testing.T) {
+	tempDir := t.TempDir()
+	for path, content := range map[string]string{
+		"main.go":              "package main",
+		"models.gen.go":        "package main",
+		"release/notes.txt":    "v1.0.0",
+		"testdata/input.json":  "{}",
+		"testdata/golden.json": "{}",
+		".gitattributes": "/release/** export-ignore\n*.gen.go linguist-generated=true\n" +
+			"testdata/*.json linguist-generated\ntestdata/golden.json -linguist-generated\n*.go text eol=lf\n",
+	} {
+		fullPath := filepath.Join(tempDir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scanFiles := func(t *testing.T, config *ScanConfig) ([]string, ScanStats) {
+		t.Helper()
+		fs := NewFileSystemScanner()
+		root, err := fs.Scan(tempDir, config)
+		if err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+
+		var paths []string
+		var walk func(*FileNode)
+		walk = func(node *FileNode) {
+			if !node.IsDir {
+				paths = append(paths, filepath.ToSlash(node.RelPath))
+			}
+			for _, child := range node.Children {
+				walk(child)
+			}
+		}
+		walk(root)
+		sort.Strings(paths)
+
+		return paths, fs.LastScanStats()
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		paths, _ := scanFiles(t, DefaultScanConfig())
+		assert.Equal(t, []string{"main.go", "models.gen.go", "release/notes.txt", "testdata/golden.json",
+			"testdata/input.json"}, paths)
+	})
+
+	t.Run("excludes export-ignore and linguist-generated files", func(t *testing.T) {
+		config := DefaultScanConfig()
+		config.RespectGitattributes = true
+
+		paths, stats := scanFiles(t, config)
+		assert.Equal(t, []string{"main.go", "testdata/golden.json"}, paths)
+		assert.Equal(t, int64(3), stats.IgnoredByReason["gitattributes"])
+	})
+}
+
 func TestShotgunignoreIntegration(t *testing.T) {
 	tempDir, err := os.MkdirTemp("", "shotgunignore_integration_test")
 	if err != nil {
@@ -1350,7 +1952,7 @@ func TestHandleWalkError(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := scanner.handleWalkError(tt.entry)
+			result := scanner.handleWalkError("testdir", tt.entry, map[string]*FileNode{})
 			if result != tt.expected {
 				t.Errorf("handleWalkError() = %v, want %v", result, tt.expected)
 			}
@@ -1358,6 +1960,46 @@ func TestHandleWalkError(t *testing.T) {
 	}
 }
 
+func TestHandleWalkErrorMarksDirectory(t *testing.T) {
+	t.Parallel()
+
+	scanner := NewFileSystemScanner()
+	secret := &FileNode{Name: "secret", RelPath: "secret", IsDir: true}
+	dirNodes := map[string]*FileNode{normRel("secret"): secret}
+
+	err := scanner.handleWalkError("secret", &mockDirEntry{isDir: true, name: "secret"}, dirNodes)
+
+	assert.Equal(t, filepath.SkipDir, err)
+	assert.True(t, secret.AccessError)
+	assert.Equal(t, []string{"secret"}, scanner.LastScanStats().Inaccessible)
+}
+
+func TestScanSkipsUnreadableDirectory(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("directory permissions are not enforced for this user")
+	}
+
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n"), 0o600))
+	secret := filepath.Join(root, "secret")
+	require.NoError(t, os.Mkdir(secret, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(secret, "key.txt"), []byte("key\n"), 0o600))
+	require.NoError(t, os.Chmod(secret, 0))
+	t.Cleanup(func() { _ = os.Chmod(secret, 0o750) })
+
+	scanner := NewFileSystemScanner()
+	tree, err := scanner.Scan(root, DefaultScanConfig())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, tree.CountFiles())
+	assert.Equal(t, []string{"secret"}, scanner.LastScanStats().Inaccessible)
+	for _, child := range tree.Children {
+		if child.Name == "secret" {
+			assert.True(t, child.AccessError)
+		}
+	}
+}
+
 func TestShouldSkipLargeFile(t *testing.T) {
 	t.Parallel()
 
@@ -1498,3 +2140,106 @@ func (m *mockFileInfo) Mode() os.FileMode  { return 0 }
 func (m *mockFileInfo) ModTime() time.Time { return time.Time{} }
 func (m *mockFileInfo) IsDir() bool        { return m.isDir }
 func (m *mockFileInfo) Sys() interface{}   { return nil }
+
+func TestShotgunignoreAnchoredPatternsInWalk(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"gen/root.go",
+		"src/gen/nested.go",
+		"src/cache/data.txt",
+		"cache/data.txt",
+		"pkg/stage/a.go",
+		"pkg/sub/stage/b.go",
+		"stage/c.go",
+		"main.go",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte("x"), 0o600))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".shotgunignore"), []byte("/gen\ncache/\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pkg", ".shotgunignore"), []byte("/stage\n"), 0o600))
+
+	config := DefaultScanConfig()
+	config.IncludeIgnored = false
+
+	root, err := NewFileSystemScanner().Scan(dir, config)
+	require.NoError(t, err)
+
+	var files, dirs []string
+	var walk func(*FileNode)
+	walk = func(node *FileNode) {
+		if node.IsDir && node.RelPath != "" && node.RelPath != "." {
+			dirs = append(dirs, filepath.ToSlash(node.RelPath))
+		} else if !node.IsDir {
+			files = append(files, filepath.ToSlash(node.RelPath))
+		}
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(root)
+	sort.Strings(files)
+
+	assert.Equal(t, []string{"main.go", "pkg/sub/stage/b.go", "src/gen/nested.go", "stage/c.go"}, files)
+	// Directory-only patterns prune the directory itself, not just its contents
+	assert.NotContains(t, dirs, "cache")
+	assert.NotContains(t, dirs, "src/cache")
+}
+
+func TestPathFilterMatchesScanRules(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*.log\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".shotgunignore"), []byte("cache/\n"), 0o600))
+
+	config := DefaultScanConfig()
+	config.IgnorePatterns = []string{"*.tmp"}
+
+	filter, err := NewPathFilter(dir, config)
+	require.NoError(t, err)
+
+	tests := []struct {
+		relPath  string
+		isDir    bool
+		excluded bool
+	}{
+		{"main.go", false, false},
+		{"pkg", true, false},
+		{"debug.log", false, true},
+		{"cache", true, true},
+		{"scratch.tmp", false, true},
+		{".env", false, true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.excluded, filter.Excluded(tt.relPath, tt.isDir), tt.relPath)
+	}
+}
+
+func TestScanMaxFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*.log\n"), 0o600))
+	for _, name := range []string{"a.go", "b.go", "c.go", "debug.log", "trace.log"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(name), 0o600))
+	}
+
+	config := DefaultScanConfig()
+	config.MaxFiles = 3
+
+	root, err := NewFileSystemScanner().Scan(dir, config)
+	require.NoError(t, err, "ignored files must not count toward the limit")
+	assert.Equal(t, 3, root.CountFiles())
+
+	config.IncludeIgnored = true
+	root, err = NewFileSystemScanner().Scan(dir, config)
+	require.NoError(t, err, "ignored files kept by IncludeIgnored must not count either")
+	assert.Equal(t, 5, root.CountFiles())
+
+	config.MaxFiles = 2
+	root, err = NewFileSystemScanner().Scan(dir, config)
+	assert.Nil(t, root, "an over-limit scan must not return a partial tree")
+	var maxErr *MaxFilesError
+	require.ErrorAs(t, err, &maxErr)
+	assert.Equal(t, int64(2), maxErr.Limit)
+	assert.EqualError(t, err,
+		"scan hit max-files limit of 2; narrow with --include/--exclude or raise scanner.max-files")
+}
@@ -0,0 +1,71 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+)
+
+// ScanStream scans rootPath like Scan, applying the same ignore rules and filters, but
+// sends each file on the returned node channel as it is found instead of building a
+// tree, so memory stays bounded however large the tree is. Files arrive in walk order,
+// with Parent and Children unset; directories are traversed but not sent.
+//
+// The node channel is closed when the scan ends. The error channel then yields the
+// error that stopped the scan, if any, and is closed. A consumer that stops reading
+// nodes early must cancel ctx so the scan can finish. LastScanStats describes the scan
+// once the error channel is closed. Like Scan, ScanStream must not run concurrently
+// with another scan on the same scanner.
+func (fs *FileSystemScanner) ScanStream(
+	ctx context.Context, rootPath string, config *ScanConfig,
+) (<-chan *FileNode, <-chan error) {
+	nodes := make(chan *FileNode)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		err := fs.stream(ctx, rootPath, config, nodes)
+		close(nodes)
+		if err != nil {
+			errs <- err
+		}
+	}()
+
+	return nodes, errs
+}
+
+// stream walks rootPath, sending the files that pass the scan filters to nodes.
+func (fs *FileSystemScanner) stream(
+	ctx context.Context, rootPath string, config *ScanConfig, nodes chan<- *FileNode,
+) error {
+	config, err := fs.prepareScan(rootPath, config)
+	if err != nil {
+		return err
+	}
+
+	err = fs.walkNodes(ctx, rootPath, config,
+		func(node *FileNode) error {
+			if node.IsDir {
+				return nil
+			}
+			select {
+			case nodes <- node:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+		func(relPath string, d os.DirEntry) error {
+			if d == nil || !d.IsDir() {
+				return nil
+			}
+			fs.stats.Inaccessible = append(fs.stats.Inaccessible, filepath.ToSlash(relPath))
+			return filepath.SkipDir
+		},
+	)
+	if err != nil {
+		return scanError(ctx, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package scanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStreamTestTree(t *testing.T) string {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	for path, content := range map[string]string{
+		"main.go":               "package main",
+		"cache.tmp":             "cache",
+		"notes.txt":             "notes",
+		"node_modules/dep/x.js": "dep",
+		"pkg/util.go":           "package pkg",
+		"pkg/sub/deep.go":       "package sub",
+		".gitignore":            "*.tmp\n",
+	} {
+		fullPath := filepath.Join(tempDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o750))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0o600))
+	}
+
+	return tempDir
+}
+
+// drainStream collects the relative paths a stream sends and the error it ends with.
+func drainStream(nodes <-chan *FileNode, errs <-chan error) ([]string, error) {
+	var paths []string
+	for node := range nodes {
+		paths = append(paths, filepath.ToSlash(node.RelPath))
+	}
+	sort.Strings(paths)
+
+	return paths, <-errs
+}
+
+func TestScanStream(t *testing.T) {
+	tempDir := newStreamTestTree(t)
+
+	fs := NewFileSystemScanner()
+	paths, err := drainStream(fs.ScanStream(context.Background(), tempDir, DefaultScanConfig()))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"main.go", "notes.txt", "pkg/sub/deep.go", "pkg/util.go"}, paths)
+	assert.Equal(t, int64(1), fs.LastScanStats().IgnoredByReason["gitignore"])
+
+	// The stream sends exactly the files a tree scan selects
+	root, err := NewFileSystemScanner().Scan(tempDir, DefaultScanConfig())
+	require.NoError(t, err)
+	var treeFiles []string
+	for _, node := range SelectedFiles(root, NewSelectAll(root)) {
+		treeFiles = append(treeFiles, filepath.ToSlash(node.RelPath))
+	}
+	sort.Strings(treeFiles)
+	assert.Equal(t, treeFiles, paths)
+
+	t.Run("filters", func(t *testing.T) {
+		config := DefaultScanConfig()
+		config.MaxDepth = 1
+		config.IncludeExtensions = []string{".go"}
+
+		paths, err := drainStream(NewFileSystemScanner().ScanStream(context.Background(), tempDir, config))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"main.go"}, paths)
+	})
+
+	t.Run("max files", func(t *testing.T) {
+		config := DefaultScanConfig()
+		config.MaxFiles = 2
+
+		_, err := drainStream(NewFileSystemScanner().ScanStream(context.Background(), tempDir, config))
+		var maxFilesErr *MaxFilesError
+		require.ErrorAs(t, err, &maxFilesErr)
+		assert.Equal(t, int64(2), maxFilesErr.Limit)
+	})
+
+	t.Run("invalid root", func(t *testing.T) {
+		paths, err := drainStream(NewFileSystemScanner().ScanStream(
+			context.Background(), filepath.Join(tempDir, "missing"), nil))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid root path")
+		assert.Empty(t, paths)
+	})
+}
+
+func TestScanStream_Cancel(t *testing.T) {
+	tempDir := newStreamTestTree(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	nodes, errs := NewFileSystemScanner().ScanStream(ctx, tempDir, DefaultScanConfig())
+
+	// Stop after the first file without reading the rest
+	first, ok := <-nodes
+	require.True(t, ok)
+	require.NotNil(t, first)
+	cancel()
+
+	_, err := drainStream(nodes, errs)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,136 @@
+package scanner
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// isSymlink reports whether d is a symbolic link that has not been resolved.
+func isSymlink(d fs.DirEntry) bool {
+	return d.Type()&fs.ModeSymlink != 0
+}
+
+// walkTree walks rootPath like filepath.WalkDir. With config.FollowSymlinks, links are
+// resolved and reported under their path inside the root: links to files as files and
+// links to directories as directories whose contents are walked in turn.
+//
+// Cycle Prevention:
+// Real directories are always entered, and their real paths are recorded. A link to a
+// directory that was already entered is reported unresolved, as a leaf, so each link
+// target is walked at most once and a link back to an ancestor never recurses.
+// Links whose target lies outside the root are skipped entirely unless
+// config.AllowExternalSymlinks is set.
+func walkTree(rootPath string, config *ScanConfig, fn fs.WalkDirFunc) error {
+	if !config.FollowSymlinks {
+		return filepath.WalkDir(rootPath, fn)
+	}
+
+	realRoot, err := filepath.EvalSymlinks(rootPath)
+	if err != nil {
+		return fn(rootPath, nil, err)
+	}
+
+	w := &symlinkWalker{
+		realRoot: realRoot,
+		config:   config,
+		fn:       fn,
+		visited:  make(map[string]bool),
+	}
+
+	return filepath.WalkDir(rootPath, w.visit)
+}
+
+type symlinkWalker struct {
+	realRoot string
+	config   *ScanConfig
+	fn       fs.WalkDirFunc
+	visited  map[string]bool
+}
+
+func (w *symlinkWalker) visit(path string, d fs.DirEntry, err error) error {
+	if err != nil {
+		return w.fn(path, d, err)
+	}
+
+	if d.IsDir() {
+		if realPath, evalErr := filepath.EvalSymlinks(path); evalErr == nil {
+			w.visited[realPath] = true
+		}
+		return w.fn(path, d, nil)
+	}
+
+	if !isSymlink(d) {
+		return w.fn(path, d, nil)
+	}
+
+	return w.visitLink(path, d)
+}
+
+// visitLink resolves the link at path and reports it as its target.
+// Dangling links and links back into an entered directory are reported unresolved.
+func (w *symlinkWalker) visitLink(path string, d fs.DirEntry) error {
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return w.fn(path, d, nil)
+	}
+	info, err := os.Stat(target)
+	if err != nil {
+		return w.fn(path, d, nil)
+	}
+
+	if !w.config.AllowExternalSymlinks && !isWithin(w.realRoot, target) {
+		return nil
+	}
+
+	resolved := fs.FileInfoToDirEntry(linkInfo{FileInfo: info, name: d.Name()})
+	if !info.IsDir() {
+		return w.fn(path, resolved, nil)
+	}
+
+	if w.visited[target] {
+		return w.fn(path, d, nil)
+	}
+
+	if err := w.fn(path, resolved, nil); err != nil {
+		if errors.Is(err, filepath.SkipDir) {
+			return nil
+		}
+		return err
+	}
+	w.visited[target] = true
+
+	// Walk the target, translating its paths back to paths under the link
+	return filepath.WalkDir(target, func(p string, entry fs.DirEntry, walkErr error) error {
+		if p == target {
+			return nil
+		}
+		rel, relErr := filepath.Rel(target, p)
+		if relErr != nil {
+			return nil //nolint:nilerr // intentional: skip entries that cannot be mapped under the link
+		}
+		return w.visit(filepath.Join(path, rel), entry, walkErr)
+	})
+}
+
+// isWithin reports whether path is root or lies below it.
+func isWithin(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// linkInfo reports a link target's file info under the link's own name.
+type linkInfo struct {
+	os.FileInfo
+	name string
+}
+
+func (i linkInfo) Name() string {
+	return i.name
+}
@@ -0,0 +1,82 @@
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const frontMatterDelimiter = "---"
+
+// frontMatter is the optional YAML header of a template file, delimited by "---" lines:
+//
+//	---
+//	description: Find the root cause of a bug
+//	defaults:
+//	  RULES: Reproduce the bug before proposing a fix.
+//	requires: [TASK]
+//	---
+type frontMatter struct {
+	// Description replaces the description taken from the first heading.
+	Description string `yaml:"description"`
+	// Defaults are variable values used when the caller leaves a variable empty.
+	Defaults map[string]string `yaml:"defaults"`
+	// Requires lists the variables the user must supply. When set, it replaces
+	// looking for {TASK} and {RULES} in the content.
+	Requires []string `yaml:"requires"`
+}
+
+// splitFrontMatter separates a leading front-matter block from the template body.
+// Content without one is returned unchanged with a nil frontMatter.
+func splitFrontMatter(content string) (*frontMatter, string, error) {
+	normalized := strings.TrimPrefix(content, "\ufeff")
+	firstLine, rest, found := strings.Cut(normalized, "\n")
+	if !found || strings.TrimRight(firstLine, "\r") != frontMatterDelimiter {
+		return nil, content, nil
+	}
+
+	var header []string
+	for {
+		line, remaining, more := strings.Cut(rest, "\n")
+		if strings.TrimRight(line, "\r") == frontMatterDelimiter {
+			fm, err := decodeFrontMatter(strings.Join(header, "\n"))
+			if err != nil {
+				return nil, content, err
+			}
+			return fm, remaining, nil
+		}
+		if !more {
+			return nil, content, fmt.Errorf("front-matter is missing its closing %q line", frontMatterDelimiter)
+		}
+		header = append(header, line)
+		rest = remaining
+	}
+}
+
+func decodeFrontMatter(header string) (*frontMatter, error) {
+	fm := &frontMatter{}
+	if strings.TrimSpace(header) == "" {
+		return fm, nil
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader([]byte(header)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(fm); err != nil {
+		return nil, fmt.Errorf("invalid front-matter: %w", err)
+	}
+
+	for _, name := range fm.Requires {
+		if !variablePattern.MatchString("{" + name + "}") {
+			return nil, fmt.Errorf("invalid front-matter: requires lists invalid variable name %q", name)
+		}
+	}
+	for name := range fm.Defaults {
+		if !variablePattern.MatchString("{" + name + "}") {
+			return nil, fmt.Errorf("invalid front-matter: defaults sets invalid variable name %q", name)
+		}
+	}
+
+	return fm, nil
+}
@@ -0,0 +1,101 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitFrontMatter(t *testing.T) {
+	content := "---\r\ndescription: Debug a failure\r\ndefaults:\r\n  RULES: Reproduce first\r\n" +
+		"requires: [TASK]\r\n---\r\n# Bug\n{TASK}\n"
+
+	fm, body, err := splitFrontMatter(content)
+	require.NoError(t, err)
+	require.NotNil(t, fm)
+	assert.Equal(t, "Debug a failure", fm.Description)
+	assert.Equal(t, map[string]string{"RULES": "Reproduce first"}, fm.Defaults)
+	assert.Equal(t, []string{"TASK"}, fm.Requires)
+	assert.Equal(t, "# Bug\n{TASK}\n", body)
+
+	fm, body, err = splitFrontMatter("# Plain\n---\n{TASK}\n")
+	require.NoError(t, err)
+	assert.Nil(t, fm)
+	assert.Equal(t, "# Plain\n---\n{TASK}\n", body)
+
+	fm, _, err = splitFrontMatter("---\n---\nbody")
+	require.NoError(t, err)
+	assert.Nil(t, fm.Requires)
+
+	fm, _, err = splitFrontMatter("---\nrequires: []\n---\nbody")
+	require.NoError(t, err)
+	assert.NotNil(t, fm.Requires, "an explicit empty requires must be kept")
+}
+
+func TestSplitFrontMatter_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"unterminated", "---\ndescription: x\n{TASK}", "missing its closing"},
+		{"invalid yaml", "---\ndefaults: [\n---\nbody", "invalid front-matter"},
+		{"unknown key", "---\ndescripton: x\n---\nbody", "invalid front-matter"},
+		{"bad requires", "---\nrequires: [task]\n---\nbody", `invalid variable name "task"`},
+		{"bad default", "---\ndefaults: {rules: x}\n---\nbody", `invalid variable name "rules"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := splitFrontMatter(tt.content)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseTemplate_FrontMatter(t *testing.T) {
+	content := "---\ndescription: Debug a failure\ndefaults:\n  RULES: Reproduce first\nrequires: [TASK]\n---\n" +
+		"# Bug Analysis\n{TASK}\n{RULES}\n"
+
+	tmpl, err := parseTemplate(content, "prompt_analyzeBug.md", "prompt_analyzeBug.md")
+	require.NoError(t, err)
+	assert.Equal(t, "Debug a failure", tmpl.Description)
+	assert.NotContains(t, tmpl.Content, "requires:")
+	assert.True(t, tmpl.RequiresVariable(VarTask))
+	assert.False(t, tmpl.RequiresVariable(VarRules), "requires replaces placeholder detection")
+
+	rendered, err := NewRenderer().RenderTemplate(tmpl, map[string]string{VarTask: "Fix crash", VarRules: ""})
+	require.NoError(t, err)
+	assert.Contains(t, rendered, "Reproduce first")
+
+	rendered, err = NewRenderer().RenderTemplate(tmpl, map[string]string{VarTask: "Fix crash", VarRules: "Be brief"})
+	require.NoError(t, err)
+	assert.Contains(t, rendered, "Be brief")
+	assert.NotContains(t, rendered, "Reproduce first")
+
+	_, err = parseTemplate("---\nrequires: [x]\n---\nbody", "bad.md", "bad.md")
+	assert.Error(t, err)
+}
+
+func TestTemplateRequiresVariable_WithoutFrontMatter(t *testing.T) {
+	tmpl := &Template{Content: "{TASK} only"}
+	assert.True(t, tmpl.RequiresVariable(VarTask))
+	assert.False(t, tmpl.RequiresVariable(VarRules))
+}
+
+func TestMergeDefaults(t *testing.T) {
+	vars := map[string]string{"TASK": "t", "RULES": "  "}
+	merged := MergeDefaults(vars, map[string]string{"RULES": "r", "TASK": "d", "EXTRA": "e"})
+
+	assert.Equal(t, map[string]string{"TASK": "t", "RULES": "r", "EXTRA": "e"}, merged)
+	assert.Equal(t, "  ", vars["RULES"], "the input map must not be modified")
+}
+
+func TestValidate_FrontMatter(t *testing.T) {
+	result := Validate("---\nrequires: [TASK]\n---\n{TASK}\n{FILE_STRUCTURE}\n")
+	assert.True(t, result.Valid(), "errors: %v", result.Errors)
+	assert.Equal(t, []string{"FILE_STRUCTURE", "TASK"}, result.Variables)
+
+	result = Validate("---\nrequires: [TASK]\n{TASK}\n")
+	assert.False(t, result.Valid())
+}
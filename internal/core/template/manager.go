@@ -5,9 +5,12 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
+	gotemplate "text/template"
 
 	"github.com/adrg/xdg"
 	"github.com/quantmind-br/shotgun-cli/internal/assets"
@@ -25,10 +28,13 @@ type TemplateManager interface {
 // Manager implements the TemplateManager interface
 type Manager struct {
 	templates map[string]*Template
+	funcs     gotemplate.FuncMap
 	mu        sync.RWMutex
 	renderer  *Renderer
 }
 
+var funcNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 // ManagerConfig holds configuration for the template manager.
 type ManagerConfig struct {
 	CustomPath string
@@ -38,6 +44,7 @@ type ManagerConfig struct {
 func NewManager(cfg ManagerConfig) (*Manager, error) {
 	manager := &Manager{
 		templates: make(map[string]*Template),
+		funcs:     make(gotemplate.FuncMap),
 		renderer:  NewRenderer(),
 	}
 
@@ -185,3 +192,44 @@ func (m *Manager) GetRequiredVariables(name string) ([]string, error) {
 
 	return m.renderer.GetRequiredVariables(template), nil
 }
+
+// RegisterFunc adds a helper function, e.g. {{ basename .RelPath }}, to the FuncMap
+// used when templates are rendered. fn must be a function returning one value, or
+// a value and an error. Registering a name again replaces the earlier function.
+func (m *Manager) RegisterFunc(name string, fn interface{}) error {
+	if !funcNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid template function name %q", name)
+	}
+
+	fnType := reflect.TypeOf(fn)
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		return fmt.Errorf("template function %q must be a function, got %T", name, fn)
+	}
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	switch {
+	case fnType.NumOut() == 1:
+	case fnType.NumOut() == 2 && fnType.Out(1) == errorType:
+	default:
+		return fmt.Errorf("template function %q must return one value, or a value and an error", name)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.funcs[name] = fn
+
+	return nil
+}
+
+// FuncMap returns a copy of the registered template functions.
+func (m *Manager) FuncMap() gotemplate.FuncMap {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	funcs := make(gotemplate.FuncMap, len(m.funcs))
+	for name, fn := range m.funcs {
+		funcs[name] = fn
+	}
+
+	return funcs
+}
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	gotemplate "text/template"
 	"time"
 
 	"github.com/quantmind-br/shotgun-cli/internal/assets"
@@ -509,3 +510,53 @@ Rules: {RULES}
 		t.Error("Embedded templates should still be available")
 	}
 }
+
+func TestManager_RegisterFunc(t *testing.T) {
+	t.Parallel()
+
+	mgr := newTestManager(t)
+	if err := mgr.RegisterFunc("basename", filepath.Base); err != nil {
+		t.Fatalf("RegisterFunc failed: %v", err)
+	}
+
+	tmpl, err := gotemplate.New("t").Funcs(mgr.FuncMap()).Parse(`{{ basename .Path }}`)
+	if err != nil {
+		t.Fatalf("parse with registered func failed: %v", err)
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, map[string]string{"Path": "internal/core/manager.go"}); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	if out.String() != "manager.go" {
+		t.Errorf("expected %q, got %q", "manager.go", out.String())
+	}
+}
+
+func TestManager_RegisterFunc_Invalid(t *testing.T) {
+	t.Parallel()
+
+	mgr := newTestManager(t)
+	tests := []struct {
+		name string
+		fn   interface{}
+	}{
+		{"bad-name", strings.ToUpper},
+		{"notFunc", "value"},
+		{"nilFunc", nil},
+		{"noResult", func() {}},
+		{"badSecondResult", func() (string, string) { return "", "" }},
+	}
+
+	for _, tt := range tests {
+		if err := mgr.RegisterFunc(tt.name, tt.fn); err == nil {
+			t.Errorf("RegisterFunc(%q) should fail", tt.name)
+		}
+	}
+	if len(mgr.FuncMap()) != 0 {
+		t.Errorf("invalid functions should not be registered, got %v", mgr.FuncMap())
+	}
+
+	if err := mgr.RegisterFunc("check", func(s string) (string, error) { return s, nil }); err != nil {
+		t.Errorf("a (value, error) function should be accepted: %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package template
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 )
@@ -24,6 +25,9 @@ func (r *Renderer) RenderTemplate(template *Template, vars map[string]string) (s
 		return "", fmt.Errorf("template is nil")
 	}
 
+	// Front-matter defaults fill in whatever the caller left empty
+	vars = template.ApplyDefaults(vars)
+
 	// Validate that all required variables are provided
 	if err := r.validateVariables(template, vars); err != nil {
 		return "", err
@@ -116,15 +120,7 @@ func getDefaultVariables() map[string]string {
 
 // isAutoGeneratedVar checks if a variable is automatically generated
 func (r *Renderer) isAutoGeneratedVar(varName string) bool {
-	autoVars := []string{VarCurrentDate}
-
-	for _, autoVar := range autoVars {
-		if varName == autoVar {
-			return true
-		}
-	}
-
-	return false
+	return varName == VarCurrentDate || slices.Contains(GitVars, varName)
 }
 
 // sanitizeVariableValue ensures variable values are safe for substitution
@@ -3,6 +3,7 @@ package template
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -15,6 +16,11 @@ type Template struct {
 	FilePath     string   `json:"file_path"`
 	IsEmbedded   bool     `json:"is_embedded"` // true if from embedded filesystem
 	Source       string   `json:"source"`      // "embedded", "user", or custom path
+	// Defaults are the front-matter values used for variables the caller leaves empty.
+	Defaults map[string]string `json:"defaults,omitempty"`
+	// Requires lists the variables the user must supply, from front-matter. Nil means
+	// the template declares none and requirement is inferred from its placeholders.
+	Requires []string `json:"requires,omitempty"`
 }
 
 // Common template variable constants
@@ -23,8 +29,16 @@ const (
 	VarRules         = "RULES"
 	VarFileStructure = "FILE_STRUCTURE"
 	VarCurrentDate   = "CURRENT_DATE"
+	// Git metadata of the root, empty outside a repository
+	VarGitBranch   = "GIT_BRANCH"
+	VarGitCommit   = "GIT_COMMIT"
+	VarGitStatus   = "GIT_STATUS"
+	VarGitDiffStat = "GIT_DIFF_STAT"
 )
 
+// GitVars lists the git metadata variables, filled in by context generation.
+var GitVars = []string{VarGitBranch, VarGitCommit, VarGitStatus, VarGitDiffStat}
+
 // Variable pattern for extracting template variables
 var variablePattern = regexp.MustCompile(`\{([A-Z_][A-Z0-9_]*)\}`)
 
@@ -34,6 +48,11 @@ func parseTemplate(content, fileName, filePath string) (*Template, error) {
 		return nil, fmt.Errorf("template content is empty")
 	}
 
+	fm, content, err := splitFrontMatter(content)
+	if err != nil {
+		return nil, err
+	}
+
 	template := &Template{
 		Name:       extractTemplateName(fileName),
 		Content:    content,
@@ -43,6 +62,13 @@ func parseTemplate(content, fileName, filePath string) (*Template, error) {
 
 	// Extract description from the first comment or header
 	template.Description = extractDescription(content, fileName)
+	if fm != nil {
+		if fm.Description != "" {
+			template.Description = fm.Description
+		}
+		template.Defaults = fm.Defaults
+		template.Requires = fm.Requires
+	}
 
 	// Extract required variables from the template content
 	requiredVars, err := extractRequiredVars(content)
@@ -176,11 +202,60 @@ func (t *Template) GetVariableNames() []string {
 	return vars
 }
 
+// CustomVariableNames returns, sorted, the variables of the template that context
+// generation does not fill in itself: all but TASK, RULES, FILE_STRUCTURE,
+// CURRENT_DATE and the git variables.
+func (t *Template) CustomVariableNames() []string {
+	builtIn := append([]string{VarTask, VarRules, VarFileStructure, VarCurrentDate}, GitVars...)
+
+	var names []string
+	for _, name := range t.GetVariableNames() {
+		if !slices.Contains(builtIn, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	return names
+}
+
 // HasVariable checks if the template contains a specific variable
 func (t *Template) HasVariable(varName string) bool {
 	return strings.Contains(t.Content, "{"+varName+"}")
 }
 
+// RequiresVariable reports whether the user must supply varName: it is listed in the
+// front-matter requires, or, without one, the content contains {varName}.
+func (t *Template) RequiresVariable(varName string) bool {
+	if t.Requires == nil {
+		return t.HasVariable(varName)
+	}
+
+	return slices.Contains(t.Requires, varName)
+}
+
+// ApplyDefaults returns a copy of vars with the template's front-matter defaults
+// filled in for variables that are missing or blank.
+func (t *Template) ApplyDefaults(vars map[string]string) map[string]string {
+	return MergeDefaults(vars, t.Defaults)
+}
+
+// MergeDefaults returns a copy of vars with defaults filled in for variables that
+// are missing or blank.
+func MergeDefaults(vars, defaults map[string]string) map[string]string {
+	merged := make(map[string]string, len(vars)+len(defaults))
+	for name, value := range vars {
+		merged[name] = value
+	}
+	for name, value := range defaults {
+		if strings.TrimSpace(merged[name]) == "" {
+			merged[name] = value
+		}
+	}
+
+	return merged
+}
+
 // GetVariableCount returns the number of times a variable appears in the template
 func (t *Template) GetVariableCount(varName string) int {
 	return strings.Count(t.Content, "{"+varName+"}")
@@ -317,6 +317,15 @@ func TestTemplateGetVariableNames(t *testing.T) {
 	assert.True(t, varMap["CURRENT_DATE"])
 }
 
+func TestTemplateCustomVariableNames(t *testing.T) {
+	tmpl := &Template{
+		Content: "{TASK} {RULES} {FILE_STRUCTURE} {CURRENT_DATE} {GIT_BRANCH} {TICKET} {AUDIENCE} {TICKET}",
+	}
+	assert.Equal(t, []string{"AUDIENCE", "TICKET"}, tmpl.CustomVariableNames())
+
+	assert.Empty(t, (&Template{Content: "Task: {TASK}\nRules: {RULES}"}).CustomVariableNames())
+}
+
 func TestTemplateHasVariable(t *testing.T) {
 	tmpl := &Template{
 		Content: "Task: {TASK}\nRules: {RULES}",
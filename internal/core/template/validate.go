@@ -0,0 +1,92 @@
+package template
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SupportedVariables are the placeholders context generation fills in. Any other
+// {NAME} placeholder is left in the generated context as written.
+var SupportedVariables = []string{VarTask, VarRules, VarFileStructure, VarCurrentDate}
+
+// lowerPlaceholderPattern matches placeholders that are not upper case, e.g. {task}.
+var lowerPlaceholderPattern = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// ValidationResult is the outcome of checking a template's content.
+type ValidationResult struct {
+	// Variables are the placeholders the template references, sorted.
+	Variables []string
+	// Errors are problems that break rendering, such as unbalanced braces.
+	Errors []string
+	// Warnings are problems that render but likely produce the wrong context.
+	Warnings []string
+}
+
+// Valid reports whether the template has no errors.
+func (r ValidationResult) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// Validate checks template content for malformed or unbalanced placeholders,
+// placeholders context generation does not fill in, and a missing {FILE_STRUCTURE}.
+// A leading front-matter block is checked separately and excluded from the rest.
+func Validate(content string) ValidationResult {
+	var result ValidationResult
+
+	if _, body, err := splitFrontMatter(content); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	} else {
+		content = body
+	}
+
+	if err := validateTemplateContent(content); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	}
+
+	tmpl := &Template{Content: content}
+	result.Variables = tmpl.GetVariableNames()
+	sort.Strings(result.Variables)
+
+	for _, name := range result.Variables {
+		if !isSupportedVariable(name) {
+			result.Warnings = append(result.Warnings, fmt.Sprintf(
+				"unsupported placeholder {%s} is left as is by context generation (supported: %s)",
+				name, strings.Join(SupportedVariables, ", ")))
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, loc := range lowerPlaceholderPattern.FindAllStringSubmatchIndex(content, -1) {
+		// Skip Go template actions such as {{end}}
+		if (loc[0] > 0 && content[loc[0]-1] == '{') || (loc[1] < len(content) && content[loc[1]] == '}') {
+			continue
+		}
+		name := content[loc[2]:loc[3]]
+		if variablePattern.MatchString(content[loc[0]:loc[1]]) || seen[name] {
+			continue
+		}
+		seen[name] = true
+		result.Warnings = append(result.Warnings, fmt.Sprintf(
+			"unknown placeholder {%s}: variables are upper case, e.g. {%s}", name, strings.ToUpper(name)))
+	}
+
+	if !tmpl.HasVariable(VarFileStructure) && !strings.Contains(content, ".FileStructure") &&
+		!strings.Contains(content, ".Files") {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(
+			"template does not reference {%s}, so the generated context will not include any files", VarFileStructure))
+	}
+
+	return result
+}
+
+func isSupportedVariable(name string) bool {
+	for _, supported := range SupportedVariables {
+		if name == supported {
+			return true
+		}
+	}
+
+	return false
+}
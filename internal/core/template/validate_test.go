@@ -0,0 +1,84 @@
+package template
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quantmind-br/shotgun-cli/internal/assets"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantVars     []string
+		wantErrors   int
+		wantWarnings []string
+	}{
+		{
+			name:     "valid template",
+			content:  "# Review\n\n{TASK}\n{RULES}\n\n{FILE_STRUCTURE}\n",
+			wantVars: []string{"FILE_STRUCTURE", "RULES", "TASK"},
+		},
+		{
+			name:       "unbalanced braces",
+			content:    "{TASK\n{FILE_STRUCTURE}\n",
+			wantVars:   []string{"FILE_STRUCTURE"},
+			wantErrors: 1,
+		},
+		{
+			name:         "unsupported placeholder",
+			content:      "{TASK} for {LANGUAGE}\n{FILE_STRUCTURE}\n",
+			wantVars:     []string{"FILE_STRUCTURE", "LANGUAGE", "TASK"},
+			wantWarnings: []string{"unsupported placeholder {LANGUAGE}"},
+		},
+		{
+			name:         "lower case placeholder",
+			content:      "{task} and {task}\n{FILE_STRUCTURE}\n",
+			wantVars:     []string{"FILE_STRUCTURE"},
+			wantWarnings: []string{"unknown placeholder {task}"},
+		},
+		{
+			name:         "missing file structure",
+			content:      "{TASK}\n",
+			wantVars:     []string{"TASK"},
+			wantWarnings: []string{"does not reference {FILE_STRUCTURE}"},
+		},
+		{
+			name:     "go template files",
+			content:  "{{range .Files}}{{.RelPath}}{{end}}\n",
+			wantVars: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Validate(tt.content)
+			assert.Equal(t, tt.wantVars, result.Variables)
+			assert.Len(t, result.Errors, tt.wantErrors)
+			assert.Equal(t, tt.wantErrors == 0, result.Valid())
+			require.Len(t, result.Warnings, len(tt.wantWarnings))
+			for i, want := range tt.wantWarnings {
+				assert.Contains(t, result.Warnings[i], want)
+			}
+		})
+	}
+}
+
+func TestValidate_EmbeddedTemplates(t *testing.T) {
+	templatesFS, err := fs.Sub(assets.Templates, "templates")
+	require.NoError(t, err)
+
+	templates, err := NewEmbeddedSource(templatesFS).LoadTemplates()
+	require.NoError(t, err)
+	require.NotEmpty(t, templates)
+
+	for name, tmpl := range templates {
+		result := Validate(tmpl.Content)
+		assert.Empty(t, result.Errors, "template %s", name)
+		assert.Empty(t, result.Warnings, "template %s", name)
+	}
+}
@@ -0,0 +1,308 @@
+package tokens
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// commentStyle describes the comment and string-literal syntax of a language family.
+type commentStyle struct {
+	lineComment string   // Line comment prefix (e.g. "//" or "#")
+	blockStart  string   // Block comment opener (empty if unsupported)
+	blockEnd    string   // Block comment closer
+	quotes      string   // Characters that open a string literal
+	rawQuote    byte     // Quote character whose literals do not support escapes (0 if none)
+	spanQuote   byte     // Quote character whose literals may span lines (0 if none)
+	triple      bool     // Whether tripled quotes open multi-line strings (Python)
+	regex       bool     // Whether /.../ regex literals exist (JavaScript)
+	directives  []string // Line comment prefixes that are code, kept as they are (Go)
+}
+
+var (
+	cStyle = commentStyle{
+		lineComment: "//", blockStart: "/*", blockEnd: "*/", quotes: "\"'`", rawQuote: '`', spanQuote: '`',
+	}
+	goStyle = commentStyle{
+		lineComment: "//", blockStart: "/*", blockEnd: "*/", quotes: "\"'`", rawQuote: '`', spanQuote: '`',
+		directives: []string{"//go:", "//line ", "//export ", "// +build "},
+	}
+	// Template literals span lines but, unlike Go raw strings, support escapes.
+	jsStyle = commentStyle{
+		lineComment: "//", blockStart: "/*", blockEnd: "*/", quotes: "\"'`", spanQuote: '`', regex: true,
+	}
+	// Rust uses ' for lifetimes, so only double quotes delimit strings.
+	rustStyle = commentStyle{lineComment: "//", blockStart: "/*", blockEnd: "*/", quotes: "\""}
+	cssStyle  = commentStyle{blockStart: "/*", blockEnd: "*/", quotes: "\"'"}
+	hashStyle = commentStyle{lineComment: "#", quotes: "\"'"}
+	pyStyle   = commentStyle{lineComment: "#", quotes: "\"'", triple: true}
+)
+
+var extensionCommentStyles = map[string]commentStyle{
+	".go":    goStyle,
+	".js":    jsStyle,
+	".jsx":   jsStyle,
+	".mjs":   jsStyle,
+	".cjs":   jsStyle,
+	".ts":    jsStyle,
+	".tsx":   jsStyle,
+	".java":  cStyle,
+	".c":     cStyle,
+	".h":     cStyle,
+	".cpp":   cStyle,
+	".cc":    cStyle,
+	".cxx":   cStyle,
+	".hpp":   cStyle,
+	".hh":    cStyle,
+	".cs":    cStyle,
+	".swift": cStyle,
+	".kt":    cStyle,
+	".kts":   cStyle,
+	".scala": cStyle,
+	".dart":  cStyle,
+	".rs":    rustStyle,
+	".css":   cssStyle,
+	".scss":  cStyle,
+	".less":  cStyle,
+	".py":    pyStyle,
+	".pyw":   pyStyle,
+	".rb":    hashStyle,
+	".sh":    hashStyle,
+	".bash":  hashStyle,
+	".zsh":   hashStyle,
+	".yaml":  hashStyle,
+	".yml":   hashStyle,
+	".toml":  hashStyle,
+	".r":     hashStyle,
+	".pl":    hashStyle,
+}
+
+// CanStripComments reports whether comment stripping is supported for the given file path.
+func CanStripComments(path string) bool {
+	_, ok := extensionCommentStyles[strings.ToLower(filepath.Ext(path))]
+	return ok
+}
+
+// StripComments removes line and block comments from content, choosing the comment
+// syntax from the file extension of path. A leading shebang line and Go directives
+// such as //go:build are preserved, and lines that only contained comments are dropped.
+// Content of unknown file types is returned unchanged.
+func StripComments(path, content string) string {
+	style, ok := extensionCommentStyles[strings.ToLower(filepath.Ext(path))]
+	if !ok || content == "" {
+		return content
+	}
+
+	var shebang string
+	if strings.HasPrefix(content, "#!") {
+		end := strings.IndexByte(content, '\n')
+		if end < 0 {
+			return content
+		}
+		shebang, content = content[:end+1], content[end+1:]
+	}
+
+	return shebang + stripWithStyle(content, style)
+}
+
+// stripState carries lexer state across lines.
+type stripState struct {
+	inBlock bool
+	quote   byte // Open string delimiter, 0 when outside a string
+	triple  bool // Whether the open string was opened with a tripled delimiter
+}
+
+func stripWithStyle(content string, style commentStyle) string {
+	var out strings.Builder
+	out.Grow(len(content))
+
+	state := &stripState{}
+	lines := strings.SplitAfter(content, "\n")
+	for _, line := range lines {
+		body := strings.TrimSuffix(line, "\n")
+		newline := len(body) < len(line)
+
+		if !state.inBlock && state.quote == 0 && isDirective(body, style) {
+			out.WriteString(line)
+			continue
+		}
+
+		stripped, removed := stripLine(body, style, state)
+		if removed {
+			stripped = strings.TrimRight(stripped, " \t\r")
+			// Drop lines that only held comments.
+			if strings.TrimSpace(stripped) == "" && strings.TrimSpace(body) != "" {
+				continue
+			}
+		}
+
+		out.WriteString(stripped)
+		if newline {
+			out.WriteByte('\n')
+		}
+	}
+
+	return out.String()
+}
+
+// stripLine removes comments from a single line and reports whether anything was removed.
+//
+//nolint:gocyclo // small hand-written lexer, clearer as one function
+func stripLine(line string, style commentStyle, state *stripState) (string, bool) {
+	var out strings.Builder
+	removed := false
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+
+		switch {
+		case state.inBlock:
+			removed = true
+			if strings.HasPrefix(line[i:], style.blockEnd) {
+				state.inBlock = false
+				i += len(style.blockEnd) - 1
+			}
+
+		case state.quote != 0:
+			out.WriteByte(c)
+			switch {
+			case c == '\\' && state.quote != style.rawQuote && i+1 < len(line):
+				i++
+				out.WriteByte(line[i])
+			case c == state.quote && state.triple:
+				if closer := strings.Repeat(string(c), 3); strings.HasPrefix(line[i:], closer) {
+					out.WriteString(closer[1:])
+					i += 2
+					state.quote, state.triple = 0, false
+				}
+			case c == state.quote:
+				state.quote = 0
+			}
+
+		case style.blockStart != "" && strings.HasPrefix(line[i:], style.blockStart):
+			removed = true
+			state.inBlock = true
+			i += len(style.blockStart) - 1
+
+		case style.regex && c == '/' && line[i+1:] != "" && line[i+1] != '/' && line[i+1] != '*' &&
+			regexAllowed(out.String()):
+			end := regexEnd(line, i)
+			if end < 0 {
+				// Division after all
+				out.WriteByte(c)
+				continue
+			}
+			out.WriteString(line[i:end])
+			i = end - 1
+
+		case style.lineComment != "" && strings.HasPrefix(line[i:], style.lineComment) &&
+			(style.lineComment != "#" || i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return out.String(), true
+
+		case strings.IndexByte(style.quotes, c) >= 0:
+			state.quote = c
+			out.WriteByte(c)
+			if opener := strings.Repeat(string(c), 3); style.triple && strings.HasPrefix(line[i:], opener) {
+				out.WriteString(opener[1:])
+				i += 2
+				state.triple = true
+			}
+
+		default:
+			out.WriteByte(c)
+		}
+	}
+
+	// Only raw, template and triple-quoted strings may span lines; reset other unterminated quotes.
+	if state.quote != 0 && state.quote != style.spanQuote && !state.triple {
+		state.quote = 0
+	}
+
+	return out.String(), removed
+}
+
+// isDirective reports whether line is a comment the style keeps as code, such as //go:embed.
+func isDirective(line string, style commentStyle) bool {
+	trimmed := strings.TrimLeft(line, " \t")
+	for _, directive := range style.directives {
+		if strings.HasPrefix(trimmed, directive) {
+			return true
+		}
+	}
+	return false
+}
+
+// regexKeywords may precede a regex literal; after any other word a slash divides.
+var regexKeywords = []string{"return", "typeof", "case", "do", "else", "in", "of", "void", "yield", "await",
+	"delete", "throw", "new"}
+
+// regexAllowed reports whether a slash following code starts a regex literal rather
+// than a division: at the start of a line, after an operator or punctuation, or after
+// a keyword such as return.
+func regexAllowed(code string) bool {
+	code = strings.TrimRight(code, " \t")
+	if code == "" {
+		return true
+	}
+	if strings.IndexByte("(,=:[!&|?{};+-*%<>~^", code[len(code)-1]) >= 0 {
+		return true
+	}
+	for _, keyword := range regexKeywords {
+		if strings.HasSuffix(code, keyword) {
+			rest := code[:len(code)-len(keyword)]
+			if rest == "" || !isIdentByte(rest[len(rest)-1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// regexEnd returns the index just past the flags of the regex literal starting at
+// line[start], or -1 when the literal is not closed on the line.
+func regexEnd(line string, start int) int {
+	inClass := false
+	for i := start + 1; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '\\':
+			i++
+		case c == '[':
+			inClass = true
+		case c == ']':
+			inClass = false
+		case c == '/' && !inClass:
+			end := i + 1
+			for end < len(line) && isIdentByte(line[end]) {
+				end++
+			}
+			return end
+		}
+	}
+	return -1
+}
+
+// CommentStripper is a content transform that strips comments and records how
+// many bytes were removed across all files it processed.
+type CommentStripper struct {
+	bytesSaved int64
+}
+
+// NewCommentStripper creates a new CommentStripper.
+func NewCommentStripper() *CommentStripper {
+	return &CommentStripper{}
+}
+
+// Transform strips comments from content and accumulates the bytes saved.
+func (s *CommentStripper) Transform(relPath, content string) string {
+	stripped := StripComments(relPath, content)
+	s.bytesSaved += int64(len(content) - len(stripped))
+	return stripped
+}
+
+// BytesSaved returns the total number of bytes removed so far.
+func (s *CommentStripper) BytesSaved() int64 {
+	return s.bytesSaved
+}
@@ -0,0 +1,119 @@
+package tokens
+
+import (
+	"testing"
+)
+
+func TestStripComments(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "go line and block comments",
+			path:     "main.go",
+			content:  "// Package main.\npackage main\n\n/* block\n   comment */\nfunc main() { // trailing\n\tx := 1 /* inline */ + 2\n}\n",
+			expected: "package main\n\nfunc main() {\n\tx := 1  + 2\n}\n",
+		},
+		{
+			name:     "go strings are preserved",
+			path:     "url.go",
+			content:  "var u = \"http://example.com\" // site\nvar r = `/* not a comment */`\n",
+			expected: "var u = \"http://example.com\"\nvar r = `/* not a comment */`\n",
+		},
+		{
+			name:     "escaped quote inside string",
+			path:     "a.js",
+			content:  "const s = \"a \\\" // b\"; // c\n",
+			expected: "const s = \"a \\\" // b\";\n",
+		},
+		{
+			name:     "python hash comments",
+			path:     "script.py",
+			content:  "# header\nx = 1  # trailing\ny = \"#not\"\n",
+			expected: "x = 1\ny = \"#not\"\n",
+		},
+		{
+			name:     "python docstring keeps hashes",
+			path:     "doc.py",
+			content:  "def f():\n    \"\"\"Docs\n    see # issue\n    \"\"\"\n    return 1  # one\n",
+			expected: "def f():\n    \"\"\"Docs\n    see # issue\n    \"\"\"\n    return 1\n",
+		},
+		{
+			name:     "shebang is preserved",
+			path:     "run.sh",
+			content:  "#!/bin/bash\n# comment\necho hi # bye\necho $#\n",
+			expected: "#!/bin/bash\necho hi\necho $#\n",
+		},
+		{
+			name:     "css block comments",
+			path:     "style.css",
+			content:  "/* reset */\nbody { margin: 0; } /* x */\n",
+			expected: "body { margin: 0; }\n",
+		},
+		{
+			name:     "js regex literals are preserved",
+			path:     "url.js",
+			content:  "const re = /https?:\\/\\//; // scheme\nif (/[/]\\/\\//g.test(s)) return x / 2 / y; // half\n",
+			expected: "const re = /https?:\\/\\//;\nif (/[/]\\/\\//g.test(s)) return x / 2 / y;\n",
+		},
+		{
+			name:     "js template literals support escapes and span lines",
+			path:     "tpl.ts",
+			content:  "const s = `esc \\` // x`; // note\nconst t = `one\n// two`;\n",
+			expected: "const s = `esc \\` // x`;\nconst t = `one\n// two`;\n",
+		},
+		{
+			name: "go directives are preserved",
+			path: "embed.go",
+			content: "//go:build linux\n\n// Package a.\npackage a\n\n" +
+				"//go:embed static\nvar static embed.FS\n\n//export Add\n",
+			expected: "//go:build linux\n\npackage a\n\n//go:embed static\nvar static embed.FS\n\n//export Add\n",
+		},
+		{
+			name:     "unknown extension passes through",
+			path:     "notes.txt",
+			content:  "// keep\n# keep\n",
+			expected: "// keep\n# keep\n",
+		},
+		{
+			name:     "blank lines are kept",
+			path:     "a.go",
+			content:  "package a\n\n\nvar x = 1\n",
+			expected: "package a\n\n\nvar x = 1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := StripComments(tt.path, tt.content)
+			if got != tt.expected {
+				t.Errorf("StripComments(%q) =\n%q\nwant\n%q", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCanStripComments(t *testing.T) {
+	if !CanStripComments("dir/File.GO") {
+		t.Error("expected .GO to be supported")
+	}
+	if CanStripComments("README.md") {
+		t.Error("expected .md to be unsupported")
+	}
+}
+
+func TestCommentStripperBytesSaved(t *testing.T) {
+	s := NewCommentStripper()
+
+	s.Transform("a.go", "package a // one\n")
+	s.Transform("b.txt", "// untouched\n")
+	s.Transform("c.py", "# two\nx = 1\n")
+
+	// " // one" (7 bytes) + "# two\n" (6 bytes)
+	if got := s.BytesSaved(); got != 13 {
+		t.Errorf("BytesSaved() = %d, want 13", got)
+	}
+}
@@ -4,6 +4,7 @@ package tokens
 
 import (
 	"fmt"
+	"strings"
 )
 
 // Common token heuristic: approximately 1 token per 4 characters (bytes) for English text.
@@ -21,6 +22,93 @@ const (
 	Window128K = 131072
 )
 
+// Tokenizers recommended by TokenizerForModel. Estimates here stay byte-based; the
+// name tells callers which exact tokenizer to use when they have one.
+const (
+	TokenizerO200K  = "o200k_base"  // GPT-4o, GPT-4.1, GPT-5 and the o-series
+	TokenizerCL100K = "cl100k_base" // GPT-4 and GPT-3.5
+	TokenizerClaude = "claude"      // Anthropic's tokenizer, only available through its API
+	TokenizerGemini = "gemini"      // Gemini's SentencePiece tokenizer
+)
+
+// ModelInfo is a known model family: its name prefix, context window in tokens and
+// recommended tokenizer.
+type ModelInfo struct {
+	Prefix    string
+	Window    int
+	Tokenizer string
+}
+
+// knownModels lists the model families WindowForModel and TokenizerForModel know,
+// one per line. A name matches its longest listed prefix, so "gpt-4.5-preview" is
+// gpt-4.5 rather than gpt-4 whatever the order.
+var knownModels = []ModelInfo{
+	{"gpt-5", 400_000, TokenizerO200K},
+	{"gpt-4o", 128_000, TokenizerO200K},
+	{"gpt-4.1", 1_047_576, TokenizerO200K},
+	{"gpt-4.5", 128_000, TokenizerO200K},
+	{"gpt-4-turbo", 128_000, TokenizerCL100K},
+	{"gpt-4-32k", 32_768, TokenizerCL100K},
+	{"gpt-4", 8_192, TokenizerCL100K},
+	{"gpt-3.5-turbo", 16_385, TokenizerCL100K},
+	{"o1-mini", 128_000, TokenizerO200K},
+	{"o1-preview", 128_000, TokenizerO200K},
+	{"o1", 200_000, TokenizerO200K},
+	{"o3", 200_000, TokenizerO200K},
+	{"o4-mini", 200_000, TokenizerO200K},
+	{"claude-", 200_000, TokenizerClaude},
+	{"gemini-1.5-pro", 2_097_152, TokenizerGemini},
+	{"gemini-", 1_048_576, TokenizerGemini},
+}
+
+// lookupModel returns the family of model, matched by its longest name prefix
+// (e.g. "claude-sonnet-4-20250514"). The lookup is case-insensitive.
+func lookupModel(model string) (ModelInfo, bool) {
+	model = strings.ToLower(strings.TrimSpace(model))
+	if model == "" {
+		return ModelInfo{}, false
+	}
+
+	var best ModelInfo
+	found := false
+	for _, info := range knownModels {
+		if strings.HasPrefix(model, info.Prefix) && len(info.Prefix) > len(best.Prefix) {
+			best, found = info, true
+		}
+	}
+
+	return best, found
+}
+
+// WindowForModel returns the context window in tokens of a known model. Unknown
+// models return false, so callers fall back to byte-based limits.
+func WindowForModel(model string) (int, bool) {
+	info, ok := lookupModel(model)
+	return info.Window, ok
+}
+
+// TokenizerForModel returns the tokenizer recommended for a known model, such as
+// TokenizerO200K, or "" for an unknown one.
+func TokenizerForModel(model string) string {
+	info, _ := lookupModel(model)
+	return info.Tokenizer
+}
+
+// KnownModels returns the model families WindowForModel recognizes.
+func KnownModels() []ModelInfo {
+	return append([]ModelInfo(nil), knownModels...)
+}
+
+// KnownModelPrefixes returns the model name prefixes WindowForModel recognizes.
+func KnownModelPrefixes() []string {
+	prefixes := make([]string, 0, len(knownModels))
+	for _, info := range knownModels {
+		prefixes = append(prefixes, info.Prefix)
+	}
+
+	return prefixes
+}
+
 // Estimate returns an estimated token count for the given text.
 // Uses the heuristic: 1 token ~= 4 characters.
 func Estimate(text string) int {
@@ -157,3 +157,72 @@ func TestCheckContextFit(t *testing.T) {
 		})
 	}
 }
+
+func TestWindowForModel(t *testing.T) {
+	tests := []struct {
+		model    string
+		expected int
+		ok       bool
+	}{
+		{"gpt-4o", 128_000, true},
+		{"gpt-4o-mini", 128_000, true},
+		{"gpt-5-mini", 400_000, true},
+		{"gpt-3.5-turbo-0125", 16_385, true},
+		{"GPT-4.1", 1_047_576, true},
+		{"o1-mini", 128_000, true},
+		{"o1", 200_000, true},
+		{"o1-preview-2024-09-12", 128_000, true},
+		{"gpt-4.5-preview", 128_000, true},
+		{"gpt-4-turbo-2024-04-09", 128_000, true},
+		{"gpt-4-32k-0613", 32_768, true},
+		{"gpt-4-0613", 8_192, true},
+		{"claude-sonnet-4-20250514", 200_000, true},
+		{"gemini-2.5-flash", 1_048_576, true},
+		{"gemini-1.5-pro-latest", 2_097_152, true},
+		{"llama-3", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			window, ok := WindowForModel(tt.model)
+			if window != tt.expected || ok != tt.ok {
+				t.Errorf("WindowForModel(%q) = %d, %v; want %d, %v", tt.model, window, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestTokenizerForModel(t *testing.T) {
+	tests := map[string]string{
+		"gpt-4o":          TokenizerO200K,
+		"o3-mini":         TokenizerO200K,
+		"gpt-4-0613":      TokenizerCL100K,
+		"claude-opus-4-1": TokenizerClaude,
+		"Gemini-2.5-Pro":  TokenizerGemini,
+		"mistral-large":   "",
+		"":                "",
+	}
+
+	for model, want := range tests {
+		if got := TokenizerForModel(model); got != want {
+			t.Errorf("TokenizerForModel(%q) = %q, want %q", model, got, want)
+		}
+	}
+}
+
+func TestKnownModels(t *testing.T) {
+	models := KnownModels()
+	if len(models) != len(KnownModelPrefixes()) {
+		t.Fatalf("KnownModels() has %d entries, KnownModelPrefixes() %d", len(models), len(KnownModelPrefixes()))
+	}
+	for _, info := range models {
+		if info.Window <= 0 || info.Tokenizer == "" {
+			t.Errorf("model family %q needs a window and a tokenizer, got %+v", info.Prefix, info)
+		}
+		if window, ok := WindowForModel(info.Prefix); !ok || window != info.Window {
+			t.Errorf("WindowForModel(%q) = %d, %v; want %d (is the prefix listed twice?)",
+				info.Prefix, window, ok, info.Window)
+		}
+	}
+}
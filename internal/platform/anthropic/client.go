@@ -14,11 +14,13 @@ const (
 	defaultBaseURL   = "https://api.anthropic.com"
 	anthropicVersion = "2023-06-01"
 	defaultMaxTokens = 8192
+	promptCachingAPI = "prompt-caching-2024-07-31"
 )
 
 // Client is the Anthropic implementation of the LLM provider.
 type Client struct {
 	*llmbase.BaseClient
+	cacheContext bool
 }
 
 // NewClient creates a new Anthropic client with the given configuration.
@@ -34,7 +36,7 @@ func NewClient(cfg llm.Config) (*Client, error) {
 		return nil, err
 	}
 
-	return &Client{BaseClient: base}, nil
+	return &Client{BaseClient: base, cacheContext: cfg.CacheContext}, nil
 }
 
 // Send sends a content string to the Anthropic API and returns the result.
@@ -55,17 +57,89 @@ func (c *Client) SendWithProgress(ctx context.Context, content string, progress
 	return result, nil
 }
 
+// SendStream sends a content string with a streamed response, calling onDelta as text arrives.
+func (c *Client) SendStream(ctx context.Context, content string, onDelta func(delta string)) (*llm.Result, error) {
+	result, err := c.BaseClient.SendStream(ctx, content, c, onDelta)
+	if err != nil {
+		return nil, c.handleError(err)
+	}
+	return result, nil
+}
+
 // BuildRequest constructs the Anthropic-specific request body.
-func (c *Client) BuildRequest(content string) (interface{}, error) {
+// With context caching, the file context becomes the first user block and carries
+// the cache breakpoint, so the system prompt and files are cached as one prefix.
+func (c *Client) BuildRequest(req llm.Request) (interface{}, error) {
+	var content interface{} = req.User
+	if c.cacheContext {
+		if req = req.WithFileContext(); req.Context != "" {
+			content = []TextBlock{
+				{Type: "text", Text: req.Context, CacheControl: &CacheControl{Type: "ephemeral"}},
+				{Type: "text", Text: req.User},
+			}
+		}
+	}
+
 	return MessagesRequest{
 		Model:     c.Model,
 		MaxTokens: c.MaxTokens,
+		System:    req.System,
 		Messages: []Message{
 			{Role: "user", Content: content},
 		},
 	}, nil
 }
 
+// BuildStreamRequest constructs the request body with streaming enabled.
+func (c *Client) BuildStreamRequest(req llm.Request) (interface{}, error) {
+	payload, err := c.BuildRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	msgReq := payload.(MessagesRequest)
+	msgReq.Stream = true
+	return msgReq, nil
+}
+
+// ParseStreamEvent extracts the text of content_block_delta events. Input and cache
+// usage come with message_start, the output token count with message_delta.
+func (c *Client) ParseStreamEvent(_, data string, result *llm.Result) (string, error) {
+	var event StreamEvent
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		return "", fmt.Errorf("failed to parse stream event: %w", err)
+	}
+
+	switch event.Type {
+	case "message_start":
+		if event.Message != nil {
+			usage := event.Message.Usage
+			result.Usage = &llm.Usage{
+				PromptTokens:        usage.InputTokens,
+				CompletionTokens:    usage.OutputTokens,
+				TotalTokens:         usage.InputTokens + usage.OutputTokens,
+				CacheCreationTokens: usage.CacheCreationInputTokens,
+				CacheReadTokens:     usage.CacheReadInputTokens,
+			}
+		}
+	case "content_block_delta":
+		if event.Delta != nil && event.Delta.Type == "text_delta" {
+			return event.Delta.Text, nil
+		}
+	case "message_delta":
+		if event.Usage != nil && result.Usage != nil {
+			result.Usage.CompletionTokens = event.Usage.OutputTokens
+			result.Usage.TotalTokens = result.Usage.PromptTokens + event.Usage.OutputTokens
+		}
+	case "error":
+		if event.Error != nil {
+			return "", fmt.Errorf("API error: %s", event.Error.Message)
+		}
+	}
+
+	return "", nil
+}
+
 // ParseResponse extracts the result from the Anthropic API response.
 func (c *Client) ParseResponse(response interface{}, rawJSON []byte) (*llm.Result, error) {
 	msgResp, ok := response.(*MessagesResponse)
@@ -83,9 +157,11 @@ func (c *Client) ParseResponse(response interface{}, rawJSON []byte) (*llm.Resul
 	var usage *llm.Usage
 	if msgResp.Usage.InputTokens > 0 || msgResp.Usage.OutputTokens > 0 {
 		usage = &llm.Usage{
-			PromptTokens:     msgResp.Usage.InputTokens,
-			CompletionTokens: msgResp.Usage.OutputTokens,
-			TotalTokens:      msgResp.Usage.InputTokens + msgResp.Usage.OutputTokens,
+			PromptTokens:        msgResp.Usage.InputTokens,
+			CompletionTokens:    msgResp.Usage.OutputTokens,
+			TotalTokens:         msgResp.Usage.InputTokens + msgResp.Usage.OutputTokens,
+			CacheCreationTokens: msgResp.Usage.CacheCreationInputTokens,
+			CacheReadTokens:     msgResp.Usage.CacheReadInputTokens,
 		}
 	}
 
@@ -104,11 +180,16 @@ func (c *Client) GetEndpoint() string {
 }
 
 // GetHeaders returns the necessary headers for Anthropic API requests, including the API key and version.
+// Context caching adds the prompt caching beta header.
 func (c *Client) GetHeaders() map[string]string {
-	return map[string]string{
+	headers := map[string]string{
 		"x-api-key":         c.APIKey,
 		"anthropic-version": anthropicVersion,
 	}
+	if c.cacheContext {
+		headers["anthropic-beta"] = promptCachingAPI
+	}
+	return headers
 }
 
 // NewResponse returns a new instance of the Anthropic response structure for unmarshaling.
@@ -109,6 +109,12 @@ func TestClient_Send_APIError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "Invalid API key")
 	assert.Contains(t, err.Error(), "401")
+	assert.ErrorIs(t, err, llm.ErrAuth)
+
+	var apiErr *llm.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "Anthropic", apiErr.Provider)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
 }
 
 func TestClient_NewClient_Validation(t *testing.T) {
@@ -163,3 +169,134 @@ func TestClient_SendWithProgress(t *testing.T) {
 	assert.Contains(t, stages, "Connecting to Anthropic...")
 	assert.Contains(t, stages, "Response received")
 }
+
+func TestClient_BuildRequest_SystemPrompt(t *testing.T) {
+	client, err := NewClient(llm.Config{APIKey: "test-key"})
+	require.NoError(t, err)
+
+	body, err := client.BuildRequest(llm.Request{System: "You are a reviewer.", User: "Review this code."})
+	require.NoError(t, err)
+
+	req, ok := body.(MessagesRequest)
+	require.True(t, ok)
+	assert.Equal(t, "You are a reviewer.", req.System)
+	assert.Equal(t, []Message{{Role: "user", Content: "Review this code."}}, req.Messages)
+}
+
+func TestClient_Send_CacheContext(t *testing.T) {
+	prompt := "Fix the bug.\n{END_SYSTEM}\n## Task\nFind the leak.\n\n## Files\n" +
+		"<file path=\"main.go\">\npackage main\n</file>\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, promptCachingAPI, r.Header.Get("anthropic-beta"))
+
+		var body struct {
+			System   string `json:"system"`
+			Messages []struct {
+				Content []TextBlock `json:"content"`
+			} `json:"messages"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "Fix the bug.", body.System)
+		require.Len(t, body.Messages, 1)
+		assert.Equal(t, []TextBlock{
+			{Type: "text", Text: "<file path=\"main.go\">\npackage main\n</file>",
+				CacheControl: &CacheControl{Type: "ephemeral"}},
+			{Type: "text", Text: "## Task\nFind the leak.\n\n## Files\n"},
+		}, body.Messages[0].Content)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(MessagesResponse{
+			Content: []ContentBlock{{Type: "text", Text: "Done"}},
+			Usage: UsageAPI{
+				InputTokens:              12,
+				OutputTokens:             3,
+				CacheCreationInputTokens: 0,
+				CacheReadInputTokens:     4000,
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(llm.Config{APIKey: "test-key", BaseURL: server.URL, CacheContext: true})
+	require.NoError(t, err)
+
+	result, err := client.Send(context.Background(), prompt)
+	require.NoError(t, err)
+	assert.Equal(t, 4000, result.Usage.CacheReadTokens)
+	assert.Equal(t, 0, result.Usage.CacheCreationTokens)
+}
+
+func TestClient_CacheContextDisabled(t *testing.T) {
+	client, err := NewClient(llm.Config{APIKey: "test-key"})
+	require.NoError(t, err)
+
+	assert.NotContains(t, client.GetHeaders(), "anthropic-beta")
+
+	user := "Task\n<file path=\"a.go\">\npackage a\n</file>\n"
+	body, err := client.BuildRequest(llm.Request{User: user})
+	require.NoError(t, err)
+	assert.Equal(t, []Message{{Role: "user", Content: user}}, body.(MessagesRequest).Messages)
+}
+
+func TestClient_SendStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body MessagesRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.True(t, body.Stream)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte(`event: message_start
+data: {"type":"message_start","message":{"usage":{"input_tokens":10,"output_tokens":1,"cache_read_input_tokens":7}}}
+
+event: ping
+data: {"type":"ping"}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hello"}}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":", world"}}
+
+event: message_delta
+data: {"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":5}}
+
+event: message_stop
+data: {"type":"message_stop"}
+
+`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(llm.Config{APIKey: "test-key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	var deltas []string
+	result, err := client.SendStream(context.Background(), "Hi", func(delta string) {
+		deltas = append(deltas, delta)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Hello", ", world"}, deltas)
+	assert.Equal(t, "Hello, world", result.Response)
+	require.NotNil(t, result.Usage)
+	assert.Equal(t, 10, result.Usage.PromptTokens)
+	assert.Equal(t, 5, result.Usage.CompletionTokens)
+	assert.Equal(t, 15, result.Usage.TotalTokens)
+	assert.Equal(t, 7, result.Usage.CacheReadTokens)
+}
+
+func TestClient_SendStream_ErrorEvent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("event: error\n" +
+			`data: {"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}` + "\n\n"))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(llm.Config{APIKey: "test-key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	_, err = client.SendStream(context.Background(), "Hi", nil)
+	assert.ErrorContains(t, err, "Overloaded")
+}
@@ -12,8 +12,20 @@ type MessagesRequest struct {
 
 // Message represents a chat message.
 type Message struct {
-	Role    string `json:"role"` // "user" or "assistant"
-	Content string `json:"content"`
+	Role    string      `json:"role"`    // "user" or "assistant"
+	Content interface{} `json:"content"` // string or []TextBlock
+}
+
+// TextBlock is a text content block of a request message.
+type TextBlock struct {
+	Type         string        `json:"type"` // "text"
+	Text         string        `json:"text"`
+	CacheControl *CacheControl `json:"cache_control,omitempty"`
+}
+
+// CacheControl marks the prompt prefix ending with a block as cacheable.
+type CacheControl struct {
+	Type string `json:"type"` // "ephemeral"
 }
 
 // MessagesResponse represents the API response.
@@ -34,10 +46,32 @@ type ContentBlock struct {
 	Text string `json:"text"`
 }
 
+// StreamEvent is one server-sent event of a streamed response. Which fields are set
+// depends on Type: message_start carries Message, content_block_delta a text Delta,
+// message_delta the final Usage and error an Error.
+type StreamEvent struct {
+	Type    string            `json:"type"`
+	Message *MessagesResponse `json:"message,omitempty"`
+	Delta   *StreamDelta      `json:"delta,omitempty"`
+	Usage   *UsageAPI         `json:"usage,omitempty"`
+	Error   *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// StreamDelta is the change carried by a delta event.
+type StreamDelta struct {
+	Type string `json:"type"` // "text_delta" for response text
+	Text string `json:"text"`
+}
+
 // UsageAPI represents token usage.
 type UsageAPI struct {
-	InputTokens  int `json:"input_tokens"`
-	OutputTokens int `json:"output_tokens"`
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens,omitempty"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens,omitempty"`
 }
 
 // ErrorResponse represents an API error.
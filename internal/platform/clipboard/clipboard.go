@@ -3,11 +3,23 @@
 package clipboard
 
 import (
+	"crypto/sha256"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/atotto/clipboard"
 )
 
+// ErrReadUnsupported is returned by Verify when the clipboard cannot be read back.
+var ErrReadUnsupported = errors.New("reading the clipboard is not supported on this system")
+
+// readAll and readUnsupported read the clipboard back; tests replace them.
+var (
+	readAll         = clipboard.ReadAll
+	readUnsupported = clipboard.Unsupported
+)
+
 // ClipboardError represents an error during clipboard operations
 type ClipboardError struct {
 	Err error
@@ -35,3 +47,30 @@ func Copy(content string) error {
 func IsAvailable() bool {
 	return !clipboard.Unsupported
 }
+
+// Verify reads the clipboard back and reports whether it holds expected, comparing
+// length and SHA-256 hash. Line endings are normalized first, since some backends
+// convert them. It returns ErrReadUnsupported, or a ClipboardError when the read
+// fails, so callers can tell an unverifiable copy from a truncated one.
+func Verify(expected string) (bool, error) {
+	if readUnsupported {
+		return false, ErrReadUnsupported
+	}
+
+	actual, err := readAll()
+	if err != nil {
+		return false, &ClipboardError{Err: err}
+	}
+
+	expected = normalizeLineEndings(expected)
+	actual = normalizeLineEndings(actual)
+	if len(actual) != len(expected) {
+		return false, nil
+	}
+
+	return sha256.Sum256([]byte(actual)) == sha256.Sum256([]byte(expected)), nil
+}
+
+func normalizeLineEndings(s string) string {
+	return strings.ReplaceAll(s, "\r\n", "\n")
+}
@@ -1,6 +1,7 @@
 package clipboard
 
 import (
+	"errors"
 	"strings"
 	"testing"
 )
@@ -58,6 +59,56 @@ func TestCopySuccess(t *testing.T) {
 	}
 }
 
+func TestVerify(t *testing.T) {
+	originalRead, originalUnsupported := readAll, readUnsupported
+	t.Cleanup(func() { readAll, readUnsupported = originalRead, originalUnsupported })
+	readUnsupported = false
+
+	tests := []struct {
+		name     string
+		expected string
+		held     string
+		want     bool
+	}{
+		{"identical", "line1\nline2", "line1\nline2", true},
+		{"crlf converted", "line1\nline2\n", "line1\r\nline2\r\n", true},
+		{"truncated", strings.Repeat("x", 10000), strings.Repeat("x", 4096), false},
+		{"same length differs", "abc", "abd", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readAll = func() (string, error) { return tt.held, nil }
+
+			ok, err := Verify(tt.expected)
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if ok != tt.want {
+				t.Errorf("Verify() = %v, want %v", ok, tt.want)
+			}
+		})
+	}
+
+	t.Run("read error", func(t *testing.T) {
+		readAll = func() (string, error) { return "", errors.New("no selection owner") }
+
+		ok, err := Verify("x")
+		var clipErr *ClipboardError
+		if ok || !errors.As(err, &clipErr) {
+			t.Fatalf("Verify() = %v, %v; want false with a ClipboardError", ok, err)
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		readUnsupported = true
+
+		if _, err := Verify("x"); !errors.Is(err, ErrReadUnsupported) {
+			t.Fatalf("Verify() error = %v, want ErrReadUnsupported", err)
+		}
+	})
+}
+
 // Note: Copy function cannot be easily unit tested without mocking the system clipboard.
 // The atotto/clipboard library handles platform-specific operations internally.
 // Integration tests should verify clipboard functionality on actual systems.
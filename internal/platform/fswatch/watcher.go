@@ -0,0 +1,156 @@
+// Package fswatch watches a directory tree and reports debounced batches of changes.
+// It wraps fsnotify, which only watches single directories, by adding every
+// directory under the root and following directories created later.
+package fswatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDebounce is the quiet period used when Options.Debounce is zero.
+const DefaultDebounce = 500 * time.Millisecond
+
+// Options controls which changes are reported and how they are grouped.
+type Options struct {
+	// Debounce is how long the tree must stay quiet before a batch is reported.
+	// Events arriving within this window are coalesced into one batch.
+	Debounce time.Duration
+	// Skip reports whether a path, relative to the root with forward slashes,
+	// should be ignored. Skipped directories are not watched at all.
+	Skip func(relPath string, isDir bool) bool
+}
+
+// Watch blocks until ctx is cancelled, calling onChange with the sorted relative
+// paths changed in each debounced batch. onChange runs on the watching goroutine,
+// so events that arrive while it runs are reported in the next batch.
+// It returns nil on cancellation and an error if the watcher cannot be set up or fails.
+func Watch(ctx context.Context, root string, opts Options, onChange func(changed []string)) error {
+	if opts.Debounce <= 0 {
+		opts.Debounce = DefaultDebounce
+	}
+	if opts.Skip == nil {
+		opts.Skip = func(string, bool) bool { return false }
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	defer func() { _ = watcher.Close() }()
+
+	w := &treeWatcher{root: root, opts: opts, watcher: watcher}
+	if err := w.addTree(root); err != nil {
+		return err
+	}
+
+	pending := make(map[string]bool)
+	timer := time.NewTimer(opts.Debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if rel, relevant := w.handle(event); relevant {
+				pending[rel] = true
+				timer.Reset(opts.Debounce)
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			return fmt.Errorf("file watcher failed: %w", err)
+
+		case <-timer.C:
+			if len(pending) == 0 {
+				continue
+			}
+			changed := make([]string, 0, len(pending))
+			for rel := range pending {
+				changed = append(changed, rel)
+			}
+			sort.Strings(changed)
+			clear(pending)
+
+			onChange(changed)
+		}
+	}
+}
+
+type treeWatcher struct {
+	root    string
+	opts    Options
+	watcher *fsnotify.Watcher
+}
+
+// addTree watches dir and every directory below it that is not skipped.
+func (w *treeWatcher) addTree(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Directories can vanish between the event and the walk
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != w.root && w.opts.Skip(w.rel(path), true) {
+			return filepath.SkipDir
+		}
+		if err := w.watcher.Add(path); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// handle starts watching newly created directories and reports whether the event
+// should trigger a rebuild, along with its relative path.
+func (w *treeWatcher) handle(event fsnotify.Event) (string, bool) {
+	if event.Op == fsnotify.Chmod {
+		return "", false
+	}
+
+	rel := w.rel(event.Name)
+	isDir := false
+	if info, err := os.Stat(event.Name); err == nil {
+		isDir = info.IsDir()
+	}
+
+	if w.opts.Skip(rel, isDir) {
+		return "", false
+	}
+
+	if isDir && event.Has(fsnotify.Create) {
+		// Best effort: a directory removed right after creation is simply not watched
+		_ = w.addTree(event.Name)
+	}
+
+	return rel, true
+}
+
+func (w *treeWatcher) rel(path string) string {
+	rel, err := filepath.Rel(w.root, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
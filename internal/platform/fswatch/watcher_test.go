@@ -0,0 +1,103 @@
+package fswatch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDebounce = 100 * time.Millisecond
+
+// startWatch runs Watch in the background and returns a channel of reported batches.
+func startWatch(t *testing.T, root string, opts Options) <-chan []string {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	batches := make(chan []string, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- Watch(ctx, root, opts, func(changed []string) { batches <- changed })
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(2 * time.Second):
+			t.Error("Watch did not return after cancellation")
+		}
+	})
+
+	// Give the watcher time to register the tree
+	time.Sleep(50 * time.Millisecond)
+	return batches
+}
+
+func waitBatch(t *testing.T, batches <-chan []string) []string {
+	t.Helper()
+
+	select {
+	case batch := <-batches:
+		return batch
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for a change batch")
+		return nil
+	}
+}
+
+func assertNoBatch(t *testing.T, batches <-chan []string) {
+	t.Helper()
+
+	select {
+	case batch := <-batches:
+		t.Fatalf("unexpected change batch: %v", batch)
+	case <-time.After(3 * testDebounce):
+	}
+}
+
+func TestWatch_CoalescesBurst(t *testing.T) {
+	root := t.TempDir()
+	batches := startWatch(t, root, Options{Debounce: testDebounce})
+
+	for _, name := range []string{"a.go", "b.go", "c.go"} {
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), []byte("package x\n"), 0o600))
+	}
+
+	assert.Equal(t, []string{"a.go", "b.go", "c.go"}, waitBatch(t, batches))
+	assertNoBatch(t, batches)
+}
+
+func TestWatch_SkipsIgnoredPaths(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, "cache"), 0o750))
+
+	skip := func(rel string, _ bool) bool {
+		return rel == "cache" || strings.HasSuffix(rel, ".log")
+	}
+	batches := startWatch(t, root, Options{Debounce: testDebounce, Skip: skip})
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "debug.log"), []byte("x"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "cache", "entry"), []byte("x"), 0o600))
+	assertNoBatch(t, batches)
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n"), 0o600))
+	assert.Equal(t, []string{"main.go"}, waitBatch(t, batches))
+}
+
+func TestWatch_FollowsNewDirectories(t *testing.T) {
+	root := t.TempDir()
+	batches := startWatch(t, root, Options{Debounce: testDebounce})
+
+	require.NoError(t, os.Mkdir(filepath.Join(root, "pkg"), 0o750))
+	assert.Equal(t, []string{"pkg"}, waitBatch(t, batches))
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "pkg", "util.go"), []byte("package pkg\n"), 0o600))
+	assert.Equal(t, []string{"pkg/util.go"}, waitBatch(t, batches))
+}
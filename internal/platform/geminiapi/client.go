@@ -3,6 +3,10 @@ package geminiapi
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/quantmind-br/shotgun-cli/internal/core/llm"
@@ -53,18 +57,67 @@ func (c *Client) SendWithProgress(ctx context.Context, content string, progress
 	return result, nil
 }
 
+// SendStream sends a prompt and delivers the whole response in a single onDelta call,
+// since this client does not use Gemini's streaming endpoint.
+func (c *Client) SendStream(ctx context.Context, content string, onDelta func(delta string)) (*llm.Result, error) {
+	result, err := c.Send(ctx, content)
+	if err != nil {
+		return nil, err
+	}
+	if onDelta != nil && result.Response != "" {
+		onDelta(result.Response)
+	}
+	return result, nil
+}
+
+// ListModels returns the models that support generateContent, without the "models/"
+// prefix of their resource names.
+func (c *Client) ListModels(ctx context.Context) ([]string, error) {
+	var ids []string
+	pageToken := ""
+	for {
+		path := "/models?pageSize=1000&key=" + url.QueryEscape(c.APIKey)
+		if pageToken != "" {
+			path += "&pageToken=" + url.QueryEscape(pageToken)
+		}
+
+		var resp ListModelsResponse
+		if err := c.FetchModels(ctx, path, nil, &resp); err != nil {
+			return nil, c.handleError(err)
+		}
+		for _, model := range resp.Models {
+			methods := model.SupportedGenerationMethods
+			if len(methods) == 0 || slices.Contains(methods, "generateContent") {
+				ids = append(ids, strings.TrimPrefix(model.Name, "models/"))
+			}
+		}
+
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	sort.Strings(ids)
+	return ids, nil
+}
+
 // BuildRequest constructs the Gemini-specific request body.
-func (c *Client) BuildRequest(content string) (interface{}, error) {
-	return GenerateRequest{
+func (c *Client) BuildRequest(req llm.Request) (interface{}, error) {
+	body := GenerateRequest{
 		Contents: []Content{
 			{
-				Parts: []Part{{Text: content}},
+				Parts: []Part{{Text: req.User}},
 			},
 		},
 		GenerationConfig: &GenerationConfig{
 			MaxOutputTokens: c.MaxTokens,
 		},
-	}, nil
+	}
+	if req.System != "" {
+		body.SystemInstruction = &Content{Parts: []Part{{Text: req.System}}}
+	}
+	return body, nil
 }
 
 // ParseResponse extracts the result from the Gemini API response.
@@ -105,9 +158,12 @@ func (c *Client) ParseResponse(response interface{}, rawJSON []byte) (*llm.Resul
 	}, nil
 }
 
-// GetEndpoint returns the Gemini API endpoint including the model and API key.
+// GetEndpoint returns the Gemini API endpoint including the model and API key. It is
+// relative to the base URL, so a proxied or regional endpoint keeps its path prefix. A
+// model given by its resource name, such as "models/gemini-2.5-flash", is accepted.
 func (c *Client) GetEndpoint() string {
-	return fmt.Sprintf("/models/%s:generateContent?key=%s", c.Model, c.APIKey)
+	model := strings.TrimPrefix(c.Model, "models/")
+	return fmt.Sprintf("/models/%s:generateContent?key=%s", url.PathEscape(model), url.QueryEscape(c.APIKey))
 }
 
 // GetHeaders returns the necessary headers for Gemini API requests.
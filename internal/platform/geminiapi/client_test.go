@@ -62,6 +62,33 @@ func TestClient_Send_Success(t *testing.T) {
 	assert.Equal(t, 5, result.Usage.CompletionTokens)
 }
 
+func TestClient_Send_CustomBaseURL(t *testing.T) {
+	var gotPath, gotKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotKey = r.URL.Query().Get("key")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(GenerateResponse{
+			Candidates: []Candidate{{Content: Content{Parts: []Part{{Text: "ok"}}}}},
+		})
+	}))
+	defer server.Close()
+
+	// A proxied endpoint with a path prefix and a trailing slash
+	client, err := NewClient(llm.Config{
+		APIKey:  "key+/=",
+		BaseURL: server.URL + "/europe/gemini/v1beta/",
+		Model:   "models/gemini-2.5-pro",
+	})
+	require.NoError(t, err)
+
+	_, err = client.Send(context.Background(), "test prompt")
+	require.NoError(t, err)
+
+	assert.Equal(t, "/europe/gemini/v1beta/models/gemini-2.5-pro:generateContent", gotPath)
+	assert.Equal(t, "key+/=", gotKey)
+}
+
 func TestClient_Send_MultipleParts(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		resp := GenerateResponse{
@@ -192,3 +219,71 @@ func TestClient_SendWithProgress(t *testing.T) {
 	assert.Contains(t, stages, "Connecting to Gemini...")
 	assert.Contains(t, stages, "Response received")
 }
+
+func TestClient_SendStream_SingleDelta(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := GenerateResponse{
+			Candidates: []Candidate{
+				{Content: Content{Parts: []Part{{Text: "Hello!"}}}},
+			},
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(llm.Config{APIKey: "test-key", BaseURL: server.URL, Model: "gemini-2.5-flash"})
+
+	var deltas []string
+	result, err := client.SendStream(context.Background(), "test", func(delta string) {
+		deltas = append(deltas, delta)
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Hello!", result.Response)
+	assert.Equal(t, []string{"Hello!"}, deltas)
+}
+
+func TestClient_BuildRequest_SystemPrompt(t *testing.T) {
+	client, err := NewClient(llm.Config{APIKey: "test-key"})
+	require.NoError(t, err)
+
+	body, err := client.BuildRequest(llm.Request{System: "You are a reviewer.", User: "Review this code."})
+	require.NoError(t, err)
+
+	req, ok := body.(GenerateRequest)
+	require.True(t, ok)
+	require.NotNil(t, req.SystemInstruction)
+	assert.Equal(t, "You are a reviewer.", req.SystemInstruction.Parts[0].Text)
+	assert.Equal(t, "Review this code.", req.Contents[0].Parts[0].Text)
+
+	body, err = client.BuildRequest(llm.Request{User: "prompt"})
+	require.NoError(t, err)
+	assert.Nil(t, body.(GenerateRequest).SystemInstruction)
+}
+
+func TestClient_ListModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/v1beta/models", r.URL.Path)
+		assert.Equal(t, "test-key", r.URL.Query().Get("key"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("pageToken") == "" {
+			_, _ = w.Write([]byte(`{"models":[
+				{"name":"models/gemini-2.5-pro","supportedGenerationMethods":["generateContent","countTokens"]},
+				{"name":"models/text-embedding-004","supportedGenerationMethods":["embedContent"]}
+			],"nextPageToken":"page2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"models":[
+			{"name":"models/gemini-2.5-flash","supportedGenerationMethods":["generateContent"]}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(llm.Config{APIKey: "test-key", BaseURL: server.URL + "/v1beta"})
+	require.NoError(t, err)
+
+	models, err := client.ListModels(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gemini-2.5-flash", "gemini-2.5-pro"}, models)
+}
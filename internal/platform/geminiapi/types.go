@@ -3,9 +3,10 @@ package geminiapi
 
 // GenerateRequest represents the request body.
 type GenerateRequest struct {
-	Contents         []Content         `json:"contents"`
-	GenerationConfig *GenerationConfig `json:"generationConfig,omitempty"`
-	SafetySettings   []SafetySetting   `json:"safetySettings,omitempty"`
+	Contents          []Content         `json:"contents"`
+	SystemInstruction *Content          `json:"systemInstruction,omitempty"`
+	GenerationConfig  *GenerationConfig `json:"generationConfig,omitempty"`
+	SafetySettings    []SafetySetting   `json:"safetySettings,omitempty"`
 }
 
 // Content represents message content.
@@ -61,3 +62,15 @@ type APIError struct {
 	Message string `json:"message"`
 	Status  string `json:"status"`
 }
+
+// ListModelsResponse is a page of the models endpoint.
+type ListModelsResponse struct {
+	Models        []ModelInfo `json:"models"`
+	NextPageToken string      `json:"nextPageToken"`
+}
+
+// ModelInfo describes a model; Name is its resource name, e.g. "models/gemini-2.5-flash".
+type ModelInfo struct {
+	Name                       string   `json:"name"`
+	SupportedGenerationMethods []string `json:"supportedGenerationMethods"`
+}
@@ -0,0 +1,158 @@
+// Package git provides a thin wrapper around the git command-line tool.
+// It is used to discover changed files so context can be built from a diff.
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ErrNotRepository is returned when the given directory is not inside a git work tree.
+var ErrNotRepository = errors.New("not a git repository")
+
+// DiffOptions controls which changes are reported by ChangedFiles.
+type DiffOptions struct {
+	// Staged restricts the diff to changes in the index (git diff --staged).
+	Staged bool
+	// Base compares against the given ref instead of the index/HEAD.
+	Base string
+}
+
+// IsAvailable checks if the git binary can be found in PATH.
+func IsAvailable() bool {
+	_, err := exec.LookPath("git")
+	return err == nil
+}
+
+// IsRepository reports whether dir is inside a git work tree.
+func IsRepository(ctx context.Context, dir string) bool {
+	out, err := run(ctx, dir, "rev-parse", "--is-inside-work-tree")
+	return err == nil && strings.TrimSpace(out) == "true"
+}
+
+// CurrentBranch returns the short name of the branch checked out in dir. It fails
+// when dir is not in a repository or HEAD is detached.
+func CurrentBranch(ctx context.Context, dir string) (string, error) {
+	out, err := run(ctx, dir, "symbolic-ref", "--short", "HEAD")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(out), nil
+}
+
+// LastCommit returns the abbreviated hash and subject of the commit checked out in
+// dir, e.g. "1c8b830 Add config get". It fails when the repository has no commits.
+func LastCommit(ctx context.Context, dir string) (string, error) {
+	out, err := run(ctx, dir, "log", "-1", "--format=%h %s")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(out), nil
+}
+
+// ShortStatus returns the short status of the work tree (git status --short).
+func ShortStatus(ctx context.Context, dir string) (string, error) {
+	out, err := run(ctx, dir, "status", "--short")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(out, "\n"), nil
+}
+
+// DiffStat returns the diffstat of the uncommitted changes in dir against HEAD,
+// staged or not (git diff --stat HEAD).
+func DiffStat(ctx context.Context, dir string) (string, error) {
+	out, err := run(ctx, dir, "diff", "--stat", "--no-color", "HEAD")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(out, "\n"), nil
+}
+
+// ChangedFiles returns the paths of changed files relative to dir.
+// Paths are reported with forward slashes and unquoted, even when they hold
+// non-ASCII characters that git would otherwise escape (core.quotePath).
+func ChangedFiles(ctx context.Context, dir string, opts DiffOptions) ([]string, error) {
+	out, err := diff(ctx, dir, opts, "--name-only", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, name := range strings.Split(out, "\x00") {
+		if name != "" {
+			files = append(files, name)
+		}
+	}
+
+	return files, nil
+}
+
+// UnifiedDiff returns the zero-context unified diff (git diff -U0) for dir,
+// with paths relative to dir. The paths keep the a/ and b/ prefixes whatever
+// diff.noprefix or diff.mnemonicPrefix say.
+func UnifiedDiff(ctx context.Context, dir string, opts DiffOptions) (string, error) {
+	return diff(ctx, dir, opts, "-U0", "--no-color", "--no-ext-diff", "--src-prefix=a/", "--dst-prefix=b/")
+}
+
+func diff(ctx context.Context, dir string, opts DiffOptions, extra ...string) (string, error) {
+	if !IsAvailable() {
+		return "", fmt.Errorf("git executable not found in PATH")
+	}
+	if !IsRepository(ctx, dir) {
+		return "", fmt.Errorf("%s: %w", dir, ErrNotRepository)
+	}
+
+	args := append([]string{"diff", "--relative"}, extra...)
+	if opts.Staged {
+		args = append(args, "--staged")
+	}
+	if opts.Base != "" {
+		base, err := resolveCommit(ctx, dir, opts.Base)
+		if err != nil {
+			return "", err
+		}
+		args = append(args, base, "--")
+	}
+
+	return run(ctx, dir, args...)
+}
+
+// resolveCommit returns the hash of the commit ref names, so a ref is never
+// passed on to git where an option could be read from it.
+func resolveCommit(ctx context.Context, dir, ref string) (string, error) {
+	if strings.HasPrefix(ref, "-") {
+		return "", fmt.Errorf("invalid git ref %q: refs cannot start with '-'", ref)
+	}
+	out, err := run(ctx, dir, "rev-parse", "--verify", "--quiet", "--end-of-options", ref+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("unknown git ref %q", ref)
+	}
+
+	return strings.TrimSpace(out), nil
+}
+
+func run(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...) //nolint:gosec // fixed binary
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			return "", fmt.Errorf("git %s failed: %w", args[0], err)
+		}
+		return "", fmt.Errorf("git %s failed: %s", args[0], msg)
+	}
+
+	return stdout.String(), nil
+}
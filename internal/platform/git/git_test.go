@@ -0,0 +1,198 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func initRepo(t *testing.T) string {
+	t.Helper()
+	if !IsAvailable() {
+		t.Skip("git not available in this environment")
+	}
+
+	dir := t.TempDir()
+	gitCmd(t, dir, "init", "-q")
+	gitCmd(t, dir, "config", "user.email", "test@example.com")
+	gitCmd(t, dir, "config", "user.name", "test")
+	gitCmd(t, dir, "config", "commit.gpgsign", "false")
+
+	writeFile(t, dir, "a.go", "package a\n")
+	writeFile(t, dir, "b.go", "package b\n")
+	gitCmd(t, dir, "add", ".")
+	gitCmd(t, dir, "commit", "-q", "-m", "initial")
+
+	return dir
+}
+
+func gitCmd(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestChangedFiles_NotRepository(t *testing.T) {
+	if !IsAvailable() {
+		t.Skip("git not available in this environment")
+	}
+
+	_, err := ChangedFiles(context.Background(), t.TempDir(), DiffOptions{})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrNotRepository))
+}
+
+func TestChangedFiles_WorkingTree(t *testing.T) {
+	dir := initRepo(t)
+	writeFile(t, dir, "a.go", "package a\n\nfunc A() {}\n")
+
+	files, err := ChangedFiles(context.Background(), dir, DiffOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.go"}, files)
+}
+
+func TestChangedFiles_Staged(t *testing.T) {
+	dir := initRepo(t)
+	writeFile(t, dir, "a.go", "package a\n\nfunc A() {}\n")
+	writeFile(t, dir, "b.go", "package b\n\nfunc B() {}\n")
+	gitCmd(t, dir, "add", "b.go")
+
+	files, err := ChangedFiles(context.Background(), dir, DiffOptions{Staged: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b.go"}, files)
+}
+
+func TestChangedFiles_Base(t *testing.T) {
+	dir := initRepo(t)
+	gitCmd(t, dir, "tag", "v1")
+	writeFile(t, dir, "pkg/c.go", "package pkg\n")
+	gitCmd(t, dir, "add", ".")
+	gitCmd(t, dir, "commit", "-q", "-m", "second")
+
+	files, err := ChangedFiles(context.Background(), dir, DiffOptions{Base: "v1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pkg/c.go"}, files)
+}
+
+func TestChangedFiles_InvalidBase(t *testing.T) {
+	dir := initRepo(t)
+	target := filepath.Join(t.TempDir(), "x")
+
+	_, err := ChangedFiles(context.Background(), dir, DiffOptions{Base: "--output=" + target})
+	assert.ErrorContains(t, err, "refs cannot start with '-'")
+	assert.NoFileExists(t, target)
+
+	_, err = ChangedFiles(context.Background(), dir, DiffOptions{Base: "no-such-branch"})
+	assert.ErrorContains(t, err, `unknown git ref "no-such-branch"`)
+}
+
+func TestChangedFiles_RelativeToSubdirectory(t *testing.T) {
+	dir := initRepo(t)
+	writeFile(t, dir, "pkg/c.go", "package pkg\n")
+	gitCmd(t, dir, "add", ".")
+	gitCmd(t, dir, "commit", "-q", "-m", "second")
+	writeFile(t, dir, "pkg/c.go", "package pkg\n\nvar C = 1\n")
+	writeFile(t, dir, "a.go", "package a\n\nvar A = 1\n")
+
+	files, err := ChangedFiles(context.Background(), filepath.Join(dir, "pkg"), DiffOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c.go"}, files)
+}
+
+func TestChangedFiles_NonASCIIPath(t *testing.T) {
+	dir := initRepo(t)
+	writeFile(t, dir, "ção.go", "package a\n")
+	writeFile(t, dir, "with space.go", "package a\n")
+	gitCmd(t, dir, "add", ".")
+
+	files, err := ChangedFiles(context.Background(), dir, DiffOptions{Staged: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"with space.go", "ção.go"}, files)
+}
+
+func TestUnifiedDiff_ZeroContext(t *testing.T) {
+	dir := initRepo(t)
+	writeFile(t, dir, "a.go", "package a\n\nfunc A() {}\n")
+
+	out, err := UnifiedDiff(context.Background(), dir, DiffOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, out, "+++ b/a.go")
+	assert.Contains(t, out, "@@ -1,0 +2,2 @@")
+	assert.Contains(t, out, "+func A() {}")
+}
+
+func TestUnifiedDiff_PrefixConfig(t *testing.T) {
+	for _, config := range []string{"diff.mnemonicPrefix", "diff.noprefix"} {
+		t.Run(config, func(t *testing.T) {
+			dir := initRepo(t)
+			gitCmd(t, dir, "config", config, "true")
+			writeFile(t, dir, "a.go", "package a\n\nfunc A() {}\n")
+
+			out, err := UnifiedDiff(context.Background(), dir, DiffOptions{})
+			require.NoError(t, err)
+			assert.Contains(t, out, "+++ b/a.go")
+		})
+	}
+}
+
+func TestCurrentBranch(t *testing.T) {
+	dir := initRepo(t)
+	gitCmd(t, dir, "checkout", "-q", "-b", "feature/login")
+
+	branch, err := CurrentBranch(context.Background(), dir)
+	require.NoError(t, err)
+	assert.Equal(t, "feature/login", branch)
+
+	gitCmd(t, dir, "checkout", "-q", "--detach")
+	_, err = CurrentBranch(context.Background(), dir)
+	assert.Error(t, err)
+}
+
+func TestRepositoryMetadata(t *testing.T) {
+	dir := initRepo(t)
+	writeFile(t, dir, "a.go", "package a\n\nfunc A() {}\n")
+	writeFile(t, dir, "new.go", "package a\n")
+	ctx := context.Background()
+
+	commit, err := LastCommit(ctx, dir)
+	require.NoError(t, err)
+	assert.Regexp(t, `^[0-9a-f]+ initial$`, commit)
+
+	status, err := ShortStatus(ctx, dir)
+	require.NoError(t, err)
+	assert.Equal(t, " M a.go\n?? new.go", status)
+
+	stat, err := DiffStat(ctx, dir)
+	require.NoError(t, err)
+	assert.Contains(t, stat, "a.go | 2 ++")
+	assert.Contains(t, stat, "1 file changed, 2 insertions(+)")
+}
+
+func TestRepositoryMetadata_NotRepository(t *testing.T) {
+	if !IsAvailable() {
+		t.Skip("git not available in this environment")
+	}
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	_, err := LastCommit(ctx, dir)
+	assert.Error(t, err)
+	_, err = ShortStatus(ctx, dir)
+	assert.Error(t, err)
+	_, err = DiffStat(ctx, dir)
+	assert.Error(t, err)
+}
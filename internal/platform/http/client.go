@@ -1,12 +1,14 @@
 package http
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	nethttp "net/http"
+	"strings"
 	"time"
 )
 
@@ -19,10 +21,13 @@ type JSONClient struct {
 
 // ClientConfig holds configuration for the JSONClient.
 type ClientConfig struct {
-	// BaseURL is the base URL for the API (e.g. "https://api.openai.com/v1").
+	// BaseURL is the base URL for the API (e.g. "https://api.openai.com/v1"). Request
+	// paths are appended to it, so a trailing slash is dropped.
 	BaseURL string
 	// Timeout is the maximum duration for a request.
 	Timeout time.Duration
+	// Transport sends the requests; nil uses nethttp.DefaultTransport (see NewTransport).
+	Transport nethttp.RoundTripper
 }
 
 // NewJSONClient creates a new JSONClient with the given configuration.
@@ -33,8 +38,8 @@ func NewJSONClient(cfg ClientConfig) *JSONClient {
 		timeout = 300 * time.Second
 	}
 	return &JSONClient{
-		httpClient: &nethttp.Client{Timeout: timeout},
-		baseURL:    cfg.BaseURL,
+		httpClient: &nethttp.Client{Timeout: timeout, Transport: cfg.Transport},
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
 	}
 }
 
@@ -82,6 +87,43 @@ func (c *JSONClient) PostJSON(ctx context.Context, path string, headers map[stri
 	return nil
 }
 
+// GetJSON sends a GET request and unmarshals the response into target. Returns an
+// *HTTPError if the status code is not OK.
+func (c *JSONClient) GetJSON(ctx context.Context, path string, headers map[string]string, target interface{}) error {
+	req, err := nethttp.NewRequestWithContext(ctx, nethttp.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != nethttp.StatusOK {
+		return &HTTPError{StatusCode: resp.StatusCode, Body: respBody}
+	}
+
+	if err := json.Unmarshal(respBody, target); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return nil
+}
+
 // PostJSONWithProgress posts JSON with progress reporting.
 // It behaves like PostJSON but invokes the progressFn callback during the operation.
 func (c *JSONClient) PostJSONWithProgress(ctx context.Context, path string, headers map[string]string, body interface{}, target interface{}, progressFn ProgressCallback) ([]byte, error) {
@@ -131,6 +173,93 @@ func (c *JSONClient) PostJSONWithProgress(ctx context.Context, path string, head
 	return respBody, nil
 }
 
+// maxStreamLine bounds a single line of a server-sent event stream.
+const maxStreamLine = 1024 * 1024
+
+// PostStream sends a POST request with the given body marshaled as JSON and reads the
+// response as a stream of server-sent events, calling onEvent with the event type
+// (empty when the stream does not name events) and data of each event in order.
+// An error returned by onEvent stops reading and is returned as is.
+func (c *JSONClient) PostStream(
+	ctx context.Context, path string, headers map[string]string, body interface{},
+	onEvent func(event, data string) error,
+) error {
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := c.baseURL + path
+	req, err := nethttp.NewRequestWithContext(ctx, nethttp.MethodPost, url, bytes.NewReader(jsonBody))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != nethttp.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return &HTTPError{StatusCode: resp.StatusCode, Body: respBody}
+	}
+
+	return readEvents(resp.Body, onEvent)
+}
+
+// readEvents parses a server-sent event stream. Data lines of one event are joined
+// with newlines; comments and unknown fields are ignored.
+func readEvents(r io.Reader, onEvent func(event, data string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLine)
+
+	var event string
+	var data []string
+	dispatch := func() error {
+		if len(data) == 0 {
+			event = ""
+			return nil
+		}
+		err := onEvent(event, strings.Join(data, "\n"))
+		event, data = "", nil
+		return err
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if err := dispatch(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read response stream: %w", err)
+	}
+
+	return dispatch()
+}
+
 // ProgressCallback is a function type for progress reporting.
 // stage: The current operation stage (e.g., "uploading", "processing").
 // message: A human-readable progress message.
@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	nethttp "net/http"
 	"net/http/httptest"
 	"testing"
@@ -38,6 +39,11 @@ func TestNewJSONClient(t *testing.T) {
 		assert.Equal(t, 10*time.Second, client.httpClient.Timeout)
 		assert.Equal(t, "http://test.com", client.baseURL)
 	})
+
+	t.Run("trailing slash", func(t *testing.T) {
+		client := NewJSONClient(ClientConfig{BaseURL: "http://proxy.test/gemini/v1beta/"})
+		assert.Equal(t, "http://proxy.test/gemini/v1beta", client.baseURL)
+	})
 }
 
 func TestPostJSON(t *testing.T) {
@@ -166,3 +172,69 @@ func TestPostJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestPostStream(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+		assert.Equal(t, "secret", r.Header.Get("X-Key"))
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte(": keep-alive\n\n" +
+			"event: first\ndata: one\n\n" +
+			"data: two\ndata: lines\n\n" +
+			"data: unterminated"))
+	}))
+	defer server.Close()
+
+	client := NewJSONClient(ClientConfig{BaseURL: server.URL})
+
+	type event struct{ name, data string }
+	var events []event
+	err := client.PostStream(context.Background(), "/stream", map[string]string{"X-Key": "secret"},
+		testRequest{Name: "test"}, func(name, data string) error {
+			events = append(events, event{name, data})
+			return nil
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, []event{{"first", "one"}, {"", "two\nlines"}, {"", "unterminated"}}, events)
+}
+
+func TestPostStream_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+			w.WriteHeader(nethttp.StatusTooManyRequests)
+			_, _ = w.Write([]byte("slow down"))
+		}))
+		defer server.Close()
+
+		err := NewJSONClient(ClientConfig{BaseURL: server.URL}).PostStream(
+			context.Background(), "/", nil, testRequest{}, func(string, string) error { return nil })
+
+		var httpErr *HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, nethttp.StatusTooManyRequests, httpErr.StatusCode)
+		assert.Equal(t, "slow down", string(httpErr.Body))
+	})
+
+	t.Run("callback error stops the stream", func(t *testing.T) {
+		server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+			_, _ = w.Write([]byte("data: one\n\ndata: two\n\n"))
+		}))
+		defer server.Close()
+
+		calls := 0
+		stop := errors.New("stop")
+		err := NewJSONClient(ClientConfig{BaseURL: server.URL}).PostStream(
+			context.Background(), "/", nil, testRequest{}, func(string, string) error {
+				calls++
+				return stop
+			})
+
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, 1, calls)
+	})
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	nethttp "net/http"
+	"net/url"
+	"os"
+)
+
+// TransportConfig holds the network settings applied to API requests.
+type TransportConfig struct {
+	// Proxy is the URL of a proxy used for every request (http, https or socks5).
+	// Empty falls back to the HTTP_PROXY, HTTPS_PROXY and NO_PROXY variables.
+	Proxy string
+	// CACert is the path of a PEM bundle of CA certificates trusted in addition
+	// to the system ones, e.g. for a proxy that re-signs TLS traffic.
+	CACert string
+	// InsecureSkipVerify disables TLS certificate verification. Dangerous: it
+	// exposes requests, including the API key, to interception. For lab use only.
+	InsecureSkipVerify bool
+}
+
+// IsDefault reports whether c leaves the default transport unchanged.
+func (c TransportConfig) IsDefault() bool {
+	return c.Proxy == "" && c.CACert == "" && !c.InsecureSkipVerify
+}
+
+// NewTransport returns a transport applying cfg, or nil when cfg is the default so
+// clients keep using nethttp.DefaultTransport.
+func NewTransport(cfg TransportConfig) (nethttp.RoundTripper, error) {
+	if cfg.IsDefault() {
+		return nil, nil
+	}
+
+	transport := nethttp.DefaultTransport.(*nethttp.Transport).Clone()
+
+	if cfg.Proxy != "" {
+		proxyURL, err := ParseProxyURL(cfg.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = nethttp.ProxyURL(proxyURL)
+	}
+
+	if cfg.CACert != "" || cfg.InsecureSkipVerify {
+		tlsConfig := &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: cfg.InsecureSkipVerify, //nolint:gosec // opt-in via llm.insecure-skip-verify
+		}
+		if cfg.CACert != "" {
+			pool, err := loadCACert(cfg.CACert)
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.RootCAs = pool
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return transport, nil
+}
+
+// ParseProxyURL parses a proxy URL, which needs an http, https or socks5 scheme and a host.
+func ParseProxyURL(value string) (*url.URL, error) {
+	proxyURL, err := url.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: scheme must be http, https or socks5", proxyURL.Redacted())
+	}
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: missing host", proxyURL.Redacted())
+	}
+
+	return proxyURL, nil
+}
+
+// loadCACert returns the system certificate pool with the certificates of the PEM
+// bundle at path added.
+func loadCACert(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path comes from the user's configuration
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", path)
+	}
+
+	return pool, nil
+}
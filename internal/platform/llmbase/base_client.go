@@ -3,7 +3,10 @@ package llmbase
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	nethttp "net/http"
+	"strings"
 	"time"
 
 	"github.com/quantmind-br/shotgun-cli/internal/core/llm"
@@ -85,10 +88,20 @@ func NewBaseClientWithDefaults(cfg llm.Config, defaults DefaultConfig, providerN
 		maxTokens = defaults.MaxTokens
 	}
 
+	transport, err := platformhttp.NewTransport(platformhttp.TransportConfig{
+		Proxy:              cfg.Proxy,
+		CACert:             cfg.CACert,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return &BaseClient{
 		JSONClient: platformhttp.NewJSONClient(platformhttp.ClientConfig{
-			BaseURL: baseURL,
-			Timeout: timeout,
+			BaseURL:   baseURL,
+			Timeout:   timeout,
+			Transport: transport,
 		}),
 		APIKey:       cfg.APIKey,
 		Model:        model,
@@ -127,7 +140,7 @@ func (c *BaseClient) ValidateConfig() error {
 func (c *BaseClient) Send(ctx context.Context, content string, sender Sender) (*llm.Result, error) {
 	startTime := time.Now()
 
-	reqBody, err := sender.BuildRequest(content)
+	reqBody, err := sender.BuildRequest(llm.ParseRequest(content))
 	if err != nil {
 		return nil, fmt.Errorf("failed to build request: %w", err)
 	}
@@ -162,13 +175,80 @@ func (c *BaseClient) SendWithProgress(ctx context.Context, content string, sende
 	return result, err
 }
 
-// HandleHTTPError converts platformhttp.HTTPError to a formatted error message.
+// SendStream sends content using the StreamSender strategy, calling onDelta with each
+// piece of response text as it arrives. The result holds the full response, and its
+// RawResponse the data of every event, one per line.
+func (c *BaseClient) SendStream(
+	ctx context.Context, content string, sender StreamSender, onDelta func(delta string),
+) (*llm.Result, error) {
+	startTime := time.Now()
+
+	reqBody, err := sender.BuildStreamRequest(llm.ParseRequest(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	result := &llm.Result{Model: c.Model, Provider: c.ProviderName}
+	var response, raw strings.Builder
+	err = c.JSONClient.PostStream(ctx, sender.GetEndpoint(), sender.GetHeaders(), reqBody,
+		func(event, data string) error {
+			raw.WriteString(data)
+			raw.WriteString("\n")
+
+			delta, err := sender.ParseStreamEvent(event, data, result)
+			if err != nil {
+				return err
+			}
+			if delta != "" {
+				response.WriteString(delta)
+				if onDelta != nil {
+					onDelta(delta)
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Response = response.String()
+	result.RawResponse = raw.String()
+	result.Duration = time.Since(startTime)
+	return result, nil
+}
+
+// FetchModels gets a model listing from path into target. An endpoint answering 404,
+// 405 or 501 is reported as llm.ErrModelListingUnsupported.
+func (c *BaseClient) FetchModels(
+	ctx context.Context, path string, headers map[string]string, target interface{},
+) error {
+	err := c.JSONClient.GetJSON(ctx, path, headers, target)
+
+	var httpErr *platformhttp.HTTPError
+	if errors.As(err, &httpErr) {
+		switch httpErr.StatusCode {
+		case nethttp.StatusNotFound, nethttp.StatusMethodNotAllowed, nethttp.StatusNotImplemented:
+			return fmt.Errorf("%w (HTTP %d)", llm.ErrModelListingUnsupported, httpErr.StatusCode)
+		}
+	}
+
+	return err
+}
+
+// HandleHTTPError converts a failed request into an *llm.APIError classified by its
+// status, using parseBody to extract the provider's message. Timeouts without a
+// response become llm.ErrTimeout; other errors are returned as they are.
 func (c *BaseClient) HandleHTTPError(err error, parseBody func([]byte) string) error {
-	if httpErr, ok := err.(*platformhttp.HTTPError); ok {
-		if msg := parseBody(httpErr.Body); msg != "" {
-			return fmt.Errorf("API error [%d]: %s", httpErr.StatusCode, msg)
+	var httpErr *platformhttp.HTTPError
+	if errors.As(err, &httpErr) {
+		msg := parseBody(httpErr.Body)
+		if msg == "" {
+			msg = string(httpErr.Body)
 		}
-		return fmt.Errorf("API error [%d]: %s", httpErr.StatusCode, string(httpErr.Body))
+		return llm.NewAPIError(c.ProviderName, httpErr.StatusCode, msg, err)
+	}
+	if llm.IsTimeout(err) {
+		return &llm.APIError{Kind: llm.ErrTimeout, Provider: c.ProviderName, Message: err.Error(), Err: err}
 	}
 	return err
 }
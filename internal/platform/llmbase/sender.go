@@ -10,9 +10,10 @@ import (
 // This interface follows the Strategy pattern - BaseClient handles common logic while
 // concrete providers implement these methods for their specific API requirements.
 type Sender interface {
-	// BuildRequest creates the provider-specific request payload.
+	// BuildRequest creates the provider-specific request payload, mapping the
+	// system and user parts to the provider's roles.
 	// The returned value should be JSON-serializable.
-	BuildRequest(content string) (interface{}, error)
+	BuildRequest(req llm.Request) (interface{}, error)
 
 	// ParseResponse extracts llm.Result from the provider's response.
 	// The response parameter contains the unmarshaled API response.
@@ -32,3 +33,15 @@ type Sender interface {
 	// GetProviderName returns the display name for this provider (e.g., "OpenAI", "Anthropic").
 	GetProviderName() string
 }
+
+// StreamSender is a Sender whose API can stream the response as server-sent events.
+type StreamSender interface {
+	Sender
+
+	// BuildStreamRequest creates the request payload with streaming enabled.
+	BuildStreamRequest(req llm.Request) (interface{}, error)
+
+	// ParseStreamEvent handles one server-sent event, returning the response text it
+	// adds, if any. Usage reported by the stream is recorded in result.
+	ParseStreamEvent(event, data string, result *llm.Result) (delta string, err error)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/quantmind-br/shotgun-cli/internal/core/llm"
@@ -49,13 +50,41 @@ func (c *Client) SendWithProgress(ctx context.Context, content string, progress
 	return result, nil
 }
 
+// SendStream sends a prompt with a streamed response, calling onDelta as text arrives.
+func (c *Client) SendStream(ctx context.Context, content string, onDelta func(delta string)) (*llm.Result, error) {
+	result, err := c.BaseClient.SendStream(ctx, content, c, onDelta)
+	if err != nil {
+		return nil, c.handleError(err)
+	}
+	return result, nil
+}
+
+// ListModels returns the IDs of the models served by the /models endpoint.
+func (c *Client) ListModels(ctx context.Context) ([]string, error) {
+	var resp ModelsResponse
+	if err := c.FetchModels(ctx, "/models", c.GetHeaders(), &resp); err != nil {
+		return nil, c.handleError(err)
+	}
+
+	ids := make([]string, 0, len(resp.Data))
+	for _, model := range resp.Data {
+		ids = append(ids, model.ID)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
 // BuildRequest creates the OpenAI-specific request payload.
-func (c *Client) BuildRequest(content string) (interface{}, error) {
+func (c *Client) BuildRequest(request llm.Request) (interface{}, error) {
+	var messages []Message
+	if request.System != "" {
+		messages = append(messages, Message{Role: "system", Content: request.System})
+	}
+	messages = append(messages, Message{Role: "user", Content: request.User})
+
 	req := ChatCompletionRequest{
-		Model: c.Model,
-		Messages: []Message{
-			{Role: "user", Content: content},
-		},
+		Model:    c.Model,
+		Messages: messages,
 	}
 	if c.MaxTokens > 0 {
 		req.MaxTokens = c.MaxTokens
@@ -92,6 +121,48 @@ func (c *Client) ParseResponse(response interface{}, rawJSON []byte) (*llm.Resul
 	}, nil
 }
 
+// BuildStreamRequest creates the request payload with streaming and usage reporting enabled.
+func (c *Client) BuildStreamRequest(request llm.Request) (interface{}, error) {
+	payload, err := c.BuildRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req := payload.(ChatCompletionRequest)
+	req.Stream = true
+	req.StreamOptions = &StreamOptions{IncludeUsage: true}
+	return req, nil
+}
+
+// ParseStreamEvent extracts the text delta and usage from one streamed chunk.
+// The closing "[DONE]" event carries nothing.
+func (c *Client) ParseStreamEvent(_, data string, result *llm.Result) (string, error) {
+	if data == "[DONE]" {
+		return "", nil
+	}
+
+	var chunk ChatCompletionChunk
+	if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+		return "", fmt.Errorf("failed to parse stream chunk: %w", err)
+	}
+	if chunk.Error != nil {
+		return "", fmt.Errorf("API error: %s", chunk.Error.Message)
+	}
+
+	if chunk.Usage != nil && chunk.Usage.TotalTokens > 0 {
+		result.Usage = &llm.Usage{
+			PromptTokens:     chunk.Usage.PromptTokens,
+			CompletionTokens: chunk.Usage.CompletionTokens,
+			TotalTokens:      chunk.Usage.TotalTokens,
+		}
+	}
+
+	if len(chunk.Choices) == 0 {
+		return "", nil
+	}
+	return chunk.Choices[0].Delta.Content, nil
+}
+
 // GetEndpoint returns the API endpoint path.
 func (c *Client) GetEndpoint() string {
 	return "/chat/completions"
@@ -183,3 +183,136 @@ func TestClient_SendWithProgress(t *testing.T) {
 	assert.Contains(t, stages, "Connecting to OpenAI...")
 	assert.Contains(t, stages, "Response received")
 }
+
+func TestClient_Send_SystemPrompt(t *testing.T) {
+	var got ChatCompletionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ChatCompletionResponse{
+			Choices: []Choice{{Message: Message{Role: "assistant", Content: "ok"}}},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(llm.Config{APIKey: "test-key", BaseURL: server.URL, Model: "gpt-4o", Timeout: 30})
+	require.NoError(t, err)
+
+	_, err = client.Send(context.Background(), "You are a reviewer.\n{END_SYSTEM}\nReview this code.")
+	require.NoError(t, err)
+
+	assert.Equal(t, []Message{
+		{Role: "system", Content: "You are a reviewer."},
+		{Role: "user", Content: "Review this code."},
+	}, got.Messages)
+}
+
+func TestClient_BuildRequest_NoSystem(t *testing.T) {
+	client, err := NewClient(llm.Config{APIKey: "test-key", Model: "gpt-4o"})
+	require.NoError(t, err)
+
+	body, err := client.BuildRequest(llm.Request{User: "prompt"})
+	require.NoError(t, err)
+
+	req, ok := body.(ChatCompletionRequest)
+	require.True(t, ok)
+	assert.Equal(t, []Message{{Role: "user", Content: "prompt"}}, req.Messages)
+}
+
+func TestClient_SendStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body ChatCompletionRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.True(t, body.Stream)
+		require.NotNil(t, body.StreamOptions)
+		assert.True(t, body.StreamOptions.IncludeUsage)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte(`data: {"choices":[{"index":0,"delta":{"role":"assistant","content":""}}]}
+
+data: {"choices":[{"index":0,"delta":{"content":"Hello"}}]}
+
+data: {"choices":[{"index":0,"delta":{"content":", world"}}]}
+
+data: {"choices":[],"usage":{"prompt_tokens":8,"completion_tokens":4,"total_tokens":12}}
+
+data: [DONE]
+
+`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(llm.Config{APIKey: "test-key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	var deltas []string
+	result, err := client.SendStream(context.Background(), "Hi", func(delta string) {
+		deltas = append(deltas, delta)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Hello", ", world"}, deltas)
+	assert.Equal(t, "Hello, world", result.Response)
+	require.NotNil(t, result.Usage)
+	assert.Equal(t, 12, result.Usage.TotalTokens)
+}
+
+func TestClient_SendStream_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":{"message":"Incorrect API key provided"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(llm.Config{APIKey: "bad-key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	_, err = client.SendStream(context.Background(), "Hi", nil)
+	assert.ErrorContains(t, err, "API error [401]: Incorrect API key provided")
+	assert.ErrorIs(t, err, llm.ErrAuth)
+}
+
+func TestClient_Send_RateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":{"message":"Rate limit reached for requests"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(llm.Config{APIKey: "key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	_, err = client.Send(context.Background(), "Hi")
+	assert.ErrorIs(t, err, llm.ErrRateLimited)
+	assert.NotErrorIs(t, err, llm.ErrQuota)
+	assert.ErrorContains(t, err, "API error [429]: Rate limit reached for requests")
+}
+
+func TestClient_ListModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/v1/models", r.URL.Path)
+		assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"object":"list","data":[{"id":"gpt-4o-mini"},{"id":"gpt-4o"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(llm.Config{APIKey: "test-key", BaseURL: server.URL + "/v1"})
+	require.NoError(t, err)
+
+	models, err := client.ListModels(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gpt-4o", "gpt-4o-mini"}, models)
+}
+
+func TestClient_ListModels_Unsupported(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	client, err := NewClient(llm.Config{APIKey: "test-key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	_, err = client.ListModels(context.Background())
+	assert.ErrorIs(t, err, llm.ErrModelListingUnsupported)
+}
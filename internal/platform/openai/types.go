@@ -8,6 +8,13 @@ type ChatCompletionRequest struct {
 	MaxTokens   int       `json:"max_tokens,omitempty"`
 	Temperature float64   `json:"temperature,omitempty"`
 	Stream      bool      `json:"stream,omitempty"`
+	// StreamOptions asks for a final usage chunk when streaming.
+	StreamOptions *StreamOptions `json:"stream_options,omitempty"`
+}
+
+// StreamOptions configures a streamed response.
+type StreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
 }
 
 // Message represents a chat message.
@@ -33,6 +40,22 @@ type Choice struct {
 	FinishReason string  `json:"finish_reason"`
 }
 
+// ChatCompletionChunk is one event of a streamed response. The final chunk carries
+// the usage, with no choices; errors during the stream arrive as an error chunk.
+type ChatCompletionChunk struct {
+	Choices []ChunkChoice `json:"choices"`
+	Usage   *UsageAPI     `json:"usage,omitempty"`
+	Error   *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// ChunkChoice represents the part of a choice sent in one chunk.
+type ChunkChoice struct {
+	Index int     `json:"index"`
+	Delta Message `json:"delta"`
+}
+
 // UsageAPI represents token usage.
 type UsageAPI struct {
 	PromptTokens     int `json:"prompt_tokens"`
@@ -48,3 +71,13 @@ type ErrorResponse struct {
 		Code    string `json:"code"`
 	} `json:"error"`
 }
+
+// ModelsResponse is the response of the /models endpoint.
+type ModelsResponse struct {
+	Data []ModelInfo `json:"data"`
+}
+
+// ModelInfo describes a model served by the endpoint.
+type ModelInfo struct {
+	ID string `json:"id"`
+}
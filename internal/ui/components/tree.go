@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"unicode"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
@@ -36,6 +37,7 @@ type treeItem struct {
 	depth   int
 	isLast  bool
 	hasNext []bool
+	matches []int // Rune indices of the name matched by the filter
 }
 
 func NewFileTree(tree *scanner.FileNode, selections map[string]bool) *FileTreeModel {
@@ -104,6 +106,49 @@ func (m *FileTreeModel) CollapseNode() {
 	}
 }
 
+// CursorNode returns the node under the cursor, or nil when nothing is shown.
+func (m *FileTreeModel) CursorNode() *scanner.FileNode {
+	if m.cursor < 0 || m.cursor >= len(m.visibleItems) {
+		return nil
+	}
+
+	return m.visibleItems[m.cursor].node
+}
+
+// ExpandedPaths returns a copy of the paths of the expanded directories.
+func (m *FileTreeModel) ExpandedPaths() map[string]bool {
+	expanded := make(map[string]bool, len(m.expanded))
+	for path, open := range m.expanded {
+		if open {
+			expanded[path] = true
+		}
+	}
+
+	return expanded
+}
+
+// RestoreExpanded expands the directories in paths that exist in the tree, e.g. to
+// keep the view of a previous tree after a rescan. Other paths are ignored.
+func (m *FileTreeModel) RestoreExpanded(paths map[string]bool) {
+	var restore func(node *scanner.FileNode)
+	restore = func(node *scanner.FileNode) {
+		if !node.IsDir {
+			return
+		}
+		if paths[node.Path] {
+			m.expanded[node.Path] = true
+		}
+		for _, child := range node.Children {
+			restore(child)
+		}
+	}
+
+	if m.tree != nil {
+		restore(m.tree)
+	}
+	m.rebuildVisibleItems()
+}
+
 func (m *FileTreeModel) ToggleSelection() {
 	if m.cursor < len(m.visibleItems) {
 		item := m.visibleItems[m.cursor]
@@ -137,6 +182,35 @@ func (m *FileTreeModel) DeselectAllVisible() {
 	m.recomputeSelectionStates()
 }
 
+// SelectAllFiltered selects every file matching the active filter, including files
+// under collapsed directories. Directories shown only as ancestors of matches are
+// not selected themselves. Without a filter it behaves like SelectAllVisible.
+func (m *FileTreeModel) SelectAllFiltered() {
+	m.setFilteredSelection(true)
+}
+
+// DeselectAllFiltered deselects every file matching the active filter.
+// Without a filter it behaves like DeselectAllVisible.
+func (m *FileTreeModel) DeselectAllFiltered() {
+	m.setFilteredSelection(false)
+}
+
+func (m *FileTreeModel) setFilteredSelection(selected bool) {
+	if m.filter == "" {
+		if selected {
+			m.SelectAllVisible()
+		} else {
+			m.DeselectAllVisible()
+		}
+		return
+	}
+	if m.tree == nil {
+		return
+	}
+
+	m.setDirectorySelection(m.tree, selected)
+}
+
 func (m *FileTreeModel) ToggleShowIgnored() {
 	m.showIgnored = !m.showIgnored
 	m.filterCacheValid = false // Invalidate cache since visibility rules changed
@@ -369,11 +443,16 @@ func (m *FileTreeModel) renderItemName(item treeItem, selectionState styles.Sele
 		baseName += "/"
 	}
 
-	return styles.RenderFileName(baseName, selectionState)
+	return styles.RenderFileNameMatches(baseName, item.matches, selectionState)
 }
 
 func (m *FileTreeModel) renderIgnoreStatus(item treeItem) string {
-	return styles.RenderIgnoreIndicator(item.node.IsGitignored, item.node.IsCustomIgnored)
+	status := styles.RenderIgnoreIndicator(item.node.IsGitignored, item.node.IsCustomIgnored)
+	if item.node.AccessError {
+		status += styles.WarningStyle.Render(" (not readable)")
+	}
+
+	return status
 }
 
 func (m *FileTreeModel) renderSizeInfo(item treeItem) string {
@@ -425,6 +504,9 @@ func (m *FileTreeModel) buildVisibleItems(node *scanner.FileNode, _ string, dept
 		isLast:  isLast,
 		hasNext: currentHasNext,
 	}
+	if m.filter != "" {
+		item.matches = filterHighlights(node, m.filter)
+	}
 	m.visibleItems = append(m.visibleItems, item)
 
 	// Add children if expanded
@@ -568,6 +650,9 @@ func (m *FileTreeModel) recomputeSelectionStates() {
 		hasUnselected := false
 
 		for _, child := range node.Children {
+			if !m.shouldShowNode(child) {
+				continue
+			}
 			childState := visit(child)
 			switch childState {
 			case styles.SelectionSelected:
@@ -665,19 +750,50 @@ func formatFileSize(bytes int64) string {
 // fuzzyMatch implements fuzzy matching - characters must appear in order but not consecutively
 // For example, "abc" matches "aXbYc", "abc", "aaaabc", etc.
 func fuzzyMatch(text, pattern string) bool {
-	if pattern == "" {
-		return true
+	return pattern == "" || fuzzyMatchPositions(text, pattern) != nil
+}
+
+// fuzzyMatchPositions returns the rune indices of text matched by pattern, case-insensitively,
+// or nil when pattern does not match. Each pattern rune takes its earliest possible match.
+func fuzzyMatchPositions(text, pattern string) []int {
+	patternRunes := []rune(strings.ToLower(pattern))
+	if len(patternRunes) == 0 {
+		return nil
+	}
+
+	positions := make([]int, 0, len(patternRunes))
+	for i, r := range []rune(text) {
+		if unicode.ToLower(r) == patternRunes[len(positions)] {
+			positions = append(positions, i)
+			if len(positions) == len(patternRunes) {
+				return positions
+			}
+		}
+	}
+
+	return nil
+}
+
+// filterHighlights returns the rune indices of the node name to highlight for filter.
+// A match on the name itself is preferred; otherwise the part of a relative path match
+// that falls in the name is used. Ancestors shown only for their descendants get none.
+func filterHighlights(node *scanner.FileNode, filter string) []int {
+	if positions := fuzzyMatchPositions(node.Name, filter); positions != nil {
+		return positions
 	}
 
-	text = strings.ToLower(text)
-	pattern = strings.ToLower(pattern)
+	positions := fuzzyMatchPositions(node.RelPath, filter)
+	if positions == nil || !strings.HasSuffix(node.RelPath, node.Name) {
+		return nil
+	}
 
-	patternIdx := 0
-	for i := 0; i < len(text) && patternIdx < len(pattern); i++ {
-		if text[i] == pattern[patternIdx] {
-			patternIdx++
+	offset := len([]rune(node.RelPath)) - len([]rune(node.Name))
+	var matches []int
+	for _, pos := range positions {
+		if pos >= offset {
+			matches = append(matches, pos-offset)
 		}
 	}
 
-	return patternIdx == len(pattern)
+	return matches
 }
@@ -164,6 +164,29 @@ func TestFileTreeExpandCollapse(t *testing.T) {
 	})
 }
 
+func TestFileTreeRestoreExpanded(t *testing.T) {
+	subFile := createTestNode("sub.go", "/project/subdir/sub.go", false)
+	subDir := createTestNode("subdir", "/project/subdir", true, subFile)
+	root := createTestNode("project", "/project", true, subDir)
+
+	model := NewFileTree(root, nil)
+	model.expanded[subDir.Path] = true
+	expanded := model.ExpandedPaths()
+	assert.Equal(t, map[string]bool{"/project": true, "/project/subdir": true}, expanded)
+
+	// A rescanned tree keeps the expanded directories that still exist
+	newSubFile := createTestNode("sub.go", "/project/subdir/sub.go", false)
+	newSubDir := createTestNode("subdir", "/project/subdir", true, newSubFile)
+	newRoot := createTestNode("project", "/project", true, newSubDir)
+
+	rescanned := NewFileTree(newRoot, nil)
+	rescanned.RestoreExpanded(map[string]bool{"/project/subdir": true, "/project/gone": true})
+
+	assert.True(t, rescanned.expanded["/project/subdir"])
+	assert.False(t, rescanned.expanded["/project/gone"])
+	assert.Len(t, rescanned.visibleItems, 3, "sub.go should be visible under the restored directory")
+}
+
 func TestFileTreeToggleSelection(t *testing.T) {
 	file := createTestNode("main.go", "/project/main.go", false)
 	root := createTestNode("project", "/project", true, file)
@@ -544,6 +567,42 @@ func TestFuzzyMatch(t *testing.T) {
 	}
 }
 
+func TestFuzzyMatchPositions(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		pattern  string
+		expected []int
+	}{
+		{"prefix", "file.go", "fil", []int{0, 1, 2}},
+		{"fuzzy", "file_selection.go", "fsg", []int{0, 5, 15}},
+		{"case insensitive", "FileSelection.go", "fs", []int{0, 4}},
+		{"earliest match", "aab", "ab", []int{0, 2}},
+		{"multibyte runes", "über.go", "ügo", []int{0, 5, 6}},
+		{"no match", "file.go", "xyz", nil},
+		{"empty pattern", "file.go", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, fuzzyMatchPositions(tt.text, tt.pattern))
+		})
+	}
+}
+
+func TestFilterHighlights(t *testing.T) {
+	file := createTestNode("target.go", "/project/src/target.go", false)
+	file.RelPath = "src/target.go"
+
+	// The name match is preferred over the relative path
+	assert.Equal(t, []int{0, 1}, filterHighlights(file, "ta"))
+	// Only the part of a path match inside the name is highlighted
+	assert.Equal(t, []int{0}, filterHighlights(file, "srct"))
+	// A path match entirely outside the name highlights nothing
+	assert.Empty(t, filterHighlights(file, "sr"))
+	assert.Nil(t, filterHighlights(file, "xyz"))
+}
+
 func TestFuzzyFilterShowsAncestors(t *testing.T) {
 	// Create a nested structure
 	file := createTestNode("target.go", "/project/src/pkg/target.go", false)
@@ -979,3 +1038,82 @@ func TestDeselectAllVisible(t *testing.T) {
 		assert.Empty(t, model.GetSelections())
 	})
 }
+
+func TestSelectAllFiltered(t *testing.T) {
+	newTree := func() (*scanner.FileNode, map[string]*scanner.FileNode) {
+		nodes := map[string]*scanner.FileNode{
+			"api/user_handler.go": createTestNode("user_handler.go", "/project/api/user_handler.go", false),
+			"api/routes.go":       createTestNode("routes.go", "/project/api/routes.go", false),
+			"web/handler.go":      createTestNode("handler.go", "/project/web/handler.go", false),
+			"main.go":             createTestNode("main.go", "/project/main.go", false),
+		}
+		for relPath, node := range nodes {
+			node.RelPath = relPath
+		}
+		nodes["api"] = createTestNode("api", "/project/api", true, nodes["api/user_handler.go"], nodes["api/routes.go"])
+		nodes["web"] = createTestNode("web", "/project/web", true, nodes["web/handler.go"])
+		root := createTestNode("project", "/project", true, nodes["api"], nodes["web"], nodes["main.go"])
+		root.RelPath = "."
+
+		return root, nodes
+	}
+
+	t.Run("selects matches under collapsed directories", func(t *testing.T) {
+		root, nodes := newTree()
+		model := NewFileTree(root, map[string]bool{"/project/main.go": true})
+		model.SetFilter("handler")
+		model.expanded[nodes["web"].Path] = false
+		model.rebuildVisibleItems()
+
+		model.SelectAllFiltered()
+
+		assert.Equal(t, map[string]bool{
+			"/project/main.go":             true,
+			"/project/api/user_handler.go": true,
+			"/project/web/handler.go":      true,
+		}, model.GetSelections())
+		assert.NotContains(t, model.GetSelections(), nodes["api"].Path, "ancestor directories are not selected")
+		assert.Equal(t, styles.SelectionSelected, model.selectionStateFor(nodes["api"].Path))
+		assert.Equal(t, styles.SelectionSelected, model.selectionStateFor(nodes["web"].Path))
+
+		model.ClearFilter()
+		model.recomputeSelectionStates()
+		assert.Equal(t, styles.SelectionPartial, model.selectionStateFor(nodes["api"].Path))
+	})
+
+	t.Run("deselects only matches", func(t *testing.T) {
+		root, _ := newTree()
+		model := NewFileTree(root, map[string]bool{
+			"/project/api/user_handler.go": true,
+			"/project/api/routes.go":       true,
+			"/project/main.go":             true,
+		})
+		model.SetFilter("handler")
+
+		model.DeselectAllFiltered()
+
+		assert.Equal(t, map[string]bool{
+			"/project/api/routes.go": true,
+			"/project/main.go":       true,
+		}, model.GetSelections())
+	})
+
+	t.Run("no matches selects nothing", func(t *testing.T) {
+		root, _ := newTree()
+		model := NewFileTree(root, nil)
+		model.SetFilter("zzz")
+
+		model.SelectAllFiltered()
+
+		assert.Empty(t, model.GetSelections())
+	})
+
+	t.Run("without filter selects visible files", func(t *testing.T) {
+		root, _ := newTree()
+		model := NewFileTree(root, nil)
+
+		model.SelectAllFiltered()
+
+		assert.Equal(t, map[string]bool{"/project/main.go": true}, model.GetSelections())
+	})
+}
@@ -1,13 +1,16 @@
 package ui
 
 import (
+	"context"
 	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/quantmind-br/shotgun-cli/internal/app"
 	"github.com/quantmind-br/shotgun-cli/internal/core/contextgen"
 	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
 	"github.com/quantmind-br/shotgun-cli/internal/core/template"
+	"github.com/quantmind-br/shotgun-cli/internal/core/tokens"
 	"github.com/quantmind-br/shotgun-cli/internal/ui/screens"
 )
 
@@ -19,12 +22,18 @@ type GenerateConfig struct {
 	Template       *template.Template
 	TaskDesc       string
 	Rules          string
+	TemplateVars   map[string]string // Values for the template's custom variables
 	RootPath       string
 	MaxFileSize    int64
 	MaxTotalSize   int64
 	MaxFiles       int
 	IncludeTree    bool
 	IncludeSummary bool
+	StripComments  bool
+	FoldSiblings   bool
+	FileDelimiters *contextgen.FileDelimiters // Lines around each file (nil = defaults)
+	Redact         bool                       // Replace secrets with [REDACTED] before embedding
+	RedactPatterns []string                   // Extra regular expressions to redact
 }
 
 type GenerateCoordinator struct {
@@ -35,7 +44,11 @@ type GenerateCoordinator struct {
 	done       chan bool
 	content    string
 	genErr     error
+	redacted   []contextgen.RedactedFile
 	started    bool
+	// cancel stops the running generation; nil when none is running
+	cancel context.CancelFunc
+	ctx    context.Context
 }
 
 func NewGenerateCoordinator(gen contextgen.ContextGenerator) *GenerateCoordinator {
@@ -49,6 +62,8 @@ func (c *GenerateCoordinator) Start(cfg *GenerateConfig) tea.Cmd {
 	c.started = false
 	c.content = ""
 	c.genErr = nil
+	c.redacted = nil
+	c.ctx, c.cancel = context.WithCancel(context.Background())
 
 	return c.iterativeGenerateCmd()
 }
@@ -61,6 +76,7 @@ func (c *GenerateCoordinator) Poll() tea.Cmd {
 	select {
 	case progress, ok := <-c.progressCh:
 		if !ok {
+			c.cancel = nil
 			return c.finishGenerate()
 		}
 		return tea.Batch(
@@ -73,6 +89,7 @@ func (c *GenerateCoordinator) Poll() tea.Cmd {
 			c.schedulePoll(),
 		)
 	case <-c.done:
+		c.cancel = nil
 		return c.finishGenerate()
 	default:
 		return c.schedulePoll()
@@ -96,22 +113,39 @@ func (c *GenerateCoordinator) IsStarted() bool {
 }
 
 func (c *GenerateCoordinator) iterativeGenerateCmd() tea.Cmd {
+	// The worker keeps its own references, since Cancel and Reset clear the fields
+	ctx, done, cfg := c.ctx, c.done, c.config
 	return func() tea.Msg {
 		if !c.started {
 			c.started = true
 			go func() {
-				defer close(c.done)
-
-				genConfig := *c.buildGeneratorConfig()
-				content, err := c.generator.Generate(
-					c.config.FileTree,
-					c.config.Selections,
-					genConfig,
-				)
+				defer close(done)
+
+				genConfig := *c.buildGeneratorConfig(cfg)
+				var content string
+				var err error
+				var redactor *contextgen.Redactor
+				if cfg.Redact {
+					redactor, err = contextgen.NewRedactor(cfg.RedactPatterns)
+					genConfig.Redactor = redactor
+				}
+				if err == nil {
+					if cancelable, ok := c.generator.(contextgen.CancelableGenerator); ok {
+						content, err = cancelable.GenerateWithContext(ctx, cfg.FileTree, cfg.Selections, genConfig, nil)
+					} else {
+						content, err = c.generator.Generate(cfg.FileTree, cfg.Selections, genConfig)
+					}
+				}
 				c.mu.Lock()
+				defer c.mu.Unlock()
+				if ctx.Err() != nil {
+					return
+				}
 				c.content = content
 				c.genErr = err
-				c.mu.Unlock()
+				if redactor != nil {
+					c.redacted = redactor.Files()
+				}
 			}()
 		}
 
@@ -124,7 +158,12 @@ func (c *GenerateCoordinator) finishGenerate() tea.Cmd {
 		if c.genErr != nil {
 			return screens.GenerationErrorMsg{Err: c.genErr}
 		}
-		return screens.GenerationCompleteMsg{Content: c.content}
+		msg := screens.GenerationCompleteMsg{Content: c.content}
+		for _, file := range c.redacted {
+			msg.RedactedSecrets += file.Count
+			msg.RedactedFiles++
+		}
+		return msg
 	}
 }
 
@@ -134,25 +173,56 @@ func (c *GenerateCoordinator) schedulePoll() tea.Cmd {
 	})
 }
 
-func (c *GenerateCoordinator) buildGeneratorConfig() *contextgen.GenerateConfig {
+func (c *GenerateCoordinator) buildGeneratorConfig(cfg *GenerateConfig) *contextgen.GenerateConfig {
+	var transforms []contextgen.ContentTransform
+	if cfg.StripComments {
+		transforms = append(transforms, tokens.StripComments)
+	}
+
+	vars := map[string]string{
+		"TASK":           cfg.TaskDesc,
+		"RULES":          cfg.Rules,
+		"FILE_STRUCTURE": "",
+		"CURRENT_DATE":   time.Now().Format("2006-01-02"),
+	}
+	for name, value := range cfg.TemplateVars {
+		vars[name] = value
+	}
+	for name, value := range app.GitTemplateVars(context.Background(), cfg.RootPath, cfg.Template.Content) {
+		vars[name] = value
+	}
+
 	return &contextgen.GenerateConfig{
-		TemplateVars: map[string]string{
-			"TASK":           c.config.TaskDesc,
-			"RULES":          c.config.Rules,
-			"FILE_STRUCTURE": "",
-			"CURRENT_DATE":   time.Now().Format("2006-01-02"),
-		},
-		Template:       c.config.Template.Content,
-		IncludeTree:    c.config.IncludeTree,
-		IncludeSummary: c.config.IncludeSummary,
+		TemplateVars:   cfg.Template.ApplyDefaults(vars),
+		Template:       cfg.Template.Content,
+		IncludeTree:    cfg.IncludeTree,
+		IncludeSummary: cfg.IncludeSummary,
+		FoldSiblings:   cfg.FoldSiblings,
+		FileDelimiters: cfg.FileDelimiters,
+		Transforms:     transforms,
 	}
 }
 
+// Cancel stops a running generation and reports whether one was running. The result
+// is discarded and polling stops; the worker exits in the background.
+func (c *GenerateCoordinator) Cancel() bool {
+	if c.cancel == nil {
+		return false
+	}
+	c.cancel()
+	c.cancel = nil
+	go drainUntilDone(c.progressCh, c.done)
+	c.progressCh = nil
+	return true
+}
+
 func (c *GenerateCoordinator) Reset() {
+	c.Cancel()
 	c.config = nil
 	c.progressCh = nil
 	c.done = nil
 	c.content = ""
 	c.genErr = nil
 	c.started = false
+	c.ctx = nil
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/quantmind-br/shotgun-cli/internal/core/contextgen"
 	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
 	"github.com/quantmind-br/shotgun-cli/internal/core/template"
+	"github.com/quantmind-br/shotgun-cli/internal/ui/screens"
 )
 
 // mockGenerator is a test double for ContextGenerator
@@ -203,3 +204,127 @@ func TestGenerateCoordinator_Reset(t *testing.T) {
 		t.Error("progressCh should be nil after reset")
 	}
 }
+
+func TestGenerateCoordinator_Cancel(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	mockGen := &mockGenerator{
+		generateFunc: func(root *scanner.FileNode, selections map[string]bool, config contextgen.GenerateConfig) (string, error) {
+			<-release
+			return "late result", nil
+		},
+	}
+
+	coord := NewGenerateCoordinator(mockGen)
+	if coord.Cancel() {
+		t.Error("Cancel should report false before generation starts")
+	}
+
+	cmd := coord.Start(&GenerateConfig{
+		FileTree: &scanner.FileNode{Name: "root"},
+		Template: &template.Template{},
+	})
+	cmd()
+	done := coord.done
+
+	if !coord.Cancel() {
+		t.Fatal("Cancel should report true while generation runs")
+	}
+	close(release)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("generation worker did not exit after Cancel")
+	}
+
+	if coord.Poll() != nil {
+		t.Error("Poll should stop after Cancel")
+	}
+	if content, _ := coord.Result(); content != "" {
+		t.Errorf("a cancelled generation should discard its result, got %q", content)
+	}
+}
+
+func TestGenerateCoordinator_Cancel_StopsGenerator(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	tree := &scanner.FileNode{Name: "root", Path: tempDir, IsDir: true}
+	for i := 0; i < 50; i++ {
+		name := fmt.Sprintf("file%d.go", i)
+		tree.Children = append(tree.Children, &scanner.FileNode{Name: name, Path: tempDir + "/" + name, RelPath: name})
+	}
+
+	coord := NewGenerateCoordinator(contextgen.NewDefaultContextGenerator())
+	cmd := coord.Start(&GenerateConfig{FileTree: tree, Template: &template.Template{}})
+	coord.Cancel()
+	cmd()
+
+	select {
+	case <-coord.done:
+	case <-time.After(time.Second):
+		t.Fatal("generation did not stop after Cancel")
+	}
+	if coord.IsComplete() {
+		t.Error("a cancelled generation should not report a result")
+	}
+}
+
+func TestGenerateCoordinator_BuildGeneratorConfig_TemplateVars(t *testing.T) {
+	t.Parallel()
+
+	coord := NewGenerateCoordinator(&mockGenerator{})
+	genCfg := coord.buildGeneratorConfig(&GenerateConfig{
+		Template: &template.Template{
+			Content:  "{TICKET} {TEAM} {TASK}",
+			Defaults: map[string]string{"TEAM": "core"},
+		},
+		TaskDesc:     "Fix it",
+		TemplateVars: map[string]string{"TICKET": "ABC-1", "TEAM": ""},
+	})
+
+	if genCfg.TemplateVars["TICKET"] != "ABC-1" {
+		t.Errorf("expected TICKET=ABC-1, got %q", genCfg.TemplateVars["TICKET"])
+	}
+	if genCfg.TemplateVars["TEAM"] != "core" {
+		t.Errorf("expected an empty TEAM to use the default, got %q", genCfg.TemplateVars["TEAM"])
+	}
+	if genCfg.TemplateVars["TASK"] != "Fix it" {
+		t.Errorf("expected TASK=Fix it, got %q", genCfg.TemplateVars["TASK"])
+	}
+}
+
+func TestGenerateCoordinator_Redact(t *testing.T) {
+	t.Parallel()
+
+	mockGen := &mockGenerator{
+		generateFunc: func(root *scanner.FileNode, selections map[string]bool, config contextgen.GenerateConfig) (string, error) {
+			return config.Redactor.Transform("config.py", "password = \"hunter22\"\nticket: ABC-123\n"), nil
+		},
+	}
+
+	coord := NewGenerateCoordinator(mockGen)
+	coord.Start(&GenerateConfig{
+		FileTree:       &scanner.FileNode{Name: "root"},
+		Template:       &template.Template{},
+		Redact:         true,
+		RedactPatterns: []string{`ABC-[0-9]+`},
+	})()
+
+	for i := 0; i < 10 && !coord.IsComplete(); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	msg, ok := coord.finishGenerate()().(screens.GenerationCompleteMsg)
+	if !ok {
+		t.Fatalf("expected GenerationCompleteMsg, got %T", msg)
+	}
+	if want := "password = \"[REDACTED]\"\nticket: [REDACTED]\n"; msg.Content != want {
+		t.Errorf("got content %q, want %q", msg.Content, want)
+	}
+	if msg.RedactedSecrets != 2 || msg.RedactedFiles != 1 {
+		t.Errorf("got %d secrets in %d files, want 2 in 1", msg.RedactedSecrets, msg.RedactedFiles)
+	}
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -21,6 +22,11 @@ type ScanCoordinator struct {
 	result     *scanner.FileNode
 	scanErr    error
 	started    bool
+	// cancel stops the running scan; nil when no scan is running
+	cancel context.CancelFunc
+	ctx    context.Context
+	// inaccessible lists the directories the scan could not read
+	inaccessible []string
 }
 
 // NewScanCoordinator creates a new coordinator with the given scanner.
@@ -39,6 +45,8 @@ func (c *ScanCoordinator) Start(rootPath string, config *scanner.ScanConfig) tea
 	c.started = false
 	c.result = nil
 	c.scanErr = nil
+	c.inaccessible = nil
+	c.ctx, c.cancel = context.WithCancel(context.Background())
 
 	return c.iterativeScanCmd()
 }
@@ -52,6 +60,7 @@ func (c *ScanCoordinator) Poll() tea.Cmd {
 	select {
 	case progress, ok := <-c.progressCh:
 		if !ok {
+			c.cancel = nil
 			return c.finishScan()
 		}
 		return tea.Batch(
@@ -65,6 +74,7 @@ func (c *ScanCoordinator) Poll() tea.Cmd {
 			c.schedulePoll(),
 		)
 	case <-c.done:
+		c.cancel = nil
 		return c.finishScan()
 	default:
 		return c.schedulePoll()
@@ -91,20 +101,30 @@ func (c *ScanCoordinator) IsStarted() bool {
 }
 
 func (c *ScanCoordinator) iterativeScanCmd() tea.Cmd {
+	// The worker keeps its own references, since Cancel and Reset clear the fields
+	ctx, rootPath, config, progressCh, done := c.ctx, c.rootPath, c.config, c.progressCh, c.done
 	return func() tea.Msg {
 		if !c.started {
 			c.started = true
 			go func() {
-				defer close(c.done)
-				tree, err := c.scanner.ScanWithProgress(
-					c.rootPath,
-					c.config,
-					c.progressCh,
-				)
+				defer close(done)
+				var tree *scanner.FileNode
+				var err error
+				if cancelable, ok := c.scanner.(scanner.CancelableScanner); ok {
+					tree, err = cancelable.ScanWithContext(ctx, rootPath, config, progressCh)
+				} else {
+					tree, err = c.scanner.ScanWithProgress(rootPath, config, progressCh)
+				}
 				c.mu.Lock()
+				defer c.mu.Unlock()
+				if ctx.Err() != nil {
+					return
+				}
 				c.result = tree
 				c.scanErr = err
-				c.mu.Unlock()
+				if reporter, ok := c.scanner.(scanner.StatsReporter); ok {
+					c.inaccessible = reporter.LastScanStats().Inaccessible
+				}
 			}()
 		}
 
@@ -117,7 +137,9 @@ func (c *ScanCoordinator) finishScan() tea.Cmd {
 		if c.scanErr != nil {
 			return ScanErrorMsg{Err: c.scanErr}
 		}
-		return ScanCompleteMsg{Tree: c.result}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return ScanCompleteMsg{Tree: c.result, Inaccessible: c.inaccessible}
 	}
 }
 
@@ -127,13 +149,41 @@ func (c *ScanCoordinator) schedulePoll() tea.Cmd {
 	})
 }
 
+// Cancel stops a running scan and reports whether one was running. The scan's result
+// is discarded and polling stops; the worker exits in the background.
+func (c *ScanCoordinator) Cancel() bool {
+	if c.cancel == nil {
+		return false
+	}
+	c.cancel()
+	c.cancel = nil
+	go drainUntilDone(c.progressCh, c.done)
+	c.progressCh = nil
+	return true
+}
+
+// drainUntilDone discards progress until done is closed, so a worker blocked on a
+// send can see its cancelled context and exit.
+func drainUntilDone[T any](progressCh <-chan T, done <-chan bool) {
+	for {
+		select {
+		case <-progressCh:
+		case <-done:
+			return
+		}
+	}
+}
+
 // Reset clears the coordinator state for reuse.
 func (c *ScanCoordinator) Reset() {
+	c.Cancel()
 	c.rootPath = ""
 	c.config = nil
 	c.progressCh = nil
 	c.done = nil
 	c.result = nil
 	c.scanErr = nil
+	c.inaccessible = nil
 	c.started = false
+	c.ctx = nil
 }
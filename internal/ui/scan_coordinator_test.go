@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
 )
@@ -550,3 +551,46 @@ func TestScanCoordinator_Reset(t *testing.T) {
 		t.Error("started should be false after reset")
 	}
 }
+
+func TestScanCoordinator_Cancel(t *testing.T) {
+	t.Parallel()
+
+	// The scan blocks on a full progress channel until Cancel drains it
+	mockSc := &scanCoordinatorMockScanner{
+		scanProgressFunc: func(rootPath string, config *scanner.ScanConfig, progress chan<- scanner.Progress) (*scanner.FileNode, error) {
+			for i := 0; i < 200; i++ {
+				progress <- scanner.Progress{Current: int64(i), Stage: "scanning"}
+			}
+			return &scanner.FileNode{Name: "root"}, nil
+		},
+	}
+
+	coordinator := NewScanCoordinator(mockSc)
+	if coordinator.Cancel() {
+		t.Error("Cancel should report false before a scan starts")
+	}
+
+	cmd := coordinator.Start("/test", &scanner.ScanConfig{})
+	cmd()
+	done := coordinator.done
+
+	if !coordinator.Cancel() {
+		t.Fatal("Cancel should report true while a scan runs")
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scan worker did not exit after Cancel")
+	}
+
+	if coordinator.Poll() != nil {
+		t.Error("Poll should stop after Cancel")
+	}
+	if coordinator.IsComplete() {
+		t.Error("a cancelled scan should not report a result")
+	}
+	if coordinator.Cancel() {
+		t.Error("Cancel should report false once cancelled")
+	}
+}
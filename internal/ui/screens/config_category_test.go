@@ -17,11 +17,11 @@ func TestNewConfigCategory(t *testing.T) {
 		category      config.ConfigCategory
 		expectedCount int
 	}{
-		{"Scanner category", config.CategoryScanner, 9},
-		{"Context category", config.CategoryContext, 3},
+		{"Scanner category", config.CategoryScanner, 13},
+		{"Context category", config.CategoryContext, 9},
 		{"Template category", config.CategoryTemplate, 1},
-		{"Output category", config.CategoryOutput, 2},
-		{"LLM category", config.CategoryLLM, 6},
+		{"Output category", config.CategoryOutput, 5},
+		{"LLM category", config.CategoryLLM, 11},
 	}
 
 	for _, tt := range tests {
@@ -69,7 +69,8 @@ func TestConfigCategory_Navigation(t *testing.T) {
 		{"j moves cursor down", "j", 0, 1},
 		{"up moves cursor", "up", 1, 0},
 		{"k moves cursor up", "k", 1, 0},
-		{"down at end stays", "down", 8, 8},
+		{"down at end stays", "down", len(config.GetByCategory(config.CategoryScanner)) - 1,
+			len(config.GetByCategory(config.CategoryScanner)) - 1},
 		{"up at start stays", "up", 0, 0},
 	}
 
@@ -97,15 +98,17 @@ func TestConfigCategory_HomeEnd(t *testing.T) {
 	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
 	assert.Equal(t, 0, model.Cursor())
 
+	last := model.ItemCount() - 1
+
 	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
-	assert.Equal(t, 8, model.Cursor())
+	assert.Equal(t, last, model.Cursor())
 
 	model.cursor = 4
 	model.Update(tea.KeyMsg{Type: tea.KeyHome})
 	assert.Equal(t, 0, model.Cursor())
 
 	model.Update(tea.KeyMsg{Type: tea.KeyEnd})
-	assert.Equal(t, 8, model.Cursor())
+	assert.Equal(t, last, model.Cursor())
 }
 
 func TestConfigCategory_EnterEditMode(t *testing.T) {
@@ -0,0 +1,96 @@
+package screens
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/quantmind-br/shotgun-cli/internal/core/contextgen"
+	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
+	"github.com/quantmind-br/shotgun-cli/internal/ui/styles"
+)
+
+const (
+	// previewMaxLines is how many lines of the file under the cursor the preview shows
+	previewMaxLines = 40
+	// previewReadLimit caps how much of a file is read to build its preview
+	previewReadLimit = 64 * 1024
+	// previewBinaryHeaderSize is how much of a file is checked for binary content
+	previewBinaryHeaderSize = 1024
+)
+
+// filePreview caches the preview of the last file read, so moving the cursor back
+// and forth does not re-read it.
+type filePreview struct {
+	path string
+	text string
+}
+
+// readFilePreview returns the first previewMaxLines lines of the file at node, or a
+// bracketed note for binary files, files over maxFileSize (0 = no limit) and files
+// that cannot be read.
+func readFilePreview(node *scanner.FileNode, maxFileSize int64) string {
+	if maxFileSize > 0 && node.Size > maxFileSize {
+		return "[too large to preview]"
+	}
+
+	file, err := os.Open(node.Path) //nolint:gosec // path comes from the scanned tree
+	if err != nil {
+		return fmt.Sprintf("[cannot read: %v]", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	buf := make([]byte, previewReadLimit)
+	n, err := io.ReadFull(file, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Sprintf("[cannot read: %v]", err)
+	}
+	data := buf[:n]
+
+	if len(data) == 0 {
+		return "[empty file]"
+	}
+	if !contextgen.IsText(data[:min(len(data), previewBinaryHeaderSize)]) {
+		return "[binary]"
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if len(lines) > previewMaxLines {
+		lines = lines[:previewMaxLines]
+	}
+	for i, line := range lines {
+		lines[i] = strings.ReplaceAll(line, "\t", "    ")
+	}
+
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// renderFilePreview draws the preview panel for node in a width x height box.
+func renderFilePreview(node *scanner.FileNode, text string, width, height int) string {
+	innerWidth := max(width-2, 1)
+	innerHeight := max(height-2, 1)
+
+	var lines []string
+	if node == nil || node.IsDir {
+		lines = []string{styles.HelpStyle.Render("Move to a file to preview it")}
+	} else {
+		lines = append(lines, styles.TitleStyle.Render(node.Name))
+		for _, line := range strings.Split(text, "\n") {
+			if len(lines) >= innerHeight {
+				break
+			}
+			lines = append(lines, lipgloss.NewStyle().MaxWidth(innerWidth).Render(line))
+		}
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.BorderColor).
+		Width(innerWidth).
+		Height(innerHeight).
+		MaxHeight(height).
+		Render(strings.Join(lines, "\n"))
+}
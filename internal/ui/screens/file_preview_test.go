@@ -0,0 +1,88 @@
+package screens
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePreviewFile(t *testing.T, dir, name string, content []byte) *scanner.FileNode {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+	return &scanner.FileNode{Name: name, Path: path, Size: int64(len(content))}
+}
+
+func TestReadFilePreview(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	var long strings.Builder
+	for i := 1; i <= 100; i++ {
+		fmt.Fprintf(&long, "line %d\n", i)
+	}
+	longFile := writePreviewFile(t, dir, "long.txt", []byte(long.String()))
+	preview := readFilePreview(longFile, 0)
+	lines := strings.Split(preview, "\n")
+	assert.Len(t, lines, previewMaxLines)
+	assert.Equal(t, "line 1", lines[0])
+	assert.Equal(t, "line 40", lines[previewMaxLines-1])
+
+	tabbed := writePreviewFile(t, dir, "main.go", []byte("func main() {\n\treturn\n}\n"))
+	assert.Equal(t, "func main() {\n    return\n}", readFilePreview(tabbed, 0))
+
+	binary := writePreviewFile(t, dir, "image.png", []byte{0x89, 'P', 'N', 'G', 0x00, 0x01})
+	assert.Equal(t, "[binary]", readFilePreview(binary, 0))
+
+	assert.Equal(t, "[too large to preview]", readFilePreview(longFile, 10))
+
+	empty := writePreviewFile(t, dir, "empty.txt", nil)
+	assert.Equal(t, "[empty file]", readFilePreview(empty, 0))
+
+	missing := &scanner.FileNode{Name: "gone.txt", Path: filepath.Join(dir, "gone.txt")}
+	assert.Contains(t, readFilePreview(missing, 0), "[cannot read")
+}
+
+func TestFileSelectionPreviewToggle(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	root := &scanner.FileNode{Name: "root", Path: dir, IsDir: true, Expanded: true}
+	for _, name := range []string{"a.txt", "b.txt"} {
+		node := writePreviewFile(t, dir, name, []byte("contents of "+name+"\n"))
+		node.Parent = root
+		root.Children = append(root.Children, node)
+	}
+
+	model := NewFileSelection(root, nil, "")
+	model.SetSize(120, 30)
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	require.True(t, model.IsPreviewShown())
+	assert.Empty(t, model.preview.path, "directories are never read")
+	assert.Contains(t, model.View(), "Move to a file to preview it")
+
+	model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, filepath.Join(dir, "a.txt"), model.preview.path)
+	assert.Contains(t, model.View(), "contents of a.txt")
+
+	// The cached preview is kept while the cursor stays on the file
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("changed\n"), 0o600))
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	assert.Equal(t, "contents of a.txt", model.preview.text)
+
+	model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Contains(t, model.View(), "contents of b.txt")
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	assert.False(t, model.IsPreviewShown())
+	assert.NotContains(t, model.View(), "contents of b.txt")
+}
@@ -20,6 +20,9 @@ const (
 
 type RescanRequestMsg struct{}
 
+// ExportSelectionRequestMsg asks the wizard to save the current selection as JSON.
+type ExportSelectionRequestMsg struct{}
+
 type FileSelectionModel struct {
 	tree       *components.FileTreeModel
 	width      int
@@ -35,6 +38,20 @@ type FileSelectionModel struct {
 
 	maxSizeBytes int64
 	maxSizeStr   string
+
+	// rescanNote reports selections dropped by the last rescan until the next key press
+	rescanNote string
+	// inaccessibleNote reports the directories the last scan could not read
+	inaccessibleNote string
+	// exportNote reports the result of the last selection export until the next key press
+	exportNote string
+	exportErr  bool
+
+	// showPreview splits the screen to show the file under the cursor; previewMaxSize
+	// is the scanner's max file size, above which files are not previewed
+	showPreview    bool
+	previewMaxSize int64
+	preview        filePreview
 }
 
 func NewFileSelection(fileTree *scanner.FileNode, selections map[string]bool, maxSizeStr string) *FileSelectionModel {
@@ -66,8 +83,45 @@ func (m *FileSelectionModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
 	if m.tree != nil {
-		m.tree.SetSize(width, height-fileSelectionHeaderFooterHeight)
+		m.tree.SetSize(m.treeWidth(), height-fileSelectionHeaderFooterHeight)
+	}
+}
+
+// SetPreviewMaxSize sets the size above which files are shown as too large to preview
+// (0 = no limit).
+func (m *FileSelectionModel) SetPreviewMaxSize(maxFileSize int64) {
+	m.previewMaxSize = maxFileSize
+}
+
+// treeWidth is the width left to the tree, which shares the screen with the preview.
+func (m *FileSelectionModel) treeWidth() int {
+	if m.showPreview {
+		return m.width * 3 / 5
+	}
+	return m.width
+}
+
+func (m *FileSelectionModel) togglePreview() {
+	m.showPreview = !m.showPreview
+	m.SetSize(m.width, m.height)
+}
+
+// refreshPreview reads the file under the cursor when the preview is shown and it
+// is not the file read last.
+func (m *FileSelectionModel) refreshPreview() {
+	if !m.showPreview || m.tree == nil {
+		return
 	}
+	node := m.tree.CursorNode()
+	if node == nil || node.IsDir || node.Path == m.preview.path {
+		return
+	}
+	m.preview = filePreview{path: node.Path, text: readFilePreview(node, m.previewMaxSize)}
+}
+
+// IsPreviewShown reports whether the file preview panel is shown.
+func (m *FileSelectionModel) IsPreviewShown() bool {
+	return m.showPreview
 }
 
 func (m *FileSelectionModel) Init() tea.Cmd {
@@ -88,6 +142,8 @@ func (m *FileSelectionModel) Update(msg tea.Msg) tea.Cmd {
 	if !ok || m.tree == nil {
 		return nil
 	}
+	m.rescanNote = ""
+	m.exportNote = ""
 
 	if m.filterMode {
 		return m.handleFilterMode(keyMsg)
@@ -131,10 +187,10 @@ func (m *FileSelectionModel) handleNormalMode(msg tea.KeyMsg) tea.Cmd {
 		m.tree.ToggleSelection()
 		m.syncSelections()
 	case "a":
-		m.tree.SelectAllVisible()
+		m.tree.SelectAllFiltered()
 		m.syncSelections()
 	case "A":
-		m.tree.DeselectAllVisible()
+		m.tree.DeselectAllFiltered()
 		m.syncSelections()
 	case "i":
 		m.tree.ToggleShowIgnored()
@@ -147,7 +203,14 @@ func (m *FileSelectionModel) handleNormalMode(msg tea.KeyMsg) tea.Cmd {
 		}
 	case "x":
 		m.tree.ClearFilter()
+	case "p":
+		m.togglePreview()
+	case "e":
+		return func() tea.Msg {
+			return ExportSelectionRequestMsg{}
+		}
 	}
+	m.refreshPreview()
 
 	return nil
 }
@@ -199,6 +262,13 @@ func (m *FileSelectionModel) View() string {
 		treeView = m.spinner.View() + " Scanning directory..."
 	} else if m.tree != nil {
 		treeView = m.tree.View()
+		if m.showPreview {
+			treeWidth := m.treeWidth()
+			previewHeight := max(m.height-fileSelectionHeaderFooterHeight, 3)
+			treeView = lipgloss.JoinHorizontal(lipgloss.Top,
+				lipgloss.NewStyle().Width(treeWidth).MaxWidth(treeWidth).Render(treeView),
+				renderFilePreview(m.tree.CursorNode(), m.preview.text, m.width-treeWidth, previewHeight))
+		}
 	} else {
 		treeView = "No files to display"
 	}
@@ -221,8 +291,10 @@ func (m *FileSelectionModel) View() string {
 			"i: Ignored",
 			"/: Filter",
 			"x: Clear",
+			"p: Preview",
 		}
 		line2 := []string{
+			"e: Export",
 			"F5: Rescan",
 			"F7: Back",
 			"F8: Next",
@@ -244,6 +316,23 @@ func (m *FileSelectionModel) View() string {
 		content.WriteString("\n")
 	}
 
+	if m.rescanNote != "" {
+		content.WriteString(styles.RenderWarning(m.rescanNote))
+		content.WriteString("\n")
+	}
+	if m.inaccessibleNote != "" {
+		content.WriteString(styles.RenderWarning(m.inaccessibleNote))
+		content.WriteString("\n")
+	}
+	if m.exportNote != "" {
+		if m.exportErr {
+			content.WriteString(styles.RenderWarning(m.exportNote))
+		} else {
+			content.WriteString(styles.RenderSuccess(m.exportNote))
+		}
+		content.WriteString("\n")
+	}
+
 	content.WriteString("\n")
 	content.WriteString(treeView)
 	content.WriteString("\n")
@@ -252,13 +341,83 @@ func (m *FileSelectionModel) View() string {
 	return content.String()
 }
 
+// SetFileTree shows tree. When it replaces an earlier tree (a rescan), selections of
+// files that no longer exist are dropped and expanded directories stay expanded.
 func (m *FileSelectionModel) SetFileTree(tree *scanner.FileNode) {
+	var expanded map[string]bool
+	m.rescanNote = ""
+	if m.fileTree != nil && m.tree != nil {
+		expanded = m.tree.ExpandedPaths()
+		switch dropped := m.pruneSelections(tree); {
+		case dropped == 1:
+			m.rescanNote = "1 previously-selected file no longer exists"
+		case dropped > 1:
+			m.rescanNote = fmt.Sprintf("%d previously-selected files no longer exist", dropped)
+		}
+	}
+
 	m.fileTree = tree
 	m.tree = components.NewFileTree(tree, m.selections)
 	m.loading = false
+	m.preview = filePreview{}
 	if m.tree != nil {
-		m.tree.SetSize(m.width, m.height-fileSelectionHeaderFooterHeight)
+		m.tree.RestoreExpanded(expanded)
+		m.tree.SetSize(m.treeWidth(), m.height-fileSelectionHeaderFooterHeight)
+	}
+	m.refreshPreview()
+}
+
+// pruneSelections removes the selections whose path is not in tree and returns how many.
+func (m *FileSelectionModel) pruneSelections(tree *scanner.FileNode) int {
+	existing := scanner.CollectAllSelections(tree, nil)
+
+	dropped := 0
+	for path := range m.selections {
+		if !existing[path] {
+			delete(m.selections, path)
+			dropped++
+		}
+	}
+
+	return dropped
+}
+
+// SetInaccessible shows how many directories the last scan skipped because they could
+// not be read; they stay marked in the tree.
+func (m *FileSelectionModel) SetInaccessible(dirs []string) {
+	switch len(dirs) {
+	case 0:
+		m.inaccessibleNote = ""
+	case 1:
+		m.inaccessibleNote = fmt.Sprintf("1 directory skipped (permission denied or unreadable): %s", dirs[0])
+	default:
+		m.inaccessibleNote = fmt.Sprintf("%d directories skipped (permission denied or unreadable)", len(dirs))
+	}
+}
+
+// InaccessibleNote returns the note about directories the last scan could not read, if any.
+func (m *FileSelectionModel) InaccessibleNote() string {
+	return m.inaccessibleNote
+}
+
+// SetExportResult shows the outcome of exporting count selected files to path.
+func (m *FileSelectionModel) SetExportResult(path string, count int, err error) {
+	m.exportErr = err != nil
+	if err != nil {
+		m.exportNote = fmt.Sprintf("Selection not exported: %v", err)
+		return
 	}
+	m.exportNote = fmt.Sprintf("Exported %d selected files to %s", count, path)
+}
+
+// ExportNote returns the note about the last selection export, if any.
+func (m *FileSelectionModel) ExportNote() string {
+	return m.exportNote
+}
+
+// RescanNote returns the note about selections dropped by the last rescan, if any.
+func (m *FileSelectionModel) RescanNote() string {
+	return m.rescanNote
 }
 
 func (m *FileSelectionModel) IsLoading() bool {
@@ -581,6 +581,34 @@ func TestFileSelection_View_AfterSetFileTree_ShowsTree(t *testing.T) {
 	assert.Contains(t, view2, "file.go")
 }
 
+func TestFileSelection_SetFileTree_RescanKeepsSelections(t *testing.T) {
+	t.Parallel()
+
+	newTree := func(files ...string) *scanner.FileNode {
+		root := &scanner.FileNode{Name: "root", Path: "/root", IsDir: true}
+		for _, name := range files {
+			root.Children = append(root.Children, &scanner.FileNode{Name: name, Path: "/root/" + name, Parent: root})
+		}
+		return root
+	}
+
+	selections := map[string]bool{"/root/a.go": true, "/root/b.go": true, "/root/c.go": true}
+	model := NewFileSelection(newTree("a.go", "b.go", "c.go"), selections, "")
+	model.SetSize(80, 24)
+
+	model.SetFileTree(newTree("a.go", "d.go"))
+
+	assert.Equal(t, map[string]bool{"/root/a.go": true}, model.GetSelections())
+	assert.Equal(t, "2 previously-selected files no longer exist", model.RescanNote())
+	assert.Contains(t, model.View(), "2 previously-selected files no longer exist")
+
+	model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Empty(t, model.RescanNote(), "the note should clear on the next key press")
+
+	model.SetFileTree(newTree("a.go"))
+	assert.Empty(t, model.RescanNote(), "no note when every selected file still exists")
+}
+
 func TestFileSelection_IsLoading(t *testing.T) {
 	t.Parallel()
 
@@ -274,3 +274,35 @@ func TestInputModelsUseTextarea(t *testing.T) {
 	assert.IsType(t, textarea.Model{}, taskModel.textarea)
 	assert.IsType(t, textarea.Model{}, rulesModel.textarea)
 }
+
+func TestVariablesInput(t *testing.T) {
+	model := NewVariablesInput(
+		[]string{"TEAM", "TICKET"},
+		map[string]string{"TICKET": "ABC-1"},
+		map[string]string{"TEAM": "core"},
+	)
+	model.SetSize(80, 24)
+
+	assert.Equal(t, map[string]string{"TEAM": "", "TICKET": "ABC-1"}, model.GetValues())
+
+	// Typing goes to the focused field; Tab moves to the next one and wraps
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("web")})
+	model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("9")})
+	assert.Equal(t, map[string]string{"TEAM": "web", "TICKET": "ABC-19"}, model.GetValues())
+	model.Update(tea.KeyMsg{Type: tea.KeyTab})
+	assert.Equal(t, 0, model.focus)
+	model.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	assert.Equal(t, 1, model.focus)
+
+	view := model.View()
+	assert.Contains(t, view, "Fill In Template Variables")
+	assert.Contains(t, view, "TEAM")
+	assert.Contains(t, view, "▶ TICKET")
+}
+
+func TestVariablesInputPlaceholderShowsDefault(t *testing.T) {
+	model := NewVariablesInput([]string{"TEAM"}, nil, map[string]string{"TEAM": "core"})
+
+	assert.Contains(t, model.View(), "Default: core")
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/quantmind-br/shotgun-cli/internal/core/llm"
 	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
 	"github.com/quantmind-br/shotgun-cli/internal/core/template"
 	"github.com/quantmind-br/shotgun-cli/internal/core/tokens"
@@ -26,6 +27,12 @@ type ReviewModel struct {
 	generated       bool
 	generatedPath   string
 	clipboardCopied bool
+	clipboardMode   string // output.clipboard-mode: content (default), path or none
+	clipboardSize   int64  // Bytes copied to the clipboard
+	// clipboardMismatch is set when output.clipboard-verify read back less than was copied
+	clipboardMismatch bool
+	redactedSecrets   int // Secrets context.redact replaced, in redactedFiles files
+	redactedFiles     int
 
 	totalBytes  int64
 	totalTokens int
@@ -40,9 +47,13 @@ type ReviewModel struct {
 	llmOutputFile string
 	llmDuration   time.Duration
 	llmError      error
+	llmPartial    string // Response text streamed so far by the send in flight
 
 	viewport      viewport.Model
 	viewportReady bool
+
+	// expanded shows the full task and rules wrapped to the screen width
+	expanded bool
 }
 
 func NewReview(
@@ -71,13 +82,26 @@ func NewReview(
 	return m
 }
 
-const footerHeight = 4
+const (
+	footerHeight = 4
+
+	// llmPartialLines is how many trailing lines of a streaming response are shown
+	llmPartialLines = 3
+
+	taskPreviewLimit  = 150
+	rulesPreviewLimit = 100
+)
 
 // SetLLMAvailable sets whether LLM provider is available for sending.
 func (m *ReviewModel) SetLLMAvailable(available bool) {
 	m.llmAvailable = available
 }
 
+// SetClipboardMode sets what is copied after generation: "content", "path" or "none".
+func (m *ReviewModel) SetClipboardMode(mode string) {
+	m.clipboardMode = mode
+}
+
 func (m *ReviewModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
@@ -95,6 +119,9 @@ type (
 	GenerationCompleteMsg struct {
 		Content  string
 		FilePath string
+		// RedactedSecrets is the number of secrets redacted from RedactedFiles files
+		RedactedSecrets int
+		RedactedFiles   int
 	}
 
 	// GenerationErrorMsg indicates context generation failed
@@ -104,8 +131,10 @@ type (
 
 	// ClipboardCompleteMsg indicates clipboard operation finished
 	ClipboardCompleteMsg struct {
-		Success bool
-		Err     error
+		Success  bool
+		Err      error
+		Size     int64 // Bytes copied
+		Mismatch bool  // The clipboard read back differs from the copy
 	}
 
 	// LLMProgressMsg indicates LLM send progress
@@ -133,6 +162,7 @@ func (m *ReviewModel) HandleMessage(msg tea.Msg) (handled bool, cmd tea.Cmd) {
 	case ClipboardCompleteMsg:
 		if m.generatedPath != "" {
 			m.clipboardCopied = msg.Success
+			m.SetClipboardCheck(msg.Size, msg.Mismatch)
 		}
 		return true, nil
 
@@ -168,6 +198,9 @@ func (m *ReviewModel) Update(msg tea.Msg) tea.Cmd {
 				return ClipboardCopyRequestMsg{}
 			}
 		}
+	case "v":
+		m.expanded = !m.expanded
+		m.viewport.GotoTop()
 	case "up", "k":
 		m.viewport.ScrollUp(1)
 	case "down", "j":
@@ -194,7 +227,7 @@ func (m *ReviewModel) View() string {
 }
 
 func (m *ReviewModel) buildScrollableContent() string {
-	header := styles.RenderHeader(5, "Review & Generate")
+	header := styles.RenderHeader(6, "Review & Generate")
 
 	var content strings.Builder
 	content.WriteString(header)
@@ -251,12 +284,7 @@ func (m *ReviewModel) buildScrollableContent() string {
 	taskLabel := styles.TitleStyle.Render("Task:")
 	content.WriteString(taskIcon + " " + taskLabel)
 	content.WriteString("\n")
-	taskPreview := m.taskDesc
-	if len(taskPreview) > 150 {
-		taskPreview = taskPreview[:147] + "..."
-	}
-	taskStyled := lipgloss.NewStyle().Foreground(styles.TextColor).Render("  " + taskPreview)
-	content.WriteString(taskStyled)
+	content.WriteString(m.renderTextPreview(m.taskDesc, taskPreviewLimit))
 	content.WriteString("\n\n")
 
 	if strings.TrimSpace(m.rules) != "" {
@@ -264,12 +292,7 @@ func (m *ReviewModel) buildScrollableContent() string {
 		rulesLabel := styles.TitleStyle.Render("Rules & Constraints:")
 		content.WriteString(rulesIcon + " " + rulesLabel)
 		content.WriteString("\n")
-		rulesPreview := m.rules
-		if len(rulesPreview) > 100 {
-			rulesPreview = rulesPreview[:97] + "..."
-		}
-		rulesStyled := lipgloss.NewStyle().Foreground(styles.TextColor).Render("  " + rulesPreview)
-		content.WriteString(rulesStyled)
+		content.WriteString(m.renderTextPreview(m.rules, rulesPreviewLimit))
 		content.WriteString("\n\n")
 	}
 
@@ -285,9 +308,37 @@ func (m *ReviewModel) buildScrollableContent() string {
 	return content.String()
 }
 
+// renderTextPreview renders task or rules text. The summary view truncates it to limit
+// characters; the expanded view shows the full text wrapped to the screen width.
+func (m *ReviewModel) renderTextPreview(text string, limit int) string {
+	style := lipgloss.NewStyle().Foreground(styles.TextColor)
+
+	if !m.expanded {
+		if len(text) > limit {
+			text = text[:limit-3] + "..."
+		}
+		return style.Render("  " + text)
+	}
+
+	style = style.PaddingLeft(2)
+	if m.width > 0 {
+		style = style.Width(m.width)
+	}
+
+	return style.Render(text)
+}
+
+func (m *ReviewModel) expandHint() string {
+	if m.expanded {
+		return "v: Collapse"
+	}
+
+	return "v: Expand"
+}
+
 func (m *ReviewModel) renderFixedFooter() string {
 	if m.generated {
-		line1 := []string{"↑/↓: Scroll", "c: Copy"}
+		line1 := []string{"↑/↓: Scroll", "c: Copy", m.expandHint()}
 		if m.llmAvailable && !m.llmSending && !m.llmComplete {
 			line1 = append(line1, "F9: LLM")
 		}
@@ -295,7 +346,7 @@ func (m *ReviewModel) renderFixedFooter() string {
 		return styles.RenderFooter(line1) + "\n" + styles.RenderFooter(line2)
 	}
 
-	line1 := []string{"↑/↓: Scroll", "F7: Back", "F8: Generate"}
+	line1 := []string{"↑/↓: Scroll", m.expandHint(), "F7: Back", "F8: Generate"}
 	line2 := []string{"F1/?: Help", "q: Quit"}
 	return styles.RenderFooter(line1) + "\n" + styles.RenderFooter(line2)
 }
@@ -312,12 +363,33 @@ func (m *ReviewModel) renderGenerationStatusContent() string {
 		view.WriteString(fileIcon + " Saved to: " + pathStyled)
 		view.WriteString("\n")
 	}
+	if m.redactedSecrets > 0 {
+		lockIcon := lipgloss.NewStyle().Foreground(styles.AccentColor).Render("🔒")
+		view.WriteString(fmt.Sprintf("%s Redacted %d secrets in %d files\n",
+			lockIcon, m.redactedSecrets, m.redactedFiles))
+	}
 
-	if m.clipboardCopied {
+	switch {
+	case m.clipboardMode == "none":
+		// Clipboard copy disabled by configuration
+	case m.clipboardCopied && m.clipboardMismatch:
+		clipIcon := lipgloss.NewStyle().Foreground(styles.WarningColor).Render("📋")
+		clipText := styles.WarningStyle.Render("Clipboard holds an incomplete copy")
+		view.WriteString(clipIcon + " " + clipText)
+		view.WriteString("\n")
+		tip := styles.HelpStyle.Render("   Read the full context from " + m.generatedPath)
+		view.WriteString(tip)
+	case m.clipboardCopied:
 		clipIcon := lipgloss.NewStyle().Foreground(styles.SuccessColor).Render("📋")
-		clipText := styles.SuccessStyle.Render("Copied to clipboard")
+		label := "Copied to clipboard"
+		if m.clipboardMode == "path" {
+			label = "File path copied to clipboard"
+		} else if m.clipboardSize > 0 {
+			label += " (" + formatSizeHelper(m.clipboardSize) + ")"
+		}
+		clipText := styles.SuccessStyle.Render(label)
 		view.WriteString(clipIcon + " " + clipText)
-	} else {
+	default:
 		clipIcon := lipgloss.NewStyle().Foreground(styles.WarningColor).Render("📋")
 		clipText := styles.WarningStyle.Render("Clipboard copy failed")
 		view.WriteString(clipIcon + " " + clipText)
@@ -346,7 +418,13 @@ func (m *ReviewModel) renderPreGenerationContent() string {
 	items := []string{
 		"Create a comprehensive context file",
 		"Save it as shotgun-prompt-YYYYMMDD-HHMMSS.md",
-		"Copy the content to your clipboard",
+	}
+	switch m.clipboardMode {
+	case "none":
+	case "path":
+		items = append(items, "Copy the file path to your clipboard")
+	default:
+		items = append(items, "Copy the content to your clipboard")
 	}
 
 	for _, item := range items {
@@ -384,6 +462,7 @@ func (m *ReviewModel) renderLLMStatus() string {
 		sendingStyle := lipgloss.NewStyle().Foreground(styles.PrimaryColor)
 		elapsed := time.Since(m.llmStartTime).Round(time.Second)
 		status.WriteString("  " + sendingStyle.Render(fmt.Sprintf("⏳ Sending to LLM... (%s)", elapsed)))
+		status.WriteString(m.renderLLMPartial())
 	} else if m.llmComplete {
 		// Complete state
 		successIcon := lipgloss.NewStyle().Foreground(styles.SuccessColor).Render("✔")
@@ -398,6 +477,9 @@ func (m *ReviewModel) renderLLMStatus() string {
 		errorIcon := lipgloss.NewStyle().Foreground(styles.ErrorColor).Render("✖")
 		errorText := styles.ErrorStyle.Render(m.llmError.Error())
 		status.WriteString("  " + errorIcon + " Error: " + errorText)
+		if hint := llm.ErrorHint(m.llmError); hint != "" {
+			status.WriteString("\n    " + styles.HelpStyle.Render(hint))
+		}
 	} else if m.llmAvailable {
 		// Ready state
 		readyStyle := lipgloss.NewStyle().Foreground(styles.AccentColor)
@@ -420,15 +502,54 @@ func (m *ReviewModel) SetGenerated(filePath string, clipboardSuccess bool) {
 	m.clipboardCopied = clipboardSuccess
 }
 
+// SetRedacted records how many secrets redaction replaced, and in how many files.
+func (m *ReviewModel) SetRedacted(secrets, files int) {
+	m.redactedSecrets = secrets
+	m.redactedFiles = files
+}
+
+// SetClipboardCheck records the size of the clipboard copy and whether reading it
+// back found it incomplete.
+func (m *ReviewModel) SetClipboardCheck(size int64, mismatch bool) {
+	m.clipboardSize = size
+	m.clipboardMismatch = mismatch
+}
+
+// renderLLMPartial shows the last lines of the response streamed so far.
+func (m *ReviewModel) renderLLMPartial() string {
+	text := strings.TrimRight(m.llmPartial, "\n")
+	if text == "" {
+		return ""
+	}
+
+	lines := strings.Split(text, "\n")
+	if len(lines) > llmPartialLines {
+		lines = lines[len(lines)-llmPartialLines:]
+	}
+
+	var partial strings.Builder
+	for _, line := range lines {
+		partial.WriteString("\n  ")
+		partial.WriteString(styles.HelpStyle.Render(line))
+	}
+	return partial.String()
+}
+
 // SetLLMSending sets the LLM sending state
 func (m *ReviewModel) SetLLMSending(sending bool) {
 	m.llmSending = sending
 	m.llmError = nil
 	if sending {
 		m.llmStartTime = time.Now()
+		m.llmPartial = ""
 	}
 }
 
+// AppendLLMResponse adds streamed response text shown while sending.
+func (m *ReviewModel) AppendLLMResponse(delta string) {
+	m.llmPartial += delta
+}
+
 // SetLLMComplete sets the LLM complete state
 func (m *ReviewModel) SetLLMComplete(outputFile string, duration time.Duration) {
 	m.llmSending = false
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
 	"github.com/quantmind-br/shotgun-cli/internal/core/template"
 )
@@ -239,6 +240,34 @@ func TestReviewModel_View_AfterGeneration(t *testing.T) {
 	}
 }
 
+func TestReviewModel_View_AfterGeneration_ClipboardCheck(t *testing.T) {
+	t.Parallel()
+
+	tmpl := &template.Template{Name: "Test", Content: "Content"}
+	fileTree := &scanner.FileNode{Name: "root", Path: "/path", IsDir: true}
+
+	m := NewReview(map[string]bool{}, fileTree, tmpl, "Task", "Rules", "")
+	m.SetSize(80, 40)
+	m.SetGenerated("/tmp/test.md", true)
+	m.SetClipboardCheck(2048, false)
+
+	if view := m.View(); !strings.Contains(view, "Copied to clipboard (2.0 KB)") {
+		t.Fatalf("expected clipboard size in view:\n%s", view)
+	}
+
+	handled, _ := m.HandleMessage(ClipboardCompleteMsg{Success: true, Size: 2048, Mismatch: true})
+	if !handled {
+		t.Fatalf("expected ClipboardCompleteMsg to be handled")
+	}
+	view := m.View()
+	if !strings.Contains(view, "Clipboard holds an incomplete copy") {
+		t.Fatalf("expected clipboard mismatch warning in view:\n%s", view)
+	}
+	if !strings.Contains(view, "Read the full context from /tmp/test.md") {
+		t.Fatalf("expected output file path in the mismatch warning")
+	}
+}
+
 func TestReviewModel_View_AfterGeneration_ClipboardFailed(t *testing.T) {
 	t.Parallel()
 
@@ -668,6 +697,33 @@ func TestReview_ViewGeminiSending(t *testing.T) {
 	}
 }
 
+func TestReview_RenderLLMPartial(t *testing.T) {
+	t.Parallel()
+
+	m := NewReview(nil, nil, nil, "", "", "")
+	m.SetLLMSending(true)
+	if got := m.renderLLMPartial(); got != "" {
+		t.Fatalf("expected no partial response before any delta, got %q", got)
+	}
+
+	m.AppendLLMResponse("line 1\nline 2\nli")
+	m.AppendLLMResponse("ne 3\nline 4\n")
+	got := m.renderLLMPartial()
+	if strings.Contains(got, "line 1") {
+		t.Errorf("expected only the last %d lines, got %q", llmPartialLines, got)
+	}
+	for _, want := range []string{"line 2", "line 3", "line 4"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in partial response, got %q", want, got)
+		}
+	}
+
+	m.SetLLMSending(true)
+	if got := m.renderLLMPartial(); got != "" {
+		t.Errorf("expected a new send to clear the partial response, got %q", got)
+	}
+}
+
 func TestReview_ViewGeminiComplete(t *testing.T) {
 	t.Parallel()
 
@@ -955,3 +1011,72 @@ func TestReviewModel_ExistingKeysStillWork(t *testing.T) {
 		t.Fatalf("'c' key should return clipboard copy command when generated")
 	}
 }
+
+func TestReviewModel_ExpandedShowsFullTaskAndRules(t *testing.T) {
+	t.Parallel()
+
+	longTask := strings.Repeat("task ", 60)
+	longRules := strings.Repeat("rule ", 40)
+	m := NewReview(nil, nil, nil, longTask, longRules, "")
+	m.SetSize(200, 200)
+
+	summary := m.View()
+	if !strings.Contains(summary, "...") {
+		t.Fatalf("summary view should truncate long task")
+	}
+	if !strings.Contains(summary, "v: Expand") {
+		t.Fatalf("expected expand hint in footer")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	if !m.expanded {
+		t.Fatalf("'v' should enable expanded mode")
+	}
+	m.SetSize(60, 200)
+
+	expanded := m.View()
+	if strings.Count(expanded, "task") != 60 {
+		t.Fatalf("expanded view should contain the full task, got %d words", strings.Count(expanded, "task"))
+	}
+	if strings.Count(expanded, "rule") != 40 {
+		t.Fatalf("expanded view should contain the full rules, got %d words", strings.Count(expanded, "rule"))
+	}
+	for _, line := range strings.Split(expanded, "\n") {
+		if w := lipgloss.Width(line); w > 60 {
+			t.Fatalf("expanded line exceeds terminal width (%d > 60): %q", w, line)
+		}
+	}
+	if !strings.Contains(expanded, "v: Collapse") {
+		t.Fatalf("expected collapse hint in footer")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	if m.expanded {
+		t.Fatalf("second 'v' should collapse")
+	}
+}
+
+func TestReviewModel_ExpandedScrollsWhenOverflowing(t *testing.T) {
+	t.Parallel()
+
+	m := NewReview(nil, nil, nil, strings.Repeat("word ", 400), "", "")
+	m.SetSize(40, 12)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	_ = m.View()
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if m.viewport.YOffset != 1 {
+		t.Fatalf("expected scroll offset 1 after down, got %d", m.viewport.YOffset)
+	}
+
+	m.SetSize(80, 12)
+	_ = m.View()
+	if m.viewport.Width != 80 {
+		t.Fatalf("SetSize should resize viewport, got width %d", m.viewport.Width)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	if m.viewport.YOffset != 0 {
+		t.Fatalf("toggling expand should reset scroll, got offset %d", m.viewport.YOffset)
+	}
+}
@@ -267,8 +267,8 @@ func (m *TemplateSelectionModel) renderFooter() string {
 
 	nextAction := "F8: Next"
 	if m.selectedTemplate != nil {
-		requiresTask := m.selectedTemplate.HasVariable(template.VarTask)
-		requiresRules := m.selectedTemplate.HasVariable(template.VarRules)
+		requiresTask := m.selectedTemplate.RequiresVariable(template.VarTask)
+		requiresRules := m.selectedTemplate.RequiresVariable(template.VarRules)
 
 		if !requiresTask && !requiresRules {
 			nextAction = "F8: Skip to Review"
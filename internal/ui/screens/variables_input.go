@@ -0,0 +1,167 @@
+package screens
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/quantmind-br/shotgun-cli/internal/ui/styles"
+)
+
+const (
+	variablesInputHorizontalPadding = 6
+	variablesInputMinWidth          = 20
+)
+
+// VariablesInputModel collects the values of the template variables the wizard does
+// not fill in itself, with one single-line field per variable.
+type VariablesInputModel struct {
+	names    []string
+	inputs   []textinput.Model
+	defaults map[string]string
+	focus    int
+	width    int
+	height   int
+}
+
+// NewVariablesInput creates a field for each of names, starting from values. A field
+// left empty shows its default from defaults, which generation then uses.
+func NewVariablesInput(names []string, values, defaults map[string]string) *VariablesInputModel {
+	inputs := make([]textinput.Model, len(names))
+	for i, name := range names {
+		ti := textinput.New()
+		ti.Prompt = ""
+		ti.Width = variablesInputMinWidth
+		ti.Placeholder = "Value for {" + name + "}"
+		if value := defaults[name]; value != "" {
+			ti.Placeholder = "Default: " + value
+		}
+		ti.TextStyle = lipgloss.NewStyle().Foreground(styles.TextColor)
+		ti.PlaceholderStyle = styles.InputPlaceholderStyle
+		ti.Cursor.Style = lipgloss.NewStyle().Foreground(styles.AccentColor)
+		ti.SetValue(values[name])
+		inputs[i] = ti
+	}
+	if len(inputs) > 0 {
+		inputs[0].Focus()
+	}
+
+	return &VariablesInputModel{
+		names:    names,
+		inputs:   inputs,
+		defaults: defaults,
+	}
+}
+
+func (m *VariablesInputModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+
+	availableWidth := max(width-variablesInputHorizontalPadding-m.labelWidth(), variablesInputMinWidth)
+	for i := range m.inputs {
+		m.inputs[i].Width = availableWidth
+	}
+}
+
+func (m *VariablesInputModel) Update(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || len(m.inputs) == 0 {
+		return nil
+	}
+
+	switch keyMsg.String() {
+	case "tab", "down", "enter":
+		return m.setFocus((m.focus + 1) % len(m.inputs))
+	case "shift+tab", "up":
+		return m.setFocus((m.focus + len(m.inputs) - 1) % len(m.inputs))
+	}
+
+	var cmd tea.Cmd
+	m.inputs[m.focus], cmd = m.inputs[m.focus].Update(keyMsg)
+
+	return cmd
+}
+
+// setFocus moves the cursor to the field at index.
+func (m *VariablesInputModel) setFocus(index int) tea.Cmd {
+	m.inputs[m.focus].Blur()
+	m.focus = index
+
+	return m.inputs[m.focus].Focus()
+}
+
+func (m *VariablesInputModel) View() string {
+	header := styles.RenderHeader(5, "Fill In Template Variables")
+
+	instructions := styles.HelpStyle.Render(
+		"The template uses variables beyond the task and rules. " +
+			"Fields left empty use the template's default, if it has one.")
+
+	labelWidth := m.labelWidth()
+	var fields strings.Builder
+	for i, input := range m.inputs {
+		label := "  " + m.names[i]
+		style := styles.StatusInactiveStyle
+		if i == m.focus {
+			label = "▶ " + m.names[i]
+			style = styles.StatusActiveStyle
+		}
+		fields.WriteString(style.Width(labelWidth).Render(label))
+		fields.WriteString(input.View())
+		fields.WriteString("\n")
+	}
+
+	var content strings.Builder
+	content.WriteString(header)
+	content.WriteString("\n\n")
+	content.WriteString(instructions)
+	content.WriteString("\n\n")
+	content.WriteString(fields.String())
+
+	line1 := []string{
+		"Type: Enter text",
+		"Tab/↓: Next field",
+		"Shift+Tab/↑: Previous field",
+	}
+	line2 := []string{
+		"F7: Back",
+		"F8: Next",
+		"F1: Help",
+		"Ctrl+Q/Ctrl+C: Quit",
+	}
+	footer := styles.RenderFooter(line1) + "\n" + styles.RenderFooter(line2)
+	content.WriteString("\n")
+	content.WriteString(footer)
+
+	return content.String()
+}
+
+// labelWidth is the width of the variable name column, wide enough for the longest name.
+func (m *VariablesInputModel) labelWidth() int {
+	width := 0
+	for _, name := range m.names {
+		width = max(width, lipgloss.Width(name))
+	}
+
+	return width + 4
+}
+
+// GetValues returns the value entered for each variable, keyed by name.
+func (m *VariablesInputModel) GetValues() map[string]string {
+	values := make(map[string]string, len(m.names))
+	for i, name := range m.names {
+		values[name] = m.inputs[i].Value()
+	}
+
+	return values
+}
+
+// SetValueForTest sets the value of the named variable's field.
+func (m *VariablesInputModel) SetValueForTest(name, value string) {
+	for i, n := range m.names {
+		if n == name {
+			m.inputs[i].SetValue(value)
+		}
+	}
+}
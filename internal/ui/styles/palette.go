@@ -0,0 +1,56 @@
+package styles
+
+import "github.com/charmbracelet/lipgloss"
+
+// Palette holds the semantic colors users can remap, as lipgloss color strings: a hex
+// value such as "#88C0D0" or an ANSI 256 color number such as "33". An empty field
+// keeps the default.
+type Palette struct {
+	Primary string
+	Accent  string
+	Error   string
+	Warning string
+	Success string
+	Muted   string
+	Text    string
+}
+
+// DefaultPalette returns the built-in Nord dark palette.
+func DefaultPalette() Palette {
+	return Palette{
+		Primary: string(Nord8),
+		Accent:  string(Nord14),
+		Error:   string(Nord11),
+		Warning: string(Nord13),
+		Success: string(Nord14),
+		Muted:   "#7B88A1",
+		Text:    string(Nord4),
+	}
+}
+
+// ApplyPalette sets the semantic colors from palette and rebuilds the styles that use
+// them. Call it before building any UI, since components copy colors when created.
+func ApplyPalette(palette Palette) {
+	defaults := DefaultPalette()
+	pick := func(value, fallback string) lipgloss.Color {
+		if value == "" {
+			return lipgloss.Color(fallback)
+		}
+		return lipgloss.Color(value)
+	}
+
+	PrimaryColor = pick(palette.Primary, defaults.Primary)
+	AccentColor = pick(palette.Accent, defaults.Accent)
+	ErrorColor = pick(palette.Error, defaults.Error)
+	WarningColor = pick(palette.Warning, defaults.Warning)
+	SuccessColor = pick(palette.Success, defaults.Success)
+	MutedColor = pick(palette.Muted, defaults.Muted)
+	DimText = MutedColor
+	TextColor = pick(palette.Text, defaults.Text)
+
+	FileUnselectedColor = MutedColor
+	FileSelectedColor = SuccessColor
+	FilePartialColor = WarningColor
+
+	buildStyles()
+}
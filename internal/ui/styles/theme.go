@@ -48,138 +48,177 @@ var (
 	BrightText     = Nord6                     // White - bright text
 	DimText        = lipgloss.Color("#7B88A1") // High-contrast dim text
 
-	// Base styles with improved Nord colors
+	// Base styles, built from the palette by buildStyles
+	TitleStyle            lipgloss.Style
+	SubtitleStyle         lipgloss.Style
+	SelectedStyle         lipgloss.Style
+	CursorStyle           lipgloss.Style
+	ErrorStyle            lipgloss.Style
+	SuccessStyle          lipgloss.Style
+	WarningStyle          lipgloss.Style
+	HelpStyle             lipgloss.Style
+	BorderStyle           lipgloss.Style
+	FocusedBorderStyle    lipgloss.Style
+	BlurredBorderStyle    lipgloss.Style
+	ProgressStyle         lipgloss.Style
+	TreeStyle             lipgloss.Style
+	StatusActiveStyle     lipgloss.Style
+	StatusInactiveStyle   lipgloss.Style
+	StatusWarningStyle    lipgloss.Style
+	CodeStyle             lipgloss.Style
+	PathStyle             lipgloss.Style
+	InputLabelStyle       lipgloss.Style
+	InputPlaceholderStyle lipgloss.Style
+	StatsLabelStyle       lipgloss.Style
+	StatsValueStyle       lipgloss.Style
+	TokenCountStyle       lipgloss.Style
+)
+
+// SelectionState represents the selection state of a file or directory node
+type SelectionState int
+
+const (
+	SelectionUnselected SelectionState = iota
+	SelectionPartial
+	SelectionSelected
+)
+
+// Selection state colors for file tree
+var (
+	FileUnselectedColor = MutedColor   // Gray
+	FileSelectedColor   = SuccessColor // Green
+	FilePartialColor    = WarningColor // Yellow
+
+	// Styles for file/directory names based on selection state
+	UnselectedNameStyle lipgloss.Style
+	SelectedNameStyle   lipgloss.Style
+	PartialNameStyle    lipgloss.Style
+	GitIgnoredStyle     lipgloss.Style
+	CustomIgnoredStyle  lipgloss.Style
+)
+
+// buildStyles (re)creates the styles above from the current palette colors; it runs at
+// init and again from ApplyPalette.
+func buildStyles() {
 	TitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(PrimaryColor)
+		Bold(true).
+		Foreground(PrimaryColor)
 
 	SubtitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(Nord9)
+		Bold(true).
+		Foreground(Nord9)
 
 	SelectedStyle = lipgloss.NewStyle().
-			Background(Nord10).
-			Foreground(Nord6).
-			Bold(true)
+		Background(Nord10).
+		Foreground(Nord6).
+		Bold(true)
 
 	CursorStyle = lipgloss.NewStyle().
-			Background(Nord10).
-			Foreground(Nord6)
+		Background(Nord10).
+		Foreground(Nord6)
 
 	ErrorStyle = lipgloss.NewStyle().
-			Foreground(ErrorColor).
-			Bold(true)
+		Foreground(ErrorColor).
+		Bold(true)
 
 	SuccessStyle = lipgloss.NewStyle().
-			Foreground(SuccessColor).
-			Bold(true)
+		Foreground(SuccessColor).
+		Bold(true)
 
 	WarningStyle = lipgloss.NewStyle().
-			Foreground(WarningColor).
-			Bold(true)
+		Foreground(WarningColor).
+		Bold(true)
 
 	HelpStyle = lipgloss.NewStyle().
-			Foreground(MutedColor).
-			Italic(true)
+		Foreground(MutedColor).
+		Italic(true)
 
 	// Border styles with rounded corners
 	BorderStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(BorderColor).
-			Padding(1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(BorderColor).
+		Padding(1)
 
 	FocusedBorderStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(PrimaryColor).
-				Padding(1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(PrimaryColor).
+		Padding(1)
 
 	BlurredBorderStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(MutedColor).
-				Padding(1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(MutedColor).
+		Padding(1)
 
 	ProgressStyle = lipgloss.NewStyle().
-			Foreground(AccentColor)
+		Foreground(AccentColor)
 
 	TreeStyle = lipgloss.NewStyle().
-			Foreground(TextColor)
+		Foreground(TextColor)
 
 	// Status indicator styles
 	StatusActiveStyle = lipgloss.NewStyle().
-				Foreground(SuccessColor).
-				Bold(true)
+		Foreground(SuccessColor).
+		Bold(true)
 
 	StatusInactiveStyle = lipgloss.NewStyle().
-				Foreground(MutedColor)
+		Foreground(MutedColor)
 
 	StatusWarningStyle = lipgloss.NewStyle().
-				Foreground(WarningColor)
+		Foreground(WarningColor)
 
 	// Code/content styles
 	CodeStyle = lipgloss.NewStyle().
-			Foreground(Nord7)
+		Foreground(Nord7)
 
 	PathStyle = lipgloss.NewStyle().
-			Foreground(Nord9)
+		Foreground(Nord9)
 
 	// Input field styles
 	InputLabelStyle = lipgloss.NewStyle().
-			Foreground(Nord9).
-			Bold(true)
+		Foreground(Nord9).
+		Bold(true)
 
 	InputPlaceholderStyle = lipgloss.NewStyle().
-				Foreground(DimText).
-				Italic(true)
+		Foreground(DimText).
+		Italic(true)
 
 	// Stats/metrics styles
 	StatsLabelStyle = lipgloss.NewStyle().
-			Foreground(Nord9)
+		Foreground(Nord9)
 
 	StatsValueStyle = lipgloss.NewStyle().
-			Foreground(Nord6).
-			Bold(true)
+		Foreground(Nord6).
+		Bold(true)
 
 	TokenCountStyle = lipgloss.NewStyle().
-			Foreground(Nord15).
-			Bold(true)
-)
-
-// SelectionState represents the selection state of a file or directory node
-type SelectionState int
-
-const (
-	SelectionUnselected SelectionState = iota
-	SelectionPartial
-	SelectionSelected
-)
-
-// Selection state colors for file tree
-var (
-	FileUnselectedColor = MutedColor   // Gray
-	FileSelectedColor   = SuccessColor // Green
-	FilePartialColor    = WarningColor // Yellow
+		Foreground(Nord15).
+		Bold(true)
 
 	// Styles for file/directory names based on selection state
 	UnselectedNameStyle = lipgloss.NewStyle().
-				Foreground(FileUnselectedColor)
+		Foreground(FileUnselectedColor)
 
 	SelectedNameStyle = lipgloss.NewStyle().
-				Foreground(FileSelectedColor).
-				Bold(true)
+		Foreground(FileSelectedColor).
+		Bold(true)
 
 	PartialNameStyle = lipgloss.NewStyle().
-				Foreground(FilePartialColor).
-				Bold(true)
+		Foreground(FilePartialColor).
+		Bold(true)
 
 	// Styles for ignored files
 	GitIgnoredStyle = lipgloss.NewStyle().
-			Foreground(Nord11). // Red for gitignored
-			Italic(true)
+		Foreground(Nord11). // Red for gitignored
+		Italic(true)
 
 	CustomIgnoredStyle = lipgloss.NewStyle().
-				Foreground(Nord12). // Orange for custom ignored
-				Italic(true)
-)
+		Foreground(Nord12). // Orange for custom ignored
+		Italic(true)
+}
+
+func init() {
+	buildStyles()
+}
 
 // Helper functions for common styling operations
 
@@ -187,7 +226,7 @@ func RenderHeader(step int, title string) string {
 	stepIndicator := lipgloss.NewStyle().
 		Foreground(Nord15).
 		Bold(true).
-		Render(fmt.Sprintf("Step %d/5", step))
+		Render(fmt.Sprintf("Step %d/6", step))
 
 	titleStyled := TitleStyle.Render(title)
 	separator := lipgloss.NewStyle().
@@ -430,15 +469,53 @@ func RenderFileTree(isDirectory bool, isSelected bool, isExpanded bool, name str
 
 // RenderFileName applies color styling to file/directory names based on selection state
 func RenderFileName(name string, selectionState SelectionState) string {
+	return fileNameStyle(selectionState).Render(name)
+}
+
+// RenderFileNameMatches renders a file name like RenderFileName, with the runes at
+// the given indices bold and underlined to show where a filter matched.
+func RenderFileNameMatches(name string, matches []int, selectionState SelectionState) string {
+	if len(matches) == 0 {
+		return RenderFileName(name, selectionState)
+	}
+
+	base := fileNameStyle(selectionState)
+	highlight := base.Bold(true).Underline(true)
+
+	matched := make(map[int]bool, len(matches))
+	for _, idx := range matches {
+		matched[idx] = true
+	}
+
+	// Render runs of matched and unmatched runes rather than each rune on its own
+	runes := []rune(name)
+	var result strings.Builder
+	for start := 0; start < len(runes); {
+		end := start + 1
+		for end < len(runes) && matched[end] == matched[start] {
+			end++
+		}
+		style := base
+		if matched[start] {
+			style = highlight
+		}
+		result.WriteString(style.Render(string(runes[start:end])))
+		start = end
+	}
+
+	return result.String()
+}
+
+func fileNameStyle(selectionState SelectionState) lipgloss.Style {
 	switch selectionState {
 	case SelectionSelected:
-		return SelectedNameStyle.Render(name)
+		return SelectedNameStyle
 	case SelectionPartial:
-		return PartialNameStyle.Render(name)
+		return PartialNameStyle
 	case SelectionUnselected:
-		return UnselectedNameStyle.Render(name)
+		return UnselectedNameStyle
 	default:
-		return TreeStyle.Render(name)
+		return TreeStyle
 	}
 }
 
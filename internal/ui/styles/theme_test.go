@@ -3,6 +3,8 @@ package styles
 import (
 	"strings"
 	"testing"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 func TestRenderHeader(t *testing.T) {
@@ -10,7 +12,7 @@ func TestRenderHeader(t *testing.T) {
 
 	result := RenderHeader(1, "Test Title")
 
-	if !strings.Contains(result, "Step 1/5") {
+	if !strings.Contains(result, "Step 1/6") {
 		t.Fatalf("expected step info in header")
 	}
 	if !strings.Contains(result, "Test Title") {
@@ -23,7 +25,7 @@ func TestRenderHeader_DifferentStep(t *testing.T) {
 
 	result := RenderHeader(3, "Another Title")
 
-	if !strings.Contains(result, "Step 3/5") {
+	if !strings.Contains(result, "Step 3/6") {
 		t.Fatalf("expected step 3 in header")
 	}
 	if !strings.Contains(result, "Another Title") {
@@ -434,6 +436,20 @@ func TestRenderFileName_InvalidState(t *testing.T) {
 	}
 }
 
+func TestRenderFileNameMatches(t *testing.T) {
+	t.Parallel()
+
+	result := RenderFileNameMatches("test.txt", []int{0, 1, 5}, SelectionSelected)
+
+	if !strings.Contains(result, "st.") || !strings.Contains(result, "xt") {
+		t.Fatalf("expected every rune of the name to be rendered, got %q", result)
+	}
+	plain := RenderFileName("test.txt", SelectionSelected)
+	if got := RenderFileNameMatches("test.txt", nil, SelectionSelected); got != plain {
+		t.Fatalf("expected no matches to render like RenderFileName, got %q", got)
+	}
+}
+
 func TestMutedColorAccessibility(t *testing.T) {
 	t.Parallel()
 
@@ -449,3 +465,32 @@ func TestDimTextAccessibility(t *testing.T) {
 		t.Fatal("DimText should not use Nord3 - insufficient contrast ratio for accessibility")
 	}
 }
+
+// TestApplyPalette changes the package colors, so it does not run in parallel.
+func TestApplyPalette(t *testing.T) {
+	defer ApplyPalette(DefaultPalette())
+
+	ApplyPalette(Palette{Primary: "33", Error: "#FF0000"})
+
+	if PrimaryColor != lipgloss.Color("33") || ErrorColor != lipgloss.Color("#FF0000") {
+		t.Fatalf("expected remapped colors, got primary %q and error %q", PrimaryColor, ErrorColor)
+	}
+	if TitleStyle.GetForeground() != lipgloss.Color("33") {
+		t.Errorf("expected TitleStyle rebuilt with the new primary color, got %v", TitleStyle.GetForeground())
+	}
+	if ErrorStyle.GetForeground() != lipgloss.Color("#FF0000") {
+		t.Errorf("expected ErrorStyle rebuilt with the new error color, got %v", ErrorStyle.GetForeground())
+	}
+	if WarningColor != lipgloss.Color(DefaultPalette().Warning) || FilePartialColor != WarningColor {
+		t.Errorf("expected unset colors to keep the default, got warning %q", WarningColor)
+	}
+}
+
+func TestDefaultPalette_MatchesBuiltInColors(t *testing.T) {
+	t.Parallel()
+
+	palette := DefaultPalette()
+	if lipgloss.Color(palette.Primary) != Nord8 || lipgloss.Color(palette.Text) != Nord4 {
+		t.Errorf("expected the Nord dark palette, got %+v", palette)
+	}
+}
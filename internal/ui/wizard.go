@@ -16,10 +16,12 @@ import (
 	"github.com/quantmind-br/shotgun-cli/internal/core/llm"
 	"github.com/quantmind-br/shotgun-cli/internal/core/scanner"
 	"github.com/quantmind-br/shotgun-cli/internal/core/template"
+	"github.com/quantmind-br/shotgun-cli/internal/core/tokens"
 	"github.com/quantmind-br/shotgun-cli/internal/platform/clipboard"
 	"github.com/quantmind-br/shotgun-cli/internal/ui/components"
 	"github.com/quantmind-br/shotgun-cli/internal/ui/screens"
 	"github.com/quantmind-br/shotgun-cli/internal/ui/styles"
+	"github.com/quantmind-br/shotgun-cli/internal/utils"
 )
 
 const (
@@ -27,10 +29,18 @@ const (
 	StepTemplateSelection = 2
 	StepTaskInput         = 3
 	StepRulesInput        = 4
-	StepReview            = 5
+	StepVariablesInput    = 5
+	StepReview            = 6
 
 	minTerminalWidth  = 40
 	minTerminalHeight = 10
+
+	// sendPreviewChrome is the number of lines the send preview uses around the prompt
+	sendPreviewChrome   = 8
+	minSendPreviewLines = 5
+
+	// llmDeltaBuffer is how many streamed response pieces may queue up for the UI
+	llmDeltaBuffer = 64
 )
 
 // LLMConfig holds configuration for the LLM provider.
@@ -41,6 +51,15 @@ type LLMConfig struct {
 	Model        string
 	Timeout      int
 	SaveResponse bool
+	// SendPreview shows the prompt and waits for confirmation before F9 sends it
+	SendPreview bool
+	// OverwriteResponse saves the response as <context>_response.md rather than
+	// under a timestamped name
+	OverwriteResponse bool
+	// Proxy, CACert and InsecureSkipVerify configure the connection to the provider
+	Proxy              string
+	CACert             string
+	InsecureSkipVerify bool
 }
 
 // ContextConfig holds context generation configuration.
@@ -48,12 +67,25 @@ type ContextConfig struct {
 	IncludeTree    bool
 	IncludeSummary bool
 	MaxSize        string
+	StripComments  bool
+	FoldSiblings   bool
+	FileDelimiters *contextgen.FileDelimiters // Lines around each embedded file (nil = defaults)
+	Redact         bool                       // Replace secrets with [REDACTED] before embedding
+	RedactPatterns []string                   // Extra regular expressions to redact
+}
+
+// OutputConfig holds where generated files are written.
+type OutputConfig struct {
+	Dir             string // Directory for generated files (empty = scan root)
+	ClipboardMode   string // What to copy after generation: content (default), path or none
+	ClipboardVerify bool   // Read the clipboard back after copying and warn when it differs
 }
 
 // WizardConfig holds all wizard configuration.
 type WizardConfig struct {
 	LLM     LLMConfig
 	Context ContextConfig
+	Output  OutputConfig
 }
 
 type Progress struct {
@@ -81,18 +113,23 @@ type WizardModel struct {
 	templateSelection *screens.TemplateSelectionModel
 	taskInput         *screens.TaskInputModel
 	rulesInput        *screens.RulesInputModel
+	variablesInput    *screens.VariablesInputModel
 	review            *screens.ReviewModel
 
 	progressComponent *components.ProgressModel
 
 	scanCoordinator     *ScanCoordinator
 	generateCoordinator *GenerateCoordinator
+	// scannedSelection is the file selection a rescan replaced, restored if it is cancelled
+	scannedSelection *screens.FileSelectionModel
 
 	generatedFilePath string
 	generatedContent  string
 
 	llmSending      bool
+	llmPreview      bool
 	llmResponseFile string
+	llmDeltas       chan string // Response text streamed by the send in flight
 
 	validationError string
 }
@@ -105,6 +142,8 @@ type ScanProgressMsg struct {
 
 type ScanCompleteMsg struct {
 	Tree *scanner.FileNode
+	// Inaccessible lists the directories the scan skipped because they could not be read
+	Inaccessible []string
 }
 
 type ScanErrorMsg struct {
@@ -122,6 +161,12 @@ type GenerationProgressMsg struct {
 
 type LLMSendMsg struct{}
 
+// llmDeltaMsg carries a piece of a streamed LLM response, read from ch.
+type llmDeltaMsg struct {
+	delta string
+	ch    <-chan string
+}
+
 type pollScanMsg struct{}
 type pollGenerateMsg struct{}
 
@@ -136,6 +181,7 @@ type startGenerationMsg struct {
 	template      *template.Template
 	taskDesc      string
 	rules         string
+	templateVars  map[string]string
 	rootPath      string
 }
 
@@ -229,7 +275,12 @@ func (m *WizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case screens.ClipboardCopyRequestMsg:
 		if m.generatedContent != "" {
-			cmds = append(cmds, m.clipboardCopyCmd(m.generatedContent))
+			// An explicit copy request still copies the content when the mode is none
+			text, ok := m.clipboardText()
+			if !ok {
+				text = m.generatedContent
+			}
+			cmds = append(cmds, m.clipboardCopyCmd(text))
 		}
 
 	// -- LLM Operations (Async) --
@@ -244,6 +295,9 @@ func (m *WizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case screens.LLMErrorMsg:
 		m.handleLLMError(msg)
 
+	case llmDeltaMsg:
+		cmds = append(cmds, m.handleLLMDelta(msg))
+
 	// -- Async Coordinators --
 	case startScanMsg:
 		// Triggers the ScanCoordinator to begin async filesystem scan
@@ -275,6 +329,9 @@ func (m *WizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmd = m.handleRescanRequest()
 		cmds = append(cmds, cmd)
 
+	case screens.ExportSelectionRequestMsg:
+		m.handleExportSelection()
+
 	// -- Polling & Spinners --
 	default:
 		if m.progress.Visible {
@@ -308,6 +365,10 @@ func (m *WizardModel) View() string {
 		return m.renderHelp()
 	}
 
+	if m.llmPreview {
+		return m.renderSendPreview()
+	}
+
 	var mainView string
 
 	if m.error != nil {
@@ -338,6 +399,12 @@ func (m *WizardModel) View() string {
 			} else {
 				mainView = "Initializing rules input..."
 			}
+		case StepVariablesInput:
+			if m.variablesInput != nil {
+				mainView = m.variablesInput.View()
+			} else {
+				mainView = "Initializing template variables..."
+			}
 		case StepReview:
 			if m.review != nil {
 				mainView = m.review.View()
@@ -381,12 +448,15 @@ func (m *WizardModel) renderHelp() string {
 	content.WriteString("  ↑/↓ or k/j  Navigate up/down\n")
 	content.WriteString("  ←/→ or h/l  Collapse/Expand directory\n")
 	content.WriteString("  Space       Toggle selection (file or directory)\n")
-	content.WriteString("  a           Select all visible files\n")
-	content.WriteString("  A           Deselect all visible files\n")
+	content.WriteString("  a           Select all visible files (all matches while filtering)\n")
+	content.WriteString("  A           Deselect all visible files (all matches while filtering)\n")
 	content.WriteString("  i           Toggle showing ignored files\n")
 	content.WriteString("  /           Enter filter mode (fuzzy search)\n")
 	content.WriteString("  x           Clear filter\n")
+	content.WriteString("  p           Toggle preview of the file under the cursor\n")
+	content.WriteString("  e           Export the selection to shotgun-selection.json\n")
 	content.WriteString("  F5          Rescan directory\n")
+	content.WriteString("  Esc         Cancel a running scan or generation\n")
 	content.WriteString("\n")
 
 	content.WriteString(styles.TitleStyle.Render("Template Selection (Step 2)"))
@@ -410,11 +480,20 @@ func (m *WizardModel) renderHelp() string {
 	content.WriteString("  Backspace   Delete character\n")
 	content.WriteString("\n")
 
-	content.WriteString(styles.TitleStyle.Render("Review (Step 5)"))
+	content.WriteString(styles.TitleStyle.Render("Template Variables (Step 5)"))
+	content.WriteString("\n")
+	content.WriteString("  Type        Enter the value of the focused variable\n")
+	content.WriteString("  Tab/↓/Enter Next variable\n")
+	content.WriteString("  Shift+Tab/↑ Previous variable\n")
+	content.WriteString("\n")
+
+	content.WriteString(styles.TitleStyle.Render("Review (Step 6)"))
 	content.WriteString("\n")
 	content.WriteString("  F8          Generate context\n")
 	content.WriteString("  c           Copy to clipboard\n")
+	content.WriteString("  v           Expand/collapse task and rules\n")
 	content.WriteString("  F9          Send to LLM (if configured)\n")
+	content.WriteString("  Enter/Esc   Confirm/cancel the send preview (llm.send-preview)\n")
 	content.WriteString("\n")
 
 	footer := styles.RenderFooter([]string{"F1/?: Close Help", "q: Quit"})
@@ -440,6 +519,9 @@ func (m *WizardModel) handleWindowResize(msg tea.WindowSizeMsg) tea.Cmd {
 	if m.rulesInput != nil {
 		m.rulesInput.SetSize(m.width, m.height)
 	}
+	if m.variablesInput != nil {
+		m.variablesInput.SetSize(m.width, m.height)
+	}
 	if m.review != nil {
 		m.review.SetSize(m.width, m.height)
 	}
@@ -465,6 +547,23 @@ func (m *WizardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// The send preview only accepts confirming or cancelling
+	if m.llmPreview {
+		switch msg.String() {
+		case "enter":
+			m.llmPreview = false
+			return m, m.startLLMSend()
+		case "esc":
+			m.llmPreview = false
+		}
+		return m, nil
+	}
+
+	// Esc during a scan or generation cancels it
+	if msg.String() == "esc" && m.cancelProgress() {
+		return m, nil
+	}
+
 	// Process navigation shortcuts
 	switch msg.String() {
 	case "f8", "alt+right":
@@ -509,7 +608,7 @@ func (m *WizardModel) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // isTextInputActive returns true when the current step accepts free text input,
 // preventing single-key shortcuts (q, ?) from being intercepted.
 func (m *WizardModel) isTextInputActive() bool {
-	if m.step == StepTaskInput || m.step == StepRulesInput {
+	if m.step == StepTaskInput || m.step == StepRulesInput || m.step == StepVariablesInput {
 		return true
 	}
 	if m.step == StepFileSelection && m.fileSelection != nil && m.fileSelection.IsFilterMode() {
@@ -543,6 +642,8 @@ func (m *WizardModel) getValidationErrorMessage() string {
 		return "Select a template to continue"
 	case StepTaskInput:
 		return "Enter a task description to continue"
+	case StepVariablesInput:
+		return fmt.Sprintf("Enter a value for %s to continue", m.missingVariable())
 	default:
 		return ""
 	}
@@ -571,12 +672,25 @@ func (m *WizardModel) handleScanProgress(msg ScanProgressMsg) tea.Cmd {
 
 func (m *WizardModel) handleScanComplete(msg ScanCompleteMsg) {
 	m.progress.Visible = false
+	m.scannedSelection = nil
 	if m.fileSelection != nil {
 		m.fileSelection.SetFileTree(msg.Tree)
 	} else {
-		m.fileSelection = screens.NewFileSelection(msg.Tree, nil, m.wizardConfig.Context.MaxSize)
-		m.fileSelection.SetSize(m.width, m.height)
+		m.fileSelection = m.newFileSelection(msg.Tree, nil)
+	}
+	m.fileSelection.SetInaccessible(msg.Inaccessible)
+}
+
+// newFileSelection creates the file selection screen sized to the window, previewing
+// files up to the scanner's max file size.
+func (m *WizardModel) newFileSelection(tree *scanner.FileNode, selections map[string]bool) *screens.FileSelectionModel {
+	fileSelection := screens.NewFileSelection(tree, selections, m.wizardConfig.Context.MaxSize)
+	if m.scanConfig != nil {
+		fileSelection.SetPreviewMaxSize(m.scanConfig.MaxFileSize)
 	}
+	fileSelection.SetSize(m.width, m.height)
+
+	return fileSelection
 }
 
 func (m *WizardModel) handleScanError(msg ScanErrorMsg) {
@@ -616,8 +730,24 @@ func (m *WizardModel) handleGenerationComplete(msg screens.GenerationCompleteMsg
 
 	m.generatedFilePath = filePath
 	m.generatedContent = content
+	if m.review != nil {
+		m.review.SetRedacted(msg.RedactedSecrets, msg.RedactedFiles)
+	}
+
+	text, ok := m.clipboardText()
+	if !ok {
+		m.handleClipboardComplete(screens.ClipboardCompleteMsg{})
+		return nil
+	}
 
-	return m.clipboardCopyCmd(content)
+	return m.clipboardCopyCmd(text)
+}
+
+// clipboardText returns what output.clipboard-mode copies for the generated context,
+// or false when copying is disabled.
+func (m *WizardModel) clipboardText() (string, bool) {
+	mode := app.ClipboardMode(m.wizardConfig.Output.ClipboardMode)
+	return app.ClipboardText(mode, m.generatedContent, m.generatedFilePath)
 }
 
 func (m *WizardModel) handleGenerationError(msg screens.GenerationErrorMsg) {
@@ -628,6 +758,7 @@ func (m *WizardModel) handleGenerationError(msg screens.GenerationErrorMsg) {
 func (m *WizardModel) handleClipboardComplete(msg screens.ClipboardCompleteMsg) {
 	if m.review != nil && m.generatedFilePath != "" {
 		m.review.SetGenerated(m.generatedFilePath, msg.Success)
+		m.review.SetClipboardCheck(msg.Size, msg.Mismatch)
 	}
 }
 
@@ -636,6 +767,15 @@ func (m *WizardModel) handleSendToLLM() tea.Cmd {
 		return nil
 	}
 
+	if m.wizardConfig != nil && m.wizardConfig.LLM.SendPreview {
+		m.llmPreview = true
+		return nil
+	}
+
+	return m.startLLMSend()
+}
+
+func (m *WizardModel) startLLMSend() tea.Cmd {
 	m.llmSending = true
 	if m.review != nil {
 		m.review.SetLLMSending(true)
@@ -648,7 +788,64 @@ func (m *WizardModel) handleSendToLLM() tea.Cmd {
 	}
 	m.progressComponent.UpdateMessage("", "Sending to LLM...")
 
-	return tea.Batch(m.sendToLLMCmd(), m.progressComponent.Init())
+	m.llmDeltas = make(chan string, llmDeltaBuffer)
+	return tea.Batch(m.sendToLLMCmd(m.llmDeltas), waitForLLMDelta(m.llmDeltas), m.progressComponent.Init())
+}
+
+// waitForLLMDelta reads the next piece of the streamed response from ch.
+// It returns no message once the send has finished and ch is drained.
+func waitForLLMDelta(ch <-chan string) tea.Cmd {
+	return func() tea.Msg {
+		delta, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return llmDeltaMsg{delta: delta, ch: ch}
+	}
+}
+
+// handleLLMDelta shows streamed text while its send is still in flight and keeps
+// draining the channel it came from.
+func (m *WizardModel) handleLLMDelta(msg llmDeltaMsg) tea.Cmd {
+	if m.llmSending && msg.ch == m.llmDeltas && m.review != nil {
+		m.review.AppendLLMResponse(msg.delta)
+	}
+
+	return waitForLLMDelta(msg.ch)
+}
+
+// renderSendPreview shows the start of the prompt and its size, so the user can
+// check what is about to be sent before confirming.
+func (m *WizardModel) renderSendPreview() string {
+	var content strings.Builder
+
+	content.WriteString(styles.TitleStyle.Render("Send Preview"))
+	content.WriteString("\n\n")
+
+	lines := strings.Split(m.generatedContent, "\n")
+	provider := ""
+	if m.wizardConfig != nil {
+		provider = m.wizardConfig.LLM.Provider
+	}
+	content.WriteString(fmt.Sprintf("Sending to %s: %s (~%s tokens), %d lines\n\n",
+		provider, utils.FormatBytes(int64(len(m.generatedContent))),
+		tokens.FormatTokens(tokens.Estimate(m.generatedContent)), len(lines)))
+
+	shown := max(m.height-sendPreviewChrome, minSendPreviewLines)
+	if shown > len(lines) {
+		shown = len(lines)
+	}
+	content.WriteString(strings.Join(lines[:shown], "\n"))
+	content.WriteString("\n")
+	if rest := len(lines) - shown; rest > 0 {
+		content.WriteString(styles.HelpStyle.Render(fmt.Sprintf("… %d more lines", rest)))
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(styles.RenderFooter([]string{"Enter: Send", "Esc: Cancel"}))
+
+	return content.String()
 }
 
 func (m *WizardModel) handleLLMProgress(msg screens.LLMProgressMsg) {
@@ -686,13 +883,16 @@ func (m *WizardModel) buildLLMSendConfig() app.LLMSendConfig {
 		BaseURL:      m.wizardConfig.LLM.BaseURL,
 		Model:        m.wizardConfig.LLM.Model,
 		SaveResponse: m.wizardConfig.LLM.SaveResponse,
+		// Network settings for the cloud providers
+		Proxy:              m.wizardConfig.LLM.Proxy,
+		CACert:             m.wizardConfig.LLM.CACert,
+		InsecureSkipVerify: m.wizardConfig.LLM.InsecureSkipVerify,
 	}
 
 	if cfg.SaveResponse {
-		ext := ".md"
 		base := strings.TrimSuffix(filepath.Base(m.generatedFilePath), filepath.Ext(m.generatedFilePath))
-		dir := filepath.Dir(m.generatedFilePath)
-		cfg.OutputPath = filepath.Join(dir, base+"_response"+ext)
+		name := app.ResponseFileName(base+"_response", m.wizardConfig.LLM.OverwriteResponse, time.Now())
+		cfg.OutputPath = filepath.Join(filepath.Dir(m.generatedFilePath), name)
 	}
 
 	return cfg
@@ -716,9 +916,14 @@ func (m *WizardModel) isLLMAvailable() bool {
 	return true
 }
 
-func (m *WizardModel) sendToLLMCmd() tea.Cmd {
+// sendToLLMCmd sends the generated content, streaming the response text to deltas,
+// which is closed once the send finishes.
+func (m *WizardModel) sendToLLMCmd(deltas chan<- string) tea.Cmd {
 	return func() tea.Msg {
+		defer close(deltas)
+
 		cfg := m.buildLLMSendConfig()
+		cfg.OnDelta = func(delta string) { deltas <- delta }
 		ctx := gocontext.Background()
 
 		result, err := m.contextService.SendToLLMWithProgress(ctx, m.generatedContent, cfg, nil)
@@ -747,12 +952,40 @@ func (m *WizardModel) handleStartScan(msg startScanMsg) tea.Cmd {
 		m.scanCoordinator = NewScanCoordinator(scanner.NewFileSystemScanner())
 	}
 
-	m.fileSelection = screens.NewFileSelection(nil, nil, m.wizardConfig.Context.MaxSize)
-	m.fileSelection.SetSize(m.width, m.height)
+	m.validationError = ""
+	m.scannedSelection = nil
+	if m.fileSelection != nil && m.fileSelection.GetFileTree() != nil {
+		m.scannedSelection = m.fileSelection
+	}
+	m.fileSelection = m.newFileSelection(nil, nil)
 
 	return tea.Batch(m.fileSelection.Init(), m.scanCoordinator.Start(msg.rootPath, msg.config))
 }
 
+// cancelProgress stops a running scan or generation, hides the progress and returns
+// to the screen shown before it started. It reports whether anything was cancelled.
+func (m *WizardModel) cancelProgress() bool {
+	switch {
+	case m.scanCoordinator != nil && m.scanCoordinator.Cancel():
+		m.progress.Visible = false
+		if m.scannedSelection != nil {
+			m.fileSelection = m.scannedSelection
+			m.scannedSelection = nil
+		} else if m.fileSelection != nil {
+			empty := &scanner.FileNode{Name: filepath.Base(m.rootPath), Path: m.rootPath, IsDir: true}
+			m.fileSelection.SetFileTree(empty)
+		}
+		m.validationError = "Scan cancelled; press F5 to rescan"
+		return true
+	case m.generateCoordinator != nil && m.generateCoordinator.Cancel():
+		m.progress.Visible = false
+		m.validationError = "Generation cancelled"
+		return true
+	default:
+		return false
+	}
+}
+
 func (m *WizardModel) handleStartGeneration(msg startGenerationMsg) tea.Cmd {
 	cfg := &GenerateConfig{
 		FileTree:       msg.fileTree,
@@ -760,9 +993,15 @@ func (m *WizardModel) handleStartGeneration(msg startGenerationMsg) tea.Cmd {
 		Template:       msg.template,
 		TaskDesc:       msg.taskDesc,
 		Rules:          msg.rules,
+		TemplateVars:   msg.templateVars,
 		RootPath:       msg.rootPath,
 		IncludeTree:    m.wizardConfig.Context.IncludeTree,
 		IncludeSummary: m.wizardConfig.Context.IncludeSummary,
+		StripComments:  m.wizardConfig.Context.StripComments,
+		FoldSiblings:   m.wizardConfig.Context.FoldSiblings,
+		FileDelimiters: m.wizardConfig.Context.FileDelimiters,
+		Redact:         m.wizardConfig.Context.Redact,
+		RedactPatterns: m.wizardConfig.Context.RedactPatterns,
 	}
 
 	return m.generateCoordinator.Start(cfg)
@@ -776,6 +1015,25 @@ func (m *WizardModel) handleRescanRequest() tea.Cmd {
 	return nil
 }
 
+// handleExportSelection saves the current selection as JSON in the output directory
+// (the root when unset), for `context generate --selection`.
+func (m *WizardModel) handleExportSelection() {
+	if m.step != StepFileSelection || m.fileSelection == nil {
+		return
+	}
+
+	outputDir := m.wizardConfig.Output.Dir
+	if outputDir == "" {
+		outputDir = m.rootPath
+	}
+	path, err := app.ResolveOutputPath(outputDir, app.SelectionFileName)
+	count := 0
+	if err == nil {
+		count, err = app.WriteSelectionFile(path, m.getFileTree(), m.getSelectedFiles())
+	}
+	m.fileSelection.SetExportResult(path, count, err)
+}
+
 func (m *WizardModel) canAdvanceStep() bool {
 	switch m.step {
 	case StepFileSelection:
@@ -784,12 +1042,14 @@ func (m *WizardModel) canAdvanceStep() bool {
 		return m.getSelectedTemplate() != nil
 	case StepTaskInput:
 		tmpl := m.getSelectedTemplate()
-		if tmpl != nil && !tmpl.HasVariable(template.VarTask) {
+		if tmpl != nil && !tmpl.RequiresVariable(template.VarTask) {
 			return true
 		}
 		return len(strings.TrimSpace(m.getTaskDesc())) > 0
 	case StepRulesInput:
 		return true
+	case StepVariablesInput:
+		return m.missingVariable() == ""
 	case StepReview:
 		return true
 	default:
@@ -799,71 +1059,77 @@ func (m *WizardModel) canAdvanceStep() bool {
 
 func (m *WizardModel) requiresTaskInput() bool {
 	tmpl := m.getSelectedTemplate()
-	return tmpl != nil && tmpl.HasVariable(template.VarTask)
+	return tmpl != nil && tmpl.RequiresVariable(template.VarTask)
 }
 
 func (m *WizardModel) requiresRulesInput() bool {
 	tmpl := m.getSelectedTemplate()
-	return tmpl != nil && tmpl.HasVariable(template.VarRules)
+	return tmpl != nil && tmpl.RequiresVariable(template.VarRules)
 }
 
-// getNextStep returns the next step to navigate to, skipping steps that are not needed
-func (m *WizardModel) getNextStep() int {
-	switch m.step {
-	case StepFileSelection:
-		return StepTemplateSelection
-	case StepTemplateSelection:
-		if !m.requiresTaskInput() {
-			if !m.requiresRulesInput() {
-				return StepReview
-			}
-			return StepRulesInput
+// requiresVariablesInput reports whether the template uses variables beyond the ones
+// the wizard fills in itself.
+func (m *WizardModel) requiresVariablesInput() bool {
+	tmpl := m.getSelectedTemplate()
+	return tmpl != nil && len(tmpl.CustomVariableNames()) > 0
+}
+
+// missingVariable returns the first required custom variable that has neither a value
+// nor a default, or "" when all are set.
+func (m *WizardModel) missingVariable() string {
+	tmpl := m.getSelectedTemplate()
+	if tmpl == nil {
+		return ""
+	}
+
+	values := m.getTemplateVars()
+	for _, name := range tmpl.CustomVariableNames() {
+		if tmpl.RequiresVariable(name) && strings.TrimSpace(values[name]) == "" &&
+			strings.TrimSpace(tmpl.Defaults[name]) == "" {
+			return name
 		}
-		return StepTaskInput
+	}
+	return ""
+}
+
+// isStepNeeded reports whether the selected template needs the given step; the input
+// steps are skipped for templates without the matching variables.
+func (m *WizardModel) isStepNeeded(step int) bool {
+	switch step {
 	case StepTaskInput:
-		if !m.requiresRulesInput() {
-			return StepReview
-		}
-		return StepRulesInput
+		return m.requiresTaskInput()
 	case StepRulesInput:
-		return StepReview
+		return m.requiresRulesInput()
+	case StepVariablesInput:
+		return m.requiresVariablesInput()
 	default:
-		return m.step + 1
+		return true
+	}
+}
+
+// getNextStep returns the next step to navigate to, skipping steps that are not needed
+func (m *WizardModel) getNextStep() int {
+	next := m.step + 1
+	for next < StepReview && !m.isStepNeeded(next) {
+		next++
 	}
+	return next
 }
 
 // getPrevStep returns the previous step to navigate to, skipping steps that were not needed
 func (m *WizardModel) getPrevStep() int {
-	switch m.step {
-	case StepTemplateSelection:
-		return StepFileSelection
-	case StepTaskInput:
-		return StepTemplateSelection
-	case StepRulesInput:
-		if !m.requiresTaskInput() {
-			return StepTemplateSelection
-		}
-		return StepTaskInput
-	case StepReview:
-		if !m.requiresRulesInput() {
-			if !m.requiresTaskInput() {
-				return StepTemplateSelection
-			}
-			return StepTaskInput
-		}
-		return StepRulesInput
-	default:
-		return m.step - 1
+	prev := m.step - 1
+	for prev > StepTemplateSelection && !m.isStepNeeded(prev) {
+		prev--
 	}
+	return prev
 }
 
 func (m *WizardModel) initStep() tea.Cmd {
 	switch m.step {
 	case StepFileSelection:
 		if m.getFileTree() != nil {
-			maxSize := m.wizardConfig.Context.MaxSize
-			m.fileSelection = screens.NewFileSelection(m.getFileTree(), m.getSelectedFiles(), maxSize)
-			m.fileSelection.SetSize(m.width, m.height)
+			m.fileSelection = m.newFileSelection(m.getFileTree(), m.getSelectedFiles())
 		}
 	case StepTemplateSelection:
 		m.templateSelection = screens.NewTemplateSelection()
@@ -872,11 +1138,20 @@ func (m *WizardModel) initStep() tea.Cmd {
 	case StepTaskInput:
 		m.taskInput = screens.NewTaskInput(m.getTaskDesc())
 		m.taskInput.SetSize(m.width, m.height)
-		// Set skip hint if template doesn't require RULES variable
-		m.taskInput.SetWillSkipToReview(!m.requiresRulesInput())
+		// Set skip hint if the template needs neither rules nor other variables
+		m.taskInput.SetWillSkipToReview(m.getNextStep() == StepReview)
 	case StepRulesInput:
 		m.rulesInput = screens.NewRulesInput(m.getRules())
 		m.rulesInput.SetSize(m.width, m.height)
+	case StepVariablesInput:
+		// Keep the values entered so far when coming back or switching templates
+		var values map[string]string
+		if m.variablesInput != nil {
+			values = m.variablesInput.GetValues()
+		}
+		tmpl := m.getSelectedTemplate()
+		m.variablesInput = screens.NewVariablesInput(tmpl.CustomVariableNames(), values, tmpl.Defaults)
+		m.variablesInput.SetSize(m.width, m.height)
 	case StepReview:
 		m.review = screens.NewReview(
 			m.getSelectedFiles(), m.getFileTree(), m.getSelectedTemplate(),
@@ -884,6 +1159,7 @@ func (m *WizardModel) initStep() tea.Cmd {
 		)
 		m.review.SetSize(m.width, m.height)
 		m.review.SetLLMAvailable(m.isLLMAvailable())
+		m.review.SetClipboardMode(m.wizardConfig.Output.ClipboardMode)
 	}
 	return nil
 }
@@ -910,6 +1186,10 @@ func (m *WizardModel) handleStepInput(msg tea.KeyMsg) tea.Cmd {
 		if m.rulesInput != nil {
 			cmd = m.rulesInput.Update(msg)
 		}
+	case StepVariablesInput:
+		if m.variablesInput != nil {
+			cmd = m.variablesInput.Update(msg)
+		}
 	case StepReview:
 		if m.review != nil {
 			cmd = m.review.Update(msg)
@@ -927,7 +1207,9 @@ func (m *WizardModel) generateContext() tea.Cmd {
 		}
 	}
 
-	return generateContextCmd(m.getFileTree(), m.getSelectedFiles(), tmpl, m.getTaskDesc(), m.getRules(), m.rootPath)
+	return generateContextCmd(
+		m.getFileTree(), m.getSelectedFiles(), tmpl, m.getTaskDesc(), m.getRules(), m.getTemplateVars(), m.rootPath,
+	)
 }
 
 func scanDirectoryCmd(rootPath string, scanConfig *scanner.ScanConfig) tea.Cmd {
@@ -943,7 +1225,9 @@ func generateContextCmd(
 	fileTree *scanner.FileNode,
 	selectedFiles map[string]bool,
 	template *template.Template,
-	taskDesc, rules, rootPath string,
+	taskDesc, rules string,
+	templateVars map[string]string,
+	rootPath string,
 ) tea.Cmd {
 	return func() tea.Msg {
 		return startGenerationMsg{
@@ -952,18 +1236,27 @@ func generateContextCmd(
 			template:      template,
 			taskDesc:      taskDesc,
 			rules:         rules,
+			templateVars:  templateVars,
 			rootPath:      rootPath,
 		}
 	}
 }
 
 func (m *WizardModel) clipboardCopyCmd(content string) tea.Cmd {
+	verify := m.wizardConfig != nil && m.wizardConfig.Output.ClipboardVerify
 	return func() tea.Msg {
 		err := clipboard.Copy(content)
-		return screens.ClipboardCompleteMsg{
+		msg := screens.ClipboardCompleteMsg{
 			Success: err == nil,
 			Err:     err,
+			Size:    int64(len(content)),
+		}
+		if err == nil && verify {
+			// A clipboard that cannot be read back is left unreported
+			ok, verifyErr := clipboard.Verify(content)
+			msg.Mismatch = verifyErr == nil && !ok
 		}
+		return msg
 	}
 }
 
@@ -1024,7 +1317,15 @@ func (m *WizardModel) validateContentSize(content string) error {
 func (m *WizardModel) saveGeneratedContent(content string) (string, error) {
 	timestamp := time.Now().Format("20060102-150405")
 	filename := fmt.Sprintf("shotgun-prompt-%s.md", timestamp)
-	filePath := filepath.Join(m.rootPath, filename)
+
+	outputDir := m.wizardConfig.Output.Dir
+	if outputDir == "" {
+		outputDir = m.rootPath
+	}
+	filePath, err := app.ResolveOutputPath(outputDir, filename)
+	if err != nil {
+		return "", err
+	}
 
 	// #nosec G306 - Generated context files are meant to be world-readable
 	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
@@ -1068,6 +1369,15 @@ func (m *WizardModel) getRules() string {
 	return ""
 }
 
+// getTemplateVars returns the values entered for the selected template's custom
+// variables, or nil when it has none.
+func (m *WizardModel) getTemplateVars() map[string]string {
+	if m.variablesInput != nil && m.requiresVariablesInput() {
+		return m.variablesInput.GetValues()
+	}
+	return nil
+}
+
 func (m *WizardModel) isTerminalTooSmall() bool {
 	return m.width > 0 && m.height > 0 &&
 		(m.width < minTerminalWidth || m.height < minTerminalHeight)
@@ -3,6 +3,9 @@ package ui
 import (
 	gocontext "context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -183,6 +186,68 @@ func TestWizardHandlesScanLifecycle(t *testing.T) {
 	}
 }
 
+func TestWizardEscCancelsRescan(t *testing.T) {
+	t.Parallel()
+
+	wizard := NewWizard("/workspace", &scanner.ScanConfig{}, nil, nil)
+	tree := &scanner.FileNode{Name: "root", Path: "/workspace", IsDir: true}
+	model, _ := wizard.Update(ScanCompleteMsg{Tree: tree})
+	wiz := model.(*WizardModel)
+
+	release := make(chan struct{})
+	wiz.scanCoordinator = NewScanCoordinator(&wizardTestMockScanner{
+		scanProgressFunc: func(rootPath string, config *scanner.ScanConfig, progress chan<- scanner.Progress) (*scanner.FileNode, error) {
+			<-release
+			return &scanner.FileNode{Name: "new", Path: rootPath, IsDir: true}, nil
+		},
+	})
+	defer close(release)
+
+	model, _ = wiz.Update(startScanMsg{rootPath: "/workspace", config: &scanner.ScanConfig{}})
+	wiz = model.(*WizardModel)
+	model, _ = wiz.Update(ScanProgressMsg{Current: 1, Total: 10, Stage: "scanning"})
+	wiz = model.(*WizardModel)
+
+	model, _ = wiz.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	wiz = model.(*WizardModel)
+	if wiz.progress.Visible {
+		t.Error("progress should be hidden after cancelling the scan")
+	}
+	if getWizardFileTree(wiz) != tree {
+		t.Error("expected the tree from before the rescan to be restored")
+	}
+	if !strings.Contains(wiz.validationError, "Scan cancelled") {
+		t.Errorf("expected a cancellation note, got %q", wiz.validationError)
+	}
+
+	// With nothing running, Esc falls through to the screen
+	model, _ = wiz.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	wiz = model.(*WizardModel)
+	if getWizardFileTree(wiz) != tree {
+		t.Error("a second Esc should not change the file selection")
+	}
+}
+
+func TestWizardScanCompleteReportsInaccessibleDirs(t *testing.T) {
+	t.Parallel()
+
+	wiz := NewWizard("/workspace", &scanner.ScanConfig{}, nil, nil)
+	tree := &scanner.FileNode{Name: "root", Path: "/workspace", IsDir: true}
+
+	model, _ := wiz.Update(ScanCompleteMsg{Tree: tree, Inaccessible: []string{"secret", "private/keys"}})
+	wiz = model.(*WizardModel)
+
+	if note := wiz.fileSelection.InaccessibleNote(); !strings.Contains(note, "2 directories skipped") {
+		t.Errorf("expected a note about 2 skipped directories, got %q", note)
+	}
+
+	model, _ = wiz.Update(ScanCompleteMsg{Tree: tree})
+	wiz = model.(*WizardModel)
+	if note := wiz.fileSelection.InaccessibleNote(); note != "" {
+		t.Errorf("expected the note to clear after a clean rescan, got %q", note)
+	}
+}
+
 func TestWizardHandlesGenerationLifecycle(t *testing.T) {
 	t.Parallel()
 
@@ -776,6 +841,32 @@ func TestWizardNoSkipWhenBothRequired(t *testing.T) {
 	}
 }
 
+func TestWizardSkipStepsFollowsFrontMatterRequires(t *testing.T) {
+	t.Parallel()
+
+	wizard := NewWizard("/workspace", &scanner.ScanConfig{}, nil, nil)
+	setWizardFileTree(wizard, &scanner.FileNode{Name: "root", Path: "/workspace", IsDir: true})
+	setWizardSelectedFiles(wizard, map[string]bool{"main.go": true})
+	// RULES appears in the content but has a default, so only TASK is required
+	setWizardTemplate(wizard, &template.Template{
+		Name:     "defaulted_rules",
+		Content:  "Task: {TASK}\nRules: {RULES}\nFile Structure:\n{FILE_STRUCTURE}",
+		Defaults: map[string]string{"RULES": "Reproduce first"},
+		Requires: []string{"TASK"},
+	})
+	setWizardTaskDesc(wizard, testSampleTask)
+
+	wizard.step = StepTaskInput
+
+	// Press F8 to advance - should skip Rules, go to Review
+	model, _ := wizard.Update(tea.KeyMsg{Type: tea.KeyF8})
+	wizard = model.(*WizardModel)
+
+	if wizard.step != StepReview {
+		t.Fatalf("expected to skip to Review (step %d), got step %d", StepReview, wizard.step)
+	}
+}
+
 func TestWizardBackwardNavigationSkipsCorrectly(t *testing.T) {
 	t.Parallel()
 
@@ -828,6 +919,91 @@ func TestWizardBackwardFromRulesWithNoTask(t *testing.T) {
 	}
 }
 
+func TestWizardVariablesInputStep(t *testing.T) {
+	t.Parallel()
+
+	wizard := NewWizard("/workspace", &scanner.ScanConfig{}, nil, nil)
+	tree := &scanner.FileNode{Name: "root", Path: "/workspace", IsDir: true}
+	setWizardFileTree(wizard, tree)
+	setWizardSelectedFiles(wizard, map[string]bool{"main.go": true})
+	// Template with custom variables; TEAM has a default, TICKET must be entered
+	setWizardTemplate(wizard, &template.Template{
+		Name:     "ticket",
+		Content:  "Ticket {TICKET} for {TEAM}\nTask: {TASK}\n{FILE_STRUCTURE}",
+		Defaults: map[string]string{"TEAM": "core"},
+	})
+	setWizardTaskDesc(wizard, testSampleTask)
+
+	wizard.step = StepTaskInput
+
+	// Press F8 to advance - should skip Rules, go to the variables step
+	model, _ := wizard.Update(tea.KeyMsg{Type: tea.KeyF8})
+	wizard = model.(*WizardModel)
+	if wizard.step != StepVariablesInput {
+		t.Fatalf("expected to go to VariablesInput (step %d), got step %d", StepVariablesInput, wizard.step)
+	}
+	if wizard.variablesInput == nil {
+		t.Fatal("expected variablesInput to be initialized")
+	}
+
+	// TICKET has no value and no default, so the wizard stays on the step
+	model, _ = wizard.Update(tea.KeyMsg{Type: tea.KeyF8})
+	wizard = model.(*WizardModel)
+	if wizard.step != StepVariablesInput {
+		t.Fatalf("expected to stay on VariablesInput, got step %d", wizard.step)
+	}
+	if !strings.Contains(wizard.validationError, "TICKET") {
+		t.Errorf("expected validation error naming TICKET, got %q", wizard.validationError)
+	}
+
+	wizard.variablesInput.SetValueForTest("TICKET", "ABC-123")
+	model, _ = wizard.Update(tea.KeyMsg{Type: tea.KeyF8})
+	wizard = model.(*WizardModel)
+	if wizard.step != StepReview {
+		t.Fatalf("expected to go to Review (step %d), got step %d", StepReview, wizard.step)
+	}
+
+	// Going back keeps the values entered
+	model, _ = wizard.Update(tea.KeyMsg{Type: tea.KeyF7})
+	wizard = model.(*WizardModel)
+	if wizard.step != StepVariablesInput {
+		t.Fatalf("expected to go back to VariablesInput (step %d), got step %d", StepVariablesInput, wizard.step)
+	}
+	if got := wizard.getTemplateVars()["TICKET"]; got != "ABC-123" {
+		t.Errorf("expected TICKET to be kept, got %q", got)
+	}
+
+	msg, ok := wizard.generateContext()().(startGenerationMsg)
+	if !ok {
+		t.Fatal("expected startGenerationMsg")
+	}
+	if msg.templateVars["TICKET"] != "ABC-123" {
+		t.Errorf("expected TICKET in the generation vars, got %v", msg.templateVars)
+	}
+}
+
+func TestWizardRequiresVariablesInput(t *testing.T) {
+	t.Parallel()
+
+	wizard := NewWizard("/workspace", &scanner.ScanConfig{}, nil, nil)
+	if wizard.requiresVariablesInput() {
+		t.Fatal("expected requiresVariablesInput to be false when no template set")
+	}
+
+	setWizardTemplate(wizard, &template.Template{
+		Name:    "builtin_only",
+		Content: "{TASK}\n{RULES}\n{FILE_STRUCTURE}\n{CURRENT_DATE}\n{GIT_BRANCH}",
+	})
+	if wizard.requiresVariablesInput() {
+		t.Error("expected requiresVariablesInput to be false for built-in variables only")
+	}
+
+	setWizardTemplate(wizard, &template.Template{Name: "custom", Content: "{TASK} {TICKET}"})
+	if !wizard.requiresVariablesInput() {
+		t.Error("expected requiresVariablesInput to be true with {TICKET}")
+	}
+}
+
 func TestWizardRequiresTaskInput(t *testing.T) {
 	t.Parallel()
 
@@ -1991,6 +2167,12 @@ func TestWizardBuildLLMSendConfig_SaveResponse(t *testing.T) {
 	if !cfg.SaveResponse {
 		t.Error("expected SaveResponse to be true")
 	}
+	if !regexp.MustCompile(`^/tmp/test_response-\d{8}-\d{6}\.md$`).MatchString(cfg.OutputPath) {
+		t.Errorf("expected a timestamped OutputPath next to the context, got '%s'", cfg.OutputPath)
+	}
+
+	wizard.wizardConfig.LLM.OverwriteResponse = true
+	cfg = wizard.buildLLMSendConfig()
 	if cfg.OutputPath != "/tmp/test_response.md" {
 		t.Errorf("expected OutputPath '/tmp/test_response.md', got '%s'", cfg.OutputPath)
 	}
@@ -2022,12 +2204,45 @@ func TestWizardSendToLLMCmd_ReturnsCommand(t *testing.T) {
 		},
 	}
 
-	cmd := wizard.sendToLLMCmd()
+	cmd := wizard.sendToLLMCmd(make(chan string, 1))
 	if cmd == nil {
 		t.Fatal("expected non-nil command")
 	}
 }
 
+func TestWizardHandleLLMDelta(t *testing.T) {
+	t.Parallel()
+
+	wizard := NewWizard("/tmp/test", &scanner.ScanConfig{}, nil, nil)
+	wizard.review = screens.NewReview(nil, nil, nil, "", "", "")
+	wizard.review.SetSize(80, 40)
+	wizard.review.SetGenerated("/tmp/test.md", true)
+	wizard.review.SetLLMSending(true)
+	wizard.llmSending = true
+	wizard.llmDeltas = make(chan string, 1)
+
+	stale := make(chan string)
+	if cmd := wizard.handleLLMDelta(llmDeltaMsg{delta: "stale text", ch: stale}); cmd == nil {
+		t.Error("expected a command to keep draining the stale channel")
+	}
+	if cmd := wizard.handleLLMDelta(llmDeltaMsg{delta: "streamed text", ch: wizard.llmDeltas}); cmd == nil {
+		t.Error("expected a command waiting for the next delta")
+	}
+
+	view := wizard.review.View()
+	if !strings.Contains(view, "streamed text") {
+		t.Errorf("expected streamed text in the review view, got:\n%s", view)
+	}
+	if strings.Contains(view, "stale text") {
+		t.Error("expected text from a previous send to be ignored")
+	}
+
+	close(wizard.llmDeltas)
+	if msg := waitForLLMDelta(wizard.llmDeltas)(); msg != nil {
+		t.Errorf("expected no message from a drained channel, got %#v", msg)
+	}
+}
+
 func TestWizardHandleSendToGemini_NotReviewStep(t *testing.T) {
 	t.Parallel()
 
@@ -2342,6 +2557,46 @@ func TestWizardHandleSendToLLM_DelegatesToService(t *testing.T) {
 	}
 }
 
+func TestWizardHandleSendToLLM_Preview(t *testing.T) {
+	t.Parallel()
+
+	newWizard := func() *WizardModel {
+		wizard := NewWizard("/tmp/test", &scanner.ScanConfig{}, nil, &mockContextService{})
+		wizard.step = StepReview
+		wizard.height = 30
+		wizard.generatedContent = "line one\nline two"
+		wizard.wizardConfig = &WizardConfig{
+			LLM: LLMConfig{Provider: "openai", APIKey: "sk-test-key", SendPreview: true},
+		}
+		wizard.review = screens.NewReview(nil, nil, nil, "", "", "")
+		return wizard
+	}
+
+	wizard := newWizard()
+	if cmd := wizard.handleSendToLLM(); cmd != nil {
+		t.Error("expected no command while the preview is open")
+	}
+	if !wizard.llmPreview || wizard.llmSending {
+		t.Fatalf("expected preview open and nothing sent, got preview=%v sending=%v", wizard.llmPreview, wizard.llmSending)
+	}
+	view := wizard.View()
+	if !strings.Contains(view, "Send Preview") || !strings.Contains(view, "line two") {
+		t.Errorf("expected preview of the prompt, got:\n%s", view)
+	}
+
+	wizard.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	if wizard.llmPreview || wizard.llmSending {
+		t.Error("expected Esc to close the preview without sending")
+	}
+
+	wizard = newWizard()
+	wizard.handleSendToLLM()
+	_, cmd := wizard.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || !wizard.llmSending || wizard.llmPreview {
+		t.Error("expected Enter to close the preview and start sending")
+	}
+}
+
 func TestWizardHandleSendToLLM_ServiceError(t *testing.T) {
 	t.Parallel()
 
@@ -2656,3 +2911,88 @@ func TestWizard_F9KeyPress_NoContentDoesNothing(t *testing.T) {
 
 	require.False(t, wizard.llmSending, "llmSending should remain false when no content")
 }
+
+func TestWizardSaveGeneratedContentHonorsOutputDir(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	outputDir := filepath.Join(t.TempDir(), "prompts")
+
+	wizard := NewWizard(rootDir, &scanner.ScanConfig{}, &WizardConfig{Output: OutputConfig{Dir: outputDir}}, nil)
+	path, err := wizard.saveGeneratedContent("content")
+	if err != nil {
+		t.Fatalf("saveGeneratedContent failed: %v", err)
+	}
+	if filepath.Dir(path) != outputDir {
+		t.Errorf("expected file in %s, got %s", outputDir, path)
+	}
+
+	wizard = NewWizard(rootDir, &scanner.ScanConfig{}, nil, nil)
+	path, err = wizard.saveGeneratedContent("content")
+	if err != nil {
+		t.Fatalf("saveGeneratedContent failed: %v", err)
+	}
+	if filepath.Dir(path) != rootDir {
+		t.Errorf("expected file in scan root %s by default, got %s", rootDir, path)
+	}
+}
+
+func TestWizardClipboardTextFollowsClipboardMode(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	newWizard := func(mode string) *WizardModel {
+		wizard := NewWizard(rootDir, &scanner.ScanConfig{},
+			&WizardConfig{Output: OutputConfig{ClipboardMode: mode}}, nil)
+		wizard.generatedContent = "rendered context"
+		wizard.generatedFilePath = filepath.Join(rootDir, "shotgun-prompt.md")
+		return wizard
+	}
+
+	text, ok := newWizard("path").clipboardText()
+	if !ok || text != filepath.Join(rootDir, "shotgun-prompt.md") {
+		t.Errorf("path mode should copy the generated file path, got %q (ok=%v)", text, ok)
+	}
+
+	for _, mode := range []string{"content", ""} {
+		text, ok = newWizard(mode).clipboardText()
+		if !ok || text != "rendered context" {
+			t.Errorf("mode %q should copy the rendered text, got %q (ok=%v)", mode, text, ok)
+		}
+	}
+
+	if _, ok := newWizard("none").clipboardText(); ok {
+		t.Error("none mode should not copy anything")
+	}
+}
+
+func TestWizardExportSelection(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	mainPath := filepath.Join(root, "main.go")
+	if err := os.WriteFile(mainPath, []byte("package main\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tree := &scanner.FileNode{Name: "root", Path: root, IsDir: true, Children: []*scanner.FileNode{
+		{Name: "main.go", Path: mainPath, RelPath: "main.go"},
+	}}
+
+	wizard := NewWizard(root, &scanner.ScanConfig{}, nil, nil)
+	wizard.step = StepFileSelection
+	setWizardFileTree(wizard, tree)
+	setWizardSelectedFiles(wizard, map[string]bool{mainPath: true})
+
+	wizard.Update(screens.ExportSelectionRequestMsg{})
+
+	if !strings.Contains(wizard.fileSelection.ExportNote(), "Exported 1 selected files") {
+		t.Fatalf("unexpected export note %q", wizard.fileSelection.ExportNote())
+	}
+	selections, err := app.ReadSelectionFile(filepath.Join(root, app.SelectionFileName), root)
+	if err != nil {
+		t.Fatalf("exported selection not readable: %v", err)
+	}
+	if !selections[mainPath] || len(selections) != 1 {
+		t.Errorf("unexpected selections %v", selections)
+	}
+}
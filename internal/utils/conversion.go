@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // ParseSize parses a size string (e.g., "1MB", "5GB", "500KB") and returns the size in bytes.
@@ -70,3 +71,33 @@ func FormatBytes(bytes int64) string {
 
 	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
+
+// ParseSince parses a time window (e.g., "48h", "7d", "30m") or an RFC3339 timestamp
+// and returns the absolute cutoff time relative to now.
+// Durations additionally accept a "d" suffix meaning days.
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("empty time window")
+	}
+
+	if ts, err := time.Parse(time.RFC3339, value); err == nil {
+		return ts, nil
+	}
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.ParseFloat(days, 64)
+		if err != nil || n < 0 {
+			return time.Time{}, fmt.Errorf("invalid day count %q", value)
+		}
+
+		return now.Add(-time.Duration(n * float64(24*time.Hour))), nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("invalid time window %q, use a duration (48h, 7d) or RFC3339 timestamp", value)
+	}
+
+	return now.Add(-d), nil
+}
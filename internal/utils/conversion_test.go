@@ -2,6 +2,7 @@ package utils
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -119,3 +120,40 @@ func BenchmarkFormatBytes(b *testing.B) {
 		}
 	}
 }
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Time
+		wantErr  bool
+	}{
+		{"hours", "48h", now.Add(-48 * time.Hour), false},
+		{"minutes", "30m", now.Add(-30 * time.Minute), false},
+		{"days", "7d", now.Add(-7 * 24 * time.Hour), false},
+		{"fractional days", "1.5d", now.Add(-36 * time.Hour), false},
+		{"rfc3339", "2024-06-01T00:00:00Z", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), false},
+		{"with spaces", "  2h ", now.Add(-2 * time.Hour), false},
+
+		{"empty", "", time.Time{}, true},
+		{"negative duration", "-2h", time.Time{}, true},
+		{"negative days", "-1d", time.Time{}, true},
+		{"garbage", "yesterday", time.Time{}, true},
+		{"bad days", "xd", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseSince(tt.input, now)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.True(t, tt.expected.Equal(result), "expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// ansiPattern matches ANSI CSI escape sequences such as color and style codes.
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// StripANSI removes ANSI escape sequences from s, leaving only the visible text.
+func StripANSI(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+// StripEmoji removes emoji (and the spaces that follow them) from s, so that
+// "📄 Output file: x" becomes "Output file: x".
+func StripEmoji(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	afterEmoji := false
+	for _, r := range s {
+		switch {
+		case isEmoji(r):
+			afterEmoji = true
+		case afterEmoji && r != '\n' && unicode.IsSpace(r):
+			// Drop the padding that separated the emoji from the text
+		default:
+			afterEmoji = false
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // Emoji, pictographs and symbols
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // Miscellaneous symbols and dingbats (✅, ⚠, ✂)
+		return true
+	case r >= 0x2300 && r <= 0x23FF: // Miscellaneous technical (⏱, ⌛)
+		return true
+	case r == 0xFE0F || r == 0x200D: // Variation selector and zero-width joiner
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain text", "hello", "hello"},
+		{"color", "\x1b[38;5;110mkey\x1b[0m = value", "key = value"},
+		{"bold and underline", "\x1b[1m\x1b[4mname\x1b[0m", "name"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, StripANSI(tt.input))
+		})
+	}
+}
+
+func TestStripEmoji(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"leading emoji", "📄 Output file: out.md\n", "Output file: out.md\n"},
+		{"variation selector", "⚠️  Skipped 2 files\n", "Skipped 2 files\n"},
+		{"dingbat", "✅ Context generated successfully!\n", "Context generated successfully!\n"},
+		{"keeps other symbols", "≈10 / 20 tokens → 50%", "≈10 / 20 tokens → 50%"},
+		{"keeps indentation", "   - a.go: permission denied\n", "   - a.go: permission denied\n"},
+		{"newline after emoji", "✅\nnext", "\nnext"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, StripEmoji(tt.input))
+		})
+	}
+}
@@ -16,6 +16,6 @@ func main() {
 	// Execute the root command
 	if err := cmd.Execute(); err != nil {
 		log.Error().Err(err).Msg("Failed to execute command")
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }